@@ -47,20 +47,21 @@ type UserDeleteRequest struct {
 }
 
 type MenuDataItem struct {
-	ID         uint   `json:"id,omitempty"`         // 唯一id，使用整数表示
-	ParentID   uint   `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
-	Weight     int    `json:"weight"`               // 排序权重
-	Path       string `json:"path"`                 // 地址
-	Title      string `json:"title"`                // 展示名称
-	Name       string `json:"name,omitempty"`       // 同路由中的name，唯一标识
-	Component  string `json:"component,omitempty"`  // 绑定的组件
-	Locale     string `json:"locale,omitempty"`     // 本地化标识
-	Icon       string `json:"icon,omitempty"`       // 图标，使用字符串表示
-	Redirect   string `json:"redirect,omitempty"`   // 重定向地址
-	KeepAlive  bool   `json:"keepAlive,omitempty"`  // 是否保活
-	HideInMenu bool   `json:"hideInMenu,omitempty"` // 是否保活
-	URL        string `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
-	UpdatedAt  string `json:"updatedAt,omitempty"`  // 是否保活
+	ID         uint              `json:"id,omitempty"`         // 唯一id，使用整数表示
+	ParentID   uint              `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
+	Weight     int               `json:"weight"`               // 排序权重
+	Path       string            `json:"path"`                 // 地址
+	Title      string            `json:"title"`                // 展示名称
+	Name       string            `json:"name,omitempty"`       // 同路由中的name，唯一标识
+	Component  string            `json:"component,omitempty"`  // 绑定的组件
+	Locale     string            `json:"locale,omitempty"`     // 本地化标识
+	Icon       string            `json:"icon,omitempty"`       // 图标，使用字符串表示
+	Redirect   string            `json:"redirect,omitempty"`   // 重定向地址
+	KeepAlive  bool              `json:"keepAlive,omitempty"`  // 是否保活
+	HideInMenu bool              `json:"hideInMenu,omitempty"` // 是否保活
+	URL        string            `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
+	UpdatedAt  string            `json:"updatedAt,omitempty"`  // 是否保活
+	Titles     map[string]string `json:"titles,omitempty"`     // 各语言的展示名称（仅管理接口返回）
 }
 
 type GetMenuResponseData struct {
@@ -73,36 +74,38 @@ type GetMenuResponse struct {
 }
 
 type MenuCreateRequest struct {
-	ParentID   uint   `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
-	Weight     int    `json:"weight"`               // 排序权重
-	Path       string `json:"path"`                 // 地址
-	Title      string `json:"title"`                // 展示名称
-	Name       string `json:"name,omitempty"`       // 同路由中的name，唯一标识
-	Component  string `json:"component,omitempty"`  // 绑定的组件
-	Locale     string `json:"locale,omitempty"`     // 本地化标识
-	Icon       string `json:"icon,omitempty"`       // 图标，使用字符串表示
-	Redirect   string `json:"redirect,omitempty"`   // 重定向地址
-	KeepAlive  bool   `json:"keepAlive,omitempty"`  // 是否保活
-	HideInMenu bool   `json:"hideInMenu,omitempty"` // 是否保活
-	URL        string `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
+	ParentID   uint              `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
+	Weight     int               `json:"weight"`               // 排序权重
+	Path       string            `json:"path"`                 // 地址
+	Title      string            `json:"title"`                // 展示名称
+	Name       string            `json:"name,omitempty"`       // 同路由中的name，唯一标识
+	Component  string            `json:"component,omitempty"`  // 绑定的组件
+	Locale     string            `json:"locale,omitempty"`     // 本地化标识
+	Icon       string            `json:"icon,omitempty"`       // 图标，使用字符串表示
+	Redirect   string            `json:"redirect,omitempty"`   // 重定向地址
+	KeepAlive  bool              `json:"keepAlive,omitempty"`  // 是否保活
+	HideInMenu bool              `json:"hideInMenu,omitempty"` // 是否保活
+	URL        string            `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
+	Titles     map[string]string `json:"titles,omitempty"`     // 各语言的展示名称，默认语言的值可替代 title，值为空时删除该语言
 
 }
 
 type MenuUpdateRequest struct {
-	ID         uint   `json:"id,omitempty"`         // 唯一id，使用整数表示
-	ParentID   uint   `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
-	Weight     int    `json:"weight"`               // 排序权重
-	Path       string `json:"path"`                 // 地址
-	Title      string `json:"title"`                // 展示名称
-	Name       string `json:"name,omitempty"`       // 同路由中的name，唯一标识
-	Component  string `json:"component,omitempty"`  // 绑定的组件
-	Locale     string `json:"locale,omitempty"`     // 本地化标识
-	Icon       string `json:"icon,omitempty"`       // 图标，使用字符串表示
-	Redirect   string `json:"redirect,omitempty"`   // 重定向地址
-	KeepAlive  bool   `json:"keepAlive,omitempty"`  // 是否保活
-	HideInMenu bool   `json:"hideInMenu,omitempty"` // 是否保活
-	URL        string `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
-	UpdatedAt  string `json:"updatedAt"`
+	ID         uint              `json:"id,omitempty"`         // 唯一id，使用整数表示
+	ParentID   uint              `json:"parentId,omitempty"`   // 父级菜单的id，使用整数表示
+	Weight     int               `json:"weight"`               // 排序权重
+	Path       string            `json:"path"`                 // 地址
+	Title      string            `json:"title"`                // 展示名称
+	Name       string            `json:"name,omitempty"`       // 同路由中的name，唯一标识
+	Component  string            `json:"component,omitempty"`  // 绑定的组件
+	Locale     string            `json:"locale,omitempty"`     // 本地化标识
+	Icon       string            `json:"icon,omitempty"`       // 图标，使用字符串表示
+	Redirect   string            `json:"redirect,omitempty"`   // 重定向地址
+	KeepAlive  bool              `json:"keepAlive,omitempty"`  // 是否保活
+	HideInMenu bool              `json:"hideInMenu,omitempty"` // 是否保活
+	URL        string            `json:"url,omitempty"`        // iframe模式下的跳转url，不能与path重复
+	UpdatedAt  string            `json:"updatedAt"`
+	Titles     map[string]string `json:"titles,omitempty"` // 各语言的展示名称，默认语言的值可替代 title，值为空时删除该语言
 }
 
 type MenuDeleteRequest struct {
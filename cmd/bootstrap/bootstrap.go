@@ -1,9 +1,13 @@
 package bootstrap
 
 import (
+	"context"
 	"fmt"
 	"fun-admin/internal/resources"
+	"fun-admin/internal/seeder"
+	"fun-admin/internal/seeders"
 	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/declarative"
 	"fun-admin/pkg/admin/i18n"
 	"fun-admin/pkg/app"
 	"fun-admin/pkg/cache"
@@ -19,10 +23,31 @@ import (
 	"gorm.io/gorm"
 )
 
-// InitI18n registers translation resources.
-func InitI18n() {
-	i18n.AddResource("zh-CN", i18n.ChineseResources)
-	i18n.AddResource("en", i18n.EnglishResources)
+// InitI18n loads the built-in translation catalogs, then the optional catalog directory,
+// and applies the default language, fallback chains and dev-mode key tracking from config.
+func InitI18n(conf *viper.Viper) {
+	rm := i18n.GlobalResourceManager
+	if language := conf.GetString("i18n.default"); language != "" {
+		rm.SetDefaultLanguage(language)
+	}
+	if err := rm.LoadDefaults(); err != nil {
+		fmt.Printf("加载内置翻译失败: %v\n", err)
+		os.Exit(1)
+	}
+	if dir := conf.GetString("i18n.dir"); dir != "" {
+		if _, err := os.Stat(dir); err == nil {
+			if err := rm.LoadDir(dir); err != nil {
+				fmt.Printf("加载翻译目录失败: %v\n", err)
+				os.Exit(1)
+			}
+		}
+	}
+	for language, chain := range conf.GetStringMapStringSlice("i18n.fallbacks") {
+		rm.SetFallbacks(language, chain...)
+	}
+	if conf.GetBool("i18n.report") {
+		rm.EnableTracking()
+	}
 }
 
 // InitContainer builds the IoC container with all service providers.
@@ -61,8 +86,20 @@ func InitCache(conf *viper.Viper) cache.CacheManager {
 	return cache.NewMemoryCacheManager()
 }
 
-// InitAdmin registers built-in admin resources.
+// InitAdmin registers built-in admin resources, pages and dashboard widgets, and hands the
+// Casbin enforcer to resource policies.
 func InitAdmin(container *container.Container, cache cache.CacheManager, logger *logger.Logger, db *gorm.DB, enforcer *casbin.SyncedEnforcer) {
+	if enforcer != nil {
+		admin.SetPolicyEnforcer(enforcer)
+	}
+	RegisterResources()
+	RegisterPages()
+	RegisterWidgets()
+}
+
+// RegisterResources registers the built-in resource definitions only; code generators use it
+// without a database or container.
+func RegisterResources() {
 	admin.GlobalResourceManager.Register(resources.NewOperationLogResource())
 	admin.GlobalResourceManager.Register(resources.NewUserResource())
 	admin.GlobalResourceManager.Register(resources.NewCrudTableResource())
@@ -70,6 +107,82 @@ func InitAdmin(container *container.Container, cache cache.CacheManager, logger
 	admin.GlobalResourceManager.Register(resources.NewDictionaryDataResource())
 }
 
+// RegisterPages registers custom admin pages; make:page appends to it.
+func RegisterPages() {
+}
+
+// RegisterWidgets registers dashboard widgets; make:widget appends to it.
+func RegisterWidgets() {
+}
+
+// RegisterMigrations registers versioned migrations; make:migration appends to it.
+func RegisterMigrations() {
+}
+
+// RegisterSeeders registers database seeders; make:seeder appends to it.
+func RegisterSeeders() {
+}
+
+// RegisterInitialSeeders registers the built-in seeders of the admin account, menus, APIs and RBAC
+// policies, grouped under seeders.Initial which the install runs.
+func RegisterInitialSeeders(log *logger.Logger, enforcer *casbin.SyncedEnforcer, adminPassword string) {
+	seeder.Register(seeders.NewAdminUserSeeder(log, adminPassword))
+	seeder.Register(seeders.NewMenuSeeder())
+	seeder.Register(seeders.NewApiSeeder())
+	seeder.Register(seeders.NewRBACSeeder(log, enforcer))
+	seeder.Register(seeder.Group(seeders.Initial, seeders.InitialSeeders...))
+}
+
+// LoadResourceDefinitions registers the declarative resources of admin.resources.dir next to the
+// Go resources, so call it after RegisterResources. Definition errors stop startup with file and line.
+func LoadResourceDefinitions(conf *viper.Viper, log *logger.Logger) *declarative.Loader {
+	dir := conf.GetString("admin.resources.dir")
+	if dir == "" {
+		return nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil
+	}
+	loader := declarative.NewLoader(dir, admin.GlobalResourceManager)
+	if _, err := loader.Load(); err != nil {
+		fmt.Printf("加载声明式资源失败:\n%v\n", err)
+		os.Exit(1)
+	}
+	warnUnresolvedRelations(log)
+	return loader
+}
+
+// WatchResourceDefinitions reloads the declarative resources when their files change. It only
+// runs with admin.resources.watch outside production; failed reloads keep the previous definitions.
+func WatchResourceDefinitions(ctx context.Context, conf *viper.Viper, log *logger.Logger, loader *declarative.Loader) {
+	env := conf.GetString("env")
+	if loader == nil || !conf.GetBool("admin.resources.watch") || env == "prod" || env == "production" {
+		return
+	}
+	err := loader.Watch(ctx, func(resources []*declarative.Resource, err error) {
+		if err != nil {
+			log.Error("重新加载声明式资源失败，保留原定义", zap.String("errors", err.Error()))
+			return
+		}
+		log.Info("声明式资源已重新加载", zap.Int("count", len(resources)))
+		warnUnresolvedRelations(log)
+	})
+	if err != nil {
+		log.Error("监听声明式资源目录失败", zap.Error(err))
+	}
+}
+
+// warnUnresolvedRelations reports relationship fields of Go resources whose related resource is
+// not registered; declarative definitions are already checked when loading.
+func warnUnresolvedRelations(log *logger.Logger) {
+	if log == nil {
+		return
+	}
+	for _, relation := range declarative.UnresolvedRelations(admin.GlobalResourceManager.GetResources()) {
+		log.Warn("关联字段引用了未注册的资源", zap.String("relation", relation))
+	}
+}
+
 // EnsureStorage prepares upload directories.
 func EnsureStorage(log *logger.Logger) {
 	if err := os.MkdirAll("storage/uploads", os.ModePerm); err != nil {
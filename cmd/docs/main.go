@@ -3,13 +3,17 @@ package main
 import (
 	"flag"
 	"fmt"
+	v1 "fun-admin/api/v1"
 	"fun-admin/cmd/bootstrap"
+	internalserver "fun-admin/internal/server"
 	"fun-admin/pkg/admin"
 	"fun-admin/pkg/docs"
+	"fun-admin/pkg/jwt"
 	"fun-admin/pkg/logger"
 	"os"
 
 	"github.com/casbin/casbin/v2"
+	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -17,14 +21,15 @@ import (
 func main() {
 	var (
 		confPath   = flag.String("conf", "config/local.yml", "config path, eg: -conf ./config/local.yml")
-		outputPath = flag.String("output", "docs/swagger.json", "output swagger file path")
+		outputPath = flag.String("output", "docs/openapi.json", "output OpenAPI document path")
+		language   = flag.String("lang", "", "language of titles and descriptions, defaults to the default language")
 	)
 	flag.Parse()
 
 	conf := bootstrap.LoadConfig(*confPath)
 	log := logger.NewLogger(conf)
 
-	bootstrap.InitI18n()
+	bootstrap.InitI18n(conf)
 
 	containerManager := bootstrap.InitContainer(*confPath)
 	cacheManager := bootstrap.InitCache(conf)
@@ -33,16 +38,58 @@ func main() {
 	enforcer := containerManager.MustGet("enforcer").(*casbin.SyncedEnforcer)
 
 	bootstrap.InitAdmin(containerManager, cacheManager, log, db, enforcer)
+	bootstrap.LoadResourceDefinitions(conf, log)
 
-	if err := generateDocs(*outputPath); err != nil {
+	// 与服务启动使用同一套路由注册，文档中的路径即实际路由
+	jwtObj := containerManager.MustGet("jwt").(*jwt.JWT)
+	engine := internalserver.NewHTTPServer(log, enforcer, jwtObj, containerManager)
+
+	if err := generateDocs(engine.Routes(), *outputPath, *language); err != nil {
 		log.Error("生成文档失败", zap.Error(err))
 		os.Exit(1)
 	}
 
-	log.Info("swagger 生成成功", zap.String("output", *outputPath))
+	log.Info("OpenAPI 文档生成成功", zap.String("output", *outputPath))
+}
+
+// publicRoutes 无需登录即可访问的路由
+var publicRoutes = map[string]bool{
+	"POST /api/admin/login": true,
+}
+
+// routeModels 非资源路由的请求结构与响应 data 结构
+var routeModels = map[string][2]interface{}{
+	"POST /api/admin/login":              {v1.LoginRequest{}, v1.LoginResponseData{}},
+	"GET /api/admin/v1/users":            {v1.GetUsersRequest{}, v1.GetUsersResponseData{}},
+	"POST /api/admin/v1/users":           {v1.UserCreateRequest{}, nil},
+	"GET /api/admin/v1/users/:id":        {nil, v1.GetUserResponseData{}},
+	"PUT /api/admin/v1/users/:id":        {v1.UserUpdateRequest{}, nil},
+	"GET /api/admin/v1/menus":            {nil, v1.GetMenuResponseData{}},
+	"POST /api/admin/v1/menus":           {v1.MenuCreateRequest{}, nil},
+	"GET /api/admin/v1/menus/:id":        {nil, v1.GetMenuResponseData{}},
+	"PUT /api/admin/v1/menus/:id":        {v1.MenuUpdateRequest{}, nil},
+	"GET /api/admin/v1/roles":            {v1.GetRoleListRequest{}, v1.GetRolesResponseData{}},
+	"POST /api/admin/v1/roles":           {v1.RoleCreateRequest{}, nil},
+	"PUT /api/admin/v1/roles/:id":        {v1.RoleUpdateRequest{}, nil},
+	"GET /api/admin/v1/apis":             {v1.GetApisRequest{}, v1.GetApisResponseData{}},
+	"POST /api/admin/v1/apis":            {v1.ApiCreateRequest{}, nil},
+	"PUT /api/admin/v1/apis/:id":         {v1.ApiUpdateRequest{}, nil},
+	"GET /api/admin/v1/permissions":      {nil, v1.GetUserPermissionsData{}},
+	"POST /api/admin/v1/permissions":     {v1.UpdateRolePermissionRequest{}, nil},
+	"GET /api/admin/v1/permissions/role": {v1.GetRolePermissionsRequest{}, v1.GetRolePermissionsData{}},
+	"PUT /api/admin/v1/permissions/role": {v1.UpdateRolePermissionRequest{}, nil},
 }
 
-func generateDocs(output string) error {
+func generateDocs(routesInfo gin.RoutesInfo, output, language string) error {
+	routes := docs.RoutesFromGin(routesInfo)
+	for i := range routes {
+		key := routes[i].Method + " " + routes[i].Path
+		routes[i].Public = publicRoutes[key]
+		if models, ok := routeModels[key]; ok {
+			routes[i].Request, routes[i].Response = models[0], models[1]
+		}
+	}
+
 	resources := admin.GlobalResourceManager.GetResources()
 	resourceMap := make(map[string]admin.Resource, len(resources))
 	for _, resource := range resources {
@@ -50,7 +97,10 @@ func generateDocs(output string) error {
 	}
 
 	generator := docs.NewDocumentGenerator()
-	if err := generator.Generate(nil, resourceMap); err != nil {
+	if language != "" {
+		generator.SetLanguage(language)
+	}
+	if err := generator.Generate(routes, resourceMap); err != nil {
 		return fmt.Errorf("生成文档失败: %w", err)
 	}
 
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// bootstrapFile holds the Register* functions that generators append to.
+var bootstrapFile = filepath.Join("cmd", "bootstrap", "bootstrap.go")
+
+// goEdit inserts text at a byte offset of a Go source file.
+type goEdit struct {
+	offset int
+	text   string
+}
+
+// editGoFile parses path, lets plan compute insertions from the syntax tree and writes the
+// formatted result. The result is parsed again before writing so a bad edit never reaches disk.
+// It reports false when plan has nothing to insert.
+func editGoFile(path string, plan func(src []byte, fset *token.FileSet, file *ast.File) ([]goEdit, error)) (bool, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+	edits, err := plan(src, fset, file)
+	if err != nil || len(edits) == 0 {
+		return false, err
+	}
+
+	// apply from the end so earlier offsets stay valid
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset > edits[j].offset })
+	out := append([]byte(nil), src...)
+	for _, edit := range edits {
+		out = append(out[:edit.offset], append([]byte(edit.text), out[edit.offset:]...)...)
+	}
+	formatted, err := format.Source(out)
+	if err != nil {
+		return false, fmt.Errorf("edit of %s does not compile: %w", path, err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, formatted, parser.ParseComments); err != nil {
+		return false, fmt.Errorf("edit of %s does not parse: %w", path, err)
+	}
+
+	if dryRun {
+		tokenFile := fset.File(file.Pos())
+		for i := len(edits) - 1; i >= 0; i-- {
+			line := tokenFile.Line(tokenFile.Pos(edits[i].offset))
+			fmt.Printf("Would update %s at line %d:\n%s\n", path, line, strings.TrimSpace(edits[i].text))
+		}
+		return true, nil
+	}
+	return true, os.WriteFile(path, formatted, 0o644)
+}
+
+// addStatement appends stmt to the body of the top-level function funcName in path,
+// importing the given packages. Nothing changes when the statement is already there.
+func addStatement(path, funcName, stmt string, imports ...string) (bool, error) {
+	return editGoFile(path, func(src []byte, fset *token.FileSet, file *ast.File) ([]goEdit, error) {
+		var decl *ast.FuncDecl
+		for _, d := range file.Decls {
+			if fn, ok := d.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == funcName {
+				decl = fn
+				break
+			}
+		}
+		if decl == nil || decl.Body == nil {
+			return nil, fmt.Errorf("function %s not found in %s", funcName, path)
+		}
+		body := src[fset.Position(decl.Body.Lbrace).Offset:fset.Position(decl.Body.Rbrace).Offset]
+		if containsCode(body, stmt) {
+			return nil, nil
+		}
+
+		edits := []goEdit{{offset: fset.Position(decl.Body.Rbrace).Offset, text: "\t" + stmt + "\n"}}
+		return append(edits, importEdits(fset, file, imports...)...), nil
+	})
+}
+
+// registerIn adds stmt to one of the Register* functions of cmd/bootstrap/bootstrap.go.
+func registerIn(funcName, stmt string, imports ...string) {
+	changed, err := addStatement(bootstrapFile, funcName, stmt, imports...)
+	switch {
+	case err != nil:
+		fmt.Printf("Failed to update %s: %v\n", bootstrapFile, err)
+		fmt.Printf("Register it in %s manually:\n  %s\n", funcName, stmt)
+	case !changed:
+		fmt.Printf("Already registered in %s: %s\n", funcName, stmt)
+	case !dryRun:
+		fmt.Printf("Registered in %s (%s): %s\n", funcName, bootstrapFile, stmt)
+	}
+}
+
+// importEdits returns the insertions that import the missing packages.
+func importEdits(fset *token.FileSet, file *ast.File, paths ...string) []goEdit {
+	var missing []string
+	for _, path := range paths {
+		if !hasImport(file, path) {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	var edits []goEdit
+	var rest strings.Builder
+	for _, path := range missing {
+		// join the import group of the same module so gofmt keeps the groups sorted
+		if spec := lastImportOfModule(file, path); spec != nil {
+			edits = append(edits, goEdit{offset: fset.Position(spec.End()).Offset, text: "\n\t" + strconv.Quote(path)})
+			continue
+		}
+		rest.WriteString("\t" + strconv.Quote(path) + "\n")
+	}
+	if rest.Len() == 0 {
+		return edits
+	}
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Rparen.IsValid() {
+			return append(edits, goEdit{offset: fset.Position(gen.Rparen).Offset, text: rest.String()})
+		}
+	}
+	// no parenthesized import block: add one after the package clause
+	offset := fset.Position(file.Name.End()).Offset
+	return append(edits, goEdit{offset: offset, text: "\n\nimport (\n" + rest.String() + ")\n"})
+}
+
+// lastImportOfModule returns the last parenthesized import sharing the first path element of path.
+func lastImportOfModule(file *ast.File, path string) *ast.ImportSpec {
+	module := strings.SplitN(path, "/", 2)[0] + "/"
+	var last *ast.ImportSpec
+	for _, d := range file.Decls {
+		gen, ok := d.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT || !gen.Lparen.IsValid() {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			if value, err := strconv.Unquote(importSpec.Path.Value); err == nil && strings.HasPrefix(value, module) {
+				last = importSpec
+			}
+		}
+	}
+	return last
+}
+
+func hasImport(file *ast.File, path string) bool {
+	for _, spec := range file.Imports {
+		if value, err := strconv.Unquote(spec.Path.Value); err == nil && value == path {
+			return true
+		}
+	}
+	return false
+}
+
+// containsCode reports whether code appears in src, ignoring whitespace differences.
+func containsCode(src []byte, code string) bool {
+	strip := func(s []byte) []byte {
+		return bytes.Join(bytes.Fields(s), nil)
+	}
+	return bytes.Contains(strip(src), strip([]byte(code)))
+}
+
+// findMethod locates the method name of the receiver type typeName in the package dir.
+// It returns an empty path when the method does not exist.
+func findMethod(dir, typeName, name string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		fileName := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(fileName, ".go") || strings.HasSuffix(fileName, "_test.go") {
+			continue
+		}
+		path := filepath.Join(dir, fileName)
+		file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return "", err
+		}
+		if methodDecl(file, typeName, name) != nil {
+			return path, nil
+		}
+	}
+	return "", nil
+}
+
+// methodDecl returns the declaration of method name on typeName or *typeName.
+func methodDecl(file *ast.File, typeName, name string) *ast.FuncDecl {
+	for _, d := range file.Decls {
+		fn, ok := d.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 || fn.Name.Name != name {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+			return fn
+		}
+	}
+	return nil
+}
+
+// returnedString returns the string literal returned by method name of typeName in path,
+// such as the slug of GetSlug; ok is false when the method returns anything else.
+func returnedString(path, typeName, name string) (value string, ok bool) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return "", false
+	}
+	decl := methodDecl(file, typeName, name)
+	if decl == nil || decl.Body == nil {
+		return "", false
+	}
+	ast.Inspect(decl.Body, func(n ast.Node) bool {
+		if ret, isReturn := n.(*ast.ReturnStmt); isReturn && !ok && len(ret.Results) == 1 {
+			if lit, isLit := ret.Results[0].(*ast.BasicLit); isLit && lit.Kind == token.STRING {
+				value, err = strconv.Unquote(lit.Value)
+				ok = err == nil
+			}
+		}
+		return !ok
+	})
+	return value, ok
+}
+
+// addReturnedElement appends expr to the composite literal returned by method name of typeName,
+// such as the action list of GetActions.
+func addReturnedElement(path, typeName, name, expr string) (bool, error) {
+	return editGoFile(path, func(src []byte, fset *token.FileSet, file *ast.File) ([]goEdit, error) {
+		decl := methodDecl(file, typeName, name)
+		if decl == nil || decl.Body == nil {
+			return nil, fmt.Errorf("method %s.%s not found in %s", typeName, name, path)
+		}
+		var lit *ast.CompositeLit
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if ret, ok := n.(*ast.ReturnStmt); ok && lit == nil && len(ret.Results) == 1 {
+				lit, _ = ret.Results[0].(*ast.CompositeLit)
+			}
+			return lit == nil
+		})
+		if lit == nil {
+			return nil, fmt.Errorf("%s.%s does not return a composite literal", typeName, name)
+		}
+		start, end := fset.Position(lit.Lbrace).Offset, fset.Position(lit.Rbrace).Offset
+		if containsCode(src[start:end], expr) {
+			return nil, nil
+		}
+
+		text := expr + ",\n"
+		if n := len(lit.Elts); n > 0 && fset.Position(lit.Elts[n-1].End()).Line == fset.Position(lit.Rbrace).Line {
+			// single-line literal such as []admin.Action{a, b}
+			text = ", " + expr
+		}
+		return []goEdit{{offset: end, text: text}}, nil
+	})
+}
+
+// addSwitchCase adds a case before the default clause of the switch on the parameter
+// tagParam (by position) of method name of typeName. caseValue detects an existing case and
+// caseText renders the clause from the receiver and parameter names of the method.
+func addSwitchCase(path, typeName, name string, tagParam int, caseValue string, caseText func(recv string, params []string) string) (bool, error) {
+	return editGoFile(path, func(src []byte, fset *token.FileSet, file *ast.File) ([]goEdit, error) {
+		decl := methodDecl(file, typeName, name)
+		if decl == nil || decl.Body == nil {
+			return nil, fmt.Errorf("method %s.%s not found in %s", typeName, name, path)
+		}
+		recv, params := signatureNames(decl)
+		if recv == "" || tagParam >= len(params) || params[tagParam] == "_" {
+			return nil, fmt.Errorf("%s.%s needs a named receiver and parameters", typeName, name)
+		}
+		tag := params[tagParam]
+
+		var sw *ast.SwitchStmt
+		ast.Inspect(decl.Body, func(n ast.Node) bool {
+			if s, ok := n.(*ast.SwitchStmt); ok && sw == nil {
+				if ident, ok := s.Tag.(*ast.Ident); ok && ident.Name == tag {
+					sw = s
+				}
+			}
+			return sw == nil
+		})
+		if sw == nil {
+			return nil, fmt.Errorf("%s.%s has no switch on %s", typeName, name, tag)
+		}
+
+		offset := fset.Position(sw.Body.Rbrace).Offset
+		for _, stmt := range sw.Body.List {
+			clause := stmt.(*ast.CaseClause)
+			if clause.List == nil {
+				offset = fset.Position(clause.Pos()).Offset
+				continue
+			}
+			for _, value := range clause.List {
+				if lit, ok := value.(*ast.BasicLit); ok && lit.Value == strconv.Quote(caseValue) {
+					return nil, nil
+				}
+			}
+		}
+		return []goEdit{{offset: offset, text: caseText(recv, params) + "\n"}}, nil
+	})
+}
+
+// signatureNames returns the receiver name and the parameter names of a method.
+func signatureNames(decl *ast.FuncDecl) (string, []string) {
+	var recv string
+	if names := decl.Recv.List[0].Names; len(names) > 0 {
+		recv = names[0].Name
+	}
+	var params []string
+	for _, field := range decl.Type.Params.List {
+		if len(field.Names) == 0 {
+			params = append(params, "_")
+		}
+		for _, ident := range field.Names {
+			params = append(params, ident.Name)
+		}
+	}
+	return recv, params
+}
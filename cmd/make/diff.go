@@ -0,0 +1,361 @@
+package main
+
+import (
+	"fmt"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"fun-admin/cmd/bootstrap"
+	"fun-admin/internal/migrate"
+	"fun-admin/internal/repository"
+	"fun-admin/internal/server"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/config"
+	"fun-admin/pkg/database"
+	"fun-admin/pkg/logger"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// makeDiffMigration compares the base models and registered resources with the database and
+// writes a migration that creates the missing tables and adds the missing columns and indexes.
+func makeDiffMigration(name, confPath string) {
+	conf := config.NewConfig(confPath)
+	log := logger.NewLogger(conf)
+	db := database.Primary(repository.NewDB(conf, log))
+	bootstrap.RegisterResources()
+	bootstrap.LoadResourceDefinitions(conf, log)
+
+	tables, err := migrate.Tables(db, server.Models(), admin.GlobalResourceManager.GetResources())
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	diffs, err := migrate.Diff(db, tables)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+	if len(diffs) == 0 {
+		fmt.Println("The database matches the models and resources, no migration needed")
+		return
+	}
+
+	if name == "" {
+		name = "sync_schema"
+	}
+	// a migration runs on one connection, so tables on named connections get a migration each
+	var connections []string
+	grouped := make(map[string][]migrate.TableDiff)
+	for _, diff := range diffs {
+		if _, ok := grouped[diff.Connection]; !ok {
+			connections = append(connections, diff.Connection)
+		}
+		grouped[diff.Connection] = append(grouped[diff.Connection], diff)
+	}
+	for _, connection := range connections {
+		migrationName := name
+		if connection != "" {
+			migrationName = name + "_" + connection
+		}
+		connDiffs := grouped[connection]
+		writeMigration(migrationName, func(baseName, id string) (string, error) {
+			return buildDiffMigrationContent(baseName, id, connection, connDiffs), nil
+		})
+	}
+}
+
+// buildDiffMigrationContent renders one migration for the diffs of one connection. Each table gets
+// a local snapshot struct holding only the columns and indexes the migration touches, so later
+// model changes do not alter what the migration does, and GORM renders the DDL for the current dialect.
+func buildDiffMigrationContent(baseName, id, connection string, diffs []migrate.TableDiff) string {
+	var types, up strings.Builder
+	var down []string
+	usesTime := false
+
+	for _, diff := range diffs {
+		typeName := snapshotTypeName(diff.Table)
+		table := strconv.Quote(diff.Table)
+
+		var fields []*schema.Field
+		var indexes []schema.Index
+		if diff.Create {
+			for _, dbName := range diff.Schema.DBNames {
+				if field := diff.Schema.FieldsByDBName[dbName]; !field.IgnoreMigration {
+					fields = append(fields, field)
+				}
+			}
+			indexes = migrate.SortedIndexes(diff.Schema)
+		} else {
+			fields, indexes = touchedFields(diff)
+		}
+		indexTags := indexTagsByField(indexes)
+		adding := make(map[*schema.Field]bool, len(diff.Columns))
+		for _, field := range diff.Columns {
+			adding[field] = true
+		}
+
+		fmt.Fprintf(&types, "\t// %s holds the %s columns and indexes this migration creates.\n", typeName, diff.Table)
+		fmt.Fprintf(&types, "\ttype %s struct {\n", typeName)
+		names := make(map[string]bool)
+		for _, field := range fields {
+			goType := goTypeOf(field)
+			usesTime = usesTime || strings.Contains(goType, "time.")
+			// an added NOT NULL column without default fails on tables that already have rows
+			relaxed := !diff.Create && adding[field] && field.NotNull && !field.HasDefaultValue
+			tag := columnTag(field, relaxed, indexTags[field])
+			comment := ""
+			if relaxed {
+				comment = " // TODO: NOT NULL in the model; backfill existing rows, then tighten with AlterColumn"
+			}
+			fmt.Fprintf(&types, "\t\t%s %s `gorm:%s`%s\n", fieldName(field, names), goType, strconv.Quote(tag), comment)
+		}
+		types.WriteString("\t}\n\n")
+
+		if diff.Create {
+			fmt.Fprint(&up, statement("tx.Table(%s).Migrator().CreateTable(&%s{})", table, typeName))
+			down = append(down, statement("tx.Migrator().DropTable(%s)", table))
+			continue
+		}
+		for _, field := range diff.Columns {
+			fmt.Fprint(&up, statement("tx.Table(%s).Migrator().AddColumn(&%s{}, %q)", table, typeName, field.DBName))
+			// migrate.DropColumn keeps the other indexes of the table, which SQLite loses when it rebuilds the table
+			down = append(down, statement("migrate.DropColumn(tx, %s, &%s{}, %q)", table, typeName, field.DBName))
+		}
+		for _, index := range indexes {
+			fmt.Fprint(&up, statement("tx.Table(%s).Migrator().CreateIndex(&%s{}, %q)", table, typeName, index.Name))
+			down = append(down, statement("tx.Table(%s).Migrator().DropIndex(&%s{}, %q)", table, typeName, index.Name))
+		}
+	}
+
+	// Down undoes Up in reverse order
+	var downCode strings.Builder
+	for i := len(down) - 1; i >= 0; i-- {
+		downCode.WriteString(down[i])
+	}
+
+	imports := "\t\"fun-admin/internal/migrate\"\n\n\t\"gorm.io/gorm\"\n"
+	if usesTime {
+		imports = "\t\"time\"\n\n" + imports
+	}
+
+	connectionField := ""
+	if connection != "" {
+		connectionField = fmt.Sprintf("\t\tConnection: %q,\n", connection)
+	}
+
+	return fmt.Sprintf(`package migrations
+
+import (
+%[3]s)
+
+// %[1]s returns migration %[2]s, generated from the schema diff on %[6]s.
+func %[1]s() *migrate.Migration {
+%[4]s	return &migrate.Migration{
+		ID: "%[2]s",
+%[8]s		Up: func(tx *gorm.DB) error {
+%[5]s			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+%[7]s			return nil
+		},
+	}
+}
+`, baseName, id, imports, types.String(), up.String(), time.Now().Format("2006-01-02"), downCode.String(), connectionField)
+}
+
+// touchedFields returns the added columns plus the columns of the missing indexes, in schema order.
+func touchedFields(diff migrate.TableDiff) ([]*schema.Field, []schema.Index) {
+	touched := make(map[*schema.Field]bool)
+	for _, field := range diff.Columns {
+		touched[field] = true
+	}
+	var indexes []schema.Index
+	for _, index := range diff.Indexes {
+		if !renderableIndex(index) {
+			fmt.Printf("Note: index %s.%s uses an expression; add it to the migration by hand\n", diff.Table, index.Name)
+			continue
+		}
+		indexes = append(indexes, index)
+		for _, option := range index.Fields {
+			touched[option.Field] = true
+		}
+	}
+
+	var fields []*schema.Field
+	for _, field := range diff.Schema.Fields {
+		if touched[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields, indexes
+}
+
+func renderableIndex(index schema.Index) bool {
+	for _, option := range index.Fields {
+		if option.Field == nil || option.Expression != "" {
+			return false
+		}
+	}
+	return len(index.Fields) > 0
+}
+
+// indexTagsByField renders the index or uniqueIndex tag settings of every indexed field.
+func indexTagsByField(indexes []schema.Index) map[*schema.Field][]string {
+	tags := make(map[*schema.Field][]string)
+	for _, index := range indexes {
+		if !renderableIndex(index) {
+			continue
+		}
+		for position, option := range index.Fields {
+			key := "index"
+			settings := []string{index.Name}
+			switch index.Class {
+			case "":
+			case "UNIQUE":
+				key = "uniqueIndex"
+			default:
+				settings = append(settings, "class:"+index.Class)
+			}
+			if index.Type != "" {
+				settings = append(settings, "type:"+index.Type)
+			}
+			if index.Where != "" {
+				settings = append(settings, "where:"+index.Where)
+			}
+			if len(index.Fields) > 1 {
+				settings = append(settings, "priority:"+strconv.Itoa(position+1))
+			}
+			if option.Sort != "" {
+				settings = append(settings, "sort:"+option.Sort)
+			}
+			if option.Length > 0 {
+				settings = append(settings, "length:"+strconv.Itoa(option.Length))
+			}
+			tags[option.Field] = append(tags[option.Field], key+":"+strings.Join(settings, ","))
+		}
+	}
+	for _, fieldTags := range tags {
+		sort.Strings(fieldTags)
+	}
+	return tags
+}
+
+// columnTag renders the gorm tag that reproduces the column definition of field.
+func columnTag(field *schema.Field, relaxNotNull bool, indexTags []string) string {
+	settings := []string{"column:" + field.DBName}
+	if value, ok := field.TagSettings["TYPE"]; ok {
+		settings = append(settings, "type:"+value)
+	} else if !basicDataType(field.DataType) {
+		settings = append(settings, "type:"+string(field.DataType))
+	}
+	for _, key := range []string{"SIZE", "PRECISION", "SCALE"} {
+		if value, ok := field.TagSettings[key]; ok {
+			settings = append(settings, strings.ToLower(key)+":"+value)
+		}
+	}
+	if field.PrimaryKey {
+		settings = append(settings, "primaryKey")
+	}
+	if _, ok := field.TagSettings["AUTOINCREMENT"]; ok {
+		settings = append(settings, "autoIncrement")
+	}
+	if field.NotNull && !relaxNotNull {
+		settings = append(settings, "not null")
+	}
+	if value, ok := field.TagSettings["DEFAULT"]; ok {
+		settings = append(settings, "default:"+value)
+	}
+	if field.Unique {
+		settings = append(settings, "unique")
+	}
+	if field.Comment != "" {
+		settings = append(settings, "comment:"+field.Comment)
+	}
+	return strings.Join(append(settings, indexTags...), ";")
+}
+
+func basicDataType(dataType schema.DataType) bool {
+	switch dataType {
+	case schema.Bool, schema.Int, schema.Uint, schema.Float, schema.String, schema.Time, schema.Bytes:
+		return true
+	}
+	return false
+}
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// goTypeOf picks a Go type with the same column mapping as the field, without importing the model's
+// own types: builtin kinds keep their type, everything else maps by data type and size.
+func goTypeOf(field *schema.Field) string {
+	fieldType := field.IndirectFieldType
+	switch {
+	case fieldType == deletedAtType:
+		return "gorm.DeletedAt"
+	case fieldType == reflect.TypeOf(time.Time{}):
+		return "time.Time"
+	case fieldType.PkgPath() == "" && fieldType.Kind() != reflect.Struct && fieldType.Kind() != reflect.Map &&
+		fieldType.Kind() != reflect.Interface && (fieldType.Kind() != reflect.Slice || fieldType.Elem().Kind() == reflect.Uint8):
+		return fieldType.String()
+	}
+
+	switch field.DataType {
+	case schema.Bool:
+		return "bool"
+	case schema.Int:
+		return sizedType("int", field.Size)
+	case schema.Uint:
+		return sizedType("uint", field.Size)
+	case schema.Float:
+		if field.Size == 32 {
+			return "float32"
+		}
+		return "float64"
+	case schema.Time:
+		return "time.Time"
+	case schema.Bytes:
+		return "[]byte"
+	}
+	// custom data types keep their column type through the type tag
+	return "string"
+}
+
+func sizedType(prefix string, size int) string {
+	switch size {
+	case 8, 16, 32:
+		return prefix + strconv.Itoa(size)
+	}
+	return prefix + "64"
+}
+
+// fieldName returns an exported, unique Go name for the column.
+func fieldName(field *schema.Field, used map[string]bool) string {
+	name := schema.NamingStrategy{}.SchemaName(field.DBName)
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		name = field.Name
+	}
+	for base, i := name, 2; used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	used[name] = true
+	return name
+}
+
+// snapshotTypeName turns a table name into the name of its local snapshot struct.
+func snapshotTypeName(table string) string {
+	name := toPascal(splitWords(table))
+	if name == "" || !token.IsIdentifier(name) {
+		name = "Schema"
+	}
+	return strings.ToLower(name[:1]) + name[1:] + "Table"
+}
+
+// statement renders a migrator call that returns its error.
+func statement(format string, args ...interface{}) string {
+	return "\t\t\tif err := " + fmt.Sprintf(format, args...) + "; err != nil {\n\t\t\t\treturn err\n\t\t\t}\n"
+}
@@ -0,0 +1,582 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fun-admin/pkg/admin"
+)
+
+var resourcesDir = filepath.Join("internal", "resources")
+
+// resourceNames resolves the resource a generator targets and checks that its type exists.
+func resourceNames(resource string) (baseName, snakeName, typeName string, err error) {
+	baseName, snakeName, _, _, err = prepareNames(trimSuffixWord(resource, "Resource"))
+	if err != nil {
+		return "", "", "", err
+	}
+	typeName = baseName + "Resource"
+	path, err := findMethod(resourcesDir, typeName, "GetSlug")
+	if err != nil {
+		return "", "", "", err
+	}
+	if path == "" {
+		return "", "", "", fmt.Errorf("resource %s not found in %s; create it with make:resource first", typeName, resourcesDir)
+	}
+	return baseName, snakeName, typeName, nil
+}
+
+// resourceSlug returns the slug declared by GetSlug of typeName, which namespaces its
+// translation keys; fallback is used when GetSlug does not return a string literal.
+func resourceSlug(typeName, fallback string) string {
+	path, err := findMethod(resourcesDir, typeName, "GetSlug")
+	if err != nil || path == "" {
+		return fallback
+	}
+	if slug, ok := returnedString(path, typeName, "GetSlug"); ok && slug != "" {
+		return slug
+	}
+	return fallback
+}
+
+// trimSuffixWord drops a trailing kind word such as "Widget" from a generator name.
+func trimSuffixWord(name, word string) string {
+	if trimmed := strings.TrimSuffix(name, word); trimmed != "" && trimmed != name {
+		return trimmed
+	}
+	return name
+}
+
+// ensureNoMethod fails when typeName already declares one of the methods a generator adds.
+func ensureNoMethod(typeName string, names ...string) error {
+	if force {
+		return nil
+	}
+	for _, name := range names {
+		path, err := findMethod(resourcesDir, typeName, name)
+		if err != nil {
+			return err
+		}
+		if path != "" {
+			return fmt.Errorf("%s.%s already exists in %s", typeName, name, path)
+		}
+	}
+	return nil
+}
+
+// writeGenerated formats content and creates path in its directory.
+func writeGenerated(kind, path, content string) bool {
+	formatted, err := format.Source([]byte(content))
+	if err != nil {
+		fmt.Printf("Error generating %s file: %v\n", kind, err)
+		return false
+	}
+	content = string(formatted)
+	if err := ensureDir(filepath.Dir(path)); err != nil {
+		fmt.Printf("Failed to ensure directory %s: %v\n", filepath.Dir(path), err)
+		return false
+	}
+	if err := createFile(path, content); err != nil {
+		fmt.Printf("Error creating %s file: %v\n", kind, err)
+		return false
+	}
+	reportCreated(kind, path)
+	return true
+}
+
+// makeAction creates a custom action with a form, adds it to GetActions and dispatches it from RunAction.
+func makeAction(resource, name string) {
+	fmt.Printf("Creating action %s for resource %s\n", name, resource)
+
+	resBase, resSnake, typeName, err := resourceNames(resource)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+	actionBase, actionName, _, actionTitle, err := prepareNames(trimSuffixWord(name, "Action"))
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+	actionsPath, err := findMethod(resourcesDir, typeName, "GetActions")
+	if err != nil || actionsPath == "" {
+		fmt.Printf("Error: %s has no GetActions method (%v)\n", typeName, err)
+		return
+	}
+	executorPath, err := findMethod(resourcesDir, typeName, "RunAction")
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	_, _, resKebab, _, _ := prepareNames(trimSuffixWord(resource, "Resource"))
+	key := admin.LabelKey(resourceSlug(typeName, resKebab), "actions", actionName)
+	path := filepath.Join(resourcesDir, fmt.Sprintf("%s_%s_action.go", resSnake, actionName))
+	if !writeGenerated("Action", path, buildActionContent(resBase, typeName, actionBase, actionName, key)) {
+		return
+	}
+
+	constructor := fmt.Sprintf("New%s%sAction()", resBase, actionBase)
+	if _, err := addReturnedElement(actionsPath, typeName, "GetActions", constructor); err != nil {
+		fmt.Printf("Failed to add the action to %s.GetActions: %v\n", typeName, err)
+	}
+
+	dispatch := func(recv string, params []string) string {
+		return fmt.Sprintf("case %q:\n\treturn %s.run%sAction(%s, %s, %s)",
+			actionName, recv, actionBase, params[0], params[2], params[3])
+	}
+	if executorPath == "" {
+		executorPath = filepath.Join(resourcesDir, fmt.Sprintf("%s_actions.go", resSnake))
+		writeGenerated("Action executor", executorPath, buildExecutorContent(typeName,
+			dispatch("r", []string{"ctx", "actionName", "ids", "params"})))
+	} else if _, err := addSwitchCase(executorPath, typeName, "RunAction", 1, actionName, dispatch); err != nil {
+		fmt.Printf("Failed to add the action to %s.RunAction: %v\n", typeName, err)
+		fmt.Printf("Dispatch it manually:\n  %s\n", dispatch("r", []string{"ctx", "actionName", "ids", "params"}))
+	}
+
+	writeTranslations(map[string][][2]string{
+		"zh-CN": {{key, actionTitle}, {key + ".reason", "原因"}},
+		"en":    {{key, actionTitle}, {key + ".reason", "Reason"}},
+	})
+}
+
+func buildActionContent(resBase, typeName, actionBase, actionName, key string) string {
+	actionType := resBase + actionBase + "Action"
+	return fmt.Sprintf(`package resources
+
+import (
+	"context"
+	"fmt"
+
+	"fun-admin/pkg/admin"
+)
+
+// %[1]s is the %[4]s action of %[2]s; its form is shown before the action runs.
+type %[1]s struct {
+	*admin.BaseAction
+}
+
+// New%[1]s creates the %[4]s action; chain AsBulk() to run it on selected records.
+func New%[1]s() *%[1]s {
+	return &%[1]s{BaseAction: admin.NewAction("%[4]s").Label("%[5]s")}
+}
+
+// GetFormFields returns the fields of the action form, passed to RunAction as params.
+func (a *%[1]s) GetFormFields() []admin.Field {
+	return []admin.Field{
+		admin.NewTextareaField("reason").Label("%[5]s.reason"),
+	}
+}
+
+// run%[3]sAction executes the %[4]s action on the given records.
+func (r *%[2]s) run%[3]sAction(ctx context.Context, ids []interface{}, params map[string]interface{}) (interface{}, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%[4]s requires at least one record")
+	}
+	reason, _ := params["reason"].(string)
+
+	// TODO: implement the action
+	return map[string]interface{}{"affected": len(ids), "reason": reason}, nil
+}
+`, actionType, typeName, actionBase, actionName, key)
+}
+
+func buildExecutorContent(typeName, firstCase string) string {
+	return fmt.Sprintf(`package resources
+
+import (
+	"context"
+	"fmt"
+)
+
+// RunAction implements admin.ActionExecutor and dispatches the custom actions of the resource.
+func (r *%[1]s) RunAction(ctx context.Context, actionName string, ids []interface{}, params map[string]interface{}) (interface{}, error) {
+	switch actionName {
+	%[2]s
+	default:
+		return nil, fmt.Errorf("unsupported action: %%s", actionName)
+	}
+}
+`, typeName, strings.ReplaceAll(firstCase, "\n", "\n\t"))
+}
+
+// makeWidget creates a dashboard widget and registers it in RegisterWidgets.
+func makeWidget(name string) {
+	fmt.Printf("Creating widget: %s\n", name)
+
+	baseName, snakeName, _, title, err := prepareNames(trimSuffixWord(name, "Widget"))
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	path := filepath.Join("internal", "widgets", fmt.Sprintf("%s_widget.go", snakeName))
+	if !writeGenerated("Widget", path, buildWidgetContent(baseName, snakeName)) {
+		return
+	}
+	writeTranslations(map[string][][2]string{
+		"zh-CN": {{"widget." + snakeName, title}},
+		"en":    {{"widget." + snakeName, title}},
+	})
+	registerIn("RegisterWidgets",
+		fmt.Sprintf("admin.GlobalResourceManager.RegisterWidget(widgets.New%sWidget())", baseName),
+		"fun-admin/internal/widgets", "fun-admin/pkg/admin")
+}
+
+func buildWidgetContent(baseName, snakeName string) string {
+	return fmt.Sprintf(`package widgets
+
+import (
+	"context"
+
+	"fun-admin/pkg/admin"
+
+	"gorm.io/gorm"
+)
+
+// %[1]sWidget is a dashboard widget; its title is a translation key, see locales/*.yaml.
+type %[1]sWidget struct {
+	*admin.BaseWidget
+}
+
+// New%[1]sWidget creates the %[2]s widget.
+func New%[1]sWidget() *%[1]sWidget {
+	return &%[1]sWidget{BaseWidget: admin.NewBaseWidget("%[2]s", "widget.%[2]s", "stat")}
+}
+
+// Data returns the widget data for the dashboard request.
+func (w *%[1]sWidget) Data(ctx context.Context, db *gorm.DB) (interface{}, error) {
+	// TODO: query the widget data, e.g. db.Table("orders").Count(&value)
+	var value int64
+	return map[string]interface{}{"value": value}, nil
+}
+`, baseName, snakeName)
+}
+
+// makePolicy implements admin.Authorizable on a resource using the Casbin resource policies.
+func makePolicy(resource string) {
+	fmt.Printf("Creating policy for resource: %s\n", resource)
+
+	_, snakeName, typeName, err := resourceNames(resource)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+	if err := ensureNoMethod(typeName, "CanList", "CanView", "CanCreate", "CanUpdate", "CanDelete"); err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	path := filepath.Join(resourcesDir, fmt.Sprintf("%s_policy.go", snakeName))
+	if writeGenerated("Policy", path, buildPolicyContent(typeName)) {
+		fmt.Println("Grant access with Casbin policies such as:")
+		fmt.Printf("  p, <role>, resource:<slug of %s>, list|view|create|update|delete\n", typeName)
+	}
+}
+
+func buildPolicyContent(typeName string) string {
+	return fmt.Sprintf(`package resources
+
+import (
+	"context"
+
+	"fun-admin/pkg/admin"
+)
+
+// %[1]s implements admin.Authorizable with the Casbin policies on resource:<slug>;
+// the super administrator is always allowed.
+
+// CanList checks the list permission.
+func (r *%[1]s) CanList(ctx context.Context) error {
+	return admin.AuthorizeResource(ctx, r.GetSlug(), "list")
+}
+
+// CanView checks the view permission of a record.
+func (r *%[1]s) CanView(ctx context.Context, id interface{}) error {
+	return admin.AuthorizeResource(ctx, r.GetSlug(), "view")
+}
+
+// CanCreate checks the create permission.
+func (r *%[1]s) CanCreate(ctx context.Context, data map[string]interface{}) error {
+	return admin.AuthorizeResource(ctx, r.GetSlug(), "create")
+}
+
+// CanUpdate checks the update permission of a record.
+func (r *%[1]s) CanUpdate(ctx context.Context, id interface{}, data map[string]interface{}) error {
+	return admin.AuthorizeResource(ctx, r.GetSlug(), "update")
+}
+
+// CanDelete checks the delete permission of a record.
+func (r *%[1]s) CanDelete(ctx context.Context, id interface{}) error {
+	return admin.AuthorizeResource(ctx, r.GetSlug(), "delete")
+}
+`, typeName)
+}
+
+// makeHook adds the create, update and delete lifecycle hooks to a resource.
+func makeHook(resource string) {
+	fmt.Printf("Creating hooks for resource: %s\n", resource)
+
+	_, snakeName, typeName, err := resourceNames(resource)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+	if err := ensureNoMethod(typeName, "BeforeCreate", "AfterCreate", "BeforeUpdate", "AfterUpdate", "BeforeDelete", "AfterDelete"); err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	path := filepath.Join(resourcesDir, fmt.Sprintf("%s_hooks.go", snakeName))
+	writeGenerated("Hook", path, buildHookContent(typeName))
+}
+
+func buildHookContent(typeName string) string {
+	return fmt.Sprintf(`package resources
+
+import "context"
+
+// Lifecycle hooks of %[1]s (admin.CreateHook, admin.UpdateHook, admin.DeleteHook).
+// Returning an error from a Before hook aborts the operation.
+
+// BeforeCreate runs before a record is created; data may be modified.
+func (r *%[1]s) BeforeCreate(ctx context.Context, data map[string]interface{}) error {
+	return nil
+}
+
+// AfterCreate runs after a record is created.
+func (r *%[1]s) AfterCreate(ctx context.Context, data map[string]interface{}) error {
+	return nil
+}
+
+// BeforeUpdate runs before a record is updated; data may be modified.
+func (r *%[1]s) BeforeUpdate(ctx context.Context, id interface{}, data map[string]interface{}) error {
+	return nil
+}
+
+// AfterUpdate runs after a record is updated.
+func (r *%[1]s) AfterUpdate(ctx context.Context, id interface{}, data map[string]interface{}) error {
+	return nil
+}
+
+// BeforeDelete runs before a record is deleted.
+func (r *%[1]s) BeforeDelete(ctx context.Context, id interface{}) error {
+	return nil
+}
+
+// AfterDelete runs after a record is deleted.
+func (r *%[1]s) AfterDelete(ctx context.Context, id interface{}) error {
+	return nil
+}
+`, typeName)
+}
+
+// makeMigration creates a timestamped migration and registers it in RegisterMigrations.
+func makeMigration(name string) {
+	writeMigration(name, buildMigrationContent)
+}
+
+// writeMigration names a migration, writes the content built for it and registers it.
+func writeMigration(name string, build func(baseName, id string) (string, error)) {
+	fmt.Printf("Creating migration: %s\n", name)
+
+	baseName, snakeName, _, _, err := prepareNames(name)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	dir := filepath.Join("internal", "migrations")
+	id := time.Now().Format("20060102150405") + "_" + snakeName
+	// the function name comes from the name, so a name can only be used once
+	if existing, _ := filepath.Glob(filepath.Join(dir, "*_"+snakeName+".go")); len(existing) > 0 {
+		if !force {
+			fmt.Printf("Error: migration %s already exists: %s (use -force to overwrite)\n", snakeName, existing[0])
+			return
+		}
+		id = strings.TrimSuffix(filepath.Base(existing[0]), ".go")
+	}
+
+	content, err := build(baseName, id)
+	if err != nil {
+		fmt.Printf("Error generating migration: %v\n", err)
+		return
+	}
+	path := filepath.Join(dir, id+".go")
+	if !writeGenerated("Migration", path, content) {
+		return
+	}
+	registerIn("RegisterMigrations",
+		fmt.Sprintf("migrate.Register(migrations.%s())", baseName),
+		"fun-admin/internal/migrate", "fun-admin/internal/migrations")
+}
+
+func buildMigrationContent(baseName, id string) (string, error) {
+	return fmt.Sprintf(`package migrations
+
+import (
+	"fun-admin/internal/migrate"
+
+	"gorm.io/gorm"
+)
+
+// %[1]s returns migration %[2]s.
+func %[1]s() *migrate.Migration {
+	return &migrate.Migration{
+		ID: "%[2]s",
+		Up: func(tx *gorm.DB) error {
+			// TODO: change the schema, e.g. tx.Migrator().CreateTable(&model.Order{})
+			return nil
+		},
+		Down: func(tx *gorm.DB) error {
+			// TODO: revert Up, e.g. tx.Migrator().DropTable("orders")
+			return nil
+		},
+	}
+}
+`, baseName, id), nil
+}
+
+// makeSeeder creates a seeder and registers it in RegisterSeeders.
+// With a resource the seeder fills the resource table through a factory.
+func makeSeeder(name, resource string) {
+	fmt.Printf("Creating seeder: %s\n", name)
+
+	baseName, snakeName, _, _, err := prepareNames(trimSuffixWord(name, "Seeder"))
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	content := buildSeederContent(baseName, snakeName)
+	if resource != "" {
+		resourceBase, _, _, err := resourceNames(resource)
+		if err != nil {
+			fmt.Printf("Error: %s\n", err)
+			return
+		}
+		content = buildFactorySeederContent(baseName, snakeName, resourceBase)
+	}
+
+	path := filepath.Join("internal", "seeders", fmt.Sprintf("%s_seeder.go", snakeName))
+	if !writeGenerated("Seeder", path, content) {
+		return
+	}
+	registerIn("RegisterSeeders",
+		fmt.Sprintf("seeder.Register(seeders.New%sSeeder())", baseName),
+		"fun-admin/internal/seeder", "fun-admin/internal/seeders")
+}
+
+func buildSeederContent(baseName, snakeName string) string {
+	return fmt.Sprintf(`package seeders
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// %[1]sSeeder fills the %[2]s data.
+type %[1]sSeeder struct{}
+
+// New%[1]sSeeder creates the %[2]s seeder.
+func New%[1]sSeeder() *%[1]sSeeder {
+	return &%[1]sSeeder{}
+}
+
+// Name is the seeder name used on the command line and in dependencies.
+func (s *%[1]sSeeder) Name() string {
+	return "%[2]s"
+}
+
+// Dependencies lists the seeders that must run first.
+func (s *%[1]sSeeder) Dependencies() []string {
+	return nil
+}
+
+// Run inserts the data; keep it idempotent so the seeder can run again.
+func (s *%[1]sSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	// TODO: insert the data, e.g. db.WithContext(ctx).FirstOrCreate(&record, model.Order{No: "demo"})
+	return nil
+}
+`, baseName, snakeName)
+}
+
+func buildFactorySeederContent(baseName, snakeName, resourceBase string) string {
+	return fmt.Sprintf(`package seeders
+
+import (
+	"context"
+
+	"fun-admin/internal/resources"
+	"fun-admin/internal/seeder"
+
+	"gorm.io/gorm"
+)
+
+// %[1]sSeeder fills the %[2]s data with records generated from the %[3]s resource fields.
+type %[1]sSeeder struct {
+	count int
+}
+
+// New%[1]sSeeder creates the %[2]s seeder.
+func New%[1]sSeeder() *%[1]sSeeder {
+	return &%[1]sSeeder{count: 20}
+}
+
+// Name is the seeder name used on the command line and in dependencies.
+func (s *%[1]sSeeder) Name() string {
+	return "%[2]s"
+}
+
+// Dependencies lists the seeders that must run first, e.g. the seeders of related resources.
+func (s *%[1]sSeeder) Dependencies() []string {
+	return nil
+}
+
+// Run inserts s.count records; the same -rand-seed generates the same records.
+func (s *%[1]sSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	factory := seeder.NewFactory(resources.New%[3]sResource(), seeder.RandSeed(ctx))
+	// fix a field with factory.Set("status", "active") or factory.Set("code", func(n int) interface{} { ... })
+	_, err := factory.Create(ctx, db, s.count)
+	return err
+}
+`, baseName, snakeName, resourceBase)
+}
+
+// makeResourceTest creates a conformance test that checks the resource against its model
+// and runs create, update and delete on an in-memory SQLite database.
+func makeResourceTest(resource string) {
+	fmt.Printf("Creating resource test: %s\n", resource)
+
+	baseName, snakeName, typeName, err := resourceNames(resource)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	path := filepath.Join(resourcesDir, fmt.Sprintf("%s_resource_test.go", snakeName))
+	if writeGenerated("Resource test", path, buildResourceTestContent(baseName, typeName)) && !dryRun {
+		fmt.Printf("Run it with: go test ./%s -run Test%s\n", filepath.ToSlash(resourcesDir), typeName)
+	}
+}
+
+func buildResourceTestContent(baseName, typeName string) string {
+	return fmt.Sprintf(`package resources
+
+import (
+	"testing"
+
+	"fun-admin/internal/resources/resourcetest"
+)
+
+// Test%[2]s checks the fields, columns and filters of %[2]s against its model and
+// runs create, update and delete through the resource repository.
+func Test%[2]s(t *testing.T) {
+	resourcetest.Run(t, New%[1]sResource())
+}
+`, baseName, typeName)
+}
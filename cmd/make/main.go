@@ -5,19 +5,38 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"unicode"
+
+	"fun-admin/pkg/admin"
+)
+
+// force and dryRun are shared by every generator.
+var (
+	force  bool
+	dryRun bool
 )
 
 func main() {
 	// CLI flag definitions
 	var (
-		action string
-		name   string
+		action   string
+		name     string
+		table    string
+		confPath string
+		resource string
+		diff     bool
 	)
 
-	flag.StringVar(&action, "action", "", "Action to perform (make:resource, make:page)")
-	flag.StringVar(&name, "name", "", "Name of the resource or page")
+	flag.StringVar(&action, "action", "", "Action to perform (make:resource, make:page, make:action, make:widget, make:policy, make:hook, make:migration, make:seeder, make:resource-test)")
+	flag.StringVar(&name, "name", "", "Name of the generated resource, page, action, widget, migration or seeder")
+	flag.StringVar(&table, "table", "", "Existing database table to scaffold the resource and model from (make:resource)")
+	flag.StringVar(&confPath, "conf", "config/local.yml", "Config file with the database connection, used with -table and -diff")
+	flag.StringVar(&resource, "resource", "", "Resource the action, policy, hooks or test belongs to, or the seeder fills through a factory, e.g. Order")
+	flag.BoolVar(&force, "force", false, "Overwrite files that already exist")
+	flag.BoolVar(&dryRun, "dry-run", false, "Print the files and edits instead of writing them")
+	flag.BoolVar(&diff, "diff", false, "Generate the migration from the difference between the models, resources and database (make:migration)")
 	flag.Parse()
 
 	// validate required flags
@@ -29,8 +48,12 @@ func main() {
 
 	switch action {
 	case "make:resource":
+		if table != "" {
+			makeResourceFromTable(name, table, confPath)
+			return
+		}
 		if name == "" {
-			fmt.Println("Error: name is required for make:resource")
+			fmt.Println("Error: name or table is required for make:resource")
 			printUsage()
 			os.Exit(1)
 		}
@@ -42,6 +65,49 @@ func main() {
 			os.Exit(1)
 		}
 		makePage(name)
+	case "make:action":
+		if resource == "" || name == "" {
+			fmt.Println("Error: resource and name are required for make:action")
+			printUsage()
+			os.Exit(1)
+		}
+		makeAction(resource, name)
+	case "make:migration":
+		if diff {
+			makeDiffMigration(name, confPath)
+			return
+		}
+		if name == "" {
+			fmt.Println("Error: name is required for make:migration")
+			printUsage()
+			os.Exit(1)
+		}
+		makeMigration(name)
+	case "make:widget", "make:seeder":
+		if name == "" {
+			fmt.Printf("Error: name is required for %s\n", action)
+			printUsage()
+			os.Exit(1)
+		}
+		if action == "make:widget" {
+			makeWidget(name)
+		} else {
+			makeSeeder(name, resource)
+		}
+	case "make:policy", "make:hook", "make:resource-test":
+		if resource == "" {
+			fmt.Printf("Error: resource is required for %s\n", action)
+			printUsage()
+			os.Exit(1)
+		}
+		switch action {
+		case "make:policy":
+			makePolicy(resource)
+		case "make:hook":
+			makeHook(resource)
+		default:
+			makeResourceTest(resource)
+		}
 	default:
 		fmt.Printf("Error: unknown action '%s'\n", action)
 		printUsage()
@@ -70,9 +136,22 @@ func makeResource(name string) {
 		return
 	}
 
-	fmt.Printf("Resource file created: %s\n", filePath)
-	fmt.Println("Remember to register the resource in cmd/bootstrap/bootstrap.go:")
-	fmt.Printf("  admin.GlobalResourceManager.Register(resources.New%sResource())\n", baseName)
+	reportCreated("Resource", filePath)
+	key := func(parts ...string) string { return admin.LabelKey(kebabName, parts...) }
+	writeTranslations(map[string][][2]string{
+		"zh-CN": append([][2]string{{key("title"), title}, {key("fields", "id"), "ID"}, {key("columns", "created_at"), "创建时间"}},
+			actionTranslations(kebabName, "查看", "编辑", "删除")...),
+		"en": append([][2]string{{key("title"), title}, {key("fields", "id"), "ID"}, {key("columns", "created_at"), "Created At"}},
+			actionTranslations(kebabName, "View", "Edit", "Delete")...),
+	})
+	registerResource(baseName)
+}
+
+// registerResource adds the resource to RegisterResources in cmd/bootstrap/bootstrap.go.
+func registerResource(baseName string) {
+	registerIn("RegisterResources",
+		fmt.Sprintf("admin.GlobalResourceManager.Register(resources.New%sResource())", baseName),
+		"fun-admin/internal/resources", "fun-admin/pkg/admin")
 }
 
 // makePage creates a new custom admin page stub.
@@ -91,27 +170,100 @@ func makePage(name string) {
 	}
 
 	filePath := filepath.Join("internal", "pages", fmt.Sprintf("%s_page.go", snakeName))
-	if err := createFile(filePath, buildPageContent(baseName, title, kebabName)); err != nil {
+	if err := createFile(filePath, buildPageContent(baseName, title, kebabName, snakeName)); err != nil {
 		fmt.Printf("Error creating page file: %v\n", err)
 		return
 	}
 
-	fmt.Printf("Page file created: %s\n", filePath)
-	fmt.Println("Remember to register the page in cmd/bootstrap/bootstrap.go:")
-	fmt.Printf("  admin.GlobalResourceManager.RegisterPage(pages.New%sPage())\n", baseName)
+	reportCreated("Page", filePath)
+	writeTranslations(map[string][][2]string{
+		"zh-CN": {{"page." + snakeName, title}},
+		"en":    {{"page." + snakeName, title}},
+	})
+	registerIn("RegisterPages",
+		fmt.Sprintf("admin.GlobalResourceManager.RegisterPage(pages.New%sPage())", baseName),
+		"fun-admin/internal/pages", "fun-admin/pkg/admin")
+}
+
+// translationsDir is the project catalog directory loaded at startup (config i18n.dir).
+const translationsDir = "locales"
+
+// actionTranslations labels the view, edit and delete actions scaffolded for slug.
+func actionTranslations(slug, view, edit, remove string) [][2]string {
+	return [][2]string{
+		{admin.LabelKey(slug, "actions", "view"), view},
+		{admin.LabelKey(slug, "actions", "edit"), edit},
+		{admin.LabelKey(slug, "actions", "delete"), remove},
+	}
+}
+
+// writeTranslations appends scaffolded label keys to locales/<language>.yaml, keeping existing keys.
+func writeTranslations(entries map[string][][2]string) {
+	if err := ensureDir(translationsDir); err != nil {
+		fmt.Printf("Failed to ensure translations directory: %v\n", err)
+		return
+	}
+	for language, pairs := range entries {
+		path := filepath.Join(translationsDir, language+".yaml")
+		existing, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			fmt.Printf("Failed to read %s: %v\n", path, err)
+			continue
+		}
+
+		var builder strings.Builder
+		for _, pair := range pairs {
+			key := strconv.Quote(pair[0])
+			if strings.Contains(string(existing), key+":") {
+				continue
+			}
+			builder.WriteString(fmt.Sprintf("%s: %s\n", key, strconv.Quote(pair[1])))
+		}
+		if builder.Len() == 0 {
+			continue
+		}
+		if dryRun {
+			fmt.Printf("Would add translations to %s:\n%s\n", path, builder.String())
+			continue
+		}
+		if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+			existing = append(existing, '\n')
+		}
+		if err := os.WriteFile(path, append(existing, builder.String()...), 0o644); err != nil {
+			fmt.Printf("Failed to write %s: %v\n", path, err)
+			continue
+		}
+		fmt.Printf("Translations added: %s (translate the new keys)\n", path)
+	}
+}
+
+// reportCreated confirms a written file; with -dry-run createFile has already printed "Would create".
+func reportCreated(kind, path string) {
+	if !dryRun {
+		fmt.Printf("%s file created: %s\n", kind, path)
+	}
 }
 
 func ensureDir(dir string) error {
+	if dryRun {
+		return nil
+	}
 	return os.MkdirAll(dir, os.ModePerm)
 }
 
+// createFile writes a generated file; existing files are kept unless -force is set,
+// and -dry-run prints the content instead.
 func createFile(path, content string) error {
-	if _, err := os.Stat(path); err == nil {
-		return fmt.Errorf("file already exists: %s", path)
-	} else if !os.IsNotExist(err) {
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("file already exists: %s (use -force to overwrite)", path)
+	} else if err != nil && !os.IsNotExist(err) {
 		return err
 	}
 
+	if dryRun {
+		fmt.Printf("Would create %s:\n%s\n", path, content)
+		return nil
+	}
 	return os.WriteFile(path, []byte(content), 0o644)
 }
 
@@ -119,6 +271,7 @@ func buildResourceContent(baseName, title, slug string) string {
 	if slug == "" {
 		slug = strings.ToLower(baseName)
 	}
+	key := func(parts ...string) string { return admin.LabelKey(slug, parts...) }
 
 	typeName := baseName + "Resource"
 
@@ -126,60 +279,70 @@ func buildResourceContent(baseName, title, slug string) string {
 
 import "fun-admin/pkg/admin"
 
-// %s defines the admin resource for %s.
-type %s struct {
+// %[1]s defines the admin resource for %[2]s.
+type %[1]s struct {
 	admin.BaseResource
 }
 
-// New%sResource creates a new %s resource instance.
-func New%sResource() *%s {
-	return &%s{}
+// New%[3]sResource creates a new %[2]s resource instance.
+func New%[3]sResource() *%[1]s {
+	return &%[1]s{}
 }
 
-// GetTitle returns the display name of the resource.
-func (r *%s) GetTitle() string {
-	return "%s"
+// GetTitle returns the translation key of the resource title; see locales/*.yaml.
+func (r *%[1]s) GetTitle() string {
+	return %[5]q
 }
 
 // GetSlug returns the resource slug that is used in API routes.
-func (r *%s) GetSlug() string {
-	return "%s"
+func (r *%[1]s) GetSlug() string {
+	return "%[4]s"
 }
 
 // GetModel returns the underlying model for the resource.
-func (r *%s) GetModel() interface{} {
-	// TODO: return the appropriate model instance, e.g. &model.%s{}
+func (r *%[1]s) GetModel() interface{} {
+	// TODO: return the appropriate model instance, e.g. &model.%[3]s{}
 	return nil
 }
 
 // GetFields returns the editable fields for the resource.
-func (r *%s) GetFields() []admin.Field {
-	return []admin.Field{}
+func (r *%[1]s) GetFields() []admin.Field {
+	return []admin.Field{
+		admin.NewIDField().Label(%[6]q),
+	}
 }
 
 // GetActions returns the actions that can be executed on the resource.
-func (r *%s) GetActions() []admin.Action {
-	return []admin.Action{}
+func (r *%[1]s) GetActions() []admin.Action {
+	return []admin.Action{
+		admin.NewViewAction().Label(%[8]q),
+		admin.NewEditAction().Label(%[9]q),
+		admin.NewDeleteAction().Label(%[10]q),
+	}
 }
 
 // GetReadOnlyFields returns the readonly field names that should not be editable.
-func (r *%s) GetReadOnlyFields() []string {
-	return []string{}
+func (r *%[1]s) GetReadOnlyFields() []string {
+	return []string{"id", "created_at", "updated_at"}
 }
 
 // GetColumns returns the table columns displayed in the list view.
-func (r *%s) GetColumns() []*admin.Column {
-	return []*admin.Column{}
+func (r *%[1]s) GetColumns() []*admin.Column {
+	return []*admin.Column{
+		admin.NewColumn("id", %[6]q, "number").SetSortable(true),
+		admin.NewColumn("created_at", %[7]q, "datetime").SetSortable(true),
+	}
 }
 
 // GetFilters returns the filters available for the list view.
-func (r *%s) GetFilters() []*admin.Filter {
+func (r *%[1]s) GetFilters() []*admin.Filter {
 	return []*admin.Filter{}
 }
-`, typeName, title, typeName, baseName, title, baseName, typeName, typeName, typeName, title, typeName, slug, typeName, baseName, typeName, typeName, typeName, typeName, typeName)
+`, typeName, title, baseName, slug, key("title"), key("fields", "id"), key("columns", "created_at"),
+		key("actions", "view"), key("actions", "edit"), key("actions", "delete"))
 }
 
-func buildPageContent(baseName, title, slug string) string {
+func buildPageContent(baseName, title, slug, key string) string {
 	if slug == "" {
 		slug = strings.ToLower(baseName)
 	}
@@ -191,19 +354,19 @@ func buildPageContent(baseName, title, slug string) string {
 
 import "fun-admin/pkg/admin"
 
-// %s defines a custom admin page.
-type %s struct {
+// %[1]s defines a custom admin page; its title is a translation key, see locales/*.yaml.
+type %[1]s struct {
 	*admin.BasePage
 }
 
-// New%sPage creates the %s admin page instance.
-func New%sPage() *%s {
-	page := admin.NewBasePage("%s", "%s", "%s")
-	return &%s{
+// New%[2]sPage creates the %[3]s admin page instance.
+func New%[2]sPage() *%[1]s {
+	page := admin.NewBasePage("page.%[6]s", "%[4]s", "%[5]s")
+	return &%[1]s{
 		BasePage: page,
 	}
 }
-`, typeName, typeName, baseName, title, baseName, typeName, title, slug, pagePath, typeName)
+`, typeName, baseName, title, slug, pagePath, key)
 }
 
 func prepareNames(input string) (baseName, snakeName, kebabName, title string, err error) {
@@ -314,9 +477,25 @@ func capitalize(word string) string {
 func printUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  go run cmd/make/main.go -action=make:resource -name=ResourceName")
+	fmt.Println("  go run ./cmd/make -action=make:resource -table=table_name [-name=ResourceName] [-conf=config/local.yml]")
 	fmt.Println("  go run cmd/make/main.go -action=make:page -name=PageName")
+	fmt.Println("  go run ./cmd/make -action=make:action -resource=ResourceName -name=action_name")
+	fmt.Println("  go run ./cmd/make -action=make:widget|make:migration|make:seeder -name=Name")
+	fmt.Println("  go run ./cmd/make -action=make:migration -diff [-name=Name] [-conf=config/local.yml]")
+	fmt.Println("  go run ./cmd/make -action=make:seeder -name=Name -resource=ResourceName")
+	fmt.Println("  go run ./cmd/make -action=make:policy|make:hook|make:resource-test -resource=ResourceName")
+	fmt.Println("")
+	fmt.Println("Options:")
+	fmt.Println("  -force     overwrite existing files")
+	fmt.Println("  -dry-run   print the files and edits instead of writing them")
+	fmt.Println("  -diff      make:migration: create missing tables and add missing columns and indexes")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  go run cmd/make/main.go -action=make:resource -name=User")
+	fmt.Println("  go run ./cmd/make -action=make:resource -table=orders")
 	fmt.Println("  go run cmd/make/main.go -action=make:page -name=Dashboard")
+	fmt.Println("  go run ./cmd/make -action=make:action -resource=Order -name=approve")
+	fmt.Println("  go run ./cmd/make -action=make:migration -name=create_orders_table -dry-run")
+	fmt.Println("  go run ./cmd/make -action=make:migration -diff -name=add_order_note")
+	fmt.Println("  go run ./cmd/make -action=make:seeder -name=demo_orders -resource=Order")
 }
@@ -0,0 +1,490 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"fun-admin/cmd/bootstrap"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/config"
+	"fun-admin/pkg/database"
+	"fun-admin/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// Column kinds decide the admin field, list column, filter and Go type of a scaffolded column.
+const (
+	kindInteger   = "integer"
+	kindDecimal   = "decimal"
+	kindBoolean   = "boolean"
+	kindText      = "text"
+	kindEmail     = "email"
+	kindTextarea  = "textarea"
+	kindEnum      = "enum"
+	kindDate      = "date"
+	kindDateTime  = "datetime"
+	kindBinary    = "binary"
+	kindDeletedAt = "deleted_at"
+)
+
+// displayFieldCandidates are the columns preferred as the label of a related record.
+var displayFieldCandidates = []string{"name", "title", "label", "username", "nickname", "code"}
+
+// longTextColumns are column names edited as a textarea when the type does not tell.
+var longTextColumns = map[string]bool{
+	"content": true, "description": true, "body": true, "remark": true, "remarks": true,
+	"note": true, "notes": true, "summary": true, "detail": true, "details": true,
+}
+
+// tableSchema is the introspected structure of a database table.
+type tableSchema struct {
+	Name    string
+	Columns []*tableColumn
+}
+
+// tableColumn describes one column of an introspected table.
+type tableColumn struct {
+	Name          string
+	DatabaseType  string
+	Kind          string
+	Unsigned      bool
+	PrimaryKey    bool
+	AutoIncrement bool
+	Nullable      bool
+	Unique        bool
+	Size          int64
+	Default       string
+	HasDefault    bool
+	Comment       string
+	Options       []string
+	Indexes       []columnIndex
+	Reference     *foreignKey
+}
+
+// columnIndex is an index the column belongs to; composite indexes share the name.
+type columnIndex struct {
+	Name      string
+	Unique    bool
+	Composite bool
+}
+
+// foreignKey is the table a column refers to and how the related resource is displayed.
+type foreignKey struct {
+	Table        string
+	Column       string
+	Slug         string
+	DisplayField string
+	Registered   bool
+	Declared     bool
+}
+
+// Indexed reports whether the column is the primary key or part of an index.
+func (c *tableColumn) Indexed() bool {
+	return c.PrimaryKey || c.Unique || len(c.Indexes) > 0
+}
+
+// Required reports whether a value must be supplied when creating a record.
+func (c *tableColumn) Required() bool {
+	if c.PrimaryKey || c.Nullable || c.HasDefault || c.Kind == kindBoolean {
+		return false
+	}
+	return c.Name != "created_at" && c.Name != "updated_at"
+}
+
+// openDatabase connects to the database configured in the given config file.
+func openDatabase(confPath string) *gorm.DB {
+	conf := config.NewConfig(confPath)
+	return database.Primary(repository.NewDB(conf, logger.NewLogger(conf)))
+}
+
+// inspectTable reads columns, indexes and foreign keys of a table through GORM's Migrator.
+func inspectTable(db *gorm.DB, table string) (*tableSchema, error) {
+	migrator := db.Migrator()
+	if !migrator.HasTable(table) {
+		return nil, fmt.Errorf("table %s does not exist", table)
+	}
+
+	columnTypes, err := migrator.ColumnTypes(table)
+	if err != nil {
+		return nil, fmt.Errorf("read columns of %s: %w", table, err)
+	}
+	schema := &tableSchema{Name: table}
+	byName := make(map[string]*tableColumn, len(columnTypes))
+	for _, columnType := range columnTypes {
+		column := newTableColumn(db.Dialector.Name(), columnType)
+		schema.Columns = append(schema.Columns, column)
+		byName[column.Name] = column
+	}
+
+	indexes, err := migrator.GetIndexes(table)
+	if err != nil {
+		return nil, fmt.Errorf("read indexes of %s: %w", table, err)
+	}
+	for _, index := range indexes {
+		primary, _ := index.PrimaryKey()
+		unique, _ := index.Unique()
+		columns := index.Columns()
+		for _, name := range columns {
+			column, ok := byName[name]
+			if !ok {
+				continue
+			}
+			if primary {
+				column.PrimaryKey = true
+				continue
+			}
+			if unique && len(columns) == 1 {
+				column.Unique = true
+			}
+			column.Indexes = append(column.Indexes, columnIndex{Name: index.Name(), Unique: unique, Composite: len(columns) > 1})
+		}
+	}
+
+	references, err := foreignKeys(db, table)
+	if err != nil {
+		return nil, fmt.Errorf("read foreign keys of %s: %w", table, err)
+	}
+	for name, reference := range references {
+		if column, ok := byName[name]; ok {
+			reference.Declared = true
+			column.Reference = reference
+		}
+	}
+	for _, column := range schema.Columns {
+		if column.Reference == nil && column.Kind == kindInteger && strings.HasSuffix(column.Name, "_id") {
+			column.Reference = guessForeignKey(db, table, column.Name)
+		}
+	}
+	resolveReferences(db, schema)
+	return schema, nil
+}
+
+// newTableColumn converts the driver's column type to a scaffolding column.
+func newTableColumn(dialect string, columnType gorm.ColumnType) *tableColumn {
+	column := &tableColumn{
+		Name:         columnType.Name(),
+		DatabaseType: strings.ToLower(columnType.DatabaseTypeName()),
+		Nullable:     true,
+	}
+	if nullable, ok := columnType.Nullable(); ok {
+		column.Nullable = nullable
+	}
+	if primary, ok := columnType.PrimaryKey(); ok && primary {
+		column.PrimaryKey = true
+		column.Nullable = false
+	}
+	if increment, ok := columnType.AutoIncrement(); ok {
+		column.AutoIncrement = increment
+	}
+	if unique, ok := columnType.Unique(); ok {
+		column.Unique = unique
+	}
+	if comment, ok := columnType.Comment(); ok {
+		column.Comment = comment
+	}
+	if value, ok := columnType.DefaultValue(); ok {
+		column.Default, column.HasDefault = cleanDefault(value)
+	}
+
+	fullType, _ := columnType.ColumnType()
+	fullType = strings.ToLower(fullType)
+	column.Unsigned = strings.Contains(fullType, "unsigned") || strings.Contains(column.DatabaseType, "unsigned")
+	column.Kind = columnKind(dialect, column.Name, column.DatabaseType, fullType)
+	if column.Kind == kindBoolean || column.Kind == kindInteger || column.Kind == kindDecimal {
+		if looksBoolean(column) {
+			column.Kind = kindBoolean
+		}
+	}
+	switch column.Kind {
+	case kindText, kindEmail:
+		if length, ok := columnType.Length(); ok && length > 0 && length < 65535 {
+			column.Size = length
+		} else if size := typeSize(fullType); size > 0 {
+			column.Size = size
+		}
+	case kindEnum:
+		column.Options = enumOptions(fullType)
+	}
+	if strings.Contains(strings.ToLower(column.Default), "nextval(") {
+		column.AutoIncrement = true
+		column.Default, column.HasDefault = "", false
+	}
+	if column.AutoIncrement {
+		column.HasDefault = true
+	}
+	return column
+}
+
+// columnKind classifies a column by its database type; names only refine text columns.
+func columnKind(dialect, name, databaseType, fullType string) string {
+	if name == "deleted_at" {
+		return kindDeletedAt
+	}
+	if strings.HasPrefix(fullType, "enum(") {
+		return kindEnum
+	}
+	if fullType == "tinyint(1)" || fullType == "bit(1)" {
+		return kindBoolean
+	}
+	base := strings.TrimSpace(strings.Split(databaseType, "(")[0])
+	base = strings.TrimSuffix(base, " unsigned")
+	switch base {
+	case "bool", "boolean":
+		return kindBoolean
+	case "int", "integer", "tinyint", "smallint", "mediumint", "bigint", "int2", "int4", "int8",
+		"serial", "smallserial", "bigserial", "serial4", "serial8", "year":
+		return kindInteger
+	case "decimal", "numeric", "float", "float4", "float8", "double", "double precision", "real", "money":
+		return kindDecimal
+	case "date":
+		return kindDate
+	case "datetime", "timestamp", "timestamptz", "timestamp with time zone", "timestamp without time zone":
+		return kindDateTime
+	case "text":
+		// SQLite stores every string as text, so only names of long content become a textarea there
+		if dialect != "sqlite" || longTextColumns[name] {
+			return kindTextarea
+		}
+	case "mediumtext", "longtext", "clob", "json", "jsonb", "xml":
+		return kindTextarea
+	case "blob", "tinyblob", "mediumblob", "longblob", "bytea", "binary", "varbinary":
+		return kindBinary
+	}
+	if name == "email" || strings.HasSuffix(name, "_email") {
+		return kindEmail
+	}
+	return kindText
+}
+
+// looksBoolean detects flags stored as numbers, e.g. GORM's numeric bool on SQLite or tinyint is_* on MySQL.
+func looksBoolean(column *tableColumn) bool {
+	if column.Kind == kindBoolean {
+		return true
+	}
+	switch strings.ToLower(column.Default) {
+	case "true", "false":
+		return true
+	}
+	for _, prefix := range []string{"is_", "has_", "can_"} {
+		if strings.HasPrefix(column.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+var typeSizePattern = regexp.MustCompile(`\((\d+)\)`)
+
+// typeSize extracts the length from a type such as varchar(100).
+func typeSize(fullType string) int64 {
+	match := typeSizePattern.FindStringSubmatch(fullType)
+	if match == nil {
+		return 0
+	}
+	size, _ := strconv.ParseInt(match[1], 10, 64)
+	return size
+}
+
+var enumValuePattern = regexp.MustCompile(`'((?:[^']|'')*)'`)
+
+// enumOptions extracts the values of a MySQL enum('a','b') type.
+func enumOptions(fullType string) []string {
+	var options []string
+	for _, match := range enumValuePattern.FindAllStringSubmatch(fullType, -1) {
+		options = append(options, strings.ReplaceAll(match[1], "''", "'"))
+	}
+	return options
+}
+
+// cleanDefault strips quotes and Postgres casts from a column default; NULL means no default.
+func cleanDefault(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if index := strings.Index(value, "::"); index > 0 && !strings.Contains(value, "(") {
+		value = value[:index]
+	}
+	if value == "" || strings.EqualFold(value, "null") {
+		return "", false
+	}
+	if len(value) >= 2 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return value, true
+}
+
+// foreignKeyRow is one declared foreign key read from the database catalog.
+type foreignKeyRow struct {
+	Column           string `gorm:"column:column_name"`
+	ReferencedTable  string `gorm:"column:referenced_table"`
+	ReferencedColumn string `gorm:"column:referenced_column"`
+}
+
+// foreignKeys reads declared foreign keys; GORM has no portable API for this, so each dialect queries its catalog.
+func foreignKeys(db *gorm.DB, table string) (map[string]*foreignKey, error) {
+	var (
+		rows []foreignKeyRow
+		err  error
+	)
+	switch db.Dialector.Name() {
+	case "sqlite":
+		err = db.Raw(`SELECT "from" AS column_name, "table" AS referenced_table, "to" AS referenced_column
+			FROM pragma_foreign_key_list(?)`, table).Scan(&rows).Error
+	case "mysql":
+		err = db.Raw(`SELECT COLUMN_NAME AS column_name, REFERENCED_TABLE_NAME AS referenced_table,
+				REFERENCED_COLUMN_NAME AS referenced_column
+			FROM information_schema.KEY_COLUMN_USAGE
+			WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL`, table).Scan(&rows).Error
+	case "postgres":
+		err = db.Raw(`SELECT kcu.column_name AS column_name, ccu.table_name AS referenced_table,
+				ccu.column_name AS referenced_column
+			FROM information_schema.table_constraints tc
+			JOIN information_schema.key_column_usage kcu
+				ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+			JOIN information_schema.constraint_column_usage ccu
+				ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+			WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_name = ? AND tc.table_schema = CURRENT_SCHEMA()`, table).Scan(&rows).Error
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	references := make(map[string]*foreignKey, len(rows))
+	for _, row := range rows {
+		column := row.ReferencedColumn
+		if column == "" {
+			column = "id"
+		}
+		references[row.Column] = &foreignKey{Table: row.ReferencedTable, Column: column}
+	}
+	return references, nil
+}
+
+// guessForeignKey resolves <name>_id to an existing table when no constraint is declared,
+// which is the norm here because migrations run with foreign key constraints disabled.
+// Candidates are name and its plural, also prefixed like the table itself (admin_dictionary_data + type_id -> admin_dictionary_type).
+func guessForeignKey(db *gorm.DB, table, column string) *foreignKey {
+	base := strings.TrimSuffix(column, "_id")
+	if base == "" {
+		return nil
+	}
+	names := []string{base, pluralize(base)}
+	var candidates []string
+	for i := len(table) - 1; i > 0; i-- {
+		if table[i] == '_' {
+			for _, name := range names {
+				candidates = append(candidates, table[:i+1]+name)
+			}
+		}
+	}
+	candidates = append(candidates, names...)
+	for _, candidate := range candidates {
+		if candidate != table && db.Migrator().HasTable(candidate) {
+			return &foreignKey{Table: candidate, Column: "id"}
+		}
+	}
+	return nil
+}
+
+// resolveReferences maps referenced tables to registered resource slugs and picks their display field.
+func resolveReferences(db *gorm.DB, schema *tableSchema) {
+	slugs := registeredSlugs(db)
+	for _, column := range schema.Columns {
+		reference := column.Reference
+		if reference == nil {
+			continue
+		}
+		reference.Slug = reference.Table
+		if slug, ok := slugs[reference.Table]; ok {
+			reference.Slug, reference.Registered = slug, true
+		}
+
+		reference.DisplayField = reference.Column
+		related, err := db.Migrator().ColumnTypes(reference.Table)
+		if err != nil {
+			continue
+		}
+		existing := make(map[string]bool, len(related))
+		for _, columnType := range related {
+			existing[columnType.Name()] = true
+		}
+		for _, candidate := range displayFieldCandidates {
+			if existing[candidate] {
+				reference.DisplayField = candidate
+				break
+			}
+		}
+	}
+}
+
+// registeredSlugs maps the table of every registered resource model to the resource slug.
+func registeredSlugs(db *gorm.DB) map[string]string {
+	bootstrap.RegisterResources()
+	slugs := make(map[string]string)
+	for _, resource := range admin.GlobalResourceManager.GetResources() {
+		model := resource.GetModel()
+		if model == nil {
+			continue
+		}
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			continue
+		}
+		slugs[stmt.Schema.Table] = resource.GetSlug()
+	}
+	return slugs
+}
+
+// columns returns the columns that pass the filter, in table order.
+func (s *tableSchema) columns(keep func(*tableColumn) bool) []*tableColumn {
+	var columns []*tableColumn
+	for _, column := range s.Columns {
+		if keep(column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// primaryKeys returns the primary key column names.
+func (s *tableSchema) primaryKeys() []string {
+	var names []string
+	for _, column := range s.Columns {
+		if column.PrimaryKey {
+			names = append(names, column.Name)
+		}
+	}
+	return names
+}
+
+// singularize turns a plural table name into a resource name: orders -> order, categories -> category.
+func singularize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lower, "sses"), strings.HasSuffix(lower, "xes"),
+		strings.HasSuffix(lower, "ches"), strings.HasSuffix(lower, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lower, "s") && !strings.HasSuffix(lower, "ss") && !strings.HasSuffix(lower, "us"):
+		return name[:len(name)-1]
+	}
+	return name
+}
+
+// pluralize is the inverse of singularize for the common English cases.
+func pluralize(name string) string {
+	lower := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lower, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(lower, "s"), strings.HasSuffix(lower, "x"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
@@ -0,0 +1,554 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fun-admin/pkg/admin"
+)
+
+// initialisms are column words written in upper case in Go field names.
+var initialisms = map[string]string{
+	"id": "ID", "ip": "IP", "url": "URL", "uri": "URI", "uuid": "UUID",
+	"api": "API", "json": "JSON", "html": "HTML", "sql": "SQL",
+}
+
+// makeResourceFromTable scaffolds a resource and its GORM model from an existing database table.
+func makeResourceFromTable(name, table, confPath string) {
+	fmt.Printf("Creating resource from table: %s\n", table)
+	if name == "" {
+		name = singularize(table)
+	}
+
+	baseName, snakeName, _, title, err := prepareNames(name)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	schema, err := inspectTable(openDatabase(confPath), table)
+	if err != nil {
+		fmt.Printf("Error: %s\n", err)
+		return
+	}
+
+	// the resource repository addresses records by slug, so the slug must be the table name
+	resourceContent, err := buildTableResourceContent(schema, baseName, title, table)
+	if err != nil {
+		fmt.Printf("Error generating resource: %v\n", err)
+		return
+	}
+	modelContent, err := buildModelContent(schema, baseName)
+	if err != nil {
+		fmt.Printf("Error generating model: %v\n", err)
+		return
+	}
+
+	resourcePath := filepath.Join("internal", "resources", fmt.Sprintf("%s_resource.go", snakeName))
+	modelPath := filepath.Join("internal", "model", fmt.Sprintf("%s.go", snakeName))
+	for _, path := range []string{resourcePath, modelPath} {
+		if _, err := os.Stat(path); err == nil && !force {
+			fmt.Printf("Error: file already exists: %s (use -force to overwrite)\n", path)
+			return
+		}
+	}
+	for path, content := range map[string]string{modelPath: modelContent, resourcePath: resourceContent} {
+		if err := ensureDir(filepath.Dir(path)); err != nil {
+			fmt.Printf("Failed to ensure directory %s: %v\n", filepath.Dir(path), err)
+			return
+		}
+		if err := createFile(path, content); err != nil {
+			fmt.Printf("Error creating %s: %v\n", path, err)
+			return
+		}
+	}
+
+	reportCreated("Model", modelPath)
+	reportCreated("Resource", resourcePath)
+	writeTranslations(tableTranslations(schema, table, title))
+	for _, column := range schema.Columns {
+		if reference := column.Reference; reference != nil && !reference.Registered {
+			fmt.Printf("Note: %s refers to table %s, which has no registered resource; scaffold it with -table=%s\n",
+				column.Name, reference.Table, reference.Table)
+		}
+	}
+	registerResource(baseName)
+}
+
+// buildTableResourceContent renders a resource whose fields, columns and filters follow the table structure.
+func buildTableResourceContent(schema *tableSchema, baseName, title, slug string) (string, error) {
+	typeName := baseName + "Resource"
+	label := func(kind string, column *tableColumn) string {
+		return strconv.Quote(columnLabelKey(slug, kind, column))
+	}
+
+	var b strings.Builder
+	b.WriteString(`package resources
+
+import (
+	"fun-admin/internal/model"
+	"fun-admin/pkg/admin"
+)
+`)
+	for _, column := range schema.Columns {
+		if column.Kind != kindEnum {
+			continue
+		}
+		fmt.Fprintf(&b, "\nvar %s = []admin.Option{\n", optionsName(baseName, column))
+		for _, option := range column.Options {
+			fmt.Fprintf(&b, "{Value: %q, Label: %q},\n", option, option)
+		}
+		b.WriteString("}\n")
+	}
+
+	fmt.Fprintf(&b, `
+// %[1]s defines the admin resource for %[2]s, scaffolded from table %[3]s.
+type %[1]s struct {
+	admin.BaseResource
+}
+
+// New%[4]sResource creates a new %[2]s resource instance.
+func New%[4]sResource() *%[1]s {
+	return &%[1]s{}
+}
+
+// GetTitle returns the translation key of the resource title; see locales/*.yaml.
+func (r *%[1]s) GetTitle() string {
+	return %[5]q
+}
+
+// GetSlug returns the resource slug that is used in API routes.
+func (r *%[1]s) GetSlug() string {
+	return %[6]q
+}
+
+// GetModel returns the underlying model for the resource.
+func (r *%[1]s) GetModel() interface{} {
+	return &model.%[4]s{}
+}
+
+// GetFields returns the editable fields for the resource.
+func (r *%[1]s) GetFields() []admin.Field {
+	return []admin.Field{
+`, typeName, title, schema.Name, baseName, admin.LabelKey(slug, "title"), slug)
+	for _, column := range schema.Columns {
+		if expr := fieldExpr(column, baseName, label("fields", column)); expr != "" {
+			fmt.Fprintf(&b, "%s,\n", expr)
+		}
+	}
+	fmt.Fprintf(&b, `}
+}
+
+// GetActions returns the actions that can be executed on the resource.
+func (r *%[1]s) GetActions() []admin.Action {
+	return []admin.Action{
+		admin.NewViewAction().Label(%[3]q),
+		admin.NewEditAction().Label(%[4]q),
+		admin.NewDeleteAction().Label(%[5]q),
+	}
+}
+
+// GetReadOnlyFields returns the readonly field names that should not be editable.
+func (r *%[1]s) GetReadOnlyFields() []string {
+	return %[2]s
+}
+
+// GetColumns returns the table columns displayed in the list view.
+func (r *%[1]s) GetColumns() []*admin.Column {
+	return []*admin.Column{
+`, typeName, stringSlice(schema.readOnlyFields()), admin.LabelKey(slug, "actions", "view"),
+		admin.LabelKey(slug, "actions", "edit"), admin.LabelKey(slug, "actions", "delete"))
+	for _, column := range schema.columns(listed) {
+		expr := fmt.Sprintf("admin.NewColumn(%q, %s, %q)", column.Name, label("columns", column), listColumnType(column))
+		if sortable(column) {
+			expr += ".SetSortable(true)"
+		}
+		fmt.Fprintf(&b, "%s,\n", expr)
+	}
+	fmt.Fprintf(&b, `}
+}
+
+// GetFilters returns the filters available for the list view.
+func (r *%[1]s) GetFilters() []*admin.Filter {
+	return []*admin.Filter{
+`, typeName)
+	var filterable []string
+	for _, column := range schema.Columns {
+		filterType := filterType(column)
+		if filterType == "" {
+			continue
+		}
+		filterable = append(filterable, column.Name)
+		expr := fmt.Sprintf("admin.NewFilter(%q, %s, %q)", column.Name, label("filters", column), filterType)
+		if column.Kind == kindEnum {
+			expr += fmt.Sprintf(".SetOptions(%s)", optionsName(baseName, column))
+		}
+		fmt.Fprintf(&b, "%s,\n", expr)
+	}
+	fmt.Fprintf(&b, `}
+}
+
+// GetSearchableFields returns the fields matched by the keyword search.
+func (r *%[1]s) GetSearchableFields() []string {
+	return %[2]s
+}
+
+// GetFilterableFields returns the fields that accept filter values.
+func (r *%[1]s) GetFilterableFields() []string {
+	return %[3]s
+}
+
+// GetSortableFields returns the fields the list can be ordered by.
+func (r *%[1]s) GetSortableFields() []string {
+	return %[4]s
+}
+`, typeName, stringSlice(columnNames(schema.columns(searchable))), stringSlice(filterable),
+		stringSlice(columnNames(schema.columns(sortable))))
+
+	source, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(source), nil
+}
+
+// editableColumn reports whether a column gets an admin field; soft-delete and binary columns do not.
+func editableColumn(column *tableColumn) bool {
+	return column.Kind != kindDeletedAt && column.Kind != kindBinary
+}
+
+// fieldExpr is the admin field declaration of a column, empty when the column is not editable.
+func fieldExpr(column *tableColumn, baseName, label string) string {
+	if !editableColumn(column) {
+		return ""
+	}
+	if column.PrimaryKey && column.Name == "id" {
+		return fmt.Sprintf("admin.NewIDField().Label(%s)", label)
+	}
+
+	var constructor string
+	var options []string
+	switch {
+	case column.Reference != nil:
+		constructor = fmt.Sprintf("admin.NewRelationshipField(%q, %q)", column.Name, column.Reference.Slug)
+		options = append(options, fmt.Sprintf("SetDisplayField(%q)", column.Reference.DisplayField))
+	case column.Kind == kindInteger || column.Kind == kindDecimal:
+		constructor = fmt.Sprintf("admin.NewNumberField(%q)", column.Name)
+		if value, err := strconv.Atoi(column.Default); err == nil && column.HasDefault && !column.AutoIncrement {
+			options = append(options, fmt.Sprintf("SetDefault(%d)", value))
+		}
+	case column.Kind == kindBoolean:
+		constructor = fmt.Sprintf("admin.NewBooleanField(%q)", column.Name)
+		if value, err := strconv.ParseBool(column.Default); err == nil && column.HasDefault {
+			options = append(options, fmt.Sprintf("SetDefault(%t)", value))
+		}
+	case column.Kind == kindEnum:
+		constructor = fmt.Sprintf("admin.NewSelectField(%q)", column.Name)
+		options = append(options, fmt.Sprintf("SetOptions(%s)", optionsName(baseName, column)))
+		if column.HasDefault {
+			options = append(options, fmt.Sprintf("SetDefault(%q)", column.Default))
+		}
+	case column.Kind == kindDate:
+		constructor = fmt.Sprintf("admin.NewDateField(%q)", column.Name)
+	case column.Kind == kindDateTime:
+		constructor = fmt.Sprintf("admin.NewDateTimeField(%q)", column.Name)
+	case column.Kind == kindTextarea:
+		constructor = fmt.Sprintf("admin.NewTextareaField(%q)", column.Name)
+		options = append(options, "SetRows(3)")
+	case column.Kind == kindEmail:
+		constructor = fmt.Sprintf("admin.NewEmailField(%q)", column.Name)
+	default:
+		constructor = fmt.Sprintf("admin.NewTextField(%q)", column.Name)
+	}
+	if column.Size > 0 && (column.Kind == kindText || column.Kind == kindEmail) {
+		options = append(options, fmt.Sprintf("AddValidator(admin.NewMaxLengthValidator(%d))", column.Size))
+	}
+
+	expr := constructor + ".Label(" + label + ")"
+	if column.Required() {
+		expr += ".Required()"
+	}
+	for _, option := range options {
+		expr += "." + option
+	}
+	return expr
+}
+
+// listed reports whether the column is shown in the list view; long text and binary data are left to the detail view.
+func listed(column *tableColumn) bool {
+	switch column.Kind {
+	case kindTextarea, kindBinary, kindDeletedAt:
+		return false
+	}
+	return true
+}
+
+// listColumnType is the list column type of a column.
+func listColumnType(column *tableColumn) string {
+	switch column.Kind {
+	case kindInteger, kindDecimal:
+		if column.Reference != nil {
+			return "text"
+		}
+		return "number"
+	case kindBoolean:
+		return "boolean"
+	case kindDate:
+		return "date"
+	case kindDateTime:
+		return "datetime"
+	}
+	return "text"
+}
+
+// sortable reports whether ordering by the column is cheap or meaningful: keys, indexed columns, numbers and times.
+func sortable(column *tableColumn) bool {
+	if !listed(column) {
+		return false
+	}
+	switch column.Kind {
+	case kindInteger, kindDecimal, kindDate, kindDateTime:
+		return true
+	}
+	return column.Indexed()
+}
+
+// searchable reports whether the keyword search matches the column.
+func searchable(column *tableColumn) bool {
+	return !column.PrimaryKey && column.Reference == nil && (column.Kind == kindText || column.Kind == kindEmail)
+}
+
+// filterType is the list filter type of a column; unindexed text and numbers get no filter.
+func filterType(column *tableColumn) string {
+	if column.PrimaryKey {
+		return ""
+	}
+	switch {
+	case column.Reference != nil:
+		return "text"
+	case column.Kind == kindBoolean:
+		return "boolean"
+	case column.Kind == kindEnum:
+		return "select"
+	case column.Kind == kindDate || column.Kind == kindDateTime:
+		return "daterange"
+	case (column.Kind == kindInteger || column.Kind == kindDecimal) && column.Indexed():
+		return "numberrange"
+	case (column.Kind == kindText || column.Kind == kindEmail) && column.Indexed():
+		return "text"
+	}
+	return ""
+}
+
+// readOnlyFields are the primary keys and the timestamps maintained by GORM.
+func (s *tableSchema) readOnlyFields() []string {
+	fields := s.primaryKeys()
+	for _, column := range s.Columns {
+		if (column.Name == "created_at" || column.Name == "updated_at") && !column.PrimaryKey {
+			fields = append(fields, column.Name)
+		}
+	}
+	return fields
+}
+
+// buildModelContent renders the GORM model of the table; tags keep column names, sizes, defaults and indexes.
+func buildModelContent(schema *tableSchema, baseName string) (string, error) {
+	var usesTime, usesGorm bool
+	var fields strings.Builder
+	for _, column := range schema.Columns {
+		goType := goFieldType(column)
+		usesTime = usesTime || strings.Contains(goType, "time.")
+		usesGorm = usesGorm || strings.Contains(goType, "gorm.")
+		fmt.Fprintf(&fields, "%s %s `gorm:%q json:%q`", goFieldName(column.Name), goType, gormTag(column), column.Name)
+		if column.Comment != "" {
+			fmt.Fprintf(&fields, " // %s", strings.ReplaceAll(column.Comment, "\n", " "))
+		}
+		fields.WriteString("\n")
+	}
+
+	var b strings.Builder
+	b.WriteString("package model\n\n")
+	switch {
+	case usesTime && usesGorm:
+		b.WriteString("import (\n\"time\"\n\n\"gorm.io/gorm\"\n)\n\n")
+	case usesTime:
+		b.WriteString("import \"time\"\n\n")
+	case usesGorm:
+		b.WriteString("import \"gorm.io/gorm\"\n\n")
+	}
+	fmt.Fprintf(&b, `// %[1]s maps table %[2]s.
+type %[1]s struct {
+%[3]s}
+
+// TableName returns the table name.
+func (%[1]s) TableName() string {
+	return %[2]q
+}
+`, baseName, schema.Name, fields.String())
+
+	source, err := format.Source([]byte(b.String()))
+	if err != nil {
+		return "", err
+	}
+	return string(source), nil
+}
+
+// goFieldType is the Go type of a column; nullable columns become pointers.
+func goFieldType(column *tableColumn) string {
+	var goType string
+	switch column.Kind {
+	case kindDeletedAt:
+		return "gorm.DeletedAt"
+	case kindBinary:
+		return "[]byte"
+	case kindInteger:
+		switch {
+		case column.PrimaryKey || column.Reference != nil:
+			goType = "uint"
+		case strings.HasPrefix(column.DatabaseType, "bigint") || column.DatabaseType == "int8" || column.DatabaseType == "bigserial":
+			goType = "int64"
+		default:
+			goType = "int"
+		}
+		if column.Unsigned && !strings.HasPrefix(goType, "uint") {
+			goType = "u" + goType
+		}
+	case kindDecimal:
+		goType = "float64"
+	case kindBoolean:
+		goType = "bool"
+	case kindDate, kindDateTime:
+		goType = "time.Time"
+	default:
+		goType = "string"
+	}
+	if column.Nullable && !column.PrimaryKey {
+		return "*" + goType
+	}
+	return goType
+}
+
+// gormTag describes the column to GORM so that migrations keep the existing structure.
+func gormTag(column *tableColumn) string {
+	parts := []string{"column:" + column.Name}
+	if column.PrimaryKey {
+		parts = append(parts, "primarykey")
+	} else if column.AutoIncrement {
+		parts = append(parts, "autoIncrement")
+	}
+	if column.Size > 0 {
+		parts = append(parts, fmt.Sprintf("size:%d", column.Size))
+	}
+	if !column.Nullable && !column.PrimaryKey {
+		parts = append(parts, "not null")
+	}
+	if column.HasDefault && !column.AutoIncrement && !strings.Contains(column.Default, ";") {
+		parts = append(parts, "default:"+column.Default)
+	}
+	uniqueIndexed := false
+	for _, index := range column.Indexes {
+		if index.Unique {
+			uniqueIndexed = true
+			parts = append(parts, "uniqueIndex:"+index.Name)
+		} else {
+			parts = append(parts, "index:"+index.Name)
+		}
+	}
+	if column.Unique && !uniqueIndexed {
+		parts = append(parts, "unique")
+	}
+	if column.Comment != "" && !strings.Contains(column.Comment, ";") {
+		parts = append(parts, "comment:"+column.Comment)
+	}
+	return strings.Join(parts, ";")
+}
+
+// goFieldName converts a column name to an exported Go field name: type_id -> TypeID.
+func goFieldName(column string) string {
+	var b strings.Builder
+	for _, word := range splitWords(column) {
+		if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(capitalize(word))
+	}
+	name := b.String()
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Column" + name
+	}
+	return name
+}
+
+// optionsName is the package variable holding the options of an enum column.
+func optionsName(baseName string, column *tableColumn) string {
+	runes := []rune(baseName)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes) + toPascal(splitWords(column.Name)) + "Options"
+}
+
+// columnLabelKey is the label key of a column in the fields, columns or filters namespace;
+// like declarative resources, columns and filters reuse the field key when the column is editable.
+func columnLabelKey(slug, kind string, column *tableColumn) string {
+	if editableColumn(column) {
+		kind = "fields"
+	}
+	return admin.LabelKey(slug, kind, column.Name)
+}
+
+// tableTranslations are the title, action and column label keys; Chinese labels use the column comment when present.
+func tableTranslations(schema *tableSchema, slug, title string) map[string][][2]string {
+	zh := map[string]string{"id": "ID", "created_at": "创建时间", "updated_at": "更新时间", "deleted_at": "删除时间"}
+	entries := map[string][][2]string{
+		"zh-CN": append([][2]string{{admin.LabelKey(slug, "title"), title}}, actionTranslations(slug, "查看", "编辑", "删除")...),
+		"en":    append([][2]string{{admin.LabelKey(slug, "title"), title}}, actionTranslations(slug, "View", "Edit", "Delete")...),
+	}
+	for _, column := range schema.Columns {
+		words := capitalizeWords(splitWords(column.Name))
+		for i, word := range words {
+			if initialism, ok := initialisms[strings.ToLower(word)]; ok {
+				words[i] = initialism
+			}
+		}
+		english := strings.Join(words, " ")
+		chinese := column.Comment
+		if chinese == "" {
+			chinese = zh[column.Name]
+		}
+		if chinese == "" {
+			chinese = english
+		}
+		kinds := []string{"fields"}
+		if !editableColumn(column) {
+			kinds = []string{"columns", "filters"}
+		}
+		for _, kind := range kinds {
+			key := admin.LabelKey(slug, kind, column.Name)
+			entries["zh-CN"] = append(entries["zh-CN"], [2]string{key, chinese})
+			entries["en"] = append(entries["en"], [2]string{key, english})
+		}
+	}
+	return entries
+}
+
+func columnNames(columns []*tableColumn) []string {
+	names := make([]string, 0, len(columns))
+	for _, column := range columns {
+		names = append(names, column.Name)
+	}
+	return names
+}
+
+// stringSlice renders a []string literal.
+func stringSlice(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, strconv.Quote(value))
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
@@ -3,22 +3,65 @@ package main
 import (
 	"context"
 	"flag"
+	"fmt"
+	"fun-admin/cmd/bootstrap"
+	"fun-admin/internal/migrate"
 	"fun-admin/internal/repository"
+	"fun-admin/internal/seeder"
+	"fun-admin/internal/seeders"
 	"fun-admin/internal/server"
-	"fun-admin/pkg/app"
+	"fun-admin/pkg/admin"
 	"fun-admin/pkg/config"
+	"fun-admin/pkg/database"
 	"fun-admin/pkg/logger"
-	"fun-admin/pkg/sid"
 	"os"
 
 	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
+const usage = `Usage: migration [command] [flags]
+
+Commands:
+  (none)       install: create the base and resource tables, run pending migrations, seed the initial data
+  status       list the versioned migrations and whether they have run
+  up           run the pending migrations as a new batch
+  down         roll back the last batch, or the last -step migrations
+  redo         roll back and re-run the last batch, or the last -step migrations
+  fresh        drop every table on every connection, then install again (requires -allow-drop)
+  seed [name]  run every seeder, or the named seeder after its dependencies
+
+Flags:
+`
+
 func main() {
 	var envConf = flag.String("conf", "config/local.yml", "config path, eg: -conf ./config/local.yml")
 	var allowDrop = flag.Bool("allow-drop", false, "confirm dropping existing tables before migration")
 	var adminPasswordFlag = flag.String("admin-password", "", "initial admin password (if empty, a random password will be generated)")
-	flag.Parse()
+	var step = flag.Int("step", 0, "number of migrations to roll back with down/redo (0 = the last batch)")
+	var randSeed = flag.Int64("rand-seed", 0, "random seed for factories, the same seed generates the same records (0 = random)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	// the command and seeder name may come before, between or after the flags
+	var positional []string
+	for args := os.Args[1:]; ; {
+		_ = flag.CommandLine.Parse(args)
+		if flag.NArg() == 0 {
+			break
+		}
+		positional = append(positional, flag.Arg(0))
+		args = flag.Args()[1:]
+	}
+	command, target := "", ""
+	if len(positional) > 0 {
+		command = positional[0]
+	}
+	if len(positional) > 1 {
+		target = positional[1]
+	}
 	conf := config.NewConfig(*envConf)
 
 	adminPassword := *adminPasswordFlag
@@ -27,23 +70,142 @@ func main() {
 	}
 
 	log := logger.NewLogger(conf)
-	db := repository.NewDB(conf, log)
+	// schema changes and seeders must see their own writes, keep them off the replicas
+	db := database.Primary(repository.NewDB(conf, log))
+	ctx := context.Background()
+	if command == "fresh" {
+		if !*allowDrop {
+			fmt.Println("Error: fresh drops every table; pass -allow-drop to confirm")
+			os.Exit(1)
+		}
+		// drop before the enforcer below creates casbin_rule again
+		dropped, err := migrate.NewRunner(db, nil).DropAllTables(ctx)
+		printIDs("Dropped", dropped)
+		exitOnError(err)
+	}
 	enforcer := repository.NewCasbinEnforcer(conf, log, db)
-	baseSid, err := sid.NewSid()
+	bootstrap.RegisterResources()
+	bootstrap.LoadResourceDefinitions(conf, log)
+	bootstrap.RegisterMigrations()
+	bootstrap.RegisterInitialSeeders(log, enforcer, adminPassword)
+	bootstrap.RegisterSeeders()
+
+	runner := migrate.NewRunner(db, migrate.Migrations())
+	switch command {
+	case "", "install", "fresh":
+	case "status":
+		exitOnError(printStatus(ctx, db, runner))
+		return
+	case "up":
+		applied, err := runner.Up(ctx)
+		printIDs("Migrated", applied)
+		exitOnError(err)
+		return
+	case "down":
+		rolledBack, err := runner.Down(ctx, *step)
+		printIDs("Rolled back", rolledBack)
+		exitOnError(err)
+		return
+	case "redo":
+		applied, err := runner.Redo(ctx, *step)
+		printIDs("Migrated", applied)
+		exitOnError(err)
+		return
+	case "seed":
+		var names []string
+		if target != "" {
+			names = append(names, target)
+		}
+		ran, err := seeder.Run(seeder.WithRandSeed(ctx, *randSeed), db, names...)
+		printIDs("Seeded", ran)
+		exitOnError(err)
+		return
+	default:
+		fmt.Printf("Error: unknown command '%s'\n\n", command)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// install: the enforcer created casbin_rule above, so only the application tables remain
+	if err := server.NewMigrateServer(db, log, *allowDrop).Start(ctx); err != nil {
+		log.Fatal("migration run error", zap.Error(err))
+	}
+	ran, err := seeder.Run(ctx, db, seeders.Initial)
+	printIDs("Seeded", ran)
+	exitOnError(err)
+}
+
+// printStatus lists every migration, then how the database differs from the models and resources.
+func printStatus(ctx context.Context, db *gorm.DB, runner *migrate.Runner) error {
+	statuses, err := runner.Status(ctx)
 	if err != nil {
-		log.Fatal("create sid error", zap.Error(err))
+		return err
+	}
+	if len(statuses) == 0 {
+		fmt.Println("No migrations registered.")
+	}
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
+		switch {
+		case status.Missing:
+			fmt.Printf("  [missing] %s (batch %d, not registered)\n", status.ID, status.Batch)
+		case status.Applied:
+			fmt.Printf("  [applied] %s (batch %d, %s)\n", status.ID, status.Batch, status.AppliedAt.Format("2006-01-02 15:04:05"))
+		default:
+			fmt.Printf("  [pending] %s\n", status.ID)
+		}
 	}
 
-	migrateServer := server.NewMigrateServer(db, log, baseSid, enforcer, *allowDrop, adminPassword)
+	tables, err := migrate.Tables(db, server.Models(), admin.GlobalResourceManager.GetResources())
+	if err != nil {
+		return err
+	}
+	diffs, err := migrate.Diff(db, tables)
+	if err != nil {
+		return err
+	}
+	switch {
+	case len(diffs) == 0:
+	case pending > 0:
+		fmt.Println("\nThe database differs from the models and resources (run the pending migrations first):")
+	default:
+		fmt.Println("\nThe database differs from the models and resources (generate a migration with: go run ./cmd/make -action make:migration -diff):")
+	}
+	for _, diff := range diffs {
+		// tables on a named connection are shown as connection:table
+		table := diff.Table
+		if diff.Connection != "" {
+			table = diff.Connection + ":" + table
+		}
+		if diff.Create {
+			fmt.Printf("  create table %s\n", table)
+			continue
+		}
+		for _, column := range diff.Columns {
+			fmt.Printf("  add column %s.%s\n", table, column.DBName)
+		}
+		for _, index := range diff.Indexes {
+			fmt.Printf("  add index %s.%s\n", table, index.Name)
+		}
+	}
+	return nil
+}
 
-	// 创建应用
-	migrationApp := app.NewApp(
-		app.WithServer(migrateServer),
-		app.WithName("fun-migration"),
-	)
+func printIDs(verb string, ids []string) {
+	if len(ids) == 0 {
+		fmt.Printf("%s: nothing to do\n", verb)
+	}
+	for _, id := range ids {
+		fmt.Printf("%s: %s\n", verb, id)
+	}
+}
 
-	// 运行应用
-	if err := migrationApp.Run(context.Background()); err != nil {
-		log.Error("migration run error", zap.Error(err))
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
 }
@@ -37,7 +37,7 @@ func main() {
 	log := logger.NewLogger(conf)
 
 	bootstrap.EnsureStorage(log)
-	bootstrap.InitI18n()
+	bootstrap.InitI18n(conf)
 
 	containerManager := bootstrap.InitContainer(*envConf)
 	cacheManager := bootstrap.InitCache(conf)
@@ -46,6 +46,8 @@ func main() {
 	syncedEnforcer := containerManager.MustGet("enforcer").(*casbin.SyncedEnforcer)
 
 	bootstrap.InitAdmin(containerManager, cacheManager, log, db, syncedEnforcer)
+	definitions := bootstrap.LoadResourceDefinitions(conf, log)
+	bootstrap.WatchResourceDefinitions(context.Background(), conf, log, definitions)
 
 	httpServer := containerManager.MustGet("http_server").(server.Server)
 	jobServer := containerManager.MustGet("job_server").(server.Server)
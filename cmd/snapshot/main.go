@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"fun-admin/cmd/bootstrap"
+	"fun-admin/internal/repository"
+	"fun-admin/internal/service"
+	"fun-admin/internal/snapshot"
+	"fun-admin/pkg/config"
+	"fun-admin/pkg/database"
+	"fun-admin/pkg/logger"
+	"os"
+	"strings"
+	"time"
+)
+
+const usage = `Usage: snapshot <command> [flags]
+
+Commands:
+  export   write every registered resource, the core tables and attachment files to an archive
+  import   restore an archive into the configured database, keeping the primary keys
+
+Conflict policies (import -conflict), for rows whose primary key already exists:
+  skip     keep the existing row (default)
+  update   overwrite the existing row
+  fail     abort and roll back the whole import
+  replace  empty every table in the archive first
+
+Flags:
+`
+
+func main() {
+	var envConf = flag.String("conf", "config/local.yml", "config path, eg: -conf ./config/local.yml")
+	var out = flag.String("out", "", "export: archive path (default snapshot-<time>.zip)")
+	var in = flag.String("in", "", "import: archive path")
+	var conflict = flag.String("conflict", snapshot.ConflictSkip, "import: conflict policy (skip, update, fail, replace)")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), usage)
+		flag.PrintDefaults()
+	}
+
+	// the command may come before or after the flags
+	var command string
+	for args := os.Args[1:]; ; {
+		_ = flag.CommandLine.Parse(args)
+		if flag.NArg() == 0 {
+			break
+		}
+		if command == "" {
+			command = flag.Arg(0)
+		}
+		args = flag.Args()[1:]
+	}
+	if command != "export" && command != "import" {
+		flag.Usage()
+		os.Exit(1)
+	}
+	if command == "import" {
+		if *in == "" {
+			fmt.Println("Error: import requires -in <archive>")
+			os.Exit(1)
+		}
+		exitOnError(snapshot.ValidConflict(*conflict))
+	}
+
+	conf := config.NewConfig(*envConf)
+	log := logger.NewLogger(conf)
+	db := database.Primary(repository.NewDB(conf, log))
+	bootstrap.RegisterResources()
+	bootstrap.LoadResourceDefinitions(conf, log)
+	snapshotService := service.NewSnapshotService(db, service.NewFileService(log, conf), nil)
+
+	ctx := context.Background()
+	if command == "export" {
+		path := *out
+		if path == "" {
+			path = fmt.Sprintf("snapshot-%s.zip", time.Now().Format("20060102-150405"))
+		}
+		exitOnError(export(ctx, snapshotService, path))
+		return
+	}
+	exitOnError(restore(ctx, snapshotService, *in, *conflict))
+}
+
+func export(ctx context.Context, snapshotService service.SnapshotService, path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	manifest, err := snapshotService.Export(ctx, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return err
+	}
+
+	for _, table := range manifest.Tables {
+		fmt.Printf("Exported: %s (%d rows)\n", table.Name, table.Rows)
+	}
+	fmt.Printf("Files: %d\n", len(manifest.Files))
+	printWarnings(manifest.Warnings)
+	fmt.Printf("Snapshot written to %s\n", path)
+	return nil
+}
+
+func restore(ctx context.Context, snapshotService service.SnapshotService, path, conflict string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	stat, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	result, err := snapshotService.Import(ctx, file, stat.Size(), conflict)
+	if err != nil {
+		var validationErr *service.ValidationError
+		if errors.As(err, &validationErr) {
+			var messages []string
+			for field, fieldMessages := range validationErr.Errors {
+				messages = append(messages, field+": "+strings.Join(fieldMessages, "; "))
+			}
+			return errors.New(strings.Join(messages, ", "))
+		}
+		return err
+	}
+	for _, table := range result.Tables {
+		fmt.Printf("Imported: %s (%d of %d rows)\n", table.Name, table.Imported, table.Rows)
+	}
+	fmt.Printf("Files: %d\n", result.Files)
+	printWarnings(result.Warnings)
+	fmt.Println("Restart running servers so they reload the Casbin policies.")
+	return nil
+}
+
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+}
+
+func exitOnError(err error) {
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
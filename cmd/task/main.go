@@ -3,11 +3,16 @@ package main
 import (
 	"context"
 	"flag"
+	"fun-admin/cmd/bootstrap"
 	"fun-admin/internal/repository"
 	"fun-admin/internal/server"
+	"fun-admin/internal/service"
 	"fun-admin/internal/task"
+	"fun-admin/pkg/admin"
 	"fun-admin/pkg/app"
+	"fun-admin/pkg/cache"
 	"fun-admin/pkg/config"
+	"fun-admin/pkg/jwt"
 	"fun-admin/pkg/logger"
 	"fun-admin/pkg/sid"
 
@@ -27,6 +32,18 @@ func main() {
 	repo := repository.NewRepository(log, db, nil)
 	transaction := repository.NewTransaction(repo)
 	userRepo := repository.NewUserRepository(log, db, nil)
+	attachmentRepo := repository.NewAttachmentRepository(log, db)
+	reportRepo := repository.NewScheduledReportRepository(log, db)
+	fileService := service.NewFileService(log, conf)
+
+	// 定时报表经由资源导出流程执行，需要注册资源
+	bootstrap.InitI18n(conf)
+	bootstrap.InitAdmin(nil, nil, log, db, nil)
+	bootstrap.LoadResourceDefinitions(conf, log)
+	resourceRepo := repository.NewResourceRepository(*repo)
+	translationRepo := repository.NewTranslationRepository(repo)
+	resourceService := service.NewResourceService(resourceRepo, attachmentRepo, translationRepo, admin.GlobalResourceManager, cache.NewMemoryCacheManager())
+	configService := service.NewConfigService(repo, repository.NewConfigRepository(repo))
 
 	// 创建任务组件
 	baseSid, err := sid.NewSid()
@@ -35,7 +52,11 @@ func main() {
 	}
 	baseTask := task.NewTask(transaction, log, baseSid)
 	userTask := task.NewUserTask(baseTask, userRepo)
-	taskServer := server.NewTaskServer(log, userTask)
+	attachmentTask := task.NewAttachmentTask(baseTask, attachmentRepo, fileService)
+	baseService := service.NewService(transaction, log, baseSid, jwt.NewJwt(conf))
+	reportService := service.NewScheduledReportService(baseService, reportRepo, resourceService, fileService, configService)
+	reportTask := task.NewReportTask(baseTask, reportService)
+	taskServer := server.NewTaskServer(log, userTask, attachmentTask, reportTask)
 
 	// 创建应用并运行
 	taskApp := app.NewApp(
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"fun-admin/cmd/bootstrap"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/docs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// typegen 根据已注册资源生成前端 TypeScript 类型与 API 客户端
+// -check 时仅比对生成结果与已有文件，不一致返回非零退出码，供 CI 发现前后端结构不一致
+func main() {
+	var (
+		confPath = flag.String("conf", "config/local.yml", "config path, eg: -conf ./config/local.yml")
+		outDir   = flag.String("out", "web/src/api/generated", "output directory of types.ts and client.ts")
+		prefix   = flag.String("prefix", "/api/admin/v1", "API path prefix used by the generated client")
+		language = flag.String("lang", "", "language of comments, defaults to the default language")
+		check    = flag.Bool("check", false, "only compare generated output with existing files")
+	)
+	flag.Parse()
+
+	conf := bootstrap.LoadConfig(*confPath)
+	bootstrap.InitI18n(conf)
+	bootstrap.RegisterResources()
+	bootstrap.LoadResourceDefinitions(conf, nil)
+
+	resources := admin.GlobalResourceManager.GetResources()
+	resourceMap := make(map[string]admin.Resource, len(resources))
+	for _, resource := range resources {
+		resourceMap[resource.GetSlug()] = resource
+	}
+
+	generator := docs.NewTypeScriptGenerator(docs.TypeScriptOptions{
+		APIPrefix: *prefix,
+		Language:  *language,
+	})
+	files, err := generator.Generate(resourceMap)
+	if err != nil {
+		fmt.Printf("Error: 生成 TypeScript 失败: %v\n", err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if *check {
+		stale := 0
+		for _, name := range names {
+			path := filepath.Join(*outDir, name)
+			existing, err := os.ReadFile(path)
+			if err != nil || !bytes.Equal(existing, files[name]) {
+				fmt.Printf("%s 与资源定义不一致，请运行 go run ./cmd/typegen 重新生成\n", path)
+				stale++
+			}
+		}
+		if stale > 0 {
+			os.Exit(1)
+		}
+		fmt.Println("TypeScript 类型与资源定义一致")
+		return
+	}
+
+	if err := os.MkdirAll(*outDir, 0755); err != nil {
+		fmt.Printf("Error: 创建输出目录失败: %v\n", err)
+		os.Exit(1)
+	}
+	for _, name := range names {
+		path := filepath.Join(*outDir, name)
+		if err := os.WriteFile(path, files[name], 0644); err != nil {
+			fmt.Printf("Error: 写入 %s 失败: %v\n", path, err)
+			os.Exit(1)
+		}
+		fmt.Printf("Generated %s\n", path)
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AttachmentHandler 资源记录附件处理器
+type AttachmentHandler struct {
+	*Handler
+	attachmentService service.AttachmentService
+}
+
+// NewAttachmentHandler 创建附件处理器
+func NewAttachmentHandler(handler *Handler, attachmentService service.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		Handler:           handler,
+		attachmentService: attachmentService,
+	}
+}
+
+// List 获取记录附件列表，可按 field 过滤
+func (h *AttachmentHandler) List(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	attachments, err := h.attachmentService.ListAttachments(c, slug, id, c.Query("field"))
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    attachments,
+		"message": "success",
+	})
+}
+
+// Upload 上传文件并关联到记录字段
+func (h *AttachmentHandler) Upload(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": i18n.Translate(language, "error.unauthorized"),
+		})
+		return
+	}
+
+	field := c.PostForm("field")
+	file, err := c.FormFile("file")
+	if err != nil || field == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	attachment, err := h.attachmentService.Attach(c, slug, id, field, userID, file)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_create_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    attachment,
+		"message": i18n.Translate(language, "message.created_successfully"),
+	})
+}
+
+// Reorder 调整字段内附件顺序
+func (h *AttachmentHandler) Reorder(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	var payload struct {
+		Field string `json:"field"`
+		IDs   []uint `json:"ids"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil || payload.Field == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	if err := h.attachmentService.Reorder(c, slug, id, payload.Field, payload.IDs); err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Download 获取附件签名下载地址
+func (h *AttachmentHandler) Download(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_id"),
+		})
+		return
+	}
+
+	url, err := h.attachmentService.GetDownloadURL(c, slug, id, uint(attachmentID))
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    url,
+		"message": "success",
+	})
+}
+
+// Delete 解除附件关联（软删除）
+func (h *AttachmentHandler) Delete(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	attachmentID, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_id"),
+		})
+		return
+	}
+
+	if err := h.attachmentService.Detach(c, slug, id, uint(attachmentID)); err != nil {
+		h.handleError(c, language, err, "error.failed_to_delete_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.deleted_successfully"),
+	})
+}
+
+// handleError 将附件服务错误映射为 HTTP 响应
+func (h *AttachmentHandler) handleError(c *gin.Context, language string, err error, fallbackKey string) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.resource_not_found"),
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.validation_failed"),
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.record_not_found"),
+		})
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.attachment_not_found"),
+		})
+	case errors.Is(err, service.ErrFieldNotReadable), errors.Is(err, service.ErrFieldNotWritable):
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": i18n.Translate(language, "error.field_forbidden"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError(i18n.Translate(language, fallbackKey), err),
+		})
+	}
+}
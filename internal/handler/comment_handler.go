@@ -0,0 +1,231 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CommentHandler 资源记录评论处理器
+type CommentHandler struct {
+	*Handler
+	commentService service.CommentService
+}
+
+// NewCommentHandler 创建评论处理器
+func NewCommentHandler(handler *Handler, commentService service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		Handler:        handler,
+		commentService: commentService,
+	}
+}
+
+// List 获取记录评论列表
+func (h *CommentHandler) List(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	comments, total, err := h.commentService.ListComments(c, slug, id, page, pageSize)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": map[string]interface{}{
+			"items":     comments,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+		"message": "success",
+	})
+}
+
+// Create 发表评论或回复
+func (h *CommentHandler) Create(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": i18n.Translate(language, "error.unauthorized"),
+		})
+		return
+	}
+
+	var input service.CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	comment, err := h.commentService.CreateComment(c, slug, id, userID, &input)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_create_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    comment,
+		"message": i18n.Translate(language, "message.created_successfully"),
+	})
+}
+
+// Update 编辑评论（仅作者）
+func (h *CommentHandler) Update(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_id"),
+		})
+		return
+	}
+
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": i18n.Translate(language, "error.unauthorized"),
+		})
+		return
+	}
+
+	var input service.CommentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	comment, err := h.commentService.UpdateComment(c, slug, id, uint(commentID), userID, &input)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    comment,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Delete 删除评论（仅作者，软删除）
+func (h *CommentHandler) Delete(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	language := getLanguage(c)
+
+	commentID, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_id"),
+		})
+		return
+	}
+
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": i18n.Translate(language, "error.unauthorized"),
+		})
+		return
+	}
+
+	if err := h.commentService.DeleteComment(c, slug, id, uint(commentID), userID); err != nil {
+		h.handleError(c, language, err, "error.failed_to_delete_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.deleted_successfully"),
+	})
+}
+
+// handleError 将评论服务错误映射为 HTTP 响应
+func (h *CommentHandler) handleError(c *gin.Context, language string, err error, fallbackKey string) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.resource_not_found"),
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.validation_failed"),
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.record_not_found"),
+		})
+	case errors.Is(err, service.ErrCommentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.comment_not_found"),
+		})
+	case errors.Is(err, service.ErrCommentsDisabled):
+		c.JSON(http.StatusNotImplemented, gin.H{
+			"code":    http.StatusNotImplemented,
+			"message": i18n.Translate(language, "error.comments_disabled"),
+		})
+	case errors.Is(err, service.ErrCommentForbidden):
+		c.JSON(http.StatusForbidden, gin.H{
+			"code":    403,
+			"message": i18n.Translate(language, "error.comment_forbidden"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError(i18n.Translate(language, fallbackKey), err),
+		})
+	}
+}
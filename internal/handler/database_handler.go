@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"fun-admin/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DatabaseHandler 数据库连接状态处理器
+type DatabaseHandler struct {
+	*Handler
+	repo *repository.Repository
+}
+
+// NewDatabaseHandler 创建数据库连接状态处理器
+func NewDatabaseHandler(handler *Handler, repo *repository.Repository) *DatabaseHandler {
+	return &DatabaseHandler{
+		Handler: handler,
+		repo:    repo,
+	}
+}
+
+// Status 获取数据库连接状态
+// @Summary 获取数据库连接状态
+// @Description 检查默认连接、命名连接及其只读副本的健康状态，并返回连接池统计；存在异常连接时返回 503
+// @Tags system
+// @Produce json
+// @Success 200 {object} v1.Response
+// @Failure 503 {object} v1.Response
+// @Router /api/admin/v1/system/database [get]
+func (h *DatabaseHandler) Status(c *gin.Context) {
+	statuses := h.repo.DatabaseStatus(c)
+	for _, status := range statuses {
+		if !status.Healthy {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"code":    503,
+				"data":    statuses,
+				"message": "数据库连接异常",
+			})
+			return
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    statuses,
+		"message": "success",
+	})
+}
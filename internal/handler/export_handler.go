@@ -14,30 +14,35 @@ import (
 // ExportHandler 导出处理器
 type ExportHandler struct {
 	*Handler
-	resourceService *service.ResourceService
-	logger          *zap.Logger
+	resourceService  *service.ResourceService
+	exportJobService service.ExportJobService
+	logger           *zap.Logger
 }
 
 // NewExportHandler 创建导出处理器
 func NewExportHandler(
 	handler *Handler,
 	resourceService *service.ResourceService,
+	exportJobService service.ExportJobService,
 	logger *zap.Logger,
 ) *ExportHandler {
 	return &ExportHandler{
-		Handler:         handler,
-		resourceService: resourceService,
-		logger:          logger,
+		Handler:          handler,
+		resourceService:  resourceService,
+		exportJobService: exportJobService,
+		logger:           logger,
 	}
 }
 
 // ExportData 导出数据
 // @Summary 导出数据
-// @Description 导出指定资源的数据为CSV或Excel格式
+// @Description 按页流式导出指定资源的数据为CSV或Excel格式，仅包含可读字段
 // @Tags export
 // @Produce application/octet-stream
 // @Param resource path string true "资源名称"
-// @Param format query string false "导出格式 (csv/excel)" default(csv)
+// @Param format query string false "导出格式 (csv/xlsx/json/ndjson/pdf)" default(csv)
+// @Param columns query string false "导出列，逗号分隔"
+// @Param async query string false "为 1 时创建后台导出任务，完成后在导出记录中下载"
 // @Param order_by query string false "排序字段"
 // @Param order_direction query string false "排序方向 (ASC/DESC)"
 // @Success 200 {file} file "导出的数据文件"
@@ -60,7 +65,7 @@ func (h *ExportHandler) ExportData(c *gin.Context) {
 	// 处理查询参数
 	for key, values := range c.Request.URL.Query() {
 		// 跳过导出格式参数和其他系统参数
-		if key == "format" || key == "order_by" || key == "order_direction" {
+		if key == "format" || key == "order_by" || key == "order_direction" || key == "columns" || key == "async" {
 			continue
 		}
 
@@ -85,6 +90,14 @@ func (h *ExportHandler) ExportData(c *gin.Context) {
 		orderDirection = "DESC" // 默认倒序
 	}
 
+	// 获取导出列
+	var columns []string
+	for _, name := range strings.Split(c.Query("columns"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			columns = append(columns, name)
+		}
+	}
+
 	// 获取导出格式参数
 	format := c.DefaultQuery("format", "csv")
 
@@ -92,42 +105,200 @@ func (h *ExportHandler) ExportData(c *gin.Context) {
 		zap.String("resource", slug),
 		zap.String("format", format),
 		zap.Int("filters_count", len(filters)),
-		zap.Int("search_count", len(search)))
+		zap.Int("search_count", len(search)),
+		zap.Int("columns_count", len(columns)))
 
-	// 调用服务导出数据
-	data, filename, err := h.resourceService.Export(c, slug, filters, search, orderBy, orderDirection, format)
+	request := service.ExportRequest{
+		Filters:        filters,
+		Search:         search,
+		OrderBy:        orderBy,
+		OrderDirection: orderDirection,
+		Format:         format,
+		Columns:        columns,
+		Language:       getLanguage(c),
+	}
+
+	if async := c.Query("async"); async == "1" || async == "true" {
+		h.createExportJob(c, slug, request)
+		return
+	}
+
+	export, err := h.resourceService.PrepareExport(c, slug, request)
 	if err != nil {
 		h.logger.Error("导出数据失败",
 			zap.String("resource", slug),
 			zap.Error(err))
+		h.handleExportError(c, err)
+		return
+	}
+
+	// 设置响应头后按页写出，写出开始后无法再返回错误响应
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", "attachment; filename="+export.Filename)
+	c.Status(http.StatusOK)
 
-		// 检查是否为资源未找到错误
-		var notFoundErr *service.ResourceNotFoundError
-		if errors.As(err, &notFoundErr) {
-			c.JSON(http.StatusNotFound, gin.H{
-				"code":    404,
-				"message": "资源不存在",
-			})
-			return
+	rows, err := h.resourceService.WriteExport(c, export, c.Writer)
+	if err != nil {
+		h.logger.Error("导出数据失败",
+			zap.String("resource", slug),
+			zap.Int("rows", rows),
+			zap.Error(err))
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			h.handleExportError(c, err)
 		}
+		return
+	}
 
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"code":    500,
-			"message": messageWithDebugError("导出失败", err),
+	h.logger.Info("导出数据成功",
+		zap.String("resource", slug),
+		zap.String("filename", export.Filename),
+		zap.Int("rows", rows))
+}
+
+// createExportJob 创建后台导出任务
+func (h *ExportHandler) createExportJob(c *gin.Context, slug string, request service.ExportRequest) {
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未授权访问",
 		})
 		return
 	}
 
-	h.logger.Info("导出数据成功",
+	job, err := h.exportJobService.CreateJob(c, slug, request, userID)
+	if err != nil {
+		h.logger.Error("创建导出任务失败",
+			zap.String("resource", slug),
+			zap.Error(err))
+		h.handleExportError(c, err)
+		return
+	}
+
+	h.logger.Info("创建导出任务成功",
 		zap.String("resource", slug),
-		zap.String("filename", filename),
-		zap.Int("data_size", len(data)))
+		zap.Uint("job_id", job.ID))
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    job,
+		"message": "success",
+	})
+}
+
+// ListJobs 获取当前用户的导出记录
+func (h *ExportHandler) ListJobs(c *gin.Context) {
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未授权访问",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
 
-	// 设置响应头
-	c.Header("Content-Type", "application/octet-stream")
-	c.Header("Content-Disposition", "attachment; filename="+filename)
-	c.Header("Content-Length", strconv.Itoa(len(data)))
+	jobs, total, err := h.exportJobService.ListJobs(c, userID, page, pageSize)
+	if err != nil {
+		h.handleExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"list":      jobs,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+		"message": "success",
+	})
+}
+
+// DownloadJob 获取导出文件签名下载地址
+func (h *ExportHandler) DownloadJob(c *gin.Context) {
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未授权访问",
+		})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "无效的任务ID",
+		})
+		return
+	}
+
+	url, err := h.exportJobService.GetDownloadURL(c, userID, uint(id))
+	if err != nil {
+		h.handleExportError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    url,
+		"message": "success",
+	})
+}
+
+// handleExportError 将导出错误映射为 HTTP 响应
+func (h *ExportHandler) handleExportError(c *gin.Context, err error) {
+	if h.permissionDenied(c, err) {
+		return
+	}
 
-	// 输出文件内容
-	c.Data(http.StatusOK, "application/octet-stream", data)
+	// 检查是否为资源未找到错误
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "资源不存在",
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "导出参数无效",
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrExportJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "导出任务不存在",
+		})
+	case errors.Is(err, service.ErrExportNotReady):
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    409,
+			"message": "导出文件尚未生成或已过期",
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError("导出失败", err),
+		})
+	}
 }
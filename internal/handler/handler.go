@@ -2,8 +2,11 @@ package handler
 
 import (
 	"errors"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
 	"fun-admin/pkg/jwt"
 	"fun-admin/pkg/logger"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -19,6 +22,19 @@ func NewHandler(
 		logger: logger,
 	}
 }
+
+// permissionDenied 错误为 admin.ErrPermissionDenied（策略或 Authorizable 拒绝）时返回 403 并返回 true
+func (h *Handler) permissionDenied(c *gin.Context, err error) bool {
+	if !errors.Is(err, admin.ErrPermissionDenied) {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{
+		"code":    403,
+		"message": i18n.Translate(getLanguage(c), "error.permission_denied"),
+	})
+	return true
+}
+
 func GetUserIdFromCtx(ctx *gin.Context) (uint, error) {
 	v, exists := ctx.Get("claims")
 	if !exists {
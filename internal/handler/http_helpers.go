@@ -4,17 +4,17 @@ import (
 	"errors"
 	"io"
 
+	"fun-admin/pkg/admin/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
-const defaultLanguage = "zh-CN"
-
+// getLanguage 获取语言协商中间件确定的请求语言，未经过中间件时按相同规则协商
 func getLanguage(c *gin.Context) string {
-	language := c.Query("language")
-	if language == "" {
-		return defaultLanguage
+	if language := c.GetString(i18n.ContextKey); language != "" {
+		return language
 	}
-	return language
+	return i18n.RequestLanguage(c.Query("language"), c.GetHeader("Accept-Language"))
 }
 
 func messageWithDebugError(message string, err error) string {
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// I18nHandler 翻译目录处理器
+type I18nHandler struct {
+	*Handler
+}
+
+// NewI18nHandler 创建翻译目录处理器
+func NewI18nHandler(handler *Handler) *I18nHandler {
+	return &I18nHandler{Handler: handler}
+}
+
+// Report 开发模式下按语言返回缺失与未使用的翻译键，需在配置中开启 i18n.report
+func (h *I18nHandler) Report(c *gin.Context) {
+	language := getLanguage(c)
+
+	if !i18n.GlobalResourceManager.TrackingEnabled() {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.i18n_report_disabled"),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"default_language": i18n.GlobalResourceManager.DefaultLanguage(),
+			"languages":        i18n.GlobalResourceManager.GetLanguages(),
+			"locales":          i18n.GlobalResourceManager.Report(),
+		},
+		"message": "success",
+	})
+}
@@ -1,14 +1,21 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	v1 "fun-admin/api/v1"
+	"fun-admin/internal/model"
 	"fun-admin/internal/service"
 	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
+	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 // ImportHandler 导入处理器
@@ -26,6 +33,8 @@ func NewImportHandler(importService *service.ImportService, resourceService *ser
 }
 
 // ImportData 导入数据
+// 支持 mode=insert/update/upsert（配合 key_field）、dry_run 试运行、transaction=single/batch，
+// async=true 或文件超过阈值时转为后台任务，通过导入任务接口查询进度
 func (h *ImportHandler) ImportData(c *gin.Context) {
 	file, err := c.FormFile("file")
 	if err != nil {
@@ -49,35 +58,175 @@ func (h *ImportHandler) ImportData(c *gin.Context) {
 		v1.HandleValidationError(c, "文件类型不能为空")
 		return
 	}
+	if fileType != "excel" && fileType != "csv" && fileType != "json" {
+		v1.HandleValidationError(c, "不支持的文件类型")
+		return
+	}
 	if resourceSlug == "" {
 		v1.HandleValidationError(c, "资源标识不能为空")
 		return
 	}
 
-	option, err := h.getImportOption(c, resourceSlug, hasHeader, sheetName, startRow)
+	mode := c.DefaultPostForm("mode", service.ImportModeInsert)
+	if mode != service.ImportModeInsert && mode != service.ImportModeUpdate && mode != service.ImportModeUpsert {
+		v1.HandleValidationError(c, "不支持的导入模式")
+		return
+	}
+	transactionMode := c.DefaultPostForm("transaction", service.ImportTransactionBatch)
+	if transactionMode != service.ImportTransactionSingle && transactionMode != service.ImportTransactionBatch {
+		v1.HandleValidationError(c, "不支持的事务模式")
+		return
+	}
+	dryRun := c.PostForm("dry_run") == "true"
+	// 试运行需要同步返回预览，不转为后台任务
+	async := !dryRun && (c.PostForm("async") == "true" || file.Size > service.AsyncImportThreshold)
+
+	option, err := h.getImportOption(resourceSlug, hasHeader, sheetName, startRow, mode, c.PostForm("key_field"))
+	if err != nil {
+		v1.HandleValidationError(c, err.Error())
+		return
+	}
+	option.DryRun = dryRun
+	option.TransactionMode = transactionMode
+
+	rows, err := h.importService.ReadRows(file, fileType, *option)
+	if err != nil {
+		v1.HandleError(c, fmt.Errorf("导入失败: %w", err))
+		return
+	}
+
+	userID, _ := GetUserIdFromCtx(c)
+	job, err := h.importService.CreateJob(c, resourceSlug, file.Filename, fileType, *option, async, userID)
+	if err != nil {
+		v1.HandleError(c, fmt.Errorf("创建导入任务失败: %w", err))
+		return
+	}
+
+	if async {
+		h.importService.RunJobAsync(c.Copy(), job, rows, *option)
+		v1.HandleSuccess(c, job)
+		return
+	}
+
+	result, err := h.importService.RunJob(c, job, rows, *option)
+	if err != nil {
+		v1.HandleError(c, fmt.Errorf("导入失败: %w", err))
+		return
+	}
+
+	v1.HandleSuccess(c, result)
+}
+
+// ListJobs 获取导入任务历史
+func (h *ImportHandler) ListJobs(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	filters := map[string]interface{}{
+		"resource": c.Query("resource"),
+		"status":   c.Query("status"),
+	}
+
+	jobs, total, err := h.importService.GetJobs(c, page, pageSize, filters)
 	if err != nil {
 		v1.HandleError(c, err)
 		return
 	}
 
-	var result *service.ImportResult
-	switch fileType {
-	case "excel":
-		result, err = h.importService.ImportExcel(c, file, *option)
+	v1.HandleSuccess(c, gin.H{
+		"list":      jobs,
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+// GetJob 获取导入任务详情与进度
+func (h *ImportHandler) GetJob(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok {
+		return
+	}
+	v1.HandleSuccess(c, job)
+}
+
+// DownloadTemplate 下载根据资源字段生成的导入模板
+// format=xlsx（默认）包含示例行、填写说明与下拉选项，format=csv 仅包含表头与示例行
+func (h *ImportHandler) DownloadTemplate(c *gin.Context) {
+	resourceSlug := c.Param("resource")
+	res := admin.GlobalResourceManager.GetResourceBySlug(resourceSlug)
+	if res == nil {
+		v1.HandleNotFound(c)
+		return
+	}
+	format := c.DefaultQuery("format", "xlsx")
+	contentType := "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	switch format {
+	case "xlsx", "excel":
+		format = "xlsx"
 	case "csv":
-		result, err = h.importService.ImportCSV(c, file, *option)
-	case "json":
-		result, err = h.importService.ImportJSON(c, file, *option)
+		contentType = "text/csv; charset=utf-8"
 	default:
-		v1.HandleValidationError(c, "不支持的文件类型")
+		v1.HandleValidationError(c, "不支持的模板格式")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.importService.WriteImportTemplate(c, res, h.resourceService.WritableFieldSet(c, res), format, getLanguage(c), &buf); err != nil {
+		v1.HandleError(c, fmt.Errorf("生成导入模板失败: %w", err))
+		return
+	}
+
+	filename := fmt.Sprintf("%s_import_template.%s", resourceSlug, format)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
+// DownloadErrorReport 下载导入错误报告（原始行 + 错误列）
+func (h *ImportHandler) DownloadErrorReport(c *gin.Context) {
+	job, ok := h.findJob(c)
+	if !ok {
+		return
+	}
+	if job.ErrorReportKey == "" {
+		v1.HandleNotFound(c)
 		return
 	}
+
+	reader, err := h.importService.OpenErrorReport(c, job)
 	if err != nil {
-		v1.HandleError(c, fmt.Errorf("导入失败: %w", err))
+		v1.HandleError(c, err)
 		return
 	}
+	defer reader.Close()
 
-	v1.HandleSuccess(c, result)
+	filename := fmt.Sprintf("import_errors_%d.csv", job.ID)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.DataFromReader(http.StatusOK, -1, "text/csv; charset=utf-8", reader, nil)
+}
+
+// findJob 解析路径中的任务ID并加载任务
+func (h *ImportHandler) findJob(c *gin.Context) (*model.ImportJob, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		v1.HandleValidationError(c, "无效的任务ID")
+		return nil, false
+	}
+	job, err := h.importService.GetJob(c, uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			v1.HandleNotFound(c)
+			return nil, false
+		}
+		v1.HandleError(c, err)
+		return nil, false
+	}
+	return job, true
 }
 
 // GetExcelSheets 返回 Excel 工作表列表
@@ -120,7 +269,7 @@ func (h *ImportHandler) GetExcelColumns(c *gin.Context) {
 	v1.HandleSuccess(c, columns)
 }
 
-func (h *ImportHandler) getImportOption(ctx *gin.Context, resourceSlug string, hasHeader bool, sheetName string, startRow int) (*service.ImportOption, error) {
+func (h *ImportHandler) getImportOption(resourceSlug string, hasHeader bool, sheetName string, startRow int, mode string, keyField string) (*service.ImportOption, error) {
 	res := admin.GlobalResourceManager.GetResourceBySlug(resourceSlug)
 	if res == nil {
 		return nil, fmt.Errorf("资源 %s 不存在", resourceSlug)
@@ -132,6 +281,22 @@ func (h *ImportHandler) getImportOption(ctx *gin.Context, resourceSlug string, h
 		label := strings.TrimSpace(field.GetLabel())
 		if label != "" {
 			fieldMapping[label] = field.GetName()
+			// 导入模板使用本地化标题作为表头
+			for _, language := range i18n.GlobalResourceManager.GetLanguages() {
+				if translated := admin.TranslateLabel(label, language); translated != label {
+					fieldMapping[translated] = field.GetName()
+				}
+			}
+		}
+	}
+
+	if mode != service.ImportModeInsert {
+		if keyField == "" {
+			return nil, errors.New("更新导入需要指定 key_field")
+		}
+		// key_field 会拼入查询语句，只允许 id 或资源字段名
+		if name, ok := fieldMapping[keyField]; keyField != "id" && (!ok || name != keyField) {
+			return nil, fmt.Errorf("匹配字段 %s 不存在", keyField)
 		}
 	}
 
@@ -140,8 +305,38 @@ func (h *ImportHandler) getImportOption(ctx *gin.Context, resourceSlug string, h
 		SheetName:    sheetName,
 		StartRow:     startRow,
 		FieldMapping: fieldMapping,
+		Mode:         mode,
+		KeyField:     keyField,
 		BatchSize:    100,
 	}
+	option.ConvertFunc = h.importService.NewValueResolver(res).Resolve
+
+	option.PlanFunc = func(ctx context.Context, row *service.ImportRow) error {
+		if mode != service.ImportModeInsert {
+			keyValue := row.Data[keyField]
+			// id 只作为匹配键，不写入
+			if keyField == "id" {
+				delete(row.Data, "id")
+			}
+			if keyValue == nil || keyValue == "" {
+				if mode == service.ImportModeUpdate {
+					return fmt.Errorf("缺少匹配字段 %s 的值", keyField)
+				}
+			} else {
+				record, err := h.resourceService.FindByField(ctx, resourceSlug, keyField, keyValue)
+				if err != nil {
+					return err
+				}
+				if len(record) > 0 {
+					row.Action = service.ImportActionUpdate
+					row.Key = record["id"]
+				} else if mode == service.ImportModeUpdate {
+					return fmt.Errorf("未找到 %s=%v 的记录", keyField, keyValue)
+				}
+			}
+		}
+		return h.resourceService.CheckWritable(ctx, resourceSlug, row.Data)
+	}
 
 	option.ValidateFunc = func(row map[string]interface{}) error {
 		errors := admin.ValidateResourceData(res, row)
@@ -155,9 +350,15 @@ func (h *ImportHandler) getImportOption(ctx *gin.Context, resourceSlug string, h
 		return fmt.Errorf("校验失败: %s", strings.Join(parts, "; "))
 	}
 
-	option.DataHandler = func(batch []map[string]interface{}) error {
-		for _, data := range batch {
-			if _, err := h.resourceService.Create(ctx, resourceSlug, data); err != nil {
+	option.DataHandler = func(ctx context.Context, batch []*service.ImportRow) error {
+		for _, row := range batch {
+			if row.Action == service.ImportActionUpdate {
+				if err := h.resourceService.Update(ctx, resourceSlug, row.Key, row.Data); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := h.resourceService.Create(ctx, resourceSlug, row.Data); err != nil {
 				return err
 			}
 		}
@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RelationHandler 关系管理器处理器：在父记录下管理子资源记录
+type RelationHandler struct {
+	*Handler
+	resourceService *service.ResourceService
+}
+
+// NewRelationHandler 创建关系管理器处理器
+func NewRelationHandler(handler *Handler, resourceService *service.ResourceService) *RelationHandler {
+	return &RelationHandler{
+		Handler:         handler,
+		resourceService: resourceService,
+	}
+}
+
+// List 获取父记录下的子记录列表，查询参数与资源列表一致
+func (h *RelationHandler) List(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	relation := c.Param("relation")
+	language := getLanguage(c)
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+
+	filters := make(map[string]interface{})
+	search := make(map[string]interface{})
+	for key, values := range c.Request.URL.Query() {
+		if key == "page" || key == "page_size" || key == "order_by" || key == "order_direction" || key == "language" {
+			continue
+		}
+		if len(values) == 0 || values[0] == "" {
+			continue
+		}
+		if field, ok := strings.CutPrefix(key, "search_"); ok && field != "" {
+			search[field] = values[0]
+		} else {
+			filters[key] = values[0]
+		}
+	}
+
+	items, total, err := h.resourceService.ListRelated(c, slug, id, relation, page, pageSize, filters, search,
+		c.Query("order_by"), c.Query("order_direction"))
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": map[string]interface{}{
+			"items":     items,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+		"message": "success",
+	})
+}
+
+// Create 在父记录下创建子记录
+func (h *RelationHandler) Create(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	relation := c.Param("relation")
+	language := getLanguage(c)
+
+	var data map[string]interface{}
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	record, err := h.resourceService.CreateRelated(c, slug, id, relation, data)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_create_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    record,
+		"message": i18n.Translate(language, "message.created_successfully"),
+	})
+}
+
+// Update 更新父记录下的子记录
+func (h *RelationHandler) Update(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	relation := c.Param("relation")
+	childID := c.Param("child_id")
+	language := getLanguage(c)
+
+	var data map[string]interface{}
+	if err := c.ShouldBindJSON(&data); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	if err := h.resourceService.UpdateRelated(c, slug, id, relation, childID, data); err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Attach 将已有记录关联到父记录，请求体 {"ids": [...]}
+func (h *RelationHandler) Attach(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	relation := c.Param("relation")
+	language := getLanguage(c)
+
+	ids, ok := h.bindIDs(c, language)
+	if !ok {
+		return
+	}
+
+	if err := h.resourceService.AttachRelated(c, slug, id, relation, ids); err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Detach 解除记录与父记录的关联，请求体 {"ids": [...]}
+func (h *RelationHandler) Detach(c *gin.Context) {
+	slug := c.Param("resource")
+	id := c.Param("id")
+	relation := c.Param("relation")
+	language := getLanguage(c)
+
+	ids, ok := h.bindIDs(c, language)
+	if !ok {
+		return
+	}
+
+	affected, err := h.resourceService.DetachRelated(c, slug, id, relation, ids)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    gin.H{"affected": affected},
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// bindIDs 解析请求体中的子记录 ID 列表
+func (h *RelationHandler) bindIDs(c *gin.Context, language string) ([]interface{}, bool) {
+	var payload struct {
+		IDs []interface{} `json:"ids"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil || len(payload.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return nil, false
+	}
+	return payload.IDs, true
+}
+
+// handleError 将关系管理错误映射为 HTTP 响应
+func (h *RelationHandler) handleError(c *gin.Context, language string, err error, fallbackKey string) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.resource_not_found"),
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.validation_failed"),
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrRelationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.relation_not_found"),
+		})
+	case errors.Is(err, service.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.record_not_found"),
+		})
+	case errors.Is(err, service.ErrRelatedRecordMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.related_record_mismatch"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError(i18n.Translate(language, fallbackKey), err),
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReorderHandler 拖拽排序处理器
+type ReorderHandler struct {
+	*Handler
+	resourceService *service.ResourceService
+}
+
+// NewReorderHandler 创建拖拽排序处理器
+func NewReorderHandler(handler *Handler, resourceService *service.ResourceService) *ReorderHandler {
+	return &ReorderHandler{
+		Handler:         handler,
+		resourceService: resourceService,
+	}
+}
+
+// Reorder 调整记录顺序
+// 请求体 {"ids": [3, 1, 2]} 按给定顺序排列，或 {"id": 3, "before": 1} / {"id": 3, "after": 2} 移动单条记录
+func (h *ReorderHandler) Reorder(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	var input service.ReorderInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	updated, err := h.resourceService.Reorder(c, slug, &input)
+	if err != nil {
+		h.handleError(c, language, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    gin.H{"updated": updated},
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// handleError 将排序错误映射为 HTTP 响应
+func (h *ReorderHandler) handleError(c *gin.Context, language string, err error) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.resource_not_found"),
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.validation_failed"),
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.record_not_found"),
+		})
+	case errors.Is(err, service.ErrNotReorderable):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.not_reorderable"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError(i18n.Translate(language, "error.failed_to_update_record"), err),
+		})
+	}
+}
@@ -32,12 +32,14 @@ type ResourceService interface {
 
 // ResourceCRUDHandler 资源 CRUD 处理器
 type ResourceCRUDHandler struct {
+	*Handler
 	resourceService ResourceService
 }
 
 // NewResourceCRUDHandler 创建资源 CRUD 处理器
-func NewResourceCRUDHandler(resourceService ResourceService) *ResourceCRUDHandler {
+func NewResourceCRUDHandler(handler *Handler, resourceService ResourceService) *ResourceCRUDHandler {
 	return &ResourceCRUDHandler{
+		Handler:         handler,
 		resourceService: resourceService,
 	}
 }
@@ -105,6 +107,9 @@ func (h *ResourceCRUDHandler) List(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -152,6 +157,9 @@ func (h *ResourceCRUDHandler) Create(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
 
 		var validationErr *service.ValidationError
 		if errors.As(err, &validationErr) {
@@ -204,6 +212,9 @@ func (h *ResourceCRUDHandler) Get(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -263,6 +274,9 @@ func (h *ResourceCRUDHandler) Update(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
 
 		var validationErr *service.ValidationError
 		if errors.As(err, &validationErr) {
@@ -314,6 +328,9 @@ func (h *ResourceCRUDHandler) Delete(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
 
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"code":    500,
@@ -364,6 +381,27 @@ func (h *ResourceCRUDHandler) RunAction(c *gin.Context) {
 			})
 			return
 		}
+		if h.permissionDenied(c, err) {
+			return
+		}
+
+		var validationErr *service.ValidationError
+		if errors.As(err, &validationErr) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"code":    400,
+				"message": i18n.Translate(language, "error.validation_failed"),
+				"errors":  validationErr.Errors,
+			})
+			return
+		}
+
+		if errors.Is(err, service.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"code":    404,
+				"message": i18n.Translate(language, "error.record_not_found"),
+			})
+			return
+		}
 
 		if errors.Is(err, service.ErrActionNotSupported) {
 			c.JSON(http.StatusNotImplemented, gin.H{
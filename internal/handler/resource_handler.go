@@ -2,7 +2,6 @@ package handler
 
 import (
 	"net/http"
-	"strings"
 
 	"fun-admin/internal/repository"
 	"fun-admin/pkg/admin"
@@ -42,7 +41,7 @@ func (h *ResourceHandler) GlobalSearch(c *gin.Context) {
 		// 为每个资源创建一个简单的搜索结果条目
 		result := map[string]interface{}{
 			"resource": resource.GetSlug(),
-			"title":    translateResourceTitle(resource.GetTitle(), language),
+			"title":    admin.TranslateLabel(resource.GetTitle(), language),
 			"keyword":  keyword,
 		}
 		results = append(results, result)
@@ -102,15 +101,15 @@ func (h *ResourceHandler) ListResources(c *gin.Context) {
 		}
 
 		resourceMap := map[string]interface{}{
-			"title":           translateResourceTitle(resource.GetTitle(), language),
+			"title":           admin.TranslateLabel(resource.GetTitle(), language),
 			"slug":            resource.GetSlug(),
 			"model":           resource.GetModel(),
-			"fields":          resource.GetFields(),
-			"columns":         resource.GetColumns(),
-			"filters":         resource.GetFilters(),
-			"actions":         resource.GetActions(),
+			"fields":          admin.LocalizeFields(resource.GetFields(), language),
+			"columns":         admin.LocalizeColumns(resource.GetColumns(), language),
+			"filters":         admin.LocalizeFilters(resource.GetFilters(), language),
+			"actions":         localizeActions(c, resource.GetActions(), language),
 			"nav_icon":        icon,
-			"nav_group":       group,
+			"nav_group":       admin.TranslateLabel(group, language),
 			"nav_sort":        sort,
 			"nav_badge_count": badgeCount,
 			"editable":        caps.Editable,
@@ -126,7 +125,7 @@ func (h *ResourceHandler) ListResources(c *gin.Context) {
 	pageList := make([]map[string]interface{}, 0)
 	for _, page := range pages {
 		pageMap := map[string]interface{}{
-			"title": translateResourceTitle(page.GetTitle(), language),
+			"title": admin.TranslateLabel(page.GetTitle(), language),
 			"slug":  page.GetSlug(),
 			"type":  "page",
 		}
@@ -152,74 +151,14 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 	// 先尝试查找资源
 	resource := admin.GlobalResourceManager.GetResourceBySlug(slug)
 	if resource != nil {
-		// 构建字段信息（仅基础信息）
-		fields := resource.GetFields()
-		fieldList := make([]map[string]interface{}, len(fields))
-		for i, field := range fields {
-			fieldList[i] = map[string]interface{}{
-				"name":     field.GetName(),
-				"label":    translateFieldLabel(field.GetLabel(), language),
-				"type":     field.GetType(),
-				"required": field.IsRequired(),
-			}
-		}
-
-		// 操作信息
-		actions := resource.GetActions()
-		actionList := make([]map[string]interface{}, 0, len(actions))
-		for _, action := range actions {
-			// 可见性过滤
-			if v, ok := any(action).(admin.ActionVisibility); ok {
-				if !v.IsVisible(c) {
-					continue
-				}
-			}
-			// 动作扩展元信息：图标、颜色、确认、权限键、是否批量
-			icon := ""
-			if v, ok := any(action).(interface{ GetIcon() string }); ok {
-				icon = v.GetIcon()
-			}
-			color := "default"
-			if v, ok := any(action).(interface{ GetColor() string }); ok {
-				color = v.GetColor()
-			}
-			confirm := ""
-			if v, ok := any(action).(interface{ GetConfirm() string }); ok {
-				confirm = v.GetConfirm()
-			}
-			permission := ""
-			if v, ok := any(action).(interface{ GetPermission() string }); ok {
-				permission = v.GetPermission()
-			}
-			isBulk := false
-			if v, ok := any(action).(interface{ IsBulk() bool }); ok {
-				isBulk = v.IsBulk()
-			}
-			item := map[string]interface{}{
-				"name":       action.GetName(),
-				"label":      translateActionLabel(action.GetLabel(), language),
-				"primary":    action.IsPrimary(),
-				"icon":       icon,
-				"color":      color,
-				"confirm":    confirm,
-				"permission": permission,
-				"bulk":       isBulk,
-			}
-			// 若带表单，返回 schema
-			if v, ok := any(action).(admin.ActionWithForm); ok {
-				item["form_fields"] = v.GetFormFields()
-			}
-			actionList = append(actionList, item)
-		}
-
 		resourceMap := map[string]interface{}{
-			"title":   translateResourceTitle(resource.GetTitle(), language),
+			"title":   admin.TranslateLabel(resource.GetTitle(), language),
 			"slug":    resource.GetSlug(),
 			"model":   resource.GetModel(),
-			"fields":  fieldList,
-			"columns": resource.GetColumns(),
-			"filters": resource.GetFilters(),
-			"actions": actionList,
+			"fields":  admin.LocalizeFields(resource.GetFields(), language),
+			"columns": admin.LocalizeColumns(resource.GetColumns(), language),
+			"filters": admin.LocalizeFilters(resource.GetFilters(), language),
+			"actions": localizeActions(c, resource.GetActions(), language),
 			// 预留：由资源可选接口/策略动态控制
 			"editable":        true,
 			"creatable":       true,
@@ -243,6 +182,31 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 			f, d := v.GetDefaultOrder()
 			resourceMap["default_order"] = map[string]string{"field": f, "direction": d}
 		}
+		if v, ok := any(resource).(admin.Reorderable); ok {
+			resourceMap["order_column"] = v.GetOrderColumn()
+			if scope, ok := any(resource).(admin.ReorderScope); ok {
+				resourceMap["reorder_scope"] = scope.GetReorderScope()
+			}
+		}
+		if opts, ok := admin.GetTreeOptions(resource); ok {
+			resourceMap["tree"] = map[string]string{
+				"parent_key": opts.ParentKey,
+				"sort_key":   opts.SortKey,
+				"label_key":  opts.LabelKey,
+			}
+		}
+		if v, ok := any(resource).(admin.HasRelationManagers); ok {
+			relations := make([]map[string]interface{}, 0)
+			for _, m := range v.GetRelationManagers() {
+				relations = append(relations, map[string]interface{}{
+					"name":     m.Name,
+					"label":    admin.TranslateLabel(m.Label, language),
+					"resource": m.Resource,
+					"pivot":    m.IsPivot(),
+				})
+			}
+			resourceMap["relations"] = relations
+		}
 
 		c.JSON(http.StatusOK, gin.H{
 			"code": 0,
@@ -255,7 +219,7 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 	page := admin.GlobalResourceManager.GetPageBySlug(slug)
 	if page != nil {
 		pageMap := map[string]interface{}{
-			"title": translateResourceTitle(page.GetTitle(), language),
+			"title": admin.TranslateLabel(page.GetTitle(), language),
 			"slug":  page.GetSlug(),
 			"type":  "page",
 		}
@@ -274,22 +238,52 @@ func (h *ResourceHandler) GetResource(c *gin.Context) {
 	})
 }
 
-// translateResourceTitle 翻译资源标题
-func translateResourceTitle(title, language string) string {
-	// 这里可以根据需要实现翻译逻辑
-	// 目前直接返回标题，后续可以扩展为从资源映射中获取翻译
-	key := strings.ToLower(strings.ReplaceAll(title, " ", "_"))
-	return i18n.Translate(language, key, title)
-}
-
-// translateFieldLabel 翻译字段标签
-func translateFieldLabel(label, language string) string {
-	key := strings.ToLower(strings.ReplaceAll(label, " ", "_"))
-	return i18n.Translate(language, key, label)
-}
-
-// translateActionLabel 翻译操作标签
-func translateActionLabel(label, language string) string {
-	key := strings.ToLower(strings.ReplaceAll(label, " ", "_"))
-	return i18n.Translate(language, key, label)
+// localizeActions 构建动作的前端描述：过滤不可见动作，翻译标签并附带扩展元信息与表单字段
+func localizeActions(c *gin.Context, actions []admin.Action, language string) []map[string]interface{} {
+	actionList := make([]map[string]interface{}, 0, len(actions))
+	for _, action := range actions {
+		// 可见性过滤
+		if v, ok := any(action).(admin.ActionVisibility); ok {
+			if !v.IsVisible(c) {
+				continue
+			}
+		}
+		// 动作扩展元信息：图标、颜色、确认、权限键、是否批量
+		icon := ""
+		if v, ok := any(action).(interface{ GetIcon() string }); ok {
+			icon = v.GetIcon()
+		}
+		color := "default"
+		if v, ok := any(action).(interface{ GetColor() string }); ok {
+			color = v.GetColor()
+		}
+		confirm := ""
+		if v, ok := any(action).(interface{ GetConfirm() string }); ok {
+			confirm = v.GetConfirm()
+		}
+		permission := ""
+		if v, ok := any(action).(interface{ GetPermission() string }); ok {
+			permission = v.GetPermission()
+		}
+		isBulk := false
+		if v, ok := any(action).(interface{ IsBulk() bool }); ok {
+			isBulk = v.IsBulk()
+		}
+		item := map[string]interface{}{
+			"name":       action.GetName(),
+			"label":      admin.TranslateLabel(action.GetLabel(), language),
+			"primary":    action.IsPrimary(),
+			"icon":       icon,
+			"color":      color,
+			"confirm":    confirm,
+			"permission": permission,
+			"bulk":       isBulk,
+		}
+		// 若带表单，返回 schema
+		if v, ok := any(action).(admin.ActionWithForm); ok {
+			item["form_fields"] = admin.LocalizeFields(v.GetFormFields(), language)
+		}
+		actionList = append(actionList, item)
+	}
+	return actionList
 }
@@ -0,0 +1,253 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fun-admin/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ScheduledReportHandler 定时报表处理器
+type ScheduledReportHandler struct {
+	*Handler
+	reportService service.ScheduledReportService
+}
+
+// NewScheduledReportHandler 创建定时报表处理器
+func NewScheduledReportHandler(handler *Handler, reportService service.ScheduledReportService) *ScheduledReportHandler {
+	return &ScheduledReportHandler{
+		Handler:       handler,
+		reportService: reportService,
+	}
+}
+
+// List 获取当前用户的定时报表列表，可按 resource、last_status 过滤；超级管理员可查看全部并按 owner_id 过滤
+func (h *ScheduledReportHandler) List(c *gin.Context) {
+	page, pageSize := reportPagination(c)
+	filters := map[string]interface{}{
+		"resource":    c.Query("resource"),
+		"last_status": c.Query("last_status"),
+	}
+	if ownerID, err := strconv.Atoi(c.Query("owner_id")); err == nil && ownerID > 0 {
+		filters["owner_id"] = ownerID
+	}
+
+	reports, total, err := h.reportService.ListReports(c, page, pageSize, filters)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"list":      reports,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+		"message": "success",
+	})
+}
+
+// Get 获取定时报表详情
+func (h *ScheduledReportHandler) Get(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	report, err := h.reportService.GetReport(c, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    report,
+		"message": "success",
+	})
+}
+
+// Create 创建定时报表，当前用户为所有者，运行时按其权限导出
+func (h *ScheduledReportHandler) Create(c *gin.Context) {
+	userID, err := GetUserIdFromCtx(c)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"code":    401,
+			"message": "未授权访问",
+		})
+		return
+	}
+
+	var input service.ScheduledReportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数无效",
+		})
+		return
+	}
+
+	report, err := h.reportService.CreateReport(c, &input, userID)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    report,
+		"message": "success",
+	})
+}
+
+// Update 更新定时报表
+func (h *ScheduledReportHandler) Update(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	var input service.ScheduledReportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请求参数无效",
+		})
+		return
+	}
+
+	report, err := h.reportService.UpdateReport(c, id, &input)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    report,
+		"message": "success",
+	})
+}
+
+// Delete 删除定时报表
+func (h *ScheduledReportHandler) Delete(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	if err := h.reportService.DeleteReport(c, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "success",
+	})
+}
+
+// Run 立即运行定时报表，由任务进程在下一次检查时执行
+func (h *ScheduledReportHandler) Run(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	if err := h.reportService.TriggerReport(c, id); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": "success",
+	})
+}
+
+// Runs 获取定时报表运行记录
+func (h *ScheduledReportHandler) Runs(c *gin.Context) {
+	id, ok := reportID(c)
+	if !ok {
+		return
+	}
+	page, pageSize := reportPagination(c)
+
+	runs, total, err := h.reportService.ListRuns(c, id, page, pageSize)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code": 0,
+		"data": gin.H{
+			"list":      runs,
+			"total":     total,
+			"page":      page,
+			"page_size": pageSize,
+		},
+		"message": "success",
+	})
+}
+
+// reportID 解析路径中的报表ID
+func reportID(c *gin.Context) (uint, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "无效的报表ID",
+		})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// reportPagination 解析分页参数
+func reportPagination(c *gin.Context) (int, int) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
+// handleError 将定时报表错误映射为 HTTP 响应
+func (h *ScheduledReportHandler) handleError(c *gin.Context, err error) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "资源不存在",
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "报表参数无效",
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	if errors.Is(err, service.ErrScheduledReportNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": "定时报表不存在",
+		})
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"code":    500,
+		"message": messageWithDebugError("操作失败", err),
+	})
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"fun-admin/internal/service"
+	"fun-admin/internal/snapshot"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SnapshotHandler 数据快照处理器
+type SnapshotHandler struct {
+	*Handler
+	snapshotService service.SnapshotService
+}
+
+// NewSnapshotHandler 创建数据快照处理器
+func NewSnapshotHandler(handler *Handler, snapshotService service.SnapshotService) *SnapshotHandler {
+	return &SnapshotHandler{
+		Handler:         handler,
+		snapshotService: snapshotService,
+	}
+}
+
+// Export 导出数据快照
+// @Summary 导出数据快照
+// @Description 将全部已注册资源与系统数据表（用户、角色、Casbin 策略、菜单、设置、字典等）及附件文件导出为 zip 归档
+// @Tags snapshot
+// @Produce application/zip
+// @Success 200 {file} file "快照归档"
+// @Router /api/admin/v1/snapshot/export [get]
+func (h *SnapshotHandler) Export(c *gin.Context) {
+	filename := fmt.Sprintf("snapshot-%s.zip", time.Now().Format("20060102-150405"))
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Status(http.StatusOK)
+
+	// 归档边生成边写入响应，出错时响应头已发送，只能中断并记录日志
+	if _, err := h.snapshotService.Export(c, c.Writer); err != nil {
+		h.logger.Error("导出数据快照失败", zap.Error(err))
+		_ = c.Error(err)
+		c.Abort()
+	}
+}
+
+// Import 导入数据快照
+// @Summary 导入数据快照
+// @Description 从快照归档恢复数据，保留记录主键；conflict 指定主键冲突时的处理：skip 保留已有记录，update 覆盖，fail 报错回滚，replace 先清空归档中的表
+// @Tags snapshot
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "快照归档"
+// @Param conflict formData string false "冲突策略 (skip/update/fail/replace)" default(skip)
+// @Success 200 {object} snapshot.Result
+// @Router /api/admin/v1/snapshot/import [post]
+func (h *SnapshotHandler) Import(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "请选择快照文件",
+		})
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": messageWithDebugError("读取快照文件失败", err),
+		})
+		return
+	}
+	defer file.Close()
+
+	result, err := h.snapshotService.Import(c, file, fileHeader.Size, c.DefaultPostForm("conflict", snapshot.ConflictSkip))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    result,
+		"message": "success",
+	})
+}
+
+// handleError 将数据快照错误映射为 HTTP 响应
+func (h *SnapshotHandler) handleError(c *gin.Context, err error) {
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": "快照参数无效",
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	h.logger.Error("导入数据快照失败", zap.Error(err))
+	c.JSON(http.StatusInternalServerError, gin.H{
+		"code":    500,
+		"message": messageWithDebugError("导入失败", err),
+	})
+}
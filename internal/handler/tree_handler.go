@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TreeHandler 树形资源处理器：嵌套树、懒加载子节点、移动与排序
+type TreeHandler struct {
+	*Handler
+	resourceService *service.ResourceService
+}
+
+// NewTreeHandler 创建树形资源处理器
+func NewTreeHandler(handler *Handler, resourceService *service.ResourceService) *TreeHandler {
+	return &TreeHandler{
+		Handler:         handler,
+		resourceService: resourceService,
+	}
+}
+
+// Tree 获取嵌套树，root 指定子树根节点，max_depth 限制深度
+func (h *TreeHandler) Tree(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	var root interface{}
+	if v := c.Query("root"); v != "" {
+		root = v
+	}
+	maxDepth, _ := strconv.Atoi(c.DefaultQuery("max_depth", "0"))
+
+	tree, err := h.resourceService.Tree(c, slug, root, maxDepth)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    tree,
+		"message": "success",
+	})
+}
+
+// Children 懒加载直接子节点，parent_id 为空时返回根节点
+func (h *TreeHandler) Children(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	var parent interface{}
+	if v := c.Query("parent_id"); v != "" {
+		parent = v
+	}
+
+	children, err := h.resourceService.TreeChildren(c, slug, parent)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_get_data")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    children,
+		"message": "success",
+	})
+}
+
+// Move 移动节点，请求体 {"id": 5, "parent_id": 2, "position": 0}，parent_id 为空移动到根，position 缺省追加到末尾
+func (h *TreeHandler) Move(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	var payload struct {
+		ID       interface{} `json:"id"`
+		ParentID interface{} `json:"parent_id"`
+		Position *int        `json:"position"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil || payload.ID == nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+	position := -1
+	if payload.Position != nil {
+		position = *payload.Position
+	}
+
+	if err := h.resourceService.MoveNode(c, slug, payload.ID, payload.ParentID, position); err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Reorder 重排同级节点，请求体 {"parent_id": 2, "ids": [7, 5, 6]}
+func (h *TreeHandler) Reorder(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	var payload struct {
+		ParentID interface{}   `json:"parent_id"`
+		IDs      []interface{} `json:"ids"`
+	}
+	if err := c.ShouldBindJSON(&payload); err != nil || len(payload.IDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.invalid_request_data"),
+		})
+		return
+	}
+
+	if err := h.resourceService.ReorderNodes(c, slug, payload.ParentID, payload.IDs); err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// Rebuild 按父节点列重建物化路径，用于为已有数据补齐路径
+func (h *TreeHandler) Rebuild(c *gin.Context) {
+	slug := c.Param("resource")
+	language := getLanguage(c)
+
+	updated, err := h.resourceService.RebuildTreePaths(c, slug)
+	if err != nil {
+		h.handleError(c, language, err, "error.failed_to_update_record")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"code":    0,
+		"data":    gin.H{"updated": updated},
+		"message": i18n.Translate(language, "message.updated_successfully"),
+	})
+}
+
+// handleError 将树形资源错误映射为 HTTP 响应
+func (h *TreeHandler) handleError(c *gin.Context, language string, err error, fallbackKey string) {
+	if h.permissionDenied(c, err) {
+		return
+	}
+
+	var notFoundErr *service.ResourceNotFoundError
+	if errors.As(err, &notFoundErr) {
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.resource_not_found"),
+		})
+		return
+	}
+
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.validation_failed"),
+			"errors":  validationErr.Errors,
+		})
+		return
+	}
+
+	switch {
+	case errors.Is(err, service.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"code":    404,
+			"message": i18n.Translate(language, "error.record_not_found"),
+		})
+	case errors.Is(err, service.ErrNotTreeResource):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    400,
+			"message": i18n.Translate(language, "error.not_tree_resource"),
+		})
+	case errors.Is(err, service.ErrTreePathMissing):
+		c.JSON(http.StatusConflict, gin.H{
+			"code":    409,
+			"message": i18n.Translate(language, "error.tree_path_missing"),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"code":    500,
+			"message": messageWithDebugError(i18n.Translate(language, fallbackKey), err),
+		})
+	}
+}
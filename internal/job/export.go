@@ -0,0 +1,78 @@
+package job
+
+import (
+	"context"
+	"fun-admin/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// exportPollInterval 轮询待执行导出任务的间隔
+	exportPollInterval = 3 * time.Second
+	// exportPurgeInterval 清理过期导出文件的间隔
+	exportPurgeInterval = time.Hour
+	// exportRecoverInterval 回收租约过期任务的间隔
+	exportRecoverInterval = time.Minute
+)
+
+// ExportJob 异步导出任务执行器
+type ExportJob interface {
+	Run(ctx context.Context) error
+}
+
+func NewExportJob(
+	job *Job,
+	exportJobService service.ExportJobService,
+) ExportJob {
+	return &exportJob{
+		Job:              job,
+		exportJobService: exportJobService,
+	}
+}
+
+type exportJob struct {
+	*Job
+	exportJobService service.ExportJobService
+}
+
+// Run 从数据库轮询待执行任务并依次执行，定期清理过期文件，直到 ctx 取消
+// 任务状态保存在数据库中，启动时及运行期间定期重新排队租约过期的中断任务
+func (t *exportJob) Run(ctx context.Context) error {
+	if err := t.exportJobService.RecoverJobs(ctx); err != nil {
+		t.logger.Error("RecoverJobs error", zap.Error(err))
+	}
+
+	poll := time.NewTicker(exportPollInterval)
+	defer poll.Stop()
+	purge := time.NewTicker(exportPurgeInterval)
+	defer purge.Stop()
+	reclaim := time.NewTicker(exportRecoverInterval)
+	defer reclaim.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-poll.C:
+			for {
+				ran, err := t.exportJobService.RunNext(ctx)
+				if err != nil {
+					t.logger.Error("RunNext error", zap.Error(err))
+				}
+				if !ran || ctx.Err() != nil {
+					break
+				}
+			}
+		case <-reclaim.C:
+			if err := t.exportJobService.RecoverJobs(ctx); err != nil {
+				t.logger.Error("RecoverJobs error", zap.Error(err))
+			}
+		case <-purge.C:
+			if _, err := t.exportJobService.PurgeExpired(ctx); err != nil {
+				t.logger.Error("PurgeExpired error", zap.Error(err))
+			}
+		}
+	}
+}
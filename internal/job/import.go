@@ -0,0 +1,54 @@
+package job
+
+import (
+	"context"
+	"fun-admin/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// importRecoverInterval 清理中断导入任务的间隔
+const importRecoverInterval = time.Minute
+
+// ImportJob 导入任务维护：清理心跳超时的中断任务
+type ImportJob interface {
+	Run(ctx context.Context) error
+}
+
+func NewImportJob(
+	job *Job,
+	importService *service.ImportService,
+) ImportJob {
+	return &importJob{
+		Job:           job,
+		importService: importService,
+	}
+}
+
+type importJob struct {
+	*Job
+	importService *service.ImportService
+}
+
+// Run 启动时及运行期间定期将心跳超时的导入任务标记为失败，直到 ctx 取消
+// 导入在发起请求的进程内执行，进程重启后中断的任务会一直停留在执行中
+func (t *importJob) Run(ctx context.Context) error {
+	if err := t.importService.RecoverJobs(ctx); err != nil {
+		t.logger.Error("RecoverJobs error", zap.Error(err))
+	}
+
+	ticker := time.NewTicker(importRecoverInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := t.importService.RecoverJobs(ctx); err != nil {
+				t.logger.Error("RecoverJobs error", zap.Error(err))
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+
+	"fun-admin/pkg/database"
+
+	"github.com/gin-gonic/gin"
+)
+
+// StickyPrimaryMiddleware 写后读主库中间件
+// 为每个请求设置写后读主库标记：请求内发生写操作后，后续读取不再分发到只读副本
+func StickyPrimaryMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sticky := database.NewStickyPrimary()
+
+		c.Set(database.StickyKey, sticky)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), database.StickyKey, sticky))
+
+		c.Next()
+	}
+}
@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"fun-admin/pkg/admin/i18n"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageMiddleware 语言协商中间件
+// 优先使用 ?language= 参数，否则按 Accept-Language 在已加载的语言中协商，结果写入上下文与 Content-Language 响应头
+func LanguageMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		language := i18n.RequestLanguage(c.Query("language"), c.GetHeader("Accept-Language"))
+
+		c.Set(i18n.ContextKey, language)
+		c.Request = c.Request.WithContext(i18n.WithLanguage(c.Request.Context(), language))
+
+		c.Header("Content-Language", language)
+		c.Header("Vary", "Accept-Language")
+
+		c.Next()
+	}
+}
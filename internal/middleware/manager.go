@@ -41,6 +41,16 @@ func (m *Manager) SetupCORSMiddleware() gin.HandlerFunc {
 	return CORSMiddleware(cfg)
 }
 
+// SetupLanguageMiddleware 设置语言协商中间件
+func (m *Manager) SetupLanguageMiddleware() gin.HandlerFunc {
+	return LanguageMiddleware()
+}
+
+// SetupStickyPrimaryMiddleware 设置写后读主库中间件
+func (m *Manager) SetupStickyPrimaryMiddleware() gin.HandlerFunc {
+	return StickyPrimaryMiddleware()
+}
+
 // SetupAuthMiddleware 设置认证中间件
 func (m *Manager) SetupAuthMiddleware() gin.HandlerFunc {
 	return AuthMiddleware(m.enforcer)
@@ -0,0 +1,53 @@
+package migrate
+
+import (
+	"gorm.io/gorm"
+)
+
+// DropColumn 删除表 table 的列 column，model 描述该列（生成的迁移中为快照结构体）
+// SQLite 删除列时会重建表，表上的索引随之丢失；此时在删除前记录其余索引的定义，删除后重新创建
+func DropColumn(tx *gorm.DB, table string, model interface{}, column string) error {
+	if tx.Dialector.Name() != "sqlite" {
+		return tx.Table(table).Migrator().DropColumn(model, column)
+	}
+
+	var indexes []struct {
+		Name string
+		SQL  string
+	}
+	err := tx.Raw("SELECT name, sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table).
+		Scan(&indexes).Error
+	if err != nil {
+		return err
+	}
+	var kept []string
+	for _, index := range indexes {
+		var columns []string
+		if err := tx.Raw("SELECT name FROM pragma_index_info(?)", index.Name).Scan(&columns).Error; err != nil {
+			return err
+		}
+		// 包含被删除列的索引随列一起删除
+		if !containsString(columns, column) {
+			kept = append(kept, index.SQL)
+		}
+	}
+
+	if err := tx.Table(table).Migrator().DropColumn(model, column); err != nil {
+		return err
+	}
+	for _, sql := range kept {
+		if err := tx.Exec(sql).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package migrate
+
+import (
+	"fmt"
+
+	"fun-admin/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// ConnectionDB 返回命名连接的主库，name 为空或 default 时返回 db 本身
+// 命名连接挂载在 db 上（见 database.ConnectionsOf），连接不存在时返回错误
+func ConnectionDB(db *gorm.DB, name string) (*gorm.DB, error) {
+	if name == "" || name == database.DefaultConnection {
+		return db, nil
+	}
+	conn, ok := database.ConnectionsOf(db).Get(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown database connection %q", name)
+	}
+	return database.Primary(conn.DB.WithContext(db.Statement.Context)), nil
+}
@@ -0,0 +1,83 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// TableDiff 数据表与期望结构之间的差异
+type TableDiff struct {
+	Table      string
+	Connection string // 数据表所在的命名连接，空表示默认连接
+	Schema     *schema.Schema
+	Create     bool            // 数据表不存在，需要按 Schema 创建
+	Columns    []*schema.Field // 缺失的列
+	Indexes    []schema.Index  // 缺失的索引
+}
+
+// Diff 比较期望的数据表与实际数据库，返回缺失的表、列与索引
+// 只生成增量：数据库中多出的列和索引不会被删除或修改；每张表与其所在连接的数据库比较
+func Diff(db *gorm.DB, tables []Table) ([]TableDiff, error) {
+	var diffs []TableDiff
+	for _, table := range tables {
+		conn, err := ConnectionDB(db, table.Connection)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", table.Name, err)
+		}
+		stmt := &gorm.Statement{DB: conn}
+		if err := stmt.ParseWithSpecialTableName(table.Model, table.Name); err != nil {
+			return nil, fmt.Errorf("parse table %s: %w", table.Name, err)
+		}
+		diff := TableDiff{Table: table.Name, Connection: table.Connection, Schema: stmt.Schema}
+
+		migrator := conn.Migrator()
+		if !migrator.HasTable(table.Name) {
+			diff.Create = true
+			diffs = append(diffs, diff)
+			continue
+		}
+
+		columnTypes, err := migrator.ColumnTypes(table.Name)
+		if err != nil {
+			return nil, fmt.Errorf("read columns of %s: %w", table.Name, err)
+		}
+		existing := make(map[string]bool, len(columnTypes))
+		for _, columnType := range columnTypes {
+			existing[strings.ToLower(columnType.Name())] = true
+		}
+		for _, dbName := range stmt.Schema.DBNames {
+			field := stmt.Schema.FieldsByDBName[dbName]
+			if !field.IgnoreMigration && !existing[strings.ToLower(dbName)] {
+				diff.Columns = append(diff.Columns, field)
+			}
+		}
+
+		for _, index := range SortedIndexes(stmt.Schema) {
+			if !migrator.HasIndex(table.Name, index.Name) {
+				diff.Indexes = append(diff.Indexes, index)
+			}
+		}
+
+		if len(diff.Columns) > 0 || len(diff.Indexes) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+	return diffs, nil
+}
+
+// SortedIndexes 返回模型声明的索引，按名称排序保证生成的迁移稳定
+func SortedIndexes(sch *schema.Schema) []schema.Index {
+	parsed := sch.ParseIndexes()
+	indexes := make([]schema.Index, 0, len(parsed))
+	for _, index := range parsed {
+		indexes = append(indexes, index)
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+	return indexes
+}
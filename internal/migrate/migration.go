@@ -0,0 +1,50 @@
+package migrate
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Migration 版本化迁移，ID 以时间戳开头（如 20240101120000_create_orders_table），按 ID 排序执行
+// Up/Down 接收事务连接，返回错误时回滚
+// Connection 非空时 Up/Down 在该命名连接上执行，执行记录仍写入默认连接，见 Runner
+type Migration struct {
+	ID         string
+	Connection string
+	Up         func(tx *gorm.DB) error
+	Down       func(tx *gorm.DB) error
+}
+
+var (
+	migrationsMu sync.RWMutex
+	migrations   = make(map[string]*Migration)
+)
+
+// Register 注册迁移，ID 重复时 panic，避免两个迁移共用版本号
+func Register(migration *Migration) {
+	migrationsMu.Lock()
+	defer migrationsMu.Unlock()
+
+	if _, exists := migrations[migration.ID]; exists {
+		panic(fmt.Sprintf("migration %s registered twice", migration.ID))
+	}
+	migrations[migration.ID] = migration
+}
+
+// Migrations 返回已注册的迁移，按 ID 升序
+func Migrations() []*Migration {
+	migrationsMu.RLock()
+	defer migrationsMu.RUnlock()
+
+	result := make([]*Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		result = append(result, migration)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
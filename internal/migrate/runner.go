@@ -0,0 +1,281 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fun-admin/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// MigrationsTable 保存迁移执行记录的数据表
+const MigrationsTable = "migrations"
+
+// record 迁移执行记录，同一次 up 执行的迁移属于同一批次，down 默认回滚最后一批
+type record struct {
+	ID        string    `gorm:"primaryKey;size:191"`
+	Batch     int       `gorm:"index;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (record) TableName() string {
+	return MigrationsTable
+}
+
+// MigrationStatus 迁移状态
+type MigrationStatus struct {
+	ID        string
+	Applied   bool
+	Batch     int
+	AppliedAt time.Time
+	Missing   bool // 已执行但代码中不存在，无法回滚
+}
+
+// Runner 按 ID 顺序执行迁移；每个迁移与其执行记录在同一事务中提交
+// 指定了命名连接的迁移在该连接上另开事务，与记录所在的事务一起提交（非分布式事务）；
+// MySQL 的 DDL 会隐式提交事务，迁移失败时可能需要手动清理
+type Runner struct {
+	db         *gorm.DB
+	migrations []*Migration
+}
+
+// NewRunner 创建迁移执行器，migrations 通常为 Migrations()
+func NewRunner(db *gorm.DB, migrations []*Migration) *Runner {
+	sorted := append([]*Migration(nil), migrations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ID < sorted[j].ID
+	})
+	return &Runner{db: db, migrations: sorted}
+}
+
+// Status 返回所有迁移的状态，按 ID 排序；已执行但未注册的迁移标记为 Missing
+func (r *Runner) Status(ctx context.Context) ([]MigrationStatus, error) {
+	records, err := r.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]record, len(records))
+	for _, rec := range records {
+		applied[rec.ID] = rec
+	}
+
+	statuses := make([]MigrationStatus, 0, len(r.migrations))
+	for _, migration := range r.migrations {
+		status := MigrationStatus{ID: migration.ID}
+		if rec, ok := applied[migration.ID]; ok {
+			status.Applied, status.Batch, status.AppliedAt = true, rec.Batch, rec.AppliedAt
+			delete(applied, migration.ID)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, rec := range applied {
+		statuses = append(statuses, MigrationStatus{
+			ID: rec.ID, Applied: true, Batch: rec.Batch, AppliedAt: rec.AppliedAt, Missing: true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID < statuses[j].ID
+	})
+	return statuses, nil
+}
+
+// Up 执行所有未执行的迁移，作为新的一批，返回已执行的迁移 ID
+func (r *Runner) Up(ctx context.Context) ([]string, error) {
+	records, err := r.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	applied := make(map[string]bool, len(records))
+	for _, rec := range records {
+		applied[rec.ID] = true
+	}
+
+	var pending []*Migration
+	for _, migration := range r.migrations {
+		if !applied[migration.ID] {
+			pending = append(pending, migration)
+		}
+	}
+	return r.apply(ctx, pending, nextBatch(records))
+}
+
+// Down 回滚迁移，返回已回滚的迁移 ID
+// steps 大于 0 时回滚最近执行的 steps 个迁移，否则回滚最后一批
+func (r *Runner) Down(ctx context.Context, steps int) ([]string, error) {
+	records, err := r.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// 最近执行的在前
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Batch != records[j].Batch {
+			return records[i].Batch > records[j].Batch
+		}
+		return records[i].ID > records[j].ID
+	})
+	var targets []record
+	for _, rec := range records {
+		if steps > 0 && len(targets) == steps {
+			break
+		}
+		if steps <= 0 && rec.Batch != records[0].Batch {
+			break
+		}
+		targets = append(targets, rec)
+	}
+
+	registered := r.registered()
+	var rolledBack []string
+	for _, rec := range targets {
+		migration, ok := registered[rec.ID]
+		if !ok {
+			return rolledBack, fmt.Errorf("migration %s is applied but not registered", rec.ID)
+		}
+		if migration.Down == nil {
+			return rolledBack, fmt.Errorf("migration %s has no Down", rec.ID)
+		}
+		err := r.transaction(ctx, migration, func(tx, conn *gorm.DB) error {
+			if err := migration.Down(conn); err != nil {
+				return err
+			}
+			return tx.Delete(&record{}, "id = ?", rec.ID).Error
+		})
+		if err != nil {
+			return rolledBack, fmt.Errorf("rollback %s: %w", rec.ID, err)
+		}
+		rolledBack = append(rolledBack, rec.ID)
+	}
+	return rolledBack, nil
+}
+
+// Redo 回滚后重新执行同样的迁移，steps 含义同 Down；其他未执行的迁移不受影响
+func (r *Runner) Redo(ctx context.Context, steps int) ([]string, error) {
+	rolledBack, err := r.Down(ctx, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	registered := r.registered()
+	migrations := make([]*Migration, 0, len(rolledBack))
+	for i := len(rolledBack) - 1; i >= 0; i-- {
+		migrations = append(migrations, registered[rolledBack[i]])
+	}
+
+	records, err := r.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.apply(ctx, migrations, nextBatch(records))
+}
+
+// DropAllTables 删除默认连接与各命名连接中的所有表（包括迁移记录），用于 fresh；
+// 命名连接的表以 "连接名.表名" 返回
+func (r *Runner) DropAllTables(ctx context.Context) ([]string, error) {
+	var dropped []string
+	for _, conn := range database.ConnectionsOf(r.db).All() {
+		db, prefix := r.db, ""
+		if conn.Name != database.DefaultConnection {
+			db, prefix = database.Primary(conn.DB), conn.Name+"."
+		}
+		tables, err := dropTables(db.WithContext(ctx))
+		for _, table := range tables {
+			dropped = append(dropped, prefix+table)
+		}
+		if err != nil {
+			return dropped, fmt.Errorf("connection %s: %w", conn.Name, err)
+		}
+	}
+	return dropped, nil
+}
+
+// dropTables 删除 db 所在数据库的所有表，返回已删除的表
+func dropTables(db *gorm.DB) ([]string, error) {
+	migrator := db.Migrator()
+	tables, err := migrator.GetTables()
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, table := range tables {
+		// SQLite 的内部表不能删除
+		if strings.HasPrefix(table, "sqlite_") {
+			continue
+		}
+		if err := migrator.DropTable(table); err != nil {
+			return dropped, fmt.Errorf("drop table %s: %w", table, err)
+		}
+		dropped = append(dropped, table)
+	}
+	return dropped, nil
+}
+
+// apply 依次执行迁移并写入执行记录，遇到错误时停止
+func (r *Runner) apply(ctx context.Context, migrations []*Migration, batch int) ([]string, error) {
+	var applied []string
+	for _, migration := range migrations {
+		if migration.Up == nil {
+			return applied, fmt.Errorf("migration %s has no Up", migration.ID)
+		}
+		err := r.transaction(ctx, migration, func(tx, conn *gorm.DB) error {
+			if err := migration.Up(conn); err != nil {
+				return err
+			}
+			return tx.Create(&record{ID: migration.ID, Batch: batch, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("migrate %s: %w", migration.ID, err)
+		}
+		applied = append(applied, migration.ID)
+	}
+	return applied, nil
+}
+
+// transaction 在默认连接的事务 tx 与迁移所在连接的事务 conn 中执行 fn，
+// 迁移未指定连接时 conn 即 tx
+func (r *Runner) transaction(ctx context.Context, migration *Migration, fn func(tx, conn *gorm.DB) error) error {
+	manager := database.NewManager(r.db)
+	return manager.WithTransaction(ctx, func(ctx context.Context) error {
+		conn := manager.Connection(ctx, migration.Connection)
+		if conn.Error != nil {
+			return conn.Error
+		}
+		return fn(manager.GetDB(ctx), conn)
+	})
+}
+
+// records 读取执行记录，迁移记录表不存在时自动创建
+func (r *Runner) records(ctx context.Context) ([]record, error) {
+	db := r.db.WithContext(ctx)
+	if err := db.AutoMigrate(&record{}); err != nil {
+		return nil, fmt.Errorf("create %s table: %w", MigrationsTable, err)
+	}
+	var records []record
+	if err := db.Order("batch, id").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *Runner) registered() map[string]*Migration {
+	registered := make(map[string]*Migration, len(r.migrations))
+	for _, migration := range r.migrations {
+		registered[migration.ID] = migration
+	}
+	return registered
+}
+
+func nextBatch(records []record) int {
+	batch := 0
+	for _, rec := range records {
+		if rec.Batch > batch {
+			batch = rec.Batch
+		}
+	}
+	return batch + 1
+}
@@ -0,0 +1,175 @@
+package migrate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/database"
+
+	"gorm.io/gorm"
+)
+
+// Table 期望的数据表：表名与描述其结构的模型，列类型由 GORM 按数据库方言生成
+// Connection 为数据表所在的命名连接，空表示默认连接
+type Table struct {
+	Name       string
+	Model      interface{}
+	Connection string
+}
+
+// defaultProvider 提供默认值的字段
+type defaultProvider interface {
+	GetDefault() interface{}
+}
+
+// Tables 汇总模型与资源对应的数据表，同一连接上的同名表以先出现的模型为准
+func Tables(db *gorm.DB, models []interface{}, resources []admin.Resource) ([]Table, error) {
+	var tables []Table
+	seen := make(map[string]bool)
+	for _, model := range models {
+		name, err := modelTable(db, model)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[name] {
+			seen[name] = true
+			tables = append(tables, Table{Name: name, Model: model})
+		}
+	}
+	for _, resource := range resources {
+		table, err := ResourceTable(db, resource)
+		if err != nil {
+			return nil, err
+		}
+		key := table.Connection + "." + table.Name
+		if table.Connection == "" {
+			key = table.Name
+		}
+		if !seen[key] {
+			seen[key] = true
+			tables = append(tables, table)
+		}
+	}
+	return tables, nil
+}
+
+// ResourceTable 返回资源的数据表
+// 资源仓库按 slug 访问数据表，模型表名与 slug 一致时使用模型，否则按资源字段构建动态结构体
+func ResourceTable(db *gorm.DB, resource admin.Resource) (Table, error) {
+	name := resource.GetSlug()
+	if name == "" {
+		name = strings.ToLower(resource.GetTitle())
+	}
+	if name == "" {
+		return Table{}, fmt.Errorf("resource %T has no slug", resource)
+	}
+
+	var connection string
+	if conn, ok := resource.(admin.ConnectionResource); ok && conn.GetConnection() != database.DefaultConnection {
+		connection = conn.GetConnection()
+	}
+
+	if model := resource.GetModel(); model != nil {
+		if table, err := modelTable(db, model); err == nil && table == name {
+			return Table{Name: name, Model: model, Connection: connection}, nil
+		}
+	}
+	return Table{Name: name, Model: resourceModel(name, resource.GetFields()), Connection: connection}, nil
+}
+
+// modelTable 解析模型的表名
+func modelTable(db *gorm.DB, model interface{}) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("parse model %T: %w", model, err)
+	}
+	return stmt.Schema.Table, nil
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+)
+
+// resourceModel 按资源字段构建动态结构体，包含 id、created_at、updated_at、deleted_at 列
+// 结构体类型只用于生成表结构，不同表共用同一类型也不会相互影响（表名由调用方通过 Table 指定）
+func resourceModel(table string, fields []admin.Field) interface{} {
+	structFields := []reflect.StructField{
+		{Name: "ID", Type: reflect.TypeOf(uint64(0)), Tag: `gorm:"column:id;primaryKey"`},
+		{Name: "CreatedAt", Type: timeType, Tag: `gorm:"column:created_at"`},
+		{Name: "UpdatedAt", Type: timeType, Tag: `gorm:"column:updated_at"`},
+		{Name: "DeletedAt", Type: deletedAtType, Tag: `gorm:"column:deleted_at"`},
+	}
+	seen := map[string]bool{"id": true, "created_at": true, "updated_at": true, "deleted_at": true}
+
+	for i, field := range fields {
+		name := field.GetName()
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		goType, settings := columnType(field.GetType())
+		settings = append([]string{"column:" + name}, settings...)
+		if field.GetType() == "relationship" {
+			// 默认索引名取自结构体字段名，这里按列名命名
+			settings = append(settings, "index:idx_"+table+"_"+name)
+		}
+		if field.IsRequired() {
+			settings = append(settings, "not null")
+		}
+		if provider, ok := field.(defaultProvider); ok {
+			if value := defaultTag(provider.GetDefault()); value != "" {
+				settings = append(settings, "default:"+value)
+			}
+		}
+		// 列名可能不是合法的 Go 标识符，字段名使用序号
+		structFields = append(structFields, reflect.StructField{
+			Name: fmt.Sprintf("Field%d", i),
+			Type: goType,
+			Tag:  reflect.StructTag(`gorm:"` + strings.Join(settings, ";") + `"`),
+		})
+	}
+
+	return reflect.New(reflect.StructOf(structFields)).Interface()
+}
+
+// columnType 根据字段类型返回列的 Go 类型与 GORM 标签
+func columnType(fieldType string) (reflect.Type, []string) {
+	switch fieldType {
+	case "number":
+		return reflect.TypeOf(int64(0)), nil
+	case "boolean":
+		return reflect.TypeOf(false), nil
+	case "date":
+		return timeType, []string{"type:date"}
+	case "datetime":
+		return timeType, nil
+	case "relationship":
+		return reflect.TypeOf(uint64(0)), nil
+	case "textarea":
+		return reflect.TypeOf(""), []string{"type:text"}
+	default:
+		// text、email、select、file
+		return reflect.TypeOf(""), []string{"size:255"}
+	}
+}
+
+// defaultTag 将字段默认值转换为 GORM default 标签的值，无法安全表示时返回空字符串
+func defaultTag(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		if strings.ContainsAny(v, `;'"`) {
+			return ""
+		}
+		return "'" + v + "'"
+	case bool, int, int64, float64:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
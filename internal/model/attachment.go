@@ -0,0 +1,23 @@
+package model
+
+// Attachment 资源记录附件，将存储中的文件关联到 (资源, 记录, 字段)
+type Attachment struct {
+	BaseModel
+	ResourceSlug string `gorm:"size:100;not null;index:idx_attachment_record" json:"resource_slug"` // 资源标识
+	RecordID     string `gorm:"size:64;not null;index:idx_attachment_record" json:"record_id"`      // 记录ID
+	FieldName    string `gorm:"size:100;not null;index:idx_attachment_record" json:"field_name"`    // 字段名
+	StorageType  string `gorm:"size:50" json:"storage_type"`                                        // 存储磁盘
+	StorageKey   string `gorm:"size:500;not null" json:"storage_key"`                               // 存储键
+	Name         string `gorm:"size:255" json:"name"`                                               // 原始文件名
+	MimeType     string `gorm:"size:100" json:"mime_type"`                                          // MIME 类型
+	Size         int64  `json:"size"`                                                               // 文件大小（字节）
+	Checksum     string `gorm:"size:64;index" json:"checksum"`                                      // SHA-256 校验和
+	Sort         int    `gorm:"default:0" json:"sort"`                                              // 字段内排序
+	UploadedBy   uint   `gorm:"index" json:"uploaded_by"`                                           // 上传人ID
+	URL          string `gorm:"-" json:"url,omitempty"`                                             // 访问地址（仅输出）
+}
+
+// TableName 指定表名
+func (Attachment) TableName() string {
+	return "admin_attachment"
+}
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// Comment 资源记录评论（内部备注），按资源 slug 与记录 ID 关联
+type Comment struct {
+	BaseModel
+	ResourceSlug string           `gorm:"size:100;not null;index:idx_comment_record" json:"resource_slug"` // 资源标识
+	RecordID     string           `gorm:"size:64;not null;index:idx_comment_record" json:"record_id"`      // 记录ID
+	ParentID     *uint            `gorm:"index" json:"parent_id"`                                          // 父评论ID（回复）
+	UserID       uint             `gorm:"not null;index" json:"user_id"`                                   // 作者ID
+	UserName     string           `gorm:"size:50" json:"user_name"`                                        // 作者名称
+	Content      string           `gorm:"type:text;not null" json:"content"`                               // 评论内容
+	EditedAt     *time.Time       `json:"edited_at"`                                                       // 最后编辑时间
+	Mentions     []CommentMention `gorm:"foreignKey:CommentID" json:"mentions"`                            // @提及的用户
+	Replies      []*Comment       `gorm:"-" json:"replies"`                                                // 回复（仅用于输出）
+}
+
+// TableName 指定表名
+func (Comment) TableName() string {
+	return "admin_comment"
+}
+
+// CommentMention 评论中 @提及的管理员
+type CommentMention struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	CommentID uint      `gorm:"not null;index" json:"comment_id"`
+	UserID    uint      `gorm:"not null;index" json:"user_id"`
+	UserName  string    `gorm:"size:50" json:"user_name"`
+}
+
+// TableName 指定表名
+func (CommentMention) TableName() string {
+	return "admin_comment_mention"
+}
@@ -0,0 +1,43 @@
+package model
+
+import "time"
+
+// 导出任务状态
+const (
+	ExportStatusPending   = "pending"
+	ExportStatusRunning   = "running"
+	ExportStatusCompleted = "completed"
+	ExportStatusFailed    = "failed"
+	ExportStatusExpired   = "expired"
+)
+
+// ExportJob 异步导出任务，状态持久化
+// 执行中的任务由领取实例持有租约并定期续期，租约过期（实例退出或失联）后任务重新排队
+type ExportJob struct {
+	ID           uint       `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	ResourceSlug string     `gorm:"size:100;not null;index" json:"resource_slug"` // 资源标识
+	Format       string     `gorm:"size:20" json:"format"`                        // 导出格式
+	Params       string     `gorm:"type:text" json:"-"`                           // 导出参数（JSON）
+	Status       string     `gorm:"size:20;index" json:"status"`                  // 任务状态
+	Rows         int        `json:"rows"`                                         // 导出行数
+	FileName     string     `gorm:"size:255" json:"file_name"`                    // 下载文件名
+	StorageType  string     `gorm:"size:50" json:"storage_type"`                  // 存储磁盘
+	StorageKey   string     `gorm:"size:500" json:"-"`                            // 存储键
+	FileSize     int64      `json:"file_size"`                                    // 文件大小
+	Error        string     `gorm:"type:text" json:"error"`                       // 失败原因
+	CreatedBy    uint       `gorm:"index" json:"created_by"`                      // 发起人ID
+	StartedAt    *time.Time `json:"started_at"`                                   // 开始时间
+	Owner        string     `gorm:"size:100" json:"-"`                            // 执行实例标识
+	HeartbeatAt  *time.Time `gorm:"index" json:"-"`                               // 租约心跳时间
+	FinishedAt   *time.Time `json:"finished_at"`                                  // 完成时间
+	ExpiresAt    *time.Time `gorm:"index" json:"expires_at"`                      // 文件过期时间
+	NotifiedAt   *time.Time `json:"notified_at"`                                  // 通知时间
+	DownloadURL  string     `gorm:"-" json:"download_url,omitempty"`              // 签名下载地址
+}
+
+// TableName 指定表名
+func (ExportJob) TableName() string {
+	return "admin_export_job"
+}
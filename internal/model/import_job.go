@@ -0,0 +1,42 @@
+package model
+
+import "time"
+
+// 导入任务状态
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportJob 资源导入任务（同步导入也会记录，便于追溯与下载错误报告）
+type ImportJob struct {
+	ID              uint       `gorm:"primarykey" json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ResourceSlug    string     `gorm:"size:100;not null;index" json:"resource_slug"` // 资源标识
+	FileName        string     `gorm:"size:255" json:"file_name"`                    // 原始文件名
+	FileType        string     `gorm:"size:20" json:"file_type"`                     // 文件类型 excel/csv/json
+	Mode            string     `gorm:"size:20" json:"mode"`                          // insert/update/upsert
+	KeyField        string     `gorm:"size:100" json:"key_field"`                    // 更新匹配字段
+	TransactionMode string     `gorm:"size:20" json:"transaction_mode"`              // single/batch
+	DryRun          bool       `json:"dry_run"`                                      // 是否试运行
+	Async           bool       `json:"async"`                                        // 是否异步执行
+	Status          string     `gorm:"size:20;index" json:"status"`                  // 任务状态
+	TotalRows       int        `json:"total_rows"`                                   // 总行数
+	ProcessedRows   int        `json:"processed_rows"`                               // 已处理行数
+	CreatedRows     int        `json:"created_rows"`                                 // 新增行数
+	UpdatedRows     int        `json:"updated_rows"`                                 // 更新行数
+	FailedRows      int        `json:"failed_rows"`                                  // 失败行数
+	Error           string     `gorm:"type:text" json:"error"`                       // 任务级错误
+	ErrorReportKey  string     `gorm:"size:500" json:"error_report_key"`             // 错误报告存储键
+	CreatedBy       uint       `gorm:"index" json:"created_by"`                      // 发起人ID
+	StartedAt       *time.Time `json:"started_at"`                                   // 开始时间
+	FinishedAt      *time.Time `json:"finished_at"`                                  // 结束时间
+}
+
+// TableName 指定表名
+func (ImportJob) TableName() string {
+	return "admin_import_job"
+}
@@ -0,0 +1,72 @@
+package model
+
+import "time"
+
+// 定时报表统计区间：运行时换算为 created_at 范围，按报表时区取上一个完整周期
+const (
+	ReportPeriodNone      = ""
+	ReportPeriodLastDay   = "last_day"
+	ReportPeriodLastWeek  = "last_week"
+	ReportPeriodLastMonth = "last_month"
+)
+
+// 定时报表运行状态
+const (
+	ReportRunStatusRunning = "running"
+	ReportRunStatusSuccess = "success"
+	ReportRunStatusFailed  = "failed"
+)
+
+// ScheduledReport 定时报表：按 Cron 表达式导出资源数据，通过邮件发送或写入存储
+type ScheduledReport struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	Name           string     `gorm:"size:100;not null" json:"name"`                // 报表名称
+	ResourceSlug   string     `gorm:"size:100;not null;index" json:"resource_slug"` // 资源标识
+	Filters        string     `gorm:"type:text" json:"filters"`                     // 精确过滤条件（JSON）
+	Search         string     `gorm:"type:text" json:"search"`                      // 模糊搜索条件（JSON）
+	Columns        string     `gorm:"type:text" json:"columns"`                     // 导出列（JSON 数组）
+	OrderBy        string     `gorm:"size:100" json:"order_by"`                     // 排序字段
+	OrderDirection string     `gorm:"size:10" json:"order_direction"`               // 排序方向
+	Period         string     `gorm:"size:20" json:"period"`                        // 统计区间
+	Format         string     `gorm:"size:20" json:"format"`                        // 导出格式
+	CronExpr       string     `gorm:"size:100;not null" json:"cron_expr"`           // Cron 表达式
+	Timezone       string     `gorm:"size:64" json:"timezone"`                      // 时区，如 Asia/Shanghai
+	Recipients     string     `gorm:"type:text" json:"recipients"`                  // 收件人，逗号分隔
+	StorageType    string     `gorm:"size:50" json:"storage_type"`                  // 存储磁盘，空为默认
+	StoragePath    string     `gorm:"size:255" json:"storage_path"`                 // 存储目录，为空时不写入存储
+	Enabled        bool       `gorm:"default:true;index" json:"enabled"`            // 是否启用
+	OwnerID        uint       `gorm:"index" json:"owner_id"`                        // 所有者，按其权限导出
+	NextRunAt      *time.Time `gorm:"index" json:"next_run_at"`                     // 下次运行时间
+	LastRunAt      *time.Time `json:"last_run_at"`                                  // 上次运行时间
+	LastStatus     string     `gorm:"size:20" json:"last_status"`                   // 上次运行状态
+	LastError      string     `gorm:"type:text" json:"last_error"`                  // 上次失败原因
+}
+
+// TableName 指定表名
+func (ScheduledReport) TableName() string {
+	return "admin_scheduled_report"
+}
+
+// ScheduledReportRun 定时报表运行记录
+type ScheduledReportRun struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReportID    uint       `gorm:"index" json:"report_id"`      // 报表ID
+	Status      string     `gorm:"size:20;index" json:"status"` // 运行状态
+	Rows        int        `json:"rows"`                        // 导出行数
+	FileName    string     `gorm:"size:255" json:"file_name"`   // 文件名
+	StorageType string     `gorm:"size:50" json:"storage_type"` // 存储磁盘
+	StorageKey  string     `gorm:"size:500" json:"storage_key"` // 存储键，未写入存储时为空
+	FileSize    int64      `json:"file_size"`                   // 文件大小
+	Recipients  string     `gorm:"type:text" json:"recipients"` // 实际发送的收件人
+	Error       string     `gorm:"type:text" json:"error"`      // 失败原因
+	StartedAt   time.Time  `json:"started_at"`                  // 开始时间
+	FinishedAt  *time.Time `json:"finished_at"`                 // 结束时间
+}
+
+// TableName 指定表名
+func (ScheduledReportRun) TableName() string {
+	return "admin_scheduled_report_run"
+}
@@ -0,0 +1,20 @@
+package model
+
+import "time"
+
+// Translation 记录字段的多语言值，(资源, 记录, 字段, 语言) 唯一；默认语言的值保存在记录本身的列中
+type Translation struct {
+	ID           uint      `gorm:"primarykey" json:"id"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	ResourceSlug string    `gorm:"size:100;not null;uniqueIndex:idx_translation_value,priority:1" json:"resource_slug"` // 资源标识
+	RecordID     string    `gorm:"size:64;not null;uniqueIndex:idx_translation_value,priority:2" json:"record_id"`      // 记录ID
+	FieldName    string    `gorm:"size:100;not null;uniqueIndex:idx_translation_value,priority:3" json:"field_name"`    // 字段名
+	Locale       string    `gorm:"size:20;not null;uniqueIndex:idx_translation_value,priority:4" json:"locale"`         // 语言
+	Value        string    `gorm:"type:text" json:"value"`                                                              // 翻译值
+}
+
+// TableName 指定表名
+func (Translation) TableName() string {
+	return "admin_translation"
+}
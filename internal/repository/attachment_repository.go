@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/database"
+	"fun-admin/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AttachmentRepository 附件仓库接口
+type AttachmentRepository interface {
+	GetAttachments(ctx context.Context, resourceSlug, recordID, fieldName string) ([]*model.Attachment, error)
+	GetAttachment(ctx context.Context, id uint) (*model.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment *model.Attachment) error
+	NextSort(ctx context.Context, resourceSlug, recordID, fieldName string) (int, error)
+	UpdateSort(ctx context.Context, ids []uint) error
+	DeleteAttachment(ctx context.Context, id uint) error
+	DeleteFieldAttachments(ctx context.Context, resourceSlug, recordID, fieldName string) error
+	DeleteRecordAttachments(ctx context.Context, resourceSlug string, recordIDs []string) error
+	GetTrashedAttachments(ctx context.Context, before time.Time, limit int) ([]*model.Attachment, error)
+	PurgeAttachment(ctx context.Context, id uint) error
+}
+
+type attachmentRepository struct {
+	logger *logger.Logger
+	dbMgr  *database.Manager
+}
+
+// NewAttachmentRepository 创建仓库实例，上下文中有事务时附件读写参与该事务
+func NewAttachmentRepository(logger *logger.Logger, db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{
+		logger: logger,
+		dbMgr:  database.NewManager(db),
+	}
+}
+
+// GetAttachments 获取记录附件，fieldName 为空时返回全部字段
+func (r *attachmentRepository) GetAttachments(ctx context.Context, resourceSlug, recordID, fieldName string) ([]*model.Attachment, error) {
+	var list []*model.Attachment
+	query := r.dbMgr.GetDB(ctx).Where("resource_slug = ? AND record_id = ?", resourceSlug, recordID)
+	if fieldName != "" {
+		query = query.Where("field_name = ?", fieldName)
+	}
+	err := query.Order("field_name ASC, sort ASC, id ASC").Find(&list).Error
+	return list, err
+}
+
+// GetAttachment 获取单个附件
+func (r *attachmentRepository) GetAttachment(ctx context.Context, id uint) (*model.Attachment, error) {
+	var attachment model.Attachment
+	err := r.dbMgr.GetDB(ctx).Where("id = ?", id).First(&attachment).Error
+	return &attachment, err
+}
+
+// CreateAttachment 创建附件
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	return r.dbMgr.GetDB(ctx).Create(attachment).Error
+}
+
+// NextSort 返回字段内下一个排序值
+func (r *attachmentRepository) NextSort(ctx context.Context, resourceSlug, recordID, fieldName string) (int, error) {
+	var maxSort *int
+	err := r.dbMgr.GetDB(ctx).Model(&model.Attachment{}).
+		Where("resource_slug = ? AND record_id = ? AND field_name = ?", resourceSlug, recordID, fieldName).
+		Select("MAX(sort)").Scan(&maxSort).Error
+	if err != nil {
+		return 0, err
+	}
+	if maxSort == nil {
+		return 0, nil
+	}
+	return *maxSort + 1, nil
+}
+
+// UpdateSort 按 ids 顺序重写排序值
+func (r *attachmentRepository) UpdateSort(ctx context.Context, ids []uint) error {
+	return r.dbMgr.GetDB(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&model.Attachment{}).Where("id = ?", id).Update("sort", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteAttachment 软删除附件
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, id uint) error {
+	return r.dbMgr.GetDB(ctx).Where("id = ?", id).Delete(&model.Attachment{}).Error
+}
+
+// DeleteFieldAttachments 软删除字段下的全部附件
+func (r *attachmentRepository) DeleteFieldAttachments(ctx context.Context, resourceSlug, recordID, fieldName string) error {
+	return r.dbMgr.GetDB(ctx).
+		Where("resource_slug = ? AND record_id = ? AND field_name = ?", resourceSlug, recordID, fieldName).
+		Delete(&model.Attachment{}).Error
+}
+
+// DeleteRecordAttachments 软删除记录的全部附件
+func (r *attachmentRepository) DeleteRecordAttachments(ctx context.Context, resourceSlug string, recordIDs []string) error {
+	if len(recordIDs) == 0 {
+		return nil
+	}
+	return r.dbMgr.GetDB(ctx).
+		Where("resource_slug = ? AND record_id IN ?", resourceSlug, recordIDs).
+		Delete(&model.Attachment{}).Error
+}
+
+// GetTrashedAttachments 获取在 before 之前软删除的附件
+func (r *attachmentRepository) GetTrashedAttachments(ctx context.Context, before time.Time, limit int) ([]*model.Attachment, error) {
+	var list []*model.Attachment
+	err := r.dbMgr.GetDB(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Order("id ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// PurgeAttachment 物理删除附件记录
+func (r *attachmentRepository) PurgeAttachment(ctx context.Context, id uint) error {
+	return r.dbMgr.GetDB(ctx).Unscoped().Where("id = ?", id).Delete(&model.Attachment{}).Error
+}
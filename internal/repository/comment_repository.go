@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/logger"
+
+	"gorm.io/gorm"
+)
+
+// CommentRepository 评论仓库接口
+type CommentRepository interface {
+	GetRootComments(ctx context.Context, resourceSlug, recordID string, page, pageSize int) ([]*model.Comment, int64, error)
+	GetReplies(ctx context.Context, resourceSlug, recordID string) ([]*model.Comment, error)
+	GetComment(ctx context.Context, id uint) (*model.Comment, error)
+	CreateComment(ctx context.Context, comment *model.Comment) error
+	UpdateComment(ctx context.Context, comment *model.Comment) error
+	DeleteComment(ctx context.Context, id uint) error
+}
+
+type commentRepository struct {
+	logger *logger.Logger
+	db     *gorm.DB
+}
+
+// NewCommentRepository 创建仓库实例
+func NewCommentRepository(logger *logger.Logger, db *gorm.DB) CommentRepository {
+	return &commentRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// GetRootComments 分页获取记录下的顶层评论
+func (r *commentRepository) GetRootComments(ctx context.Context, resourceSlug, recordID string, page, pageSize int) ([]*model.Comment, int64, error) {
+	var list []*model.Comment
+	query := r.db.WithContext(ctx).Model(&model.Comment{}).
+		Where("resource_slug = ? AND record_id = ? AND parent_id IS NULL", resourceSlug, recordID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Preload("Mentions").Order("id DESC").
+		Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetReplies 获取记录下的全部回复
+func (r *commentRepository) GetReplies(ctx context.Context, resourceSlug, recordID string) ([]*model.Comment, error) {
+	var list []*model.Comment
+	err := r.db.WithContext(ctx).Preload("Mentions").
+		Where("resource_slug = ? AND record_id = ? AND parent_id IS NOT NULL", resourceSlug, recordID).
+		Order("id ASC").Find(&list).Error
+	return list, err
+}
+
+// GetComment 获取单条评论
+func (r *commentRepository) GetComment(ctx context.Context, id uint) (*model.Comment, error) {
+	var comment model.Comment
+	err := r.db.WithContext(ctx).Preload("Mentions").Where("id = ?", id).First(&comment).Error
+	return &comment, err
+}
+
+// CreateComment 创建评论（含提及）
+func (r *commentRepository) CreateComment(ctx context.Context, comment *model.Comment) error {
+	return r.db.WithContext(ctx).Create(comment).Error
+}
+
+// UpdateComment 更新评论内容并替换提及列表
+func (r *commentRepository) UpdateComment(ctx context.Context, comment *model.Comment) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&model.Comment{}).Where("id = ?", comment.ID).Updates(map[string]interface{}{
+			"content":   comment.Content,
+			"edited_at": comment.EditedAt,
+		}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&model.CommentMention{}).Error; err != nil {
+			return err
+		}
+		if len(comment.Mentions) == 0 {
+			return nil
+		}
+		for i := range comment.Mentions {
+			comment.Mentions[i].CommentID = comment.ID
+		}
+		return tx.Create(&comment.Mentions).Error
+	})
+}
+
+// DeleteComment 软删除评论
+func (r *commentRepository) DeleteComment(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&model.Comment{}).Error
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fun-admin/internal/model"
+	"fun-admin/pkg/admin"
 	"fun-admin/pkg/logger"
 	"strings"
 
@@ -19,6 +20,7 @@ type DashboardRepository interface {
 	GetPostStatusStats(ctx context.Context) (map[string]interface{}, error)
 	GetDatabaseVersion(ctx context.Context) (string, error)
 	GetDatabaseSize(ctx context.Context) (int64, error)
+	GetWidgetData(ctx context.Context, widget admin.Widget) (interface{}, error)
 }
 
 // dashboardRepository 仪表板仓库实现
@@ -190,4 +192,9 @@ func (r *dashboardRepository) GetDatabaseSize(ctx context.Context) (int64, error
 		// 对于不支持的数据库，返回0
 		return 0, nil
 	}
-}
\ No newline at end of file
+}
+
+// GetWidgetData 计算仪表盘小组件数据
+func (r *dashboardRepository) GetWidgetData(ctx context.Context, widget admin.Widget) (interface{}, error) {
+	return widget.Data(ctx, r.db.WithContext(ctx))
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ExportJobRepository 导出任务仓库接口
+type ExportJobRepository interface {
+	GetExportJobs(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ExportJob, int64, error)
+	GetExportJob(ctx context.Context, id uint) (*model.ExportJob, error)
+	CreateExportJob(ctx context.Context, job *model.ExportJob) error
+	UpdateExportJob(ctx context.Context, job *model.ExportJob) error
+	ClaimPendingExportJob(ctx context.Context, owner string) (*model.ExportJob, error)
+	RenewExportJobLease(ctx context.Context, id uint, owner string) (bool, error)
+	FinishExportJob(ctx context.Context, job *model.ExportJob, owner string) (bool, error)
+	ReclaimExpiredExportJobs(ctx context.Context, before time.Time) (int64, error)
+	GetExpiredExportJobs(ctx context.Context, before time.Time, limit int) ([]*model.ExportJob, error)
+}
+
+type exportJobRepository struct {
+	logger *logger.Logger
+	db     *gorm.DB
+}
+
+// NewExportJobRepository 创建仓库实例
+func NewExportJobRepository(logger *logger.Logger, db *gorm.DB) ExportJobRepository {
+	return &exportJobRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// GetExportJobs 获取导出任务列表
+func (r *exportJobRepository) GetExportJobs(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ExportJob, int64, error) {
+	var list []*model.ExportJob
+	query := r.db.WithContext(ctx).Model(&model.ExportJob{}).Order("id DESC")
+
+	if createdBy, ok := filters["created_by"]; ok {
+		query = query.Where("created_by = ?", createdBy)
+	}
+	if resource, ok := filters["resource"]; ok && resource != "" {
+		query = query.Where("resource_slug = ?", resource)
+	}
+	if status, ok := filters["status"]; ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetExportJob 获取单个导出任务
+func (r *exportJobRepository) GetExportJob(ctx context.Context, id uint) (*model.ExportJob, error) {
+	var job model.ExportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	return &job, err
+}
+
+// CreateExportJob 创建导出任务
+func (r *exportJobRepository) CreateExportJob(ctx context.Context, job *model.ExportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// UpdateExportJob 保存导出任务
+func (r *exportJobRepository) UpdateExportJob(ctx context.Context, job *model.ExportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// ClaimPendingExportJob 以 owner 身份领取最早的待执行任务并取得租约，没有任务时返回 nil
+// 通过带状态条件的更新保证多实例下同一任务只被领取一次
+func (r *exportJobRepository) ClaimPendingExportJob(ctx context.Context, owner string) (*model.ExportJob, error) {
+	for {
+		var job model.ExportJob
+		err := r.db.WithContext(ctx).Where("status = ?", model.ExportStatusPending).Order("id ASC").First(&job).Error
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		result := r.db.WithContext(ctx).Model(&model.ExportJob{}).
+			Where("id = ? AND status = ?", job.ID, model.ExportStatusPending).
+			Updates(map[string]interface{}{
+				"status":       model.ExportStatusRunning,
+				"started_at":   now,
+				"owner":        owner,
+				"heartbeat_at": now,
+			})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 1 {
+			job.Status = model.ExportStatusRunning
+			job.StartedAt = &now
+			job.Owner = owner
+			job.HeartbeatAt = &now
+			return &job, nil
+		}
+	}
+}
+
+// RenewExportJobLease 续期任务租约，返回 false 表示租约已被回收、任务不再由 owner 持有
+func (r *exportJobRepository) RenewExportJobLease(ctx context.Context, id uint, owner string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.ExportJob{}).
+		Where("id = ? AND owner = ? AND status = ?", id, owner, model.ExportStatusRunning).
+		Update("heartbeat_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+// FinishExportJob 保存任务执行结果，仅在 owner 仍持有租约时生效
+// 返回 false 表示任务已被其他实例回收，结果被丢弃
+func (r *exportJobRepository) FinishExportJob(ctx context.Context, job *model.ExportJob, owner string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(job).
+		Where("owner = ? AND status = ?", owner, model.ExportStatusRunning).
+		Select("status", "rows", "storage_type", "storage_key", "file_size", "error", "finished_at", "expires_at").
+		Updates(job)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReclaimExpiredExportJobs 将租约心跳早于 before 的执行中任务重新置为待执行
+func (r *exportJobRepository) ReclaimExpiredExportJobs(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&model.ExportJob{}).
+		Where("status = ? AND (heartbeat_at IS NULL OR heartbeat_at < ?)", model.ExportStatusRunning, before).
+		Updates(map[string]interface{}{"status": model.ExportStatusPending, "owner": "", "heartbeat_at": nil})
+	return result.RowsAffected, result.Error
+}
+
+// GetExpiredExportJobs 获取文件已过期但尚未清理的任务
+func (r *exportJobRepository) GetExpiredExportJobs(ctx context.Context, before time.Time, limit int) ([]*model.ExportJob, error) {
+	var list []*model.ExportJob
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at IS NOT NULL AND expires_at < ?", model.ExportStatusCompleted, before).
+		Order("id ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
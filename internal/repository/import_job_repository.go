@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportJobRepository 导入任务仓库接口
+type ImportJobRepository interface {
+	GetImportJobs(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ImportJob, int64, error)
+	GetImportJob(ctx context.Context, id uint) (*model.ImportJob, error)
+	CreateImportJob(ctx context.Context, job *model.ImportJob) error
+	UpdateImportJob(ctx context.Context, job *model.ImportJob) error
+	UpdateImportProgress(ctx context.Context, id uint, processed int) error
+	TouchImportJob(ctx context.Context, id uint) error
+	FailStaleImportJobs(ctx context.Context, before time.Time, reason string) (int64, error)
+}
+
+type importJobRepository struct {
+	logger *logger.Logger
+	db     *gorm.DB
+}
+
+// NewImportJobRepository 创建仓库实例
+func NewImportJobRepository(logger *logger.Logger, db *gorm.DB) ImportJobRepository {
+	return &importJobRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// GetImportJobs 获取导入任务列表
+func (r *importJobRepository) GetImportJobs(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ImportJob, int64, error) {
+	var list []*model.ImportJob
+	query := r.db.WithContext(ctx).Model(&model.ImportJob{}).Order("id DESC")
+
+	if resource, ok := filters["resource"]; ok && resource != "" {
+		query = query.Where("resource_slug = ?", resource)
+	}
+	if status, ok := filters["status"]; ok && status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if createdBy, ok := filters["created_by"]; ok && createdBy != "" {
+		query = query.Where("created_by = ?", createdBy)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetImportJob 获取单个导入任务
+func (r *importJobRepository) GetImportJob(ctx context.Context, id uint) (*model.ImportJob, error) {
+	var job model.ImportJob
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&job).Error
+	return &job, err
+}
+
+// CreateImportJob 创建导入任务
+func (r *importJobRepository) CreateImportJob(ctx context.Context, job *model.ImportJob) error {
+	return r.db.WithContext(ctx).Create(job).Error
+}
+
+// UpdateImportJob 保存导入任务
+func (r *importJobRepository) UpdateImportJob(ctx context.Context, job *model.ImportJob) error {
+	return r.db.WithContext(ctx).Save(job).Error
+}
+
+// UpdateImportProgress 更新已处理行数
+func (r *importJobRepository) UpdateImportProgress(ctx context.Context, id uint, processed int) error {
+	return r.db.WithContext(ctx).Model(&model.ImportJob{}).Where("id = ?", id).Update("processed_rows", processed).Error
+}
+
+// TouchImportJob 刷新执行中任务的更新时间，作为任务仍在执行的心跳
+func (r *importJobRepository) TouchImportJob(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&model.ImportJob{}).
+		Where("id = ? AND status = ?", id, model.ImportStatusRunning).
+		Update("updated_at", time.Now()).Error
+}
+
+// FailStaleImportJobs 将更新时间早于 before 的待执行、执行中任务标记为失败
+// 导入数据只保存在发起请求的进程内存中，中断的任务无法重新执行
+func (r *importJobRepository) FailStaleImportJobs(ctx context.Context, before time.Time, reason string) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&model.ImportJob{}).
+		Where("status IN ? AND updated_at < ?", []string{model.ImportStatusPending, model.ImportStatusRunning}, before).
+		Updates(map[string]interface{}{"status": model.ImportStatusFailed, "error": reason, "finished_at": time.Now()})
+	return result.RowsAffected, result.Error
+}
@@ -3,9 +3,6 @@ package repository
 import (
 	"context"
 	"fun-admin/internal/model"
-	"fun-admin/pkg/logger"
-
-	"gorm.io/gorm"
 )
 
 type MenuRepository interface {
@@ -15,35 +12,29 @@ type MenuRepository interface {
 	MenuDelete(ctx context.Context, id uint) error
 }
 
-func NewMenuRepository(
-	logger *logger.Logger,
-	db *gorm.DB,
-) MenuRepository {
-	return &menuRepository{
-		logger: logger,
-		db:     db,
-	}
+// NewMenuRepository 创建菜单仓库，写入经 DB(ctx) 参与菜单标题翻译所在的事务
+func NewMenuRepository(repo *Repository) MenuRepository {
+	return &menuRepository{repo}
 }
 
 type menuRepository struct {
-	logger *logger.Logger
-	db     *gorm.DB
+	*Repository
 }
 
 func (r *menuRepository) GetMenuList(ctx context.Context) ([]*model.Menu, error) {
 	var list []*model.Menu
-	err := r.db.Order("weight DESC").Find(&list).Error
+	err := r.DB(ctx).Order("weight DESC").Find(&list).Error
 	return list, err
 }
 
 func (r *menuRepository) MenuUpdate(ctx context.Context, menu *model.Menu) error {
-	return r.db.Model(&model.Menu{}).Where("id = ?", menu.ID).Updates(menu).Error
+	return r.DB(ctx).Model(&model.Menu{}).Where("id = ?", menu.ID).Updates(menu).Error
 }
 
 func (r *menuRepository) MenuCreate(ctx context.Context, menu *model.Menu) error {
-	return r.db.Create(menu).Error
+	return r.DB(ctx).Create(menu).Error
 }
 
 func (r *menuRepository) MenuDelete(ctx context.Context, id uint) error {
-	return r.db.Where("id = ?", id).Delete(&model.Menu{}).Error
+	return r.DB(ctx).Where("id = ?", id).Delete(&model.Menu{}).Error
 }
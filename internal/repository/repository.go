@@ -2,6 +2,8 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 	"fun-admin/pkg/database"
 	"fun-admin/pkg/logger"
 	"fun-admin/pkg/zapgorm2"
@@ -71,17 +73,22 @@ func (r *Repository) GetQueryBuilder(ctx context.Context) *database.QueryBuilder
 	return database.NewQueryBuilder(db)
 }
 
+// Connection 获取命名连接，name 为空或 default 时等同于 DB(ctx)
+func (r *Repository) Connection(ctx context.Context, name string) *gorm.DB {
+	return r.dbMgr.Connection(ctx, name)
+}
+
 // Health 检查数据库健康状态
 func (r *Repository) Health(ctx context.Context) error {
 	return r.dbMgr.Health(ctx)
 }
 
-func NewDB(conf *viper.Viper, l *logger.Logger) *gorm.DB {
-	var (
-		db  *gorm.DB
-		err error
-	)
+// DatabaseStatus 返回全部连接（含只读副本）的健康状态与连接池统计
+func (r *Repository) DatabaseStatus(ctx context.Context) []database.PoolStatus {
+	return r.dbMgr.Status(ctx)
+}
 
+func NewDB(conf *viper.Viper, l *logger.Logger) *gorm.DB {
 	// 设置日志级别
 	logLevel := gormlogger.Silent
 	if conf.GetBool("data.db.debug") {
@@ -89,9 +96,6 @@ func NewDB(conf *viper.Viper, l *logger.Logger) *gorm.DB {
 	}
 	gormLogger := zapgorm2.New(l.Logger).LogMode(logLevel)
 
-	driver := conf.GetString("data.db.user.driver")
-	dsn := conf.GetString("data.db.user.dsn")
-
 	config := &gorm.Config{
 		Logger: gormLogger,
 		// 禁用外键约束
@@ -102,34 +106,92 @@ func NewDB(conf *viper.Viper, l *logger.Logger) *gorm.DB {
 		PrepareStmt: true,
 	}
 
-	// GORM doc: https://gorm.io/docs/connecting_to_the_database.html
-	switch driver {
-	case "mysql":
-		db, err = gorm.Open(mysql.Open(dsn), config)
-	case "postgres":
-		db, err = gorm.Open(postgres.New(postgres.Config{
-			DSN:                  dsn,
-			PreferSimpleProtocol: true, // disables implicit prepared statement usage
-		}), config)
-	case "sqlite":
-		db, err = gorm.Open(sqlite.Open(dsn), config)
-	default:
-		l.Fatal("unknown database driver", zap.String("driver", driver))
+	driver := conf.GetString("data.db.user.driver")
+	conn, err := openConnection(conf, database.DefaultConnection, driver,
+		conf.GetString("data.db.user.dsn"), conf.GetStringSlice("data.db.user.replicas"), config)
+	if err != nil {
+		l.Fatal("failed to connect database", zap.String("driver", driver), zap.Error(err))
 		return nil
 	}
-	if err != nil {
-		l.Fatal("failed to connect database", zap.Error(err))
+
+	// 命名连接（data.db.connections.<name>），资源可通过 admin.ConnectionResource 绑定
+	conns := database.NewConnections()
+	conns.Add(conn)
+	for name := range conf.GetStringMap("data.db.connections") {
+		key := "data.db.connections." + name
+		named, err := openConnection(conf, name, conf.GetString(key+".driver"),
+			conf.GetString(key+".dsn"), conf.GetStringSlice(key+".replicas"), config)
+		if err != nil {
+			l.Fatal("failed to connect database", zap.String("connection", name), zap.Error(err))
+			return nil
+		}
+		conns.Add(named)
+	}
+	if err := conn.DB.Use(conns); err != nil {
+		l.Fatal("failed to register database connections", zap.Error(err))
 		return nil
 	}
+	return conn.DB
+}
+
+// openConnection 打开一个连接及其只读副本，副本使用与主库相同的驱动，并应用相同的连接池参数
+func openConnection(conf *viper.Viper, name, driver, dsn string, replicaDSNs []string, config *gorm.Config) (*database.Connection, error) {
+	dialector, err := newDialector(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	// GORM doc: https://gorm.io/docs/connecting_to_the_database.html
+	db, err := gorm.Open(dialector, config)
+	if err != nil {
+		return nil, err
+	}
 
 	// 获取通用数据库对象sql.DB来设置连接池
 	sqlDB, err := db.DB()
 	if err != nil {
-		l.Fatal("failed to get sql.DB", zap.Error(err))
-		return nil
+		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
+	configurePool(sqlDB, conf)
+
+	// 读写分离：查询分发到副本，写操作与事务使用主库
+	// GORM dbresolver doc: https://gorm.io/docs/dbresolver.html
+	var replicas []gorm.Dialector
+	for _, replicaDSN := range replicaDSNs {
+		replica, err := newDialector(driver, replicaDSN)
+		if err != nil {
+			return nil, err
+		}
+		replicas = append(replicas, replica)
+	}
+	pools, err := database.UseReplicas(db, replicas...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register replicas: %w", err)
+	}
+	for _, pool := range pools {
+		configurePool(pool, conf)
+	}
+
+	return &database.Connection{Name: name, Driver: driver, DB: db, Replicas: pools}, nil
+}
+
+// newDialector 按驱动名创建 GORM 方言
+func newDialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case "mysql":
+		return mysql.Open(dsn), nil
+	case "postgres":
+		return postgres.New(postgres.Config{
+			DSN:                  dsn,
+			PreferSimpleProtocol: true, // disables implicit prepared statement usage
+		}), nil
+	case "sqlite":
+		return sqlite.Open(dsn), nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q", driver)
+}
 
-	// 设置连接池参数
+// configurePool 设置连接池参数
+func configurePool(sqlDB *sql.DB, conf *viper.Viper) {
 	sqlDB.SetMaxOpenConns(conf.GetInt("data.db.max_open_conns"))        // 最大打开连接数
 	sqlDB.SetMaxIdleConns(conf.GetInt("data.db.max_idle_conns"))        // 最大空闲连接数
 	sqlDB.SetConnMaxLifetime(conf.GetDuration("data.db.max_lifetime"))  // 连接最大存活时间
@@ -139,7 +201,6 @@ func NewDB(conf *viper.Viper, l *logger.Logger) *gorm.DB {
 	sqlDB.SetMaxIdleConns(conf.GetInt("data.db.pool.max_idle_conns"))
 	sqlDB.SetMaxOpenConns(conf.GetInt("data.db.pool.max_open_conns"))
 	sqlDB.SetConnMaxLifetime(conf.GetDuration("data.db.pool.max_lifetime"))
-	return db
 }
 func NewCasbinEnforcer(conf *viper.Viper, l *logger.Logger, db *gorm.DB) *casbin.SyncedEnforcer {
 	a, _ := gormadapter.NewAdapterByDB(db)
@@ -2,8 +2,14 @@ package repository
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
+
+	"fun-admin/pkg/admin"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ResourceRepository 资源数据访问层
@@ -11,6 +17,12 @@ type ResourceRepository struct {
 	Repository
 }
 
+// TranslatedSearch 可翻译字段的搜索条件，RecordIDs 为在翻译表中命中关键字的记录
+type TranslatedSearch struct {
+	Keyword   string
+	RecordIDs []string
+}
+
 // NewResourceRepository 创建资源数据访问层
 func NewResourceRepository(repo Repository) *ResourceRepository {
 	return &ResourceRepository{
@@ -18,42 +30,44 @@ func NewResourceRepository(repo Repository) *ResourceRepository {
 	}
 }
 
-// Create 创建资源记录
-func (r *ResourceRepository) Create(ctx context.Context, resourceSlug string, data map[string]interface{}) error {
-	db := r.DB(ctx)
+// ResourceDB 获取资源数据表所在的连接
+// 资源实现 admin.ConnectionResource 时使用其命名连接，处于事务中时该连接随默认连接的事务一起提交或回滚；中间表始终使用默认连接
+func (r *ResourceRepository) ResourceDB(ctx context.Context, resourceSlug string) *gorm.DB {
+	if resource, ok := admin.GlobalResourceManager.GetResourceBySlug(resourceSlug).(admin.ConnectionResource); ok {
+		return r.Connection(ctx, resource.GetConnection())
+	}
+	return r.DB(ctx)
+}
+
+// Create 创建资源记录并返回生成的主键
+// 支持 RETURNING 的数据库（PostgreSQL、SQLite）通过 RETURNING id 取回主键，其余（MySQL）使用 LastInsertId
+func (r *ResourceRepository) Create(ctx context.Context, resourceSlug string, data map[string]interface{}) (interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
-	processedData := r.processData(data)
-	columns := ""
-	values := ""
-	vals := make([]interface{}, 0)
-	i := 0
-	for key, value := range processedData {
+	values := make(map[string]interface{})
+	for key, value := range r.processData(data) {
 		if value == nil {
 			continue
 		}
-		if i > 0 {
-			columns += ", "
-			values += ", "
-		}
-		columns += key
-		values += "?"
-		vals = append(vals, value)
-		i++
+		values[key] = value
 	}
-	if columns != "" {
-		columns += ", "
-		values += ", "
+	now := time.Now()
+	values["created_at"] = now
+	values["updated_at"] = now
+	err := db.Table(tableName).Clauses(clause.Returning{Columns: []clause.Column{{Name: "id"}}}).Create(values).Error
+	if err != nil {
+		return nil, err
 	}
-	columns += "created_at, updated_at"
-	values += "?, ?"
-	vals = append(vals, time.Now(), time.Now())
-	query := "INSERT INTO " + tableName + " (" + columns + ") VALUES (" + values + ")"
-	return db.Exec(query, vals...).Error
+	if id, ok := values["id"]; ok && id != nil {
+		return id, nil
+	}
+	// 未设置模型时 GORM 将 LastInsertId 写入 @id
+	return values["@id"], nil
 }
 
 // Update 更新资源记录
 func (r *ResourceRepository) Update(ctx context.Context, resourceSlug string, id interface{}, data map[string]interface{}) error {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	processedData := r.processData(data)
 	setClause := ""
@@ -82,7 +96,7 @@ func (r *ResourceRepository) Update(ctx context.Context, resourceSlug string, id
 
 // Delete 删除资源记录（软删除）
 func (r *ResourceRepository) Delete(ctx context.Context, resourceSlug string, id interface{}) error {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	query := "UPDATE " + tableName + " SET deleted_at = ? WHERE id = ?"
 	return db.Exec(query, time.Now(), id).Error
@@ -90,7 +104,7 @@ func (r *ResourceRepository) Delete(ctx context.Context, resourceSlug string, id
 
 // Restore 恢复软删除
 func (r *ResourceRepository) Restore(ctx context.Context, resourceSlug string, id interface{}) error {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	query := "UPDATE " + tableName + " SET deleted_at = NULL WHERE id = ?"
 	return db.Exec(query, id).Error
@@ -98,7 +112,7 @@ func (r *ResourceRepository) Restore(ctx context.Context, resourceSlug string, i
 
 // ForceDelete 强制删除（硬删）
 func (r *ResourceRepository) ForceDelete(ctx context.Context, resourceSlug string, id interface{}) error {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	query := "DELETE FROM " + tableName + " WHERE id = ?"
 	return db.Exec(query, id).Error
@@ -106,7 +120,7 @@ func (r *ResourceRepository) ForceDelete(ctx context.Context, resourceSlug strin
 
 // DeleteBatch 批量删除资源记录
 func (r *ResourceRepository) DeleteBatch(ctx context.Context, resourceSlug string, ids []interface{}) (int64, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	placeholders := make([]string, len(ids))
 	vals := make([]interface{}, len(ids))
@@ -125,7 +139,7 @@ func (r *ResourceRepository) DeleteBatch(ctx context.Context, resourceSlug strin
 
 // FindByID 根据 ID 查找资源记录
 func (r *ResourceRepository) FindByID(ctx context.Context, resourceSlug string, id interface{}) (map[string]interface{}, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	var result map[string]interface{}
 	query := "SELECT * FROM " + tableName + " WHERE id = ?"
@@ -136,9 +150,34 @@ func (r *ResourceRepository) FindByID(ctx context.Context, resourceSlug string,
 	return result, nil
 }
 
+// FindByField 根据字段值查找首条未删除的记录
+func (r *ResourceRepository) FindByField(ctx context.Context, resourceSlug string, field string, value interface{}) (map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	var result map[string]interface{}
+	query := "SELECT * FROM " + tableName + " WHERE " + field + " = ? AND deleted_at IS NULL LIMIT 1"
+	err := db.Raw(query, value).Scan(&result).Error
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindAllByField 根据字段值查找全部未删除的记录，按 ID 升序
+func (r *ResourceRepository) FindAllByField(ctx context.Context, resourceSlug string, field string, value interface{}) ([]map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	var results []map[string]interface{}
+	query := "SELECT * FROM " + tableName + " WHERE " + field + " = ? AND deleted_at IS NULL ORDER BY id ASC"
+	if err := db.Raw(query, value).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
 // List 获取资源记录列表
 func (r *ResourceRepository) List(ctx context.Context, resourceSlug string, page, pageSize int) ([]map[string]interface{}, int64, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	var total int64
 	countQuery := "SELECT COUNT(*) FROM " + tableName
@@ -166,8 +205,115 @@ func (r *ResourceRepository) ListWithFilters(
 	orderBy string, // 排序字段
 	orderDirection string, // 排序方向 ASC/DESC
 ) ([]map[string]interface{}, int64, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
+	wherePart, vals := filterClause(filters, search)
+
+	var total int64
+	countQuery := "SELECT COUNT(*) FROM " + tableName + wherePart
+	err := db.Raw(countQuery, vals...).Scan(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	offset := (page - 1) * pageSize
+	orderClause := "id DESC"
+	if orderBy != "" && (orderDirection == "ASC" || orderDirection == "DESC") {
+		orderClause = orderBy + " " + orderDirection
+	}
+	var results []map[string]interface{}
+	query := "SELECT * FROM " + tableName + wherePart + " ORDER BY " + orderClause + " LIMIT ? OFFSET ?"
+	pageVals := append(vals, pageSize, offset)
+	err = db.Raw(query, pageVals...).Scan(&results).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return results, total, nil
+}
+
+// EachWithFilters 按批读取满足条件的全部记录，供导出等需要遍历整个结果集的场景使用
+// 不统计总数，使用键集分页（按排序列与 id 定位下一批）代替 OFFSET，大结果集每批的开销保持不变；
+// 自定义排序列的 NULL 值排在最后
+func (r *ResourceRepository) EachWithFilters(
+	ctx context.Context,
+	resourceSlug string,
+	batchSize int,
+	filters map[string]interface{},
+	search map[string]interface{},
+	orderBy string,
+	orderDirection string,
+	fn func(records []map[string]interface{}) error,
+) error {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	wherePart, vals := filterClause(filters, search)
+	if wherePart == "" {
+		wherePart = " WHERE 1 = 1"
+	}
+	// 与 ListWithFilters 一致：未指定有效排序时按 id 倒序
+	if orderBy == "" || orderDirection != "ASC" && orderDirection != "DESC" {
+		orderBy, orderDirection = "", "DESC"
+	}
+	if orderBy == "id" {
+		orderBy = ""
+	}
+	compare := ">"
+	if orderDirection == "DESC" {
+		compare = "<"
+	}
+	orderClause := "id " + orderDirection
+	if orderBy != "" {
+		orderClause = "CASE WHEN " + orderBy + " IS NULL THEN 1 ELSE 0 END, " + orderBy + " " + orderDirection + ", " + orderClause
+	}
+
+	// 上一批最后一条记录的排序值与 id，在回调处理记录之前取出
+	var lastID, lastValue interface{}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		query := "SELECT * FROM " + tableName + wherePart
+		args := append([]interface{}{}, vals...)
+		if lastID != nil {
+			switch {
+			case orderBy == "":
+				query += " AND id " + compare + " ?"
+				args = append(args, lastID)
+			case lastValue == nil:
+				query += " AND " + orderBy + " IS NULL AND id " + compare + " ?"
+				args = append(args, lastID)
+			default:
+				query += " AND (" + orderBy + " " + compare + " ? OR (" + orderBy + " = ? AND id " + compare + " ?) OR " + orderBy + " IS NULL)"
+				args = append(args, lastValue, lastValue, lastID)
+			}
+		}
+		query += " ORDER BY " + orderClause + " LIMIT ?"
+		args = append(args, batchSize)
+
+		var records []map[string]interface{}
+		if err := db.Raw(query, args...).Scan(&records).Error; err != nil {
+			return err
+		}
+		if len(records) == 0 {
+			return nil
+		}
+		lastID = records[len(records)-1]["id"]
+		if orderBy != "" {
+			lastValue = records[len(records)-1][orderBy]
+		}
+		if err := fn(records); err != nil {
+			return err
+		}
+		if len(records) < batchSize {
+			return nil
+		}
+	}
+}
+
+// filterClause 按精确过滤、时间范围、模糊搜索与软删除视图（filters["trashed"]）生成 WHERE 子句
+func filterClause(filters map[string]interface{}, search map[string]interface{}) (string, []interface{}) {
 	whereClause := ""
 	vals := make([]interface{}, 0)
 
@@ -177,7 +323,6 @@ func (r *ResourceRepository) ListWithFilters(
 		if s, ok2 := v.(string); ok2 {
 			trashedMode = s
 		}
-		delete(filters, "trashed")
 	}
 	// 时间范围过滤（白名单）
 	var createdFrom interface{}
@@ -191,12 +336,22 @@ func (r *ResourceRepository) ListWithFilters(
 
 	// 处理过滤条件（精确匹配）
 	for field, value := range filters {
-		if field == "created_at_from" || field == "created_at_to" {
+		if field == "trashed" || field == "created_at_from" || field == "created_at_to" {
 			continue
 		}
 		if whereClause != "" {
 			whereClause += " AND "
 		}
+		// 切片值按 IN 匹配，如关系管理器按中间表限定的子记录 ID
+		if ids, ok := value.([]interface{}); ok {
+			if len(ids) == 0 {
+				whereClause += "1 = 0"
+				continue
+			}
+			whereClause += field + " IN ?"
+			vals = append(vals, ids)
+			continue
+		}
 		whereClause += field + " = ?"
 		vals = append(vals, value)
 	}
@@ -221,6 +376,17 @@ func (r *ResourceRepository) ListWithFilters(
 		if whereClause != "" {
 			whereClause += " AND "
 		}
+		// 可翻译字段：匹配列本身或在翻译中命中的记录
+		if translated, ok := value.(TranslatedSearch); ok {
+			if len(translated.RecordIDs) == 0 {
+				whereClause += field + " LIKE ?"
+				vals = append(vals, "%"+translated.Keyword+"%")
+				continue
+			}
+			whereClause += "(" + field + " LIKE ? OR id IN ?)"
+			vals = append(vals, "%"+translated.Keyword+"%", translated.RecordIDs)
+			continue
+		}
 		whereClause += field + " LIKE ?"
 		vals = append(vals, "%"+value.(string)+"%")
 	}
@@ -245,25 +411,7 @@ func (r *ResourceRepository) ListWithFilters(
 		wherePart = " WHERE " + whereClause
 	}
 
-	var total int64
-	countQuery := "SELECT COUNT(*) FROM " + tableName + wherePart
-	err := db.Raw(countQuery, vals...).Scan(&total).Error
-	if err != nil {
-		return nil, 0, err
-	}
-	offset := (page - 1) * pageSize
-	orderClause := "id DESC"
-	if orderBy != "" && (orderDirection == "ASC" || orderDirection == "DESC") {
-		orderClause = orderBy + " " + orderDirection
-	}
-	var results []map[string]interface{}
-	query := "SELECT * FROM " + tableName + wherePart + " ORDER BY " + orderClause + " LIMIT ? OFFSET ?"
-	pageVals := append(vals, pageSize, offset)
-	err = db.Raw(query, pageVals...).Scan(&results).Error
-	if err != nil {
-		return nil, 0, err
-	}
-	return results, total, nil
+	return wherePart, vals
 }
 
 // ListWithRelationships 获取资源记录列表，包含关联数据
@@ -273,7 +421,7 @@ func (r *ResourceRepository) ListWithRelationships(
 	page, pageSize int,
 	relationships map[string]string, // 关联字段名 -> 关联资源名
 ) ([]map[string]interface{}, int64, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	var total int64
 	countQuery := "SELECT COUNT(*) FROM " + tableName
@@ -371,7 +519,7 @@ func (r *ResourceRepository) QuickSearch(
 	keyword string,
 	limit int,
 ) ([]map[string]interface{}, error) {
-	db := r.DB(ctx)
+	db := r.ResourceDB(ctx, resourceSlug)
 	tableName := resourceSlug
 	if limit <= 0 {
 		limit = 5
@@ -398,6 +546,133 @@ func (r *ResourceRepository) QuickSearch(
 	return results, nil
 }
 
+// OrderRows 获取排序分组内未删除记录的 ID 与排序值，按排序列升序、ID 升序
+// 使用 SELECT ... FOR UPDATE 锁定分组内的记录，应在事务内调用；SQLite 不支持行锁，其写事务本身串行执行
+func (r *ResourceRepository) OrderRows(
+	ctx context.Context,
+	resourceSlug string,
+	orderColumn string,
+	scope map[string]interface{},
+) ([]map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	query := "SELECT id, " + orderColumn + " FROM " + tableName + " WHERE deleted_at IS NULL"
+	vals := make([]interface{}, 0, len(scope))
+	for field, value := range scope {
+		if value == nil {
+			query += " AND " + field + " IS NULL"
+			continue
+		}
+		query += " AND " + field + " = ?"
+		vals = append(vals, value)
+	}
+	query += " ORDER BY " + orderColumn + " ASC, id ASC" + forUpdate(db)
+	var results []map[string]interface{}
+	if err := db.Raw(query, vals...).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// ClearField 将记录的指定列置为 NULL，如一对多关系解除关联时清空外键
+func (r *ResourceRepository) ClearField(ctx context.Context, resourceSlug string, field string, ids []interface{}) error {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	query := "UPDATE " + tableName + " SET " + field + " = NULL, updated_at = ? WHERE id IN ?"
+	return db.Exec(query, time.Now(), ids).Error
+}
+
+// PivotRelatedIDs 获取中间表中父记录关联的子记录 ID
+func (r *ResourceRepository) PivotRelatedIDs(
+	ctx context.Context,
+	pivotTable, foreignKey, relatedKey string,
+	parentID interface{},
+	softDelete bool,
+) ([]interface{}, error) {
+	db := r.DB(ctx)
+	query := "SELECT " + relatedKey + " FROM " + pivotTable + " WHERE " + foreignKey + " = ?"
+	if softDelete {
+		query += " AND deleted_at IS NULL"
+	}
+	var ids []interface{}
+	rows, err := db.Raw(query, parentID).Rows()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id interface{}
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// AttachPivot 在中间表中写入父记录与子记录的关联，已存在的关联跳过
+func (r *ResourceRepository) AttachPivot(
+	ctx context.Context,
+	pivotTable, foreignKey, relatedKey string,
+	parentID interface{},
+	relatedIDs []interface{},
+	softDelete bool,
+) error {
+	existing, err := r.PivotRelatedIDs(ctx, pivotTable, foreignKey, relatedKey, parentID, softDelete)
+	if err != nil {
+		return err
+	}
+	linked := make(map[string]struct{}, len(existing))
+	for _, id := range existing {
+		linked[pivotKey(id)] = struct{}{}
+	}
+	db := r.DB(ctx)
+	for _, id := range relatedIDs {
+		if _, ok := linked[pivotKey(id)]; ok {
+			continue
+		}
+		query := "INSERT INTO " + pivotTable + " (" + foreignKey + ", " + relatedKey + ") VALUES (?, ?)"
+		vals := []interface{}{parentID, id}
+		if softDelete {
+			query = "INSERT INTO " + pivotTable + " (" + foreignKey + ", " + relatedKey + ", created_at, updated_at) VALUES (?, ?, ?, ?)"
+			vals = append(vals, time.Now(), time.Now())
+		}
+		if err := db.Exec(query, vals...).Error; err != nil {
+			return err
+		}
+		linked[pivotKey(id)] = struct{}{}
+	}
+	return nil
+}
+
+// DetachPivot 删除中间表中父记录与子记录的关联
+func (r *ResourceRepository) DetachPivot(
+	ctx context.Context,
+	pivotTable, foreignKey, relatedKey string,
+	parentID interface{},
+	relatedIDs []interface{},
+	softDelete bool,
+) (int64, error) {
+	db := r.DB(ctx)
+	var result *gorm.DB
+	if softDelete {
+		query := "UPDATE " + pivotTable + " SET deleted_at = ? WHERE " + foreignKey + " = ? AND " + relatedKey + " IN ? AND deleted_at IS NULL"
+		result = db.Exec(query, time.Now(), parentID, relatedIDs)
+	} else {
+		query := "DELETE FROM " + pivotTable + " WHERE " + foreignKey + " = ? AND " + relatedKey + " IN ?"
+		result = db.Exec(query, parentID, relatedIDs)
+	}
+	return result.RowsAffected, result.Error
+}
+
+// pivotKey 统一不同驱动返回的 ID 类型，用于比较
+func pivotKey(id interface{}) string {
+	if b, ok := id.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(id)
+}
+
 // processData 处理数据，转换特殊字段类型
 func (r *ResourceRepository) processData(data map[string]interface{}) map[string]interface{} {
 	processed := make(map[string]interface{})
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TreeNodes 获取树形资源的未删除节点，pathPrefix 非空时仅返回该路径下的节点（含自身）
+func (r *ResourceRepository) TreeNodes(
+	ctx context.Context,
+	resourceSlug string,
+	pathKey string,
+	pathPrefix string,
+	sortKey string,
+) ([]map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	query := "SELECT * FROM " + tableName + " WHERE deleted_at IS NULL"
+	vals := make([]interface{}, 0)
+	if pathPrefix != "" {
+		query += " AND " + pathKey + " LIKE ?"
+		vals = append(vals, pathPrefix+"%")
+	}
+	query += " ORDER BY " + sortKey + " ASC, id ASC"
+	var results []map[string]interface{}
+	if err := db.Raw(query, vals...).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// TreeChildren 获取节点的直接子节点；parent 为 nil 时返回根节点
+// rootValue 非 nil 时，父节点列等于该值的记录同样视为根节点
+func (r *ResourceRepository) TreeChildren(
+	ctx context.Context,
+	resourceSlug string,
+	parentKey string,
+	parent interface{},
+	rootValue interface{},
+	sortKey string,
+) ([]map[string]interface{}, error) {
+	return r.treeChildren(ctx, resourceSlug, parentKey, parent, rootValue, sortKey, false)
+}
+
+// LockTreeChildren 同 TreeChildren，并使用 SELECT ... FOR UPDATE 锁定这些节点，应在事务内调用
+func (r *ResourceRepository) LockTreeChildren(
+	ctx context.Context,
+	resourceSlug string,
+	parentKey string,
+	parent interface{},
+	rootValue interface{},
+	sortKey string,
+) ([]map[string]interface{}, error) {
+	return r.treeChildren(ctx, resourceSlug, parentKey, parent, rootValue, sortKey, true)
+}
+
+func (r *ResourceRepository) treeChildren(
+	ctx context.Context,
+	resourceSlug string,
+	parentKey string,
+	parent interface{},
+	rootValue interface{},
+	sortKey string,
+	lock bool,
+) ([]map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	where, vals := treeParentCondition(parentKey, parent, rootValue)
+	query := "SELECT * FROM " + tableName + " WHERE deleted_at IS NULL AND " + where +
+		" ORDER BY " + sortKey + " ASC, id ASC"
+	if lock {
+		query += forUpdate(db)
+	}
+	var results []map[string]interface{}
+	if err := db.Raw(query, vals...).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// LockTreeNodes 获取并锁定指定的未删除节点（SELECT ... FOR UPDATE），应在事务内调用
+// 按 ID 顺序加锁，避免并发移动相互死锁
+func (r *ResourceRepository) LockTreeNodes(ctx context.Context, resourceSlug string, ids []interface{}) ([]map[string]interface{}, error) {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	query := "SELECT * FROM " + tableName + " WHERE deleted_at IS NULL AND id IN ? ORDER BY id ASC" + forUpdate(db)
+	var results []map[string]interface{}
+	if err := db.Raw(query, ids).Scan(&results).Error; err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// CountTreeChildren 统计各节点未删除的直接子节点数，键为父节点值的字符串形式
+func (r *ResourceRepository) CountTreeChildren(
+	ctx context.Context,
+	resourceSlug string,
+	parentKey string,
+	parents []interface{},
+) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(parents) == 0 {
+		return counts, nil
+	}
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	query := "SELECT " + parentKey + " AS parent, COUNT(*) AS total FROM " + tableName +
+		" WHERE deleted_at IS NULL AND " + parentKey + " IN ? GROUP BY " + parentKey
+	var rows []map[string]interface{}
+	if err := db.Raw(query, parents).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		var total int64
+		fmt.Sscan(pivotKey(row["total"]), &total)
+		counts[pivotKey(row["parent"])] = total
+	}
+	return counts, nil
+}
+
+// ReplaceTreePath 将路径以 oldPrefix 开头的节点替换为 newPrefix，用于移动子树
+// 以单条 UPDATE 完成，MySQL 使用 CONCAT 拼接，其余数据库使用 ||
+func (r *ResourceRepository) ReplaceTreePath(
+	ctx context.Context,
+	resourceSlug string,
+	pathKey string,
+	oldPrefix string,
+	newPrefix string,
+) error {
+	db := r.ResourceDB(ctx, resourceSlug)
+	tableName := resourceSlug
+	path := "? || SUBSTR(" + pathKey + ", ?)"
+	if db.Dialector.Name() == "mysql" {
+		path = "CONCAT(?, SUBSTR(" + pathKey + ", ?))"
+	}
+	query := "UPDATE " + tableName + " SET " + pathKey + " = " + path + ", updated_at = ? WHERE " + pathKey + " LIKE ?"
+	return db.Exec(query, newPrefix, len(oldPrefix)+1, time.Now(), oldPrefix+"%").Error
+}
+
+// forUpdate 返回行锁子句；SQLite 不支持行锁，其写事务本身串行执行
+func forUpdate(db *gorm.DB) string {
+	if db.Dialector.Name() == "sqlite" {
+		return ""
+	}
+	return " FOR UPDATE"
+}
+
+// treeParentCondition 构造父节点匹配条件
+func treeParentCondition(parentKey string, parent interface{}, rootValue interface{}) (string, []interface{}) {
+	if parent != nil {
+		return parentKey + " = ?", []interface{}{parent}
+	}
+	if rootValue != nil {
+		return "(" + parentKey + " IS NULL OR " + parentKey + " = ?)", []interface{}{rootValue}
+	}
+	return parentKey + " IS NULL", nil
+}
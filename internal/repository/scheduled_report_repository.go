@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/logger"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ScheduledReportRepository 定时报表仓库接口
+type ScheduledReportRepository interface {
+	GetReports(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ScheduledReport, int64, error)
+	GetReport(ctx context.Context, id uint) (*model.ScheduledReport, error)
+	CreateReport(ctx context.Context, report *model.ScheduledReport) error
+	UpdateReport(ctx context.Context, report *model.ScheduledReport) error
+	DeleteReport(ctx context.Context, id uint) error
+	GetDueReports(ctx context.Context, now time.Time, limit int) ([]*model.ScheduledReport, error)
+	ClaimReport(ctx context.Context, id uint, scheduledAt time.Time, next *time.Time) (bool, error)
+	GetRuns(ctx context.Context, reportID uint, page, pageSize int) ([]*model.ScheduledReportRun, int64, error)
+	CreateRun(ctx context.Context, run *model.ScheduledReportRun) error
+	UpdateRun(ctx context.Context, run *model.ScheduledReportRun) error
+}
+
+type scheduledReportRepository struct {
+	logger *logger.Logger
+	db     *gorm.DB
+}
+
+// NewScheduledReportRepository 创建仓库实例
+func NewScheduledReportRepository(logger *logger.Logger, db *gorm.DB) ScheduledReportRepository {
+	return &scheduledReportRepository{
+		logger: logger,
+		db:     db,
+	}
+}
+
+// GetReports 获取定时报表列表
+func (r *scheduledReportRepository) GetReports(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ScheduledReport, int64, error) {
+	var list []*model.ScheduledReport
+	query := r.db.WithContext(ctx).Model(&model.ScheduledReport{}).Order("id DESC")
+
+	if ownerID, ok := filters["owner_id"]; ok {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	if resource, ok := filters["resource"]; ok && resource != "" {
+		query = query.Where("resource_slug = ?", resource)
+	}
+	if status, ok := filters["last_status"]; ok && status != "" {
+		query = query.Where("last_status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// GetReport 获取单个定时报表
+func (r *scheduledReportRepository) GetReport(ctx context.Context, id uint) (*model.ScheduledReport, error) {
+	var report model.ScheduledReport
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&report).Error
+	return &report, err
+}
+
+// CreateReport 创建定时报表
+func (r *scheduledReportRepository) CreateReport(ctx context.Context, report *model.ScheduledReport) error {
+	return r.db.WithContext(ctx).Create(report).Error
+}
+
+// UpdateReport 保存定时报表
+func (r *scheduledReportRepository) UpdateReport(ctx context.Context, report *model.ScheduledReport) error {
+	return r.db.WithContext(ctx).Save(report).Error
+}
+
+// DeleteReport 删除定时报表及其运行记录
+func (r *scheduledReportRepository) DeleteReport(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("report_id = ?", id).Delete(&model.ScheduledReportRun{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.ScheduledReport{}, id).Error
+	})
+}
+
+// GetDueReports 获取已到运行时间的启用报表
+func (r *scheduledReportRepository) GetDueReports(ctx context.Context, now time.Time, limit int) ([]*model.ScheduledReport, error) {
+	var list []*model.ScheduledReport
+	err := r.db.WithContext(ctx).
+		Where("enabled = ? AND next_run_at IS NOT NULL AND next_run_at <= ?", true, now).
+		Order("next_run_at ASC").Limit(limit).Find(&list).Error
+	return list, err
+}
+
+// ClaimReport 将报表的下次运行时间从 scheduledAt 推进到 next，返回是否领取成功
+// 通过带原运行时间条件的更新保证多实例下同一次运行只执行一次
+func (r *scheduledReportRepository) ClaimReport(ctx context.Context, id uint, scheduledAt time.Time, next *time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&model.ScheduledReport{}).
+		Where("id = ? AND next_run_at = ?", id, scheduledAt).
+		Update("next_run_at", next)
+	return result.RowsAffected == 1, result.Error
+}
+
+// GetRuns 获取报表运行记录
+func (r *scheduledReportRepository) GetRuns(ctx context.Context, reportID uint, page, pageSize int) ([]*model.ScheduledReportRun, int64, error) {
+	var list []*model.ScheduledReportRun
+	query := r.db.WithContext(ctx).Model(&model.ScheduledReportRun{}).Where("report_id = ?", reportID).Order("id DESC")
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	if err := query.Offset((page - 1) * pageSize).Limit(pageSize).Find(&list).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return list, total, nil
+}
+
+// CreateRun 创建运行记录
+func (r *scheduledReportRepository) CreateRun(ctx context.Context, run *model.ScheduledReportRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+// UpdateRun 保存运行记录
+func (r *scheduledReportRepository) UpdateRun(ctx context.Context, run *model.ScheduledReportRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"fun-admin/internal/model"
+
+	"gorm.io/gorm/clause"
+)
+
+// TranslationRepository 记录字段多语言值仓库，经 DB(ctx) 访问，可参与资源写入的事务
+type TranslationRepository interface {
+	GetTranslations(ctx context.Context, resourceSlug string, recordIDs []string, fields []string) ([]*model.Translation, error)
+	SaveTranslations(ctx context.Context, resourceSlug, recordID, fieldName string, values map[string]string) error
+	DeleteRecordTranslations(ctx context.Context, resourceSlug string, recordIDs []string) error
+	SearchRecordIDs(ctx context.Context, resourceSlug, fieldName, keyword string) ([]string, error)
+}
+
+type translationRepository struct {
+	*Repository
+}
+
+// NewTranslationRepository 创建仓库实例
+func NewTranslationRepository(repo *Repository) TranslationRepository {
+	return &translationRepository{repo}
+}
+
+// GetTranslations 获取记录的翻译，fields 为空时返回全部字段
+func (r *translationRepository) GetTranslations(ctx context.Context, resourceSlug string, recordIDs []string, fields []string) ([]*model.Translation, error) {
+	var list []*model.Translation
+	if len(recordIDs) == 0 {
+		return list, nil
+	}
+	query := r.DB(ctx).Where("resource_slug = ? AND record_id IN ?", resourceSlug, recordIDs)
+	if len(fields) > 0 {
+		query = query.Where("field_name IN ?", fields)
+	}
+	err := query.Order("record_id ASC, field_name ASC, locale ASC").Find(&list).Error
+	return list, err
+}
+
+// SaveTranslations 按语言写入字段的翻译，已存在则覆盖；值为空的语言删除其翻译
+func (r *translationRepository) SaveTranslations(ctx context.Context, resourceSlug, recordID, fieldName string, values map[string]string) error {
+	db := r.DB(ctx)
+	for locale, value := range values {
+		if value == "" {
+			err := db.Where("resource_slug = ? AND record_id = ? AND field_name = ? AND locale = ?",
+				resourceSlug, recordID, fieldName, locale).Delete(&model.Translation{}).Error
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		translation := &model.Translation{
+			ResourceSlug: resourceSlug,
+			RecordID:     recordID,
+			FieldName:    fieldName,
+			Locale:       locale,
+			Value:        value,
+		}
+		err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "resource_slug"}, {Name: "record_id"}, {Name: "field_name"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_at"}),
+		}).Create(translation).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteRecordTranslations 删除记录的全部翻译
+func (r *translationRepository) DeleteRecordTranslations(ctx context.Context, resourceSlug string, recordIDs []string) error {
+	if len(recordIDs) == 0 {
+		return nil
+	}
+	return r.DB(ctx).
+		Where("resource_slug = ? AND record_id IN ?", resourceSlug, recordIDs).
+		Delete(&model.Translation{}).Error
+}
+
+// SearchRecordIDs 在任一语言的翻译中模糊匹配关键字，返回命中的记录ID
+func (r *translationRepository) SearchRecordIDs(ctx context.Context, resourceSlug, fieldName, keyword string) ([]string, error) {
+	var ids []string
+	err := r.DB(ctx).Model(&model.Translation{}).
+		Where("resource_slug = ? AND field_name = ? AND value LIKE ?", resourceSlug, fieldName, "%"+keyword+"%").
+		Distinct("record_id").Pluck("record_id", &ids).Error
+	return ids, err
+}
@@ -19,7 +19,7 @@ func NewCrudTableResource() *CrudTableResource {
 
 // GetTitle returns the display title for the resource.
 func (r *CrudTableResource) GetTitle() string {
-	return "增删改查表格"
+	return "crud_items"
 }
 
 // GetSlug returns the identifier used for routing and tables.
@@ -111,3 +111,8 @@ func (r *CrudTableResource) GetDefaultOrder() (string, string) {
 func (r *CrudTableResource) IsHiddenInNavigation(ctx context.Context) bool {
 	return false
 }
+
+// IsCommentable enables internal comments on demo records.
+func (r *CrudTableResource) IsCommentable() bool {
+	return true
+}
@@ -19,7 +19,7 @@ func NewDictionaryDataResource() *DictionaryDataResource {
 
 // GetTitle returns the display title.
 func (r *DictionaryDataResource) GetTitle() string {
-	return "字典数据"
+	return "dictionary_data"
 }
 
 // GetSlug returns the resource slug.
@@ -40,7 +40,7 @@ func (r *DictionaryDataResource) GetFields() []admin.Field {
 			Label("字典类型").
 			Required().
 			SetDisplayField("name"),
-		admin.NewTextField("label").Label("标签").Required(),
+		admin.NewTextField("label").Label("标签").Required().Translatable(),
 		admin.NewTextField("value").Label("值").Required(),
 		admin.NewSelectField("status").Label("状态").Required().SetOptions(dictStatusOptions),
 		admin.NewBooleanField("is_default").Label("默认值"),
@@ -96,6 +96,16 @@ func (r *DictionaryDataResource) GetDefaultOrder() (string, string) {
 	return "sort", "ASC"
 }
 
+// GetOrderColumn enables drag-and-drop ordering by sort.
+func (r *DictionaryDataResource) GetOrderColumn() string {
+	return "sort"
+}
+
+// GetReorderScope keeps ordering within a dictionary type.
+func (r *DictionaryDataResource) GetReorderScope() []string {
+	return []string{"type_id"}
+}
+
 // IsHiddenInNavigation controls navigation visibility.
 func (r *DictionaryDataResource) IsHiddenInNavigation(ctx context.Context) bool {
 	return false
@@ -7,9 +7,14 @@ import (
 	"fun-admin/pkg/admin"
 )
 
+var (
+	dictStatusEnabled  = admin.Localize("dictionary.status.enabled", map[string]string{"zh-CN": "启用", "en": "Enabled"})
+	dictStatusDisabled = admin.Localize("dictionary.status.disabled", map[string]string{"zh-CN": "禁用", "en": "Disabled"})
+)
+
 var dictStatusOptions = []admin.Option{
-	{Value: "1", Label: "启用"},
-	{Value: "0", Label: "禁用"},
+	{Value: "1", Label: dictStatusEnabled},
+	{Value: "0", Label: dictStatusDisabled},
 }
 
 var dictStatusBadgeMap = map[string]string{
@@ -18,8 +23,8 @@ var dictStatusBadgeMap = map[string]string{
 }
 
 var dictStatusEnumMap = map[string]string{
-	"1": "启用",
-	"0": "禁用",
+	"1": dictStatusEnabled,
+	"0": dictStatusDisabled,
 }
 
 // DictionaryTypeResource defines the schema for dictionary type management.
@@ -34,7 +39,7 @@ func NewDictionaryTypeResource() *DictionaryTypeResource {
 
 // GetTitle returns the display title.
 func (r *DictionaryTypeResource) GetTitle() string {
-	return "字典类型"
+	return "dictionary_type"
 }
 
 // GetSlug returns the resource slug.
@@ -50,23 +55,34 @@ func (r *DictionaryTypeResource) GetModel() interface{} {
 // GetFields describes the form and table fields.
 func (r *DictionaryTypeResource) GetFields() []admin.Field {
 	return []admin.Field{
-		admin.NewIDField().Label("ID"),
-		admin.NewTextField("name").Label("名称").Required(),
-		admin.NewTextField("code").Label("编码").Required(),
-		admin.NewSelectField("status").Label("状态").Required().SetOptions(dictStatusOptions),
-		admin.NewNumberField("sort").Label("排序"),
-		admin.NewTextareaField("remark").Label("备注").SetRows(3),
-		admin.NewDateTimeField("created_at").Label("创建时间"),
-		admin.NewDateTimeField("updated_at").Label("更新时间"),
+		admin.NewIDField().Label("dictionary_type.id"),
+		admin.NewTextField("name").Label("dictionary_type.name").Required(),
+		admin.NewTextField("code").Label("dictionary_type.code").Required(),
+		admin.NewSelectField("status").Label("dictionary_type.status").Required().SetOptions(dictStatusOptions),
+		admin.NewNumberField("sort").Label("dictionary_type.sort"),
+		admin.NewTextareaField("remark").Label("dictionary_type.remark").SetRows(3),
+		admin.NewDateTimeField("created_at").Label("dictionary_type.created_at"),
+		admin.NewDateTimeField("updated_at").Label("dictionary_type.updated_at"),
 	}
 }
 
 // GetActions exposes resource actions.
 func (r *DictionaryTypeResource) GetActions() []admin.Action {
 	return []admin.Action{
-		admin.NewViewAction().Label("查看"),
-		admin.NewEditAction().Label("编辑"),
-		admin.NewDeleteAction().Label("删除"),
+		admin.NewViewAction().Label("view"),
+		admin.NewEditAction().Label("edit"),
+		admin.NewDeleteAction().Label("delete"),
+		admin.NewReplicateAction().Label("replicate").
+			SetFormFields(admin.NewTextField("code").Label("dictionary_type.code").Required()),
+	}
+}
+
+// GetReplicateOptions copies a type together with its dictionary items; the code must be re-entered.
+func (r *DictionaryTypeResource) GetReplicateOptions() admin.ReplicateOptions {
+	return admin.ReplicateOptions{
+		Exclude:   []string{"code"},
+		Transform: map[string]func(interface{}) interface{}{"name": admin.AppendSuffix(" (副本)")},
+		Relations: []string{"data"},
 	}
 }
 
@@ -78,24 +94,24 @@ func (r *DictionaryTypeResource) GetReadOnlyFields() []string {
 // GetColumns defines table columns.
 func (r *DictionaryTypeResource) GetColumns() []*admin.Column {
 	return []*admin.Column{
-		admin.NewColumn("id", "ID", "number").SetSortable(true),
-		admin.NewColumn("name", "名称", "text").SetSortable(true),
-		admin.NewColumn("code", "编码", "text").SetSortable(true),
-		admin.NewColumn("status", "状态", "badge").
+		admin.NewColumn("id", "dictionary_type.id", "number").SetSortable(true),
+		admin.NewColumn("name", "dictionary_type.name", "text").SetSortable(true),
+		admin.NewColumn("code", "dictionary_type.code", "text").SetSortable(true),
+		admin.NewColumn("status", "dictionary_type.status", "badge").
 			SetSortable(true).
 			SetBadgeMap(dictStatusBadgeMap).
 			SetEnumMap(dictStatusEnumMap),
-		admin.NewColumn("sort", "排序", "number"),
-		admin.NewColumn("created_at", "创建时间", "datetime").SetSortable(true),
+		admin.NewColumn("sort", "dictionary_type.sort", "number"),
+		admin.NewColumn("created_at", "dictionary_type.created_at", "datetime").SetSortable(true),
 	}
 }
 
 // GetFilters defines filterable fields.
 func (r *DictionaryTypeResource) GetFilters() []*admin.Filter {
 	return []*admin.Filter{
-		{Name: "name", Label: "名称", Type: "text"},
-		{Name: "code", Label: "编码", Type: "text"},
-		{Name: "status", Label: "状态", Type: "select", Options: dictStatusOptions},
+		{Name: "name", Label: "dictionary_type.name", Type: "text"},
+		{Name: "code", Label: "dictionary_type.code", Type: "text"},
+		{Name: "status", Label: "dictionary_type.status", Type: "select", Options: dictStatusOptions},
 	}
 }
 
@@ -119,6 +135,18 @@ func (r *DictionaryTypeResource) GetDefaultOrder() (string, string) {
 	return "sort", "ASC"
 }
 
+// GetOrderColumn enables drag-and-drop ordering by sort.
+func (r *DictionaryTypeResource) GetOrderColumn() string {
+	return "sort"
+}
+
+// GetRelationManagers exposes the dictionary items of a type as a nested relation.
+func (r *DictionaryTypeResource) GetRelationManagers() []*admin.RelationManager {
+	return []*admin.RelationManager{
+		admin.NewHasManyRelation("data", "admin_dictionary_data", "type_id").SetLabel("dictionary_type.data"),
+	}
+}
+
 // IsHiddenInNavigation controls navigation visibility.
 func (r *DictionaryTypeResource) IsHiddenInNavigation(ctx context.Context) bool {
 	return false
@@ -17,7 +17,7 @@ func NewOperationLogResource() *OperationLogResource {
 
 // GetTitle 返回资源标题
 func (r *OperationLogResource) GetTitle() string {
-	return "操作日志"
+	return "operation_log"
 }
 
 // GetSlug 返回资源标识符
@@ -0,0 +1,255 @@
+// Package resourcetest 资源一致性检查，供 make:resource-test 生成的测试调用
+package resourcetest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/logger"
+
+	"github.com/glebarez/sqlite"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// builtInActions 由框架处理、无需 ActionExecutor 的动作
+var builtInActions = map[string]bool{
+	"view": true, "edit": true, "delete": true, "create": true,
+	admin.ReplicateActionName: true, "restore": true, "force_delete": true,
+}
+
+// Run 检查资源声明与模型一致，并在内存 SQLite 上通过资源仓库完成一次增删改查
+func Run(t *testing.T, resource admin.Resource) {
+	t.Helper()
+
+	model := resource.GetModel()
+	if model == nil {
+		t.Fatalf("%T.GetModel() returns nil", resource)
+	}
+	modelSchema, err := schema.Parse(model, &sync.Map{}, schema.NamingStrategy{})
+	if err != nil {
+		t.Fatalf("parse model %T: %v", model, err)
+	}
+
+	t.Run("definition", func(t *testing.T) {
+		checkDefinition(t, resource, modelSchema)
+	})
+	t.Run("crud", func(t *testing.T) {
+		checkCRUD(t, resource, modelSchema)
+	})
+}
+
+// checkDefinition 资源声明的字段、列、过滤、搜索与排序字段都必须是模型的列
+func checkDefinition(t *testing.T, resource admin.Resource, modelSchema *schema.Schema) {
+	if resource.GetSlug() == "" {
+		t.Error("slug is empty")
+	}
+	if resource.GetTitle() == "" {
+		t.Error("title is empty")
+	}
+	// 资源仓库以 slug 作为表名
+	if resource.GetSlug() != modelSchema.Table {
+		t.Errorf("slug %q differs from model table %q", resource.GetSlug(), modelSchema.Table)
+	}
+
+	hasColumn := func(name string) bool {
+		_, ok := modelSchema.FieldsByDBName[name]
+		return ok
+	}
+	expectColumns := func(kind string, names []string) {
+		for _, name := range names {
+			if !hasColumn(name) {
+				t.Errorf("%s %q is not a column of %s", kind, name, modelSchema.Table)
+			}
+		}
+	}
+
+	var fieldNames []string
+	for _, field := range resource.GetFields() {
+		if field.GetType() != "file" {
+			fieldNames = append(fieldNames, field.GetName())
+		}
+	}
+	expectColumns("field", fieldNames)
+	expectColumns("read-only field", resource.GetReadOnlyFields())
+
+	if searchable, ok := resource.(admin.Searchable); ok {
+		expectColumns("searchable field", searchable.GetSearchableFields())
+	}
+	if sortable, ok := resource.(admin.Sortable); ok {
+		expectColumns("sortable field", sortable.GetSortableFields())
+	}
+	if filterable, ok := resource.(admin.Filterable); ok {
+		expectColumns("filterable field", filterable.GetFilterableFields())
+	}
+	for _, column := range resource.GetColumns() {
+		if !strings.Contains(column.Name, ".") && !hasColumn(column.Name) {
+			t.Errorf("list column %q is not a column of %s", column.Name, modelSchema.Table)
+		}
+	}
+	for _, filter := range resource.GetFilters() {
+		if !hasColumn(filter.Name) {
+			t.Errorf("filter %q is not a column of %s", filter.Name, modelSchema.Table)
+		}
+	}
+
+	_, executor := resource.(admin.ActionExecutor)
+	seen := make(map[string]bool)
+	for _, action := range resource.GetActions() {
+		name := action.GetName()
+		if seen[name] {
+			t.Errorf("action %q declared twice", name)
+		}
+		seen[name] = true
+		if !builtInActions[name] && !executor {
+			t.Errorf("custom action %q needs the resource to implement admin.ActionExecutor", name)
+		}
+	}
+
+	if errs := admin.ValidateResourceData(resource, sampleData(resource, 1)); len(errs) > 0 {
+		t.Errorf("sample data fails validation: %v", errs)
+	}
+}
+
+// checkCRUD 通过资源仓库创建、读取、更新与删除一条样例记录
+func checkCRUD(t *testing.T, resource admin.Resource, modelSchema *schema.Schema) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{DisableForeignKeyConstraintWhenMigrating: true})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("sql.DB: %v", err)
+	}
+	// 内存库按连接隔离，固定单连接
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if err := db.AutoMigrate(resource.GetModel()); err != nil {
+		t.Fatalf("migrate %s: %v", modelSchema.Table, err)
+	}
+
+	repo := repository.NewRepository(&logger.Logger{Logger: zap.NewNop()}, db, nil)
+	records := repository.NewResourceRepository(*repo)
+	ctx := context.Background()
+	slug := resource.GetSlug()
+
+	created := sampleData(resource, 1)
+	id, err := records.Create(ctx, slug, created)
+	if err != nil || id == nil {
+		t.Fatalf("create: id=%v, %v", id, err)
+	}
+	expectRecord(t, records, slug, id, created)
+
+	updated := sampleData(resource, 2)
+	if err := records.Update(ctx, slug, id, updated); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	expectRecord(t, records, slug, id, updated)
+
+	if _, ok := modelSchema.FieldsByDBName["deleted_at"]; ok {
+		if err := records.Delete(ctx, slug, id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+		if row, err := records.FindByID(ctx, slug, id); err != nil || row["deleted_at"] == nil {
+			t.Errorf("delete did not set deleted_at: %v", err)
+		}
+	}
+	if err := records.ForceDelete(ctx, slug, id); err != nil {
+		t.Fatalf("force delete: %v", err)
+	}
+	if row, err := records.FindByID(ctx, slug, id); err != nil || len(row) > 0 {
+		t.Errorf("record still exists after force delete: %v", err)
+	}
+}
+
+// expectRecord 读取记录并比对文本类样例值
+func expectRecord(t *testing.T, records *repository.ResourceRepository, slug string, id interface{}, want map[string]interface{}) {
+	t.Helper()
+	row, err := records.FindByID(context.Background(), slug, id)
+	if err != nil {
+		t.Fatalf("find %v: %v", id, err)
+	}
+	if len(row) == 0 {
+		t.Fatalf("record %v not found", id)
+	}
+	for name, value := range want {
+		text, ok := value.(string)
+		if !ok {
+			continue
+		}
+		if got := fmt.Sprint(row[name]); got != text {
+			t.Errorf("%s = %q, want %q", name, got, text)
+		}
+	}
+}
+
+// sampleData 按字段类型、选项与长度限制生成可写字段的样例值，round 不同时值不同
+func sampleData(resource admin.Resource, round int) map[string]interface{} {
+	readOnly := make(map[string]bool)
+	for _, name := range resource.GetReadOnlyFields() {
+		readOnly[name] = true
+	}
+	data := make(map[string]interface{})
+	for _, field := range resource.GetFields() {
+		name := field.GetName()
+		if readOnly[name] || name == "id" {
+			continue
+		}
+		if value, ok := sampleValue(field, round); ok {
+			data[name] = value
+		}
+	}
+	return data
+}
+
+func sampleValue(field admin.Field, round int) (interface{}, bool) {
+	switch field.GetType() {
+	case "text", "textarea":
+		return truncate(fmt.Sprintf("%s %d", field.GetName(), round), maxLength(field)), true
+	case "email":
+		return fmt.Sprintf("user%d@example.com", round), true
+	case "number", "relationship":
+		return round, true
+	case "boolean":
+		return round%2 == 1, true
+	case "select":
+		if selectField, ok := field.(*admin.SelectField); ok && len(selectField.Options) > 0 {
+			return selectField.Options[(round-1)%len(selectField.Options)].Value, true
+		}
+		return fmt.Sprint(round), true
+	case "date":
+		return time.Date(2024, 1, round, 0, 0, 0, 0, time.UTC).Format("2006-01-02"), true
+	case "datetime":
+		return time.Date(2024, 1, round, 8, 30, 0, 0, time.UTC), true
+	}
+	return nil, false
+}
+
+// maxLength 字段声明的最大长度，未声明返回 0
+func maxLength(field admin.Field) int {
+	provider, ok := field.(interface{ GetValidators() []admin.Validator })
+	if !ok {
+		return 0
+	}
+	for _, validator := range provider.GetValidators() {
+		if limit, ok := validator.(interface{ GetMaxLength() int }); ok {
+			return limit.GetMaxLength()
+		}
+	}
+	return 0
+}
+
+func truncate(value string, limit int) string {
+	if limit > 0 && len(value) > limit {
+		return value[:limit]
+	}
+	return value
+}
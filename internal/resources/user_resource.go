@@ -18,7 +18,7 @@ func NewUserResource() *UserResource {
 
 // GetTitle 返回资源标题
 func (r *UserResource) GetTitle() string {
-	return "用户管理"
+	return "users"
 }
 
 // GetSlug 返回资源标识符
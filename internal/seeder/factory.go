@@ -0,0 +1,519 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
+	"fun-admin/internal/service"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/cache"
+	"fun-admin/pkg/logger"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// referenceTime 指定随机种子时日期以此为基准，保证相同种子生成相同的数据
+var referenceTime = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+type randSeedKey struct{}
+
+// WithRandSeed 在 ctx 中携带随机种子，填充器中的工厂据此生成可重复的数据
+func WithRandSeed(ctx context.Context, seed int64) context.Context {
+	return context.WithValue(ctx, randSeedKey{}, seed)
+}
+
+// RandSeed 返回 ctx 中的随机种子，未设置时返回 0
+func RandSeed(ctx context.Context) int64 {
+	seed, _ := ctx.Value(randSeedKey{}).(int64)
+	return seed
+}
+
+// Factory 根据资源的 GetFields() 生成假数据
+// 字段类型与字段名决定取值（如 price 为金额、phone 为手机号），选择字段取选项或字典中的值，
+// 遵守 required、email、min_length、max_length 验证规则，关联字段取关联资源已有记录的 ID；
+// 记录经资源服务写入，与后台创建一样使用资源的命名连接，并维护树路径与翻译
+type Factory struct {
+	resource  admin.Resource
+	records   *repository.ResourceRepository
+	service   *service.ResourceService
+	rand      *rand.Rand
+	now       time.Time
+	sequence  int
+	overrides map[string]interface{}
+	relations map[string][]interface{} // 关联资源 slug -> 已有记录 ID
+	unique    map[string]bool          // 模型声明了唯一索引的列
+	dicts     map[string][]string      // 字典编码 -> 字典值
+}
+
+// NewFactory 创建资源工厂；seed 不为 0 时生成的数据可重复，为 0 时每次不同
+func NewFactory(resource admin.Resource, seed int64) *Factory {
+	now := time.Now()
+	if seed == 0 {
+		seed = now.UnixNano()
+	} else {
+		now = referenceTime
+	}
+	return &Factory{
+		resource:  resource,
+		rand:      rand.New(rand.NewSource(seed)),
+		now:       now,
+		overrides: make(map[string]interface{}),
+		relations: make(map[string][]interface{}),
+		dicts:     make(map[string][]string),
+		unique:    make(map[string]bool),
+	}
+}
+
+// Set 固定字段取值；value 为 func(sequence int) interface{} 时按记录序号（从 1 开始）计算
+func (f *Factory) Set(field string, value interface{}) *Factory {
+	f.overrides[field] = value
+	return f
+}
+
+// Make 生成 count 条记录但不写入数据库
+// db 用于读取关联记录与字典选项，为 nil 时关联字段与字典选择字段留空
+func (f *Factory) Make(ctx context.Context, db *gorm.DB, count int) ([]map[string]interface{}, error) {
+	records := make([]map[string]interface{}, 0, count)
+	for i := 0; i < count; i++ {
+		record, err := f.record(ctx, db)
+		if err != nil {
+			return records, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// Create 生成 count 条记录并经资源服务写入（资源须已注册），返回写入的记录（含 id）
+// 序号从表中已有记录数之后开始，唯一性字段（用户名、编码等）多次执行也不会重复；
+// 全部记录在同一事务中写入，任一条失败时整体回滚
+func (f *Factory) Create(ctx context.Context, db *gorm.DB, count int) ([]map[string]interface{}, error) {
+	slug := f.resource.GetSlug()
+	records := f.repository(db)
+	if f.sequence == 0 {
+		var existing int64
+		if err := records.ResourceDB(ctx, slug).Table(slug).Count(&existing).Error; err != nil {
+			return nil, err
+		}
+		f.sequence = int(existing)
+		if err := f.parseUnique(db); err != nil {
+			return nil, err
+		}
+	}
+
+	generated, err := f.Make(ctx, db, count)
+	if err != nil {
+		return nil, err
+	}
+	created := make([]map[string]interface{}, 0, len(generated))
+	err = records.Transaction(ctx, func(ctx context.Context) error {
+		for _, record := range generated {
+			data, err := f.service.Create(ctx, slug, record)
+			if err != nil {
+				return err
+			}
+			created = append(created, data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("insert %s: %w", slug, err)
+	}
+	return created, nil
+}
+
+// repository 返回基于 db 的资源仓库，首次调用时一并创建写入记录的资源服务
+func (f *Factory) repository(db *gorm.DB) *repository.ResourceRepository {
+	if f.records == nil {
+		log := &logger.Logger{Logger: zap.NewNop()}
+		repo := repository.NewRepository(log, db, nil)
+		f.records = repository.NewResourceRepository(*repo)
+		f.service = service.NewResourceService(f.records, repository.NewAttachmentRepository(log, db),
+			repository.NewTranslationRepository(repo), admin.GlobalResourceManager, cache.NewMemoryCacheManager())
+	}
+	return f.records
+}
+
+// record 生成一条记录并按资源验证规则检查
+func (f *Factory) record(ctx context.Context, db *gorm.DB) (map[string]interface{}, error) {
+	f.sequence++
+	readOnly := make(map[string]bool)
+	for _, name := range f.resource.GetReadOnlyFields() {
+		readOnly[name] = true
+	}
+
+	record := make(map[string]interface{})
+	for _, field := range f.resource.GetFields() {
+		name := field.GetName()
+		// 文件字段以附件形式关联到记录，不是数据表的列
+		if readOnly[name] || name == "id" || field.GetType() == "file" {
+			continue
+		}
+		if override, ok := f.overrides[name]; ok {
+			if compute, ok := override.(func(int) interface{}); ok {
+				override = compute(f.sequence)
+			}
+			record[name] = override
+			continue
+		}
+		value, err := f.value(ctx, db, field)
+		if err != nil {
+			return nil, err
+		}
+		if value != nil {
+			record[name] = value
+		}
+	}
+
+	errs := admin.ValidateResourceData(f.resource, record)
+	for _, field := range f.resource.GetFields() {
+		if field.GetType() == "file" {
+			delete(errs, field.GetName())
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("generated %s record fails validation: %v", f.resource.GetSlug(), errs)
+	}
+	return record, nil
+}
+
+// value 按字段类型生成取值
+func (f *Factory) value(ctx context.Context, db *gorm.DB, field admin.Field) (interface{}, error) {
+	tokens := strings.Split(strings.ToLower(field.GetName()), "_")
+	switch field.GetType() {
+	case "email":
+		return f.email(), nil
+	case "number":
+		return f.number(tokens), nil
+	case "boolean":
+		return f.rand.Intn(2) == 1, nil
+	case "date":
+		return f.now.AddDate(0, 0, -f.rand.Intn(365)).Format("2006-01-02"), nil
+	case "datetime":
+		return f.now.Add(-time.Duration(f.rand.Int63n(int64(365 * 24 * time.Hour)))).Truncate(time.Second), nil
+	case "select":
+		return f.option(ctx, db, field)
+	case "relationship":
+		return f.relation(ctx, db, field)
+	case "textarea":
+		return f.fit(field, f.paragraph()), nil
+	default:
+		if hasValidator(field, func(v admin.Validator) bool { _, ok := v.(*admin.EmailValidator); return ok }) {
+			return f.email(), nil
+		}
+		text := f.text(tokens)
+		if suffix := strconv.Itoa(f.sequence); f.unique[field.GetName()] && !strings.HasSuffix(text, suffix) {
+			// 唯一列追加序号，超出最大长度时截断前面的文本而保留序号
+			runes := []rune(f.fit(field, text))
+			if _, maxLength := lengthLimits(field); maxLength > 0 && len(runes)+1+len(suffix) > maxLength {
+				runes = runes[:max(maxLength-1-len(suffix), 0)]
+			}
+			return strings.TrimSpace(string(runes) + " " + suffix), nil
+		}
+		return f.fit(field, text), nil
+	}
+}
+
+// parseUnique 读取资源模型中的唯一列，模型表名与 slug 不一致时不做处理
+func (f *Factory) parseUnique(db *gorm.DB) error {
+	model := f.resource.GetModel()
+	if model == nil {
+		return nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return fmt.Errorf("parse model %T: %w", model, err)
+	}
+	if stmt.Schema.Table != f.resource.GetSlug() {
+		return nil
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.Unique {
+			f.unique[field.DBName] = true
+		}
+	}
+	for _, index := range stmt.Schema.ParseIndexes() {
+		if index.Class == "UNIQUE" && len(index.Fields) == 1 {
+			f.unique[index.Fields[0].DBName] = true
+		}
+	}
+	return nil
+}
+
+// option 随机选择选项值，选项来自字典时从字典数据中选择
+func (f *Factory) option(ctx context.Context, db *gorm.DB, field admin.Field) (interface{}, error) {
+	selectField, ok := field.(*admin.SelectField)
+	if !ok {
+		return nil, nil
+	}
+	values := make([]string, 0, len(selectField.Options))
+	for _, option := range selectField.Options {
+		values = append(values, option.Value)
+	}
+	if selectField.DictCode != "" && db != nil {
+		dict, ok := f.dicts[selectField.DictCode]
+		if !ok {
+			err := db.WithContext(ctx).Model(&model.DictionaryData{}).
+				Joins("JOIN admin_dictionary_type ON admin_dictionary_type.id = admin_dictionary_data.type_id").
+				Where("admin_dictionary_type.code = ? AND admin_dictionary_data.status = 1", selectField.DictCode).
+				Order("admin_dictionary_data.sort, admin_dictionary_data.id").
+				Pluck("admin_dictionary_data.value", &dict).Error
+			if err != nil {
+				return nil, fmt.Errorf("load dictionary %s: %w", selectField.DictCode, err)
+			}
+			f.dicts[selectField.DictCode] = dict
+		}
+		values = append(values, dict...)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return values[f.rand.Intn(len(values))], nil
+}
+
+// relation 随机选择关联资源已有记录的 ID；必填且关联资源没有记录时返回错误
+func (f *Factory) relation(ctx context.Context, db *gorm.DB, field admin.Field) (interface{}, error) {
+	relationField, ok := field.(*admin.RelationshipField)
+	if !ok || db == nil {
+		return nil, nil
+	}
+	related := relationField.RelatedResource
+	ids, ok := f.relations[related]
+	if !ok {
+		// 资源仓库以 slug 作为表名，关联资源可能位于命名连接
+		if err := f.repository(db).ResourceDB(ctx, related).Table(related).Order("id").Limit(1000).Pluck("id", &ids).Error; err != nil {
+			return nil, fmt.Errorf("load %s ids: %w", related, err)
+		}
+		f.relations[related] = ids
+	}
+	if len(ids) == 0 {
+		if field.IsRequired() {
+			return nil, fmt.Errorf("%s.%s requires %s records; seed %s first", f.resource.GetSlug(), field.GetName(), related, related)
+		}
+		return nil, nil
+	}
+	return ids[f.rand.Intn(len(ids))], nil
+}
+
+// number 按字段名推断数值范围
+func (f *Factory) number(tokens []string) int {
+	between := func(min, max int) int {
+		return min + f.rand.Intn(max-min+1)
+	}
+	switch {
+	case hasToken(tokens, "price", "amount", "total", "cost", "fee", "salary", "balance"):
+		return between(10, 9999)
+	case hasToken(tokens, "age"):
+		return between(18, 70)
+	case hasToken(tokens, "quantity", "qty", "count", "stock", "num", "inventory"):
+		return between(0, 500)
+	case hasToken(tokens, "sort", "order", "weight", "priority", "position", "rank", "level", "percent", "rate", "score"):
+		return between(0, 100)
+	case hasToken(tokens, "status", "state"):
+		return between(0, 1)
+	case hasToken(tokens, "year"):
+		return between(2000, referenceTime.Year())
+	}
+	return between(1, 1000)
+}
+
+// text 按字段名推断文本内容
+func (f *Factory) text(tokens []string) string {
+	seq := f.sequence
+	switch {
+	case hasToken(tokens, "email", "mail"):
+		return f.email()
+	case hasToken(tokens, "phone", "mobile", "tel"):
+		return fmt.Sprintf("1%d%09d", 3+f.rand.Intn(7), f.rand.Intn(1000000000))
+	case hasToken(tokens, "url", "website", "link", "homepage"):
+		return fmt.Sprintf("https://example.com/%s-%d", f.pick(loremWords), seq)
+	case hasToken(tokens, "avatar", "image", "logo", "photo", "cover"):
+		return fmt.Sprintf("https://example.com/images/%d.png", seq)
+	case hasToken(tokens, "ip"):
+		return fmt.Sprintf("192.168.%d.%d", f.rand.Intn(256), 1+f.rand.Intn(254))
+	case hasToken(tokens, "username", "login", "account"):
+		return fmt.Sprintf("%s%d", strings.ToLower(f.pick(firstNames)), seq)
+	case hasToken(tokens, "first", "firstname"):
+		return f.pick(firstNames)
+	case hasToken(tokens, "last", "lastname", "surname"):
+		return f.pick(lastNames)
+	case hasToken(tokens, "nickname", "realname", "fullname", "contact", "author", "owner"):
+		return f.pick(firstNames) + " " + f.pick(lastNames)
+	case hasToken(tokens, "company", "organization", "org", "vendor", "supplier", "brand"):
+		return f.pick(companies) + " " + f.pick([]string{"Inc", "Ltd", "Group", "Co"})
+	case hasToken(tokens, "city"):
+		return f.pick(cities)
+	case hasToken(tokens, "country"):
+		return f.pick(countries)
+	case hasToken(tokens, "address", "street"):
+		return fmt.Sprintf("%d %s, %s", 1+f.rand.Intn(999), f.pick(streets), f.pick(cities))
+	case hasToken(tokens, "color", "colour"):
+		return f.pick(colors)
+	case hasToken(tokens, "code", "sku", "sn", "no", "serial", "number"):
+		return fmt.Sprintf("%s-%06d", codePrefix(f.resource.GetSlug()), seq)
+	case hasToken(tokens, "slug", "key"):
+		return fmt.Sprintf("%s-%s-%d", f.pick(loremWords), f.pick(loremWords), seq)
+	case hasToken(tokens, "path", "route"):
+		return "/" + f.pick(loremWords) + "/" + f.pick(loremWords)
+	case hasToken(tokens, "password", "secret", "token"):
+		return f.alphanumeric(16)
+	case hasToken(tokens, "version"):
+		return fmt.Sprintf("%d.%d.%d", 1+f.rand.Intn(3), f.rand.Intn(10), f.rand.Intn(20))
+	case hasToken(tokens, "title", "subject", "headline"):
+		return f.sentence(3, 6, true)
+	case hasToken(tokens, "description", "remark", "note", "comment", "summary", "content", "body", "memo"):
+		return f.sentence(6, 12, false)
+	case hasToken(tokens, "name"):
+		if personLike(f.resource.GetSlug()) {
+			return f.pick(firstNames) + " " + f.pick(lastNames)
+		}
+		return f.pick(adjectives) + " " + f.pick(nouns)
+	}
+	return f.sentence(2, 3, false)
+}
+
+func (f *Factory) email() string {
+	return fmt.Sprintf("%s.%s%d@example.com", strings.ToLower(f.pick(firstNames)), strings.ToLower(f.pick(lastNames)), f.sequence)
+}
+
+func (f *Factory) paragraph() string {
+	sentences := make([]string, 2+f.rand.Intn(3))
+	for i := range sentences {
+		sentences[i] = f.sentence(6, 12, false)
+	}
+	return strings.Join(sentences, " ")
+}
+
+// sentence 生成 min 到 max 个词的句子，title 为 true 时每个词首字母大写且不带句号
+func (f *Factory) sentence(min, max int, title bool) string {
+	words := make([]string, min+f.rand.Intn(max-min+1))
+	for i := range words {
+		words[i] = f.pick(loremWords)
+		if title || i == 0 {
+			words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+		}
+	}
+	if title {
+		return strings.Join(words, " ")
+	}
+	return strings.Join(words, " ") + "."
+}
+
+func (f *Factory) alphanumeric(length int) string {
+	const charset = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = charset[f.rand.Intn(len(charset))]
+	}
+	return string(b)
+}
+
+// fit 使文本满足 min_length、max_length 规则
+func (f *Factory) fit(field admin.Field, value string) string {
+	minLength, maxLength := lengthLimits(field)
+	for minLength > 0 && utf8.RuneCountInString(value) < minLength {
+		value += " " + f.pick(loremWords)
+	}
+	if maxLength > 0 && utf8.RuneCountInString(value) > maxLength {
+		value = strings.TrimSpace(string([]rune(value)[:maxLength]))
+		for utf8.RuneCountInString(value) < minLength {
+			value += "x"
+		}
+	}
+	return value
+}
+
+func (f *Factory) pick(values []string) string {
+	return values[f.rand.Intn(len(values))]
+}
+
+// lengthLimits 字段声明的最小、最大长度，未声明为 0
+func lengthLimits(field admin.Field) (minLength, maxLength int) {
+	provider, ok := field.(interface{ GetValidators() []admin.Validator })
+	if !ok {
+		return 0, 0
+	}
+	for _, validator := range provider.GetValidators() {
+		if limit, ok := validator.(interface{ GetMinLength() int }); ok {
+			minLength = limit.GetMinLength()
+		}
+		if limit, ok := validator.(interface{ GetMaxLength() int }); ok {
+			maxLength = limit.GetMaxLength()
+		}
+	}
+	return minLength, maxLength
+}
+
+func hasValidator(field admin.Field, match func(admin.Validator) bool) bool {
+	provider, ok := field.(interface{ GetValidators() []admin.Validator })
+	if !ok {
+		return false
+	}
+	for _, validator := range provider.GetValidators() {
+		if match(validator) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasToken(tokens []string, candidates ...string) bool {
+	for _, token := range tokens {
+		for _, candidate := range candidates {
+			if token == candidate {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// personLike 资源是否描述人，决定 name 字段生成人名还是物品名
+func personLike(slug string) bool {
+	for _, word := range []string{"user", "customer", "member", "employee", "contact", "author", "staff", "student", "teacher", "people", "person"} {
+		if strings.Contains(strings.ToLower(slug), word) {
+			return true
+		}
+	}
+	return false
+}
+
+// codePrefix 取 slug 的前三个字母作为编码前缀，如 orders -> ORD
+func codePrefix(slug string) string {
+	var letters []rune
+	for _, r := range strings.ToUpper(slug) {
+		if r >= 'A' && r <= 'Z' {
+			letters = append(letters, r)
+		}
+		if len(letters) == 3 {
+			break
+		}
+	}
+	if len(letters) == 0 {
+		return "NO"
+	}
+	return string(letters)
+}
+
+var (
+	firstNames = []string{"James", "Mary", "John", "Linda", "Wei", "Fang", "Olivia", "Lucas", "Emma", "Noah", "Sofia", "Hiroshi", "Yuki", "Carlos", "Ana", "Ahmed", "Fatima", "Ivan", "Elena", "Min"}
+	lastNames  = []string{"Smith", "Johnson", "Wang", "Li", "Zhang", "Garcia", "Brown", "Miller", "Chen", "Tanaka", "Silva", "Kim", "Nguyen", "Rossi", "Novak", "Khan", "Lopez", "Martin", "Liu", "Schmidt"}
+	companies  = []string{"Acme", "Globex", "Initech", "Umbrella", "Stark", "Wayne", "Hooli", "Vandelay", "Soylent", "Cyberdyne"}
+	cities     = []string{"Beijing", "Shanghai", "Shenzhen", "Hangzhou", "Chengdu", "Tokyo", "Singapore", "London", "Berlin", "Paris", "New York", "San Francisco", "Sydney", "Toronto"}
+	countries  = []string{"China", "Japan", "Singapore", "United Kingdom", "Germany", "France", "United States", "Australia", "Canada", "Brazil"}
+	streets    = []string{"Main Street", "Oak Avenue", "Park Road", "Maple Lane", "Cedar Drive", "Lake View", "Hill Street", "River Road"}
+	colors     = []string{"red", "orange", "yellow", "green", "blue", "indigo", "violet", "black", "white", "gray"}
+	adjectives = []string{"Smart", "Classic", "Portable", "Wireless", "Compact", "Premium", "Eco", "Vintage", "Modern", "Deluxe"}
+	nouns      = []string{"Lamp", "Chair", "Speaker", "Backpack", "Keyboard", "Bottle", "Watch", "Notebook", "Camera", "Desk"}
+	loremWords = []string{"lorem", "ipsum", "dolor", "sit", "amet", "consectetur", "adipiscing", "elit", "sed", "do", "eiusmod", "tempor",
+		"incididunt", "labore", "dolore", "magna", "aliqua", "enim", "minim", "veniam", "quis", "nostrud", "exercitation", "ullamco",
+		"laboris", "nisi", "aliquip", "commodo", "consequat"}
+)
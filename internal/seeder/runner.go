@@ -0,0 +1,105 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Run 执行指定的填充器及其依赖，names 为空时执行全部已注册的填充器，返回按执行顺序的名称
+// 依赖先于自身执行，每个填充器只执行一次；填充器应当可重复执行
+func Run(ctx context.Context, db *gorm.DB, names ...string) ([]string, error) {
+	ordered, err := Resolve(Seeders(), names...)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []string
+	for _, seeder := range ordered {
+		if err := seeder.Run(ctx, db.WithContext(ctx)); err != nil {
+			return ran, fmt.Errorf("seeder %s: %w", seeder.Name(), err)
+		}
+		ran = append(ran, seeder.Name())
+	}
+	return ran, nil
+}
+
+// Resolve 按依赖排序填充器，names 为空时包含全部填充器，否则只包含指定的填充器及其依赖
+// 没有依赖关系的填充器保持注册顺序；依赖不存在或存在循环依赖时返回错误
+func Resolve(seeders []Seeder, names ...string) ([]Seeder, error) {
+	byName := make(map[string]Seeder, len(seeders))
+	for _, seeder := range seeders {
+		byName[seeder.Name()] = seeder
+	}
+	if len(names) == 0 {
+		for _, seeder := range seeders {
+			names = append(names, seeder.Name())
+		}
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(seeders))
+	var ordered []Seeder
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("seeder dependency cycle: %s", strings.Join(append(path, name), " -> "))
+		}
+		seeder, ok := byName[name]
+		if !ok {
+			if len(path) > 0 {
+				return fmt.Errorf("seeder %s depends on unknown seeder %s", path[len(path)-1], name)
+			}
+			return fmt.Errorf("unknown seeder %s", name)
+		}
+
+		state[name] = visiting
+		next := append(append([]string(nil), path...), name)
+		for _, dependency := range seeder.Dependencies() {
+			if err := visit(dependency, next); err != nil {
+				return err
+			}
+		}
+		state[name] = done
+		ordered = append(ordered, seeder)
+		return nil
+	}
+
+	for _, name := range names {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// group 只声明依赖、自身不写入数据的填充器
+type group struct {
+	name         string
+	dependencies []string
+}
+
+// Group 创建填充器分组，执行分组即按依赖执行其中的填充器，如 Group("demo", "orders", "customers")
+func Group(name string, seeders ...string) Seeder {
+	return &group{name: name, dependencies: seeders}
+}
+
+func (g *group) Name() string {
+	return g.name
+}
+
+func (g *group) Dependencies() []string {
+	return g.dependencies
+}
+
+func (g *group) Run(ctx context.Context, db *gorm.DB) error {
+	return nil
+}
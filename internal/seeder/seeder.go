@@ -0,0 +1,44 @@
+package seeder
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// Seeder 具名数据填充器，Dependencies 中的填充器先于自身执行
+type Seeder interface {
+	Name() string
+	Dependencies() []string
+	Run(ctx context.Context, db *gorm.DB) error
+}
+
+var (
+	seedersMu sync.RWMutex
+	seeders   []Seeder
+)
+
+// Register 注册填充器，名称重复时 panic
+func Register(seeder Seeder) {
+	seedersMu.Lock()
+	defer seedersMu.Unlock()
+
+	for _, existing := range seeders {
+		if existing.Name() == seeder.Name() {
+			panic(fmt.Sprintf("seeder %s registered twice", seeder.Name()))
+		}
+	}
+	seeders = append(seeders, seeder)
+}
+
+// Seeders 返回已注册的填充器，按注册顺序
+func Seeders() []Seeder {
+	seedersMu.RLock()
+	defer seedersMu.RUnlock()
+
+	result := make([]Seeder, len(seeders))
+	copy(result, seeders)
+	return result
+}
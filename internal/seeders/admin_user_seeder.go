@@ -0,0 +1,110 @@
+package seeders
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"fun-admin/internal/model"
+	"fun-admin/pkg/logger"
+
+	"go.uber.org/zap"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// AdminUserSeeder 创建管理员账号 admin 与演示账号 user，admin 已存在时跳过
+// 未指定密码时生成随机密码并在日志中输出
+type AdminUserSeeder struct {
+	log      *logger.Logger
+	password string
+}
+
+// NewAdminUserSeeder 创建管理员填充器，password 为空时生成随机密码
+func NewAdminUserSeeder(log *logger.Logger, password string) *AdminUserSeeder {
+	return &AdminUserSeeder{log: log, password: password}
+}
+
+func (s *AdminUserSeeder) Name() string {
+	return "admin_users"
+}
+
+func (s *AdminUserSeeder) Dependencies() []string {
+	return nil
+}
+
+func (s *AdminUserSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	var existing model.User
+	if err := db.Where("username = ?", "admin").First(&existing).Error; err == nil {
+		s.log.Info("Admin user already exists, skip initializing credentials")
+		return nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	password, generated, err := s.resolvePassword()
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	// 创建管理员用户
+	if err := db.Create(&model.User{
+		BaseModel: model.BaseModel{
+			ID: 1,
+		},
+		Username: "admin",
+		Password: string(hashedPassword),
+		Nickname: "Admin",
+	}).Error; err != nil {
+		return err
+	}
+
+	if generated {
+		s.log.Warn("Admin user created with generated password, please change it immediately",
+			zap.String("username", "admin"),
+			zap.String("password", password))
+	}
+
+	// 创建普通用户（用于演示）
+	return db.Where(model.User{Username: "user"}).FirstOrCreate(&model.User{
+		BaseModel: model.BaseModel{
+			ID: 2,
+		},
+		Username: "user",
+		Password: string(hashedPassword),
+		Nickname: "运营人员",
+	}).Error
+}
+
+func (s *AdminUserSeeder) resolvePassword() (string, bool, error) {
+	if s.password != "" {
+		if len(s.password) < 12 {
+			return "", false, fmt.Errorf("admin password must be at least 12 characters")
+		}
+		return s.password, false, nil
+	}
+
+	securePassword, err := generateSecurePassword(24)
+	if err != nil {
+		return "", false, err
+	}
+	return securePassword, true, nil
+}
+
+func generateSecurePassword(length int) (string, error) {
+	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#%^*()-_=+"
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = charset[int(b[i])%len(charset)]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,63 @@
+package seeders
+
+import (
+	"context"
+	"net/http"
+
+	"fun-admin/internal/model"
+
+	"gorm.io/gorm"
+)
+
+// ApiSeeder 写入内置 API 列表，按路径与方法判断是否已存在
+type ApiSeeder struct{}
+
+// NewApiSeeder 创建 API 填充器
+func NewApiSeeder() *ApiSeeder {
+	return &ApiSeeder{}
+}
+
+func (s *ApiSeeder) Name() string {
+	return "apis"
+}
+
+func (s *ApiSeeder) Dependencies() []string {
+	return nil
+}
+
+func (s *ApiSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	for _, api := range initialApis {
+		if err := db.Where(model.Api{Path: api.Path, Method: api.Method}).FirstOrCreate(&api).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var initialApis = []model.Api{
+	{Group: "基础API", Name: "获取用户菜单列表", Path: "/v1/menus", Method: http.MethodGet},
+	{Group: "基础API", Name: "获取管理员信息", Path: "/v1/admin/user", Method: http.MethodGet},
+
+	{Group: "菜单管理", Name: "获取管理菜单", Path: "/v1/admin/menus", Method: http.MethodGet},
+	{Group: "菜单管理", Name: "创建菜单", Path: "/v1/admin/menu", Method: http.MethodPost},
+	{Group: "菜单管理", Name: "更新菜单", Path: "/v1/admin/menu", Method: http.MethodPut},
+	{Group: "菜单管理", Name: "删除菜单", Path: "/v1/admin/menu", Method: http.MethodDelete},
+
+	{Group: "权限模块", Name: "获取用户权限", Path: "/v1/admin/user/permissions", Method: http.MethodGet},
+	{Group: "权限模块", Name: "获取角色权限", Path: "/v1/admin/role/permissions", Method: http.MethodGet},
+	{Group: "权限模块", Name: "更新角色权限", Path: "/v1/admin/role/permission", Method: http.MethodPut},
+	{Group: "权限模块", Name: "获取角色列表", Path: "/v1/admin/roles", Method: http.MethodGet},
+	{Group: "权限模块", Name: "创建角色", Path: "/v1/admin/role", Method: http.MethodPost},
+	{Group: "权限模块", Name: "更新角色", Path: "/v1/admin/role", Method: http.MethodPut},
+	{Group: "权限模块", Name: "删除角色", Path: "/v1/admin/role", Method: http.MethodDelete},
+
+	{Group: "权限模块", Name: "获取管理员列表", Path: "/v1/admin/users", Method: http.MethodGet},
+	{Group: "权限模块", Name: "更新管理员信息", Path: "/v1/admin/user", Method: http.MethodPut},
+	{Group: "权限模块", Name: "创建管理员账号", Path: "/v1/admin/user", Method: http.MethodPost},
+	{Group: "权限模块", Name: "删除管理员", Path: "/v1/admin/user", Method: http.MethodDelete},
+
+	{Group: "权限模块", Name: "获取API列表", Path: "/v1/admin/apis", Method: http.MethodGet},
+	{Group: "权限模块", Name: "创建API", Path: "/v1/admin/api", Method: http.MethodPost},
+	{Group: "权限模块", Name: "更新API", Path: "/v1/admin/api", Method: http.MethodPut},
+	{Group: "权限模块", Name: "删除API", Path: "/v1/admin/api", Method: http.MethodDelete},
+}
@@ -0,0 +1,8 @@
+// Package seeders 数据填充器：内置的初始数据填充器与 make:seeder 生成的填充器
+package seeders
+
+// Initial 初始数据分组，安装时执行：管理员账号、菜单、API 与 RBAC 策略
+const Initial = "initial"
+
+// InitialSeeders 初始数据分组包含的填充器
+var InitialSeeders = []string{"admin_users", "menus", "apis", "rbac"}
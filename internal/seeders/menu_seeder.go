@@ -0,0 +1,133 @@
+package seeders
+
+import (
+	"context"
+	"encoding/json"
+
+	"fun-admin/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MenuSeeder 写入内置菜单，菜单 ID 已存在时跳过
+type MenuSeeder struct{}
+
+// NewMenuSeeder 创建菜单填充器
+func NewMenuSeeder() *MenuSeeder {
+	return &MenuSeeder{}
+}
+
+func (s *MenuSeeder) Name() string {
+	return "menus"
+}
+
+func (s *MenuSeeder) Dependencies() []string {
+	return nil
+}
+
+func (s *MenuSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	menuList := make([]model.Menu, 0)
+	if err := json.Unmarshal([]byte(menuData), &menuList); err != nil {
+		return err
+	}
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&menuList).Error
+}
+
+var menuData = `[
+  {
+    "id": 1,
+    "parentId": 0,
+    "title": "仪表盘",
+    "icon": "DashboardOutlined",
+    "component": "RouteView",
+    "redirect": "/dashboard/analysis",
+    "path": "/dashboard",
+    "name": "Dashboard",
+    "locale": "menu.dashboard"
+  },
+  {
+    "id": 2,
+    "parentId": 0,
+    "title": "分析页",
+    "icon": "DashboardOutlined",
+    "component": "/dashboard/analysis",
+    "path": "/dashboard/analysis",
+    "name": "DashboardAnalysis",
+    "keepAlive": true,
+    "locale": "menu.dashboard.analysis",
+    "weight": 2
+  },
+  {
+    "id": 15,
+    "path": "/access",
+    "component": "RouteView",
+    "redirect": "/access/common",
+    "title": "权限模块",
+    "name": "Access",
+    "parentId": 0,
+    "icon": "ClusterOutlined",
+    "locale": "menu.access",
+    "weight": 1
+  },
+  {
+    "id": 18,
+    "parentId": 15,
+    "path": "/access/admin",	
+    "title": "管理员账号",
+    "name": "accessAdmin",
+    "component": "/access/admin",
+    "locale": "menu.access.admin"
+  },
+  {
+    "id": 51,
+    "parentId": 15,
+    "path": "/access/role",	
+    "title": "角色管理",
+    "name": "AccessRoles",
+    "component": "/access/role",
+    "locale": "menu.access.roles"
+  },
+  {
+    "id": 52,
+    "parentId": 15,
+    "path": "/access/menu",	
+    "title": "菜单管理",
+    "name": "AccessMenu",
+    "component": "/access/menu",
+    "locale": "menu.access.menus"
+  },
+  {
+    "id": 53,
+    "parentId": 15,
+    "path": "/access/api",	
+    "title": "API管理",
+    "name": "AccessAPI",
+    "component": "/access/api",
+    "locale": "menu.access.api"
+  }
+  ,
+  {
+    "id": 44,
+    "parentId": 0,
+    "path": "/list",
+    "component": "RouteView",
+    "redirect": "/list/crud-table",
+    "title": "列表页",
+    "name": "List",
+    "locale": "menu.list",
+    "icon": "TableOutlined",
+    "weight": 3
+  },
+  {
+    "id": 45,
+    "parentId": 44,
+    "path": "/list/crud-table",
+    "component": "/list/crud-table",
+    "title": "增删改查表格",
+    "name": "CrudTable",
+    "locale": "menu.list.crud-table",
+    "icon": "TableOutlined",
+    "keepAlive": true
+  }
+]`
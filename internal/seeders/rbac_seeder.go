@@ -0,0 +1,107 @@
+package seeders
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"fun-admin/internal/model"
+	"fun-admin/pkg"
+	"fun-admin/pkg/logger"
+
+	"github.com/casbin/casbin/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// RBACSeeder 创建内置角色，为超级管理员授予全部菜单与 API 权限，为运营人员授予基础权限
+type RBACSeeder struct {
+	log *logger.Logger
+	e   *casbin.SyncedEnforcer
+}
+
+// NewRBACSeeder 创建 RBAC 填充器
+func NewRBACSeeder(log *logger.Logger, e *casbin.SyncedEnforcer) *RBACSeeder {
+	return &RBACSeeder{log: log, e: e}
+}
+
+func (s *RBACSeeder) Name() string {
+	return "rbac"
+}
+
+// Dependencies 权限依赖菜单与 API 数据，运营人员角色分配给演示账号
+func (s *RBACSeeder) Dependencies() []string {
+	return []string{"admin_users", "menus", "apis"}
+}
+
+func (s *RBACSeeder) Run(ctx context.Context, db *gorm.DB) error {
+	roles := []model.Role{
+		{Sid: pkg.AdminRole, Name: "超级管理员"},
+		{Sid: "1000", Name: "运营人员"},
+		{Sid: "1001", Name: "访客"},
+	}
+	for _, role := range roles {
+		if err := db.Where(model.Role{Sid: role.Sid}).FirstOrCreate(&role).Error; err != nil {
+			return err
+		}
+	}
+
+	// 获取所有菜单和API
+	menuList := make([]model.Menu, 0)
+	if err := db.Find(&menuList).Error; err != nil {
+		return err
+	}
+
+	apiList := make([]model.Api, 0)
+	if err := db.Find(&apiList).Error; err != nil {
+		return err
+	}
+
+	// 为管理员角色添加所有权限
+	for _, item := range menuList {
+		s.addPermission(pkg.AdminRole, pkg.MenuResourcePrefix+item.Path, "read")
+	}
+	for _, api := range apiList {
+		s.addPermission(pkg.AdminRole, pkg.ApiResourcePrefix+api.Path, api.Method)
+	}
+
+	// 添加运营人员权限
+	if _, err := s.e.AddRoleForUser("2", "1000"); err != nil {
+		s.log.Error("s.e.AddRoleForUser error", zap.Error(err))
+		return err
+	}
+
+	// 为运营人员添加基础权限
+	basicPermissions := []struct {
+		resource string
+		action   string
+	}{
+		{pkg.MenuResourcePrefix + "/profile/basic", "read"},
+		{pkg.MenuResourcePrefix + "/profile/advanced", "read"},
+		{pkg.MenuResourcePrefix + "/profile", "read"},
+		{pkg.MenuResourcePrefix + "/dashboard", "read"},
+		{pkg.MenuResourcePrefix + "/dashboard/workplace", "read"},
+		{pkg.MenuResourcePrefix + "/dashboard/analysis", "read"},
+		{pkg.MenuResourcePrefix + "/account/settings", "read"},
+		{pkg.MenuResourcePrefix + "/account/center", "read"},
+		{pkg.MenuResourcePrefix + "/account", "read"},
+		{pkg.ApiResourcePrefix + "/v1/menus", http.MethodGet},
+		{pkg.ApiResourcePrefix + "/v1/admin/user", http.MethodGet},
+	}
+	for _, perm := range basicPermissions {
+		s.addPermission("1000", perm.resource, perm.action)
+	}
+
+	// 保存策略到数据库
+	return s.e.SavePolicy()
+}
+
+// addPermission 添加权限，已存在的权限不会重复添加
+func (s *RBACSeeder) addPermission(role, resource, action string) {
+	added, err := s.e.AddPermissionForUser(role, resource, action)
+	if err != nil {
+		s.log.Sugar().Infof("为角色 %s 添加权限 %s:%s 失败: %v", role, resource, action, err)
+	} else if added {
+		fmt.Printf("为角色 %s 添加权限: %s %s\n", role, resource, action)
+	}
+}
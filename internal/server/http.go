@@ -51,10 +51,19 @@ func NewHTTPServer(
 	dictionaryHandler := c.MustGet("dictionary_handler").(*handler.DictionaryHandler)
 	fileHandler := c.MustGet("file_handler").(*handler.FileHandler)
 	importHandler := c.MustGet("import_handler").(*handler.ImportHandler)
+	snapshotHandler := c.MustGet("snapshot_handler").(*handler.SnapshotHandler)
+	databaseHandler := c.MustGet("database_handler").(*handler.DatabaseHandler)
 	permissionHandler := c.MustGet("permission_handler").(*handler.PermissionHandler)
 	roleHandler := c.MustGet("role_handler").(*handler.RoleHandler)
 	resourceHandler := c.MustGet("resource_handler").(*handler.ResourceHandler)
 	resourceCRUDHandler := c.MustGet("resource_crud_handler").(*handler.ResourceCRUDHandler)
+	commentHandler := c.MustGet("comment_handler").(*handler.CommentHandler)
+	attachmentHandler := c.MustGet("attachment_handler").(*handler.AttachmentHandler)
+	relationHandler := c.MustGet("relation_handler").(*handler.RelationHandler)
+	treeHandler := c.MustGet("tree_handler").(*handler.TreeHandler)
+	reorderHandler := c.MustGet("reorder_handler").(*handler.ReorderHandler)
+	scheduledReportHandler := c.MustGet("scheduled_report_handler").(*handler.ScheduledReportHandler)
+	i18nHandler := c.MustGet("i18n_handler").(*handler.I18nHandler)
 	repo := c.MustGet("repository").(*repository.Repository)
 	db := c.MustGet("database").(*gorm.DB)
 	mwManager := middleware.NewManager(logger, db, enforcer, repo, conf)
@@ -78,10 +87,19 @@ func NewHTTPServer(
 		dictionaryHandler,
 		fileHandler,
 		importHandler,
+		snapshotHandler,
+		databaseHandler,
 		permissionHandler,
 		roleHandler,
 		resourceHandler,
 		resourceCRUDHandler,
+		commentHandler,
+		attachmentHandler,
+		relationHandler,
+		treeHandler,
+		reorderHandler,
+		scheduledReportHandler,
+		i18nHandler,
 		loginHandler,
 		logger,
 	)
@@ -95,6 +113,8 @@ func registerMiddlewares(app *gin.Engine, manager *middleware.Manager) {
 	app.Use(manager.SetupOperationLogMiddleware())
 	app.Use(gin.Recovery())
 	app.Use(manager.SetupCORSMiddleware())
+	app.Use(manager.SetupLanguageMiddleware())
+	app.Use(manager.SetupStickyPrimaryMiddleware())
 }
 
 // registerAdminRoutes 注册管理后台路由
@@ -114,10 +134,19 @@ func registerAdminRoutes(
 	dictionaryHandler *handler.DictionaryHandler,
 	fileHandler *handler.FileHandler,
 	importHandler *handler.ImportHandler,
+	snapshotHandler *handler.SnapshotHandler,
+	databaseHandler *handler.DatabaseHandler,
 	permissionHandler *handler.PermissionHandler,
 	roleHandler *handler.RoleHandler,
 	resourceHandler *handler.ResourceHandler,
 	resourceCRUDHandler *handler.ResourceCRUDHandler,
+	commentHandler *handler.CommentHandler,
+	attachmentHandler *handler.AttachmentHandler,
+	relationHandler *handler.RelationHandler,
+	treeHandler *handler.TreeHandler,
+	reorderHandler *handler.ReorderHandler,
+	scheduledReportHandler *handler.ScheduledReportHandler,
+	i18nHandler *handler.I18nHandler,
 	// 公共路由需要的 Handler
 	loginHandler *handler.LoginHandler,
 	logger *logger.Logger,
@@ -142,6 +171,20 @@ func registerAdminRoutes(
 		adminGroup.PUT("/v1/profile/password", profileHandler.UpdatePassword)
 
 		// 注册导出路由
+		adminGroup.GET("/v1/export/jobs", exportHandler.ListJobs)
+		adminGroup.GET("/v1/export/jobs/:id/download", exportHandler.DownloadJob)
+
+		// 翻译检查（开发模式）
+		adminGroup.GET("/v1/i18n/report", i18nHandler.Report)
+
+		// 定时报表相关接口
+		adminGroup.GET("/v1/scheduled-reports", scheduledReportHandler.List)
+		adminGroup.POST("/v1/scheduled-reports", scheduledReportHandler.Create)
+		adminGroup.GET("/v1/scheduled-reports/:id", scheduledReportHandler.Get)
+		adminGroup.PUT("/v1/scheduled-reports/:id", scheduledReportHandler.Update)
+		adminGroup.DELETE("/v1/scheduled-reports/:id", scheduledReportHandler.Delete)
+		adminGroup.POST("/v1/scheduled-reports/:id/run", scheduledReportHandler.Run)
+		adminGroup.GET("/v1/scheduled-reports/:id/runs", scheduledReportHandler.Runs)
 		adminGroup.GET("/v1/export/:resource", exportHandler.ExportData)
 
 		// 用户管理相关接口
@@ -185,6 +228,17 @@ func registerAdminRoutes(
 
 		// 导入管理相关接口
 		adminGroup.POST("/v1/import/:resource", importHandler.ImportData)
+		adminGroup.GET("/v1/import/:resource/template", importHandler.DownloadTemplate)
+		adminGroup.GET("/v1/import/jobs", importHandler.ListJobs)
+		adminGroup.GET("/v1/import/jobs/:id", importHandler.GetJob)
+		adminGroup.GET("/v1/import/jobs/:id/error-report", importHandler.DownloadErrorReport)
+
+		// 数据快照：导出/恢复全部资源与系统数据，默认仅超级管理员可用
+		adminGroup.GET("/v1/snapshot/export", snapshotHandler.Export)
+		adminGroup.POST("/v1/snapshot/import", snapshotHandler.Import)
+
+		// 数据库连接状态：各连接及只读副本的健康检查与连接池统计，默认仅超级管理员可用
+		adminGroup.GET("/v1/system/database", databaseHandler.Status)
 
 		// 权限管理相关接口
 		adminGroup.GET("/v1/permissions", permissionHandler.GetUserPermissions)
@@ -215,6 +269,34 @@ func registerAdminRoutes(
 		adminGroup.PUT("/v1/resource-crud/:resource/:id", resourceCRUDHandler.Update)
 		adminGroup.DELETE("/v1/resource-crud/:resource/:id", resourceCRUDHandler.Delete)
 		adminGroup.POST("/v1/resource-crud/:resource/actions/:action", resourceCRUDHandler.RunAction)
+		adminGroup.POST("/v1/resource-crud/:resource/reorder", reorderHandler.Reorder)
+
+		// 树形资源：嵌套树、懒加载子节点、移动、同级排序与路径重建
+		adminGroup.GET("/v1/resource-crud/:resource/tree", treeHandler.Tree)
+		adminGroup.GET("/v1/resource-crud/:resource/tree/children", treeHandler.Children)
+		adminGroup.POST("/v1/resource-crud/:resource/tree/move", treeHandler.Move)
+		adminGroup.POST("/v1/resource-crud/:resource/tree/reorder", treeHandler.Reorder)
+		adminGroup.POST("/v1/resource-crud/:resource/tree/rebuild", treeHandler.Rebuild)
+
+		// 资源记录评论相关接口
+		adminGroup.GET("/v1/resource-crud/:resource/:id/comments", commentHandler.List)
+		adminGroup.POST("/v1/resource-crud/:resource/:id/comments", commentHandler.Create)
+		adminGroup.PUT("/v1/resource-crud/:resource/:id/comments/:comment_id", commentHandler.Update)
+		adminGroup.DELETE("/v1/resource-crud/:resource/:id/comments/:comment_id", commentHandler.Delete)
+
+		// 资源记录附件相关接口
+		adminGroup.GET("/v1/resource-crud/:resource/:id/attachments", attachmentHandler.List)
+		adminGroup.POST("/v1/resource-crud/:resource/:id/attachments", attachmentHandler.Upload)
+		adminGroup.PUT("/v1/resource-crud/:resource/:id/attachments/sort", attachmentHandler.Reorder)
+		adminGroup.GET("/v1/resource-crud/:resource/:id/attachments/:attachment_id/download", attachmentHandler.Download)
+		adminGroup.DELETE("/v1/resource-crud/:resource/:id/attachments/:attachment_id", attachmentHandler.Delete)
+
+		// 关系管理器：父记录下的子资源记录，关系名由资源的 GetRelationManagers 声明
+		adminGroup.GET("/v1/resource-crud/:resource/:id/:relation", relationHandler.List)
+		adminGroup.POST("/v1/resource-crud/:resource/:id/:relation", relationHandler.Create)
+		adminGroup.POST("/v1/resource-crud/:resource/:id/:relation/attach", relationHandler.Attach)
+		adminGroup.POST("/v1/resource-crud/:resource/:id/:relation/detach", relationHandler.Detach)
+		adminGroup.PUT("/v1/resource-crud/:resource/:id/:relation/:child_id", relationHandler.Update)
 
 		// 添加 ping 接口
 		adminGroup.GET("/ping", func(ctx *gin.Context) {
@@ -4,20 +4,28 @@ import (
 	"context"
 	"fun-admin/internal/job"
 	"fun-admin/pkg/logger"
+
+	"go.uber.org/zap"
 )
 
 type JobServer struct {
-	log     *logger.Logger
-	userJob job.UserJob
+	log       *logger.Logger
+	userJob   job.UserJob
+	exportJob job.ExportJob
+	importJob job.ImportJob
 }
 
 func NewJobServer(
 	log *logger.Logger,
 	userJob job.UserJob,
+	exportJob job.ExportJob,
+	importJob job.ImportJob,
 ) *JobServer {
 	return &JobServer{
-		log:     log,
-		userJob: userJob,
+		log:       log,
+		userJob:   userJob,
+		exportJob: exportJob,
+		importJob: importJob,
 	}
 }
 
@@ -26,7 +34,19 @@ func (j *JobServer) Start(ctx context.Context) error {
 
 	// eg: kafka consumer
 	err := j.userJob.KafkaConsumer(ctx)
-	return err
+	if err != nil {
+		return err
+	}
+
+	// 中断的导入任务
+	go func() {
+		if err := j.importJob.Run(ctx); err != nil {
+			j.log.Error("import job error", zap.Error(err))
+		}
+	}()
+
+	// 异步导出任务
+	return j.exportJob.Run(ctx)
 }
 func (j *JobServer) Stop(ctx context.Context) error {
 	return nil
@@ -2,22 +2,12 @@ package server
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/json"
-	"errors"
-	"fmt"
 	"fun-admin/internal/migrate"
 	"fun-admin/internal/model"
-	"fun-admin/pkg"
 	"fun-admin/pkg/admin"
 	"fun-admin/pkg/logger"
-	"fun-admin/pkg/sid"
-	"net/http"
-	"os"
 
-	"github.com/casbin/casbin/v2"
 	"go.uber.org/zap"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -34,30 +24,41 @@ func (RoleResource) TableName() string {
 	return "role_resources"
 }
 
+// Models 返回安装时自动迁移的模型，同时作为 make:migration -diff 比较的期望结构
+func Models() []interface{} {
+	return []interface{}{
+		&model.User{},
+		&model.Menu{},
+		&model.Role{},
+		&model.Api{},
+		&RoleResource{},
+		&model.Comment{},
+		&model.CommentMention{},
+		&model.Attachment{},
+		&model.Translation{},
+		&model.ImportJob{},
+		&model.ExportJob{},
+		&model.ScheduledReport{},
+		&model.ScheduledReportRun{},
+	}
+}
+
+// MigrateServer 安装数据表：基础模型、资源表与版本化迁移；初始数据由 seeders.Initial 填充
 type MigrateServer struct {
 	db             *gorm.DB
 	log            *logger.Logger
-	sid            *sid.Sid
-	e              *casbin.SyncedEnforcer
 	allowDropTable bool
-	adminPassword  string
 }
 
 func NewMigrateServer(
 	db *gorm.DB,
 	log *logger.Logger,
-	sid *sid.Sid,
-	e *casbin.SyncedEnforcer,
 	allowDrop bool,
-	adminPassword string,
 ) *MigrateServer {
 	return &MigrateServer{
-		e:              e,
 		db:             db,
 		log:            log,
-		sid:            sid,
 		allowDropTable: allowDrop,
-		adminPassword:  adminPassword,
 	}
 }
 
@@ -77,13 +78,7 @@ func (m *MigrateServer) Start(ctx context.Context) error {
 	} else {
 		m.log.Info("Running migration without dropping existing tables. Use --allow-drop to force a clean install.")
 	}
-	if err := m.db.AutoMigrate(
-		&model.User{},
-		&model.Menu{},
-		&model.Role{},
-		&model.Api{},
-		&RoleResource{},
-	); err != nil {
+	if err := m.db.AutoMigrate(Models()...); err != nil {
 		m.log.Error("user migrate error", zap.Error(err))
 		return err
 	}
@@ -95,28 +90,17 @@ func (m *MigrateServer) Start(ctx context.Context) error {
 		return err
 	}
 
-	err = m.initialAdminUser(ctx)
-	if err != nil {
-		m.log.Error("initialAdminUser error", zap.Error(err))
-	}
-
-	err = m.initialMenuData(ctx)
-	if err != nil {
-		m.log.Error("initialMenuData error", zap.Error(err))
-	}
-
-	err = m.initialApisData(ctx)
-	if err != nil {
-		m.log.Error("initialApisData error", zap.Error(err))
+	// 执行未执行的版本化迁移
+	applied, err := migrate.NewRunner(m.db, migrate.Migrations()).Up(ctx)
+	for _, id := range applied {
+		m.log.Info("migrated", zap.String("migration", id))
 	}
-
-	err = m.initialRBAC(ctx)
 	if err != nil {
-		m.log.Error("initialRBAC error", zap.Error(err))
+		m.log.Error("versioned migrations error", zap.Error(err))
+		return err
 	}
 
 	m.log.Info("AutoMigrate success")
-	os.Exit(0)
 	return nil
 }
 
@@ -124,299 +108,3 @@ func (m *MigrateServer) Stop(ctx context.Context) error {
 	m.log.Info("AutoMigrate stop")
 	return nil
 }
-
-func (m *MigrateServer) initialAdminUser(ctx context.Context) error {
-	var existing model.User
-	if err := m.db.WithContext(ctx).Where("username = ?", "admin").First(&existing).Error; err == nil {
-		m.log.Info("Admin user already exists, skip initializing credentials")
-		return nil
-	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
-		return err
-	}
-
-	password, generated, err := m.resolveAdminPassword()
-	if err != nil {
-		return err
-	}
-
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-	if err != nil {
-		return err
-	}
-
-	// 创建管理员用户
-	if err := m.db.Create(&model.User{
-		BaseModel: model.BaseModel{
-			ID: 1,
-		},
-		Username: "admin",
-		Password: string(hashedPassword),
-		Nickname: "Admin",
-	}).Error; err != nil {
-		return err
-	}
-
-	if generated {
-		m.log.Warn("Admin user created with generated password, please change it immediately",
-			zap.String("username", "admin"),
-			zap.String("password", password))
-	}
-
-	// 创建普通用户（用于演示）
-	return m.db.Create(&model.User{
-		BaseModel: model.BaseModel{
-			ID: 2,
-		},
-		Username: "user",
-		Password: string(hashedPassword),
-		Nickname: "运营人员",
-	}).Error
-}
-
-func (m *MigrateServer) initialRBAC(ctx context.Context) error {
-	roles := []model.Role{
-		{Sid: pkg.AdminRole, Name: "超级管理员"},
-		{Sid: "1000", Name: "运营人员"},
-		{Sid: "1001", Name: "访客"},
-	}
-
-	if err := m.db.Create(&roles).Error; err != nil {
-		return err
-	}
-
-	// 获取所有菜单和API
-	menuList := make([]model.Menu, 0)
-	if err := m.db.Find(&menuList).Error; err != nil {
-		return err
-	}
-
-	apiList := make([]model.Api, 0)
-	if err := m.db.Find(&apiList).Error; err != nil {
-		return err
-	}
-
-	// 为管理员角色添加所有权限
-	for _, item := range menuList {
-		_, err := m.e.AddPermissionForUser(pkg.AdminRole, pkg.MenuResourcePrefix+item.Path, "read")
-		if err != nil {
-			m.log.Sugar().Info("为角色 %s 添加权限 %s:%s 失败: %v", pkg.AdminRole, pkg.MenuResourcePrefix+item.Path, "read", err)
-		} else {
-			fmt.Printf("为角色 %s 添加权限: %s %s\n", pkg.AdminRole, pkg.MenuResourcePrefix+item.Path, "read")
-		}
-	}
-
-	for _, api := range apiList {
-		_, err := m.e.AddPermissionForUser(pkg.AdminRole, pkg.ApiResourcePrefix+api.Path, api.Method)
-		if err != nil {
-			m.log.Sugar().Info("为角色 %s 添加权限 %s:%s 失败: %v", pkg.AdminRole, pkg.ApiResourcePrefix+api.Path, api.Method, err)
-		} else {
-			fmt.Printf("为角色 %s 添加权限: %s %s\n", pkg.AdminRole, pkg.ApiResourcePrefix+api.Path, api.Method)
-		}
-	}
-
-	// 添加运营人员权限
-	_, err := m.e.AddRoleForUser("2", "1000")
-	if err != nil {
-		m.log.Error("m.e.AddRoleForUser error", zap.Error(err))
-		return err
-	}
-
-	// 为运营人员添加基础权限
-	basicPermissions := []struct {
-		resource string
-		action   string
-	}{
-		{pkg.MenuResourcePrefix + "/profile/basic", "read"},
-		{pkg.MenuResourcePrefix + "/profile/advanced", "read"},
-		{pkg.MenuResourcePrefix + "/profile", "read"},
-		{pkg.MenuResourcePrefix + "/dashboard", "read"},
-		{pkg.MenuResourcePrefix + "/dashboard/workplace", "read"},
-		{pkg.MenuResourcePrefix + "/dashboard/analysis", "read"},
-		{pkg.MenuResourcePrefix + "/account/settings", "read"},
-		{pkg.MenuResourcePrefix + "/account/center", "read"},
-		{pkg.MenuResourcePrefix + "/account", "read"},
-		{pkg.ApiResourcePrefix + "/v1/menus", http.MethodGet},
-		{pkg.ApiResourcePrefix + "/v1/admin/user", http.MethodGet},
-	}
-
-	for _, perm := range basicPermissions {
-		_, err := m.e.AddPermissionForUser("1000", perm.resource, perm.action)
-		if err != nil {
-			m.log.Sugar().Info("为角色 %s 添加权限 %s:%s 失败: %v", "1000", perm.resource, perm.action, err)
-		} else {
-			fmt.Printf("为角色 %s 添加权限: %s %s\n", "1000", perm.resource, perm.action)
-		}
-	}
-
-	// 保存策略到数据库
-	return m.e.SavePolicy()
-}
-
-func (m *MigrateServer) resolveAdminPassword() (string, bool, error) {
-	if m.adminPassword != "" {
-		if len(m.adminPassword) < 12 {
-			return "", false, fmt.Errorf("admin password must be at least 12 characters")
-		}
-		return m.adminPassword, false, nil
-	}
-
-	securePassword, err := generateSecurePassword(24)
-	if err != nil {
-		return "", false, err
-	}
-	m.adminPassword = securePassword
-	return securePassword, true, nil
-}
-
-func generateSecurePassword(length int) (string, error) {
-	const charset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789!@#%^*()-_=+"
-	b := make([]byte, length)
-	if _, err := rand.Read(b); err != nil {
-		return "", err
-	}
-	for i := range b {
-		b[i] = charset[int(b[i])%len(charset)]
-	}
-	return string(b), nil
-}
-
-func (m *MigrateServer) initialApisData(ctx context.Context) error {
-	initialApis := []model.Api{
-		{Group: "基础API", Name: "获取用户菜单列表", Path: "/v1/menus", Method: http.MethodGet},
-		{Group: "基础API", Name: "获取管理员信息", Path: "/v1/admin/user", Method: http.MethodGet},
-
-		{Group: "菜单管理", Name: "获取管理菜单", Path: "/v1/admin/menus", Method: http.MethodGet},
-		{Group: "菜单管理", Name: "创建菜单", Path: "/v1/admin/menu", Method: http.MethodPost},
-		{Group: "菜单管理", Name: "更新菜单", Path: "/v1/admin/menu", Method: http.MethodPut},
-		{Group: "菜单管理", Name: "删除菜单", Path: "/v1/admin/menu", Method: http.MethodDelete},
-
-		{Group: "权限模块", Name: "获取用户权限", Path: "/v1/admin/user/permissions", Method: http.MethodGet},
-		{Group: "权限模块", Name: "获取角色权限", Path: "/v1/admin/role/permissions", Method: http.MethodGet},
-		{Group: "权限模块", Name: "更新角色权限", Path: "/v1/admin/role/permission", Method: http.MethodPut},
-		{Group: "权限模块", Name: "获取角色列表", Path: "/v1/admin/roles", Method: http.MethodGet},
-		{Group: "权限模块", Name: "创建角色", Path: "/v1/admin/role", Method: http.MethodPost},
-		{Group: "权限模块", Name: "更新角色", Path: "/v1/admin/role", Method: http.MethodPut},
-		{Group: "权限模块", Name: "删除角色", Path: "/v1/admin/role", Method: http.MethodDelete},
-
-		{Group: "权限模块", Name: "获取管理员列表", Path: "/v1/admin/users", Method: http.MethodGet},
-		{Group: "权限模块", Name: "更新管理员信息", Path: "/v1/admin/user", Method: http.MethodPut},
-		{Group: "权限模块", Name: "创建管理员账号", Path: "/v1/admin/user", Method: http.MethodPost},
-		{Group: "权限模块", Name: "删除管理员", Path: "/v1/admin/user", Method: http.MethodDelete},
-
-		{Group: "权限模块", Name: "获取API列表", Path: "/v1/admin/apis", Method: http.MethodGet},
-		{Group: "权限模块", Name: "创建API", Path: "/v1/admin/api", Method: http.MethodPost},
-		{Group: "权限模块", Name: "更新API", Path: "/v1/admin/api", Method: http.MethodPut},
-		{Group: "权限模块", Name: "删除API", Path: "/v1/admin/api", Method: http.MethodDelete},
-	}
-
-	return m.db.Create(&initialApis).Error
-}
-
-func (m *MigrateServer) initialMenuData(ctx context.Context) error {
-	menuList := make([]model.Menu, 0)
-	err := json.Unmarshal([]byte(menuData), &menuList)
-	if err != nil {
-		m.log.Error("json.Unmarshal error", zap.Error(err))
-		return err
-	}
-
-	return m.db.Create(&menuList).Error
-}
-
-var menuData = `[
-  {
-    "id": 1,
-    "parentId": 0,
-    "title": "仪表盘",
-    "icon": "DashboardOutlined",
-    "component": "RouteView",
-    "redirect": "/dashboard/analysis",
-    "path": "/dashboard",
-    "name": "Dashboard",
-    "locale": "menu.dashboard"
-  },
-  {
-    "id": 2,
-    "parentId": 0,
-    "title": "分析页",
-    "icon": "DashboardOutlined",
-    "component": "/dashboard/analysis",
-    "path": "/dashboard/analysis",
-    "name": "DashboardAnalysis",
-    "keepAlive": true,
-    "locale": "menu.dashboard.analysis",
-    "weight": 2
-  },
-  {
-    "id": 15,
-    "path": "/access",
-    "component": "RouteView",
-    "redirect": "/access/common",
-    "title": "权限模块",
-    "name": "Access",
-    "parentId": 0,
-    "icon": "ClusterOutlined",
-    "locale": "menu.access",
-    "weight": 1
-  },
-  {
-    "id": 18,
-    "parentId": 15,
-    "path": "/access/admin",	
-    "title": "管理员账号",
-    "name": "accessAdmin",
-    "component": "/access/admin",
-    "locale": "menu.access.admin"
-  },
-  {
-    "id": 51,
-    "parentId": 15,
-    "path": "/access/role",	
-    "title": "角色管理",
-    "name": "AccessRoles",
-    "component": "/access/role",
-    "locale": "menu.access.roles"
-  },
-  {
-    "id": 52,
-    "parentId": 15,
-    "path": "/access/menu",	
-    "title": "菜单管理",
-    "name": "AccessMenu",
-    "component": "/access/menu",
-    "locale": "menu.access.menus"
-  },
-  {
-    "id": 53,
-    "parentId": 15,
-    "path": "/access/api",	
-    "title": "API管理",
-    "name": "AccessAPI",
-    "component": "/access/api",
-    "locale": "menu.access.api"
-  }
-  ,
-  {
-    "id": 44,
-    "parentId": 0,
-    "path": "/list",
-    "component": "RouteView",
-    "redirect": "/list/crud-table",
-    "title": "列表页",
-    "name": "List",
-    "locale": "menu.list",
-    "icon": "TableOutlined",
-    "weight": 3
-  },
-  {
-    "id": 45,
-    "parentId": 44,
-    "path": "/list/crud-table",
-    "component": "/list/crud-table",
-    "title": "增删改查表格",
-    "name": "CrudTable",
-    "locale": "menu.list.crud-table",
-    "icon": "TableOutlined",
-    "keepAlive": true
-  }
-]`
@@ -11,18 +11,24 @@ import (
 )
 
 type TaskServer struct {
-	log       *logger.Logger
-	scheduler *gocron.Scheduler
-	userTask  task.UserTask
+	log            *logger.Logger
+	scheduler      *gocron.Scheduler
+	userTask       task.UserTask
+	attachmentTask task.AttachmentTask
+	reportTask     task.ReportTask
 }
 
 func NewTaskServer(
 	log *logger.Logger,
 	userTask task.UserTask,
+	attachmentTask task.AttachmentTask,
+	reportTask task.ReportTask,
 ) *TaskServer {
 	return &TaskServer{
-		log:      log,
-		userTask: userTask,
+		log:            log,
+		userTask:       userTask,
+		attachmentTask: attachmentTask,
+		reportTask:     reportTask,
 	}
 }
 func (t *TaskServer) Start(ctx context.Context) error {
@@ -46,6 +52,28 @@ func (t *TaskServer) Start(ctx context.Context) error {
 		t.log.Error("CheckUser error", zap.Error(err))
 	}
 
+	// 每天凌晨清理已软删除的附件文件
+	_, err = t.scheduler.Cron("0 3 * * *").Do(func() {
+		err := t.attachmentTask.CollectGarbage(ctx)
+		if err != nil {
+			t.log.Error("CollectGarbage error", zap.Error(err))
+		}
+	})
+	if err != nil {
+		t.log.Error("CollectGarbage error", zap.Error(err))
+	}
+
+	// 每分钟检查到期的定时报表
+	_, err = t.scheduler.CronWithSeconds("0 * * * * *").SingletonMode().Do(func() {
+		err := t.reportTask.RunDueReports(ctx)
+		if err != nil {
+			t.log.Error("RunDueReports error", zap.Error(err))
+		}
+	})
+	if err != nil {
+		t.log.Error("RunDueReports error", zap.Error(err))
+	}
+
 	t.scheduler.StartBlocking()
 	return nil
 }
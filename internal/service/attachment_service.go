@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"mime/multipart"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAttachmentNotFound 表示附件不存在或不属于该记录
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrFieldNotReadable 表示当前用户无权读取该字段
+	ErrFieldNotReadable = errors.New("field is not readable")
+	// ErrFieldNotWritable 表示当前用户无权写入该字段
+	ErrFieldNotWritable = errors.New("field is not writable")
+)
+
+// defaultAttachmentURLExpire 附件签名下载地址默认有效期
+const defaultAttachmentURLExpire = 15 * time.Minute
+
+// AttachmentService 资源记录附件服务
+type AttachmentService interface {
+	ListAttachments(ctx context.Context, resourceSlug string, recordID string, fieldName string) ([]*model.Attachment, error)
+	Attach(ctx context.Context, resourceSlug string, recordID string, fieldName string, userID uint, file *multipart.FileHeader) (*model.Attachment, error)
+	Reorder(ctx context.Context, resourceSlug string, recordID string, fieldName string, ids []uint) error
+	Detach(ctx context.Context, resourceSlug string, recordID string, attachmentID uint) error
+	GetDownloadURL(ctx context.Context, resourceSlug string, recordID string, attachmentID uint) (*SignedURL, error)
+}
+
+// NewAttachmentService 创建附件服务
+func NewAttachmentService(
+	service *Service,
+	attachmentRepository repository.AttachmentRepository,
+	resourceRepository *repository.ResourceRepository,
+	resourceService *ResourceService,
+	fileService *FileService,
+) AttachmentService {
+	return &attachmentService{
+		Service:              service,
+		attachmentRepository: attachmentRepository,
+		resourceRepository:   resourceRepository,
+		resourceService:      resourceService,
+		fileService:          fileService,
+	}
+}
+
+type attachmentService struct {
+	*Service
+	attachmentRepository repository.AttachmentRepository
+	resourceRepository   *repository.ResourceRepository
+	resourceService      *ResourceService
+	fileService          *FileService
+}
+
+// ListAttachments 获取记录附件，仅返回可读字段并附带签名地址
+func (s *attachmentService) ListAttachments(ctx context.Context, resourceSlug string, recordID string, fieldName string) ([]*model.Attachment, error) {
+	resource, err := s.authorizeView(ctx, resourceSlug, recordID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.attachmentRepository.GetAttachments(ctx, resourceSlug, recordID, fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	readable := s.resourceService.getReadableFieldSet(ctx, resource)
+	visible := make([]*model.Attachment, 0, len(list))
+	for _, attachment := range list {
+		if _, ok := readable[attachment.FieldName]; !ok {
+			continue
+		}
+		attachment.URL, _ = s.fileService.GetFileURLWithContext(ctx, attachment.StorageType, attachment.StorageKey, defaultAttachmentURLExpire)
+		visible = append(visible, attachment)
+	}
+	return visible, nil
+}
+
+// Attach 上传文件并关联到记录字段
+// 单文件字段会替换原附件并同步记录列，多文件字段追加到末尾
+func (s *attachmentService) Attach(ctx context.Context, resourceSlug string, recordID string, fieldName string, userID uint, file *multipart.FileHeader) (*model.Attachment, error) {
+	resource, err := s.authorizeUpdate(ctx, resourceSlug, recordID, fieldName)
+	if err != nil {
+		return nil, err
+	}
+	field := findFileField(resource, fieldName)
+	if field == nil {
+		return nil, &ValidationError{Errors: map[string][]string{fieldName: {"字段不是文件字段"}}}
+	}
+
+	info, err := s.fileService.UploadFileWithOptions(ctx, file, field.AllowedTypes, field.MaxSize, "", resourceSlug+"/"+fieldName)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := &model.Attachment{
+		ResourceSlug: resourceSlug,
+		RecordID:     recordID,
+		FieldName:    fieldName,
+		StorageType:  info.StorageType,
+		StorageKey:   info.Path,
+		Name:         info.Name,
+		MimeType:     info.ContentType,
+		Size:         info.Size,
+		Checksum:     info.Checksum,
+		UploadedBy:   userID,
+	}
+	// 替换旧附件、写入附件与同步记录列在同一事务中完成
+	err = s.resourceService.withTransaction(ctx, func(ctx context.Context) error {
+		if field.Multiple {
+			sort, err := s.attachmentRepository.NextSort(ctx, resourceSlug, recordID, fieldName)
+			if err != nil {
+				return err
+			}
+			attachment.Sort = sort
+		} else if err := s.attachmentRepository.DeleteFieldAttachments(ctx, resourceSlug, recordID, fieldName); err != nil {
+			return err
+		}
+		if err := s.attachmentRepository.CreateAttachment(ctx, attachment); err != nil {
+			return err
+		}
+		// 单文件字段保持记录列中的路径与附件一致
+		if !field.Multiple {
+			return s.resourceRepository.Update(ctx, resourceSlug, recordID, map[string]interface{}{fieldName: info.Path})
+		}
+		return nil
+	})
+	if err != nil {
+		// 上传的文件未被任何附件引用，直接删除
+		_ = s.fileService.DeleteFileWithContext(ctx, info.StorageType, info.Path)
+		return nil, err
+	}
+	if !field.Multiple {
+		s.resourceService.clearResourceCache(ctx, resourceSlug)
+		s.resourceService.cacheManager.Delete(ctx, s.resourceService.getRecordCacheKey(resourceSlug, recordID))
+	}
+
+	attachment.URL, _ = s.fileService.GetFileURLWithContext(ctx, attachment.StorageType, attachment.StorageKey, defaultAttachmentURLExpire)
+	return attachment, nil
+}
+
+// Reorder 按给定顺序重排字段附件
+func (s *attachmentService) Reorder(ctx context.Context, resourceSlug string, recordID string, fieldName string, ids []uint) error {
+	if _, err := s.authorizeUpdate(ctx, resourceSlug, recordID, fieldName); err != nil {
+		return err
+	}
+	existing, err := s.attachmentRepository.GetAttachments(ctx, resourceSlug, recordID, fieldName)
+	if err != nil {
+		return err
+	}
+	owned := make(map[uint]struct{}, len(existing))
+	for _, attachment := range existing {
+		owned[attachment.ID] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := owned[id]; !ok {
+			return ErrAttachmentNotFound
+		}
+	}
+	return s.attachmentRepository.UpdateSort(ctx, ids)
+}
+
+// Detach 软删除附件，存储文件由附件清理任务回收
+func (s *attachmentService) Detach(ctx context.Context, resourceSlug string, recordID string, attachmentID uint) error {
+	attachment, err := s.getRecordAttachment(ctx, resourceSlug, recordID, attachmentID)
+	if err != nil {
+		return err
+	}
+	if _, err := s.authorizeUpdate(ctx, resourceSlug, recordID, attachment.FieldName); err != nil {
+		return err
+	}
+	return s.attachmentRepository.DeleteAttachment(ctx, attachment.ID)
+}
+
+// GetDownloadURL 生成附件签名下载地址，受字段可读权限控制
+func (s *attachmentService) GetDownloadURL(ctx context.Context, resourceSlug string, recordID string, attachmentID uint) (*SignedURL, error) {
+	resource, err := s.authorizeView(ctx, resourceSlug, recordID)
+	if err != nil {
+		return nil, err
+	}
+	attachment, err := s.getRecordAttachment(ctx, resourceSlug, recordID, attachmentID)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := s.resourceService.getReadableFieldSet(ctx, resource)[attachment.FieldName]; !ok {
+		return nil, ErrFieldNotReadable
+	}
+	url, err := s.fileService.GetFileURLWithContext(ctx, attachment.StorageType, attachment.StorageKey, defaultAttachmentURLExpire)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedURL{URL: url, ExpiresAt: time.Now().Add(defaultAttachmentURLExpire)}, nil
+}
+
+// authorizeView 校验资源存在、记录存在且可查看
+func (s *attachmentService) authorizeView(ctx context.Context, resourceSlug string, recordID string) (admin.Resource, error) {
+	resource := s.resourceService.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanView(ctx, recordID); err != nil {
+			return nil, permissionDenied(err)
+		}
+	}
+	record, err := s.resourceRepository.FindByID(ctx, resourceSlug, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, ErrRecordNotFound
+	}
+	return resource, nil
+}
+
+// authorizeUpdate 校验记录可更新且字段可写
+func (s *attachmentService) authorizeUpdate(ctx context.Context, resourceSlug string, recordID string, fieldName string) (admin.Resource, error) {
+	resource, err := s.authorizeView(ctx, resourceSlug, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanUpdate(ctx, recordID, map[string]interface{}{}); err != nil {
+			return nil, permissionDenied(err)
+		}
+	}
+	if _, ok := s.resourceService.getWritableFieldSet(ctx, resource)[fieldName]; !ok {
+		return nil, ErrFieldNotWritable
+	}
+	if _, ok := s.resourceService.getReadOnlyFieldSet(resource)[fieldName]; ok {
+		return nil, ErrFieldNotWritable
+	}
+	return resource, nil
+}
+
+// getRecordAttachment 获取属于该记录的附件
+func (s *attachmentService) getRecordAttachment(ctx context.Context, resourceSlug string, recordID string, attachmentID uint) (*model.Attachment, error) {
+	attachment, err := s.attachmentRepository.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	if attachment.ResourceSlug != resourceSlug || attachment.RecordID != recordID {
+		return nil, ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// findFileField 查找资源上的文件字段
+func findFileField(resource admin.Resource, fieldName string) *admin.FileField {
+	for _, field := range resource.GetFields() {
+		if fileField, ok := field.(*admin.FileField); ok && fileField.GetName() == fieldName {
+			return fileField
+		}
+	}
+	return nil
+}
+
+// recordIDsToStrings 将记录 ID 转换为附件表使用的字符串形式
+func recordIDsToStrings(ids []interface{}) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, fmt.Sprint(id))
+	}
+	return result
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"regexp"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrCommentsDisabled 表示资源未启用评论
+	ErrCommentsDisabled = errors.New("comments are not enabled for this resource")
+	// ErrCommentNotFound 表示评论不存在或不属于该记录
+	ErrCommentNotFound = errors.New("comment not found")
+	// ErrCommentForbidden 表示非作者尝试编辑或删除评论
+	ErrCommentForbidden = errors.New("only the author can modify this comment")
+	// ErrRecordNotFound 表示资源记录不存在
+	ErrRecordNotFound = errors.New("record not found")
+)
+
+// mentionPattern 匹配 @用户名
+var mentionPattern = regexp.MustCompile(`@([A-Za-z0-9_.\-]+)`)
+
+// CommentInput 创建/编辑评论的输入
+type CommentInput struct {
+	Content  string `json:"content"`
+	ParentID *uint  `json:"parent_id"`
+}
+
+// CommentService 资源记录评论服务
+type CommentService interface {
+	ListComments(ctx context.Context, resourceSlug string, recordID string, page, pageSize int) ([]*model.Comment, int64, error)
+	CreateComment(ctx context.Context, resourceSlug string, recordID string, userID uint, input *CommentInput) (*model.Comment, error)
+	UpdateComment(ctx context.Context, resourceSlug string, recordID string, commentID uint, userID uint, input *CommentInput) (*model.Comment, error)
+	DeleteComment(ctx context.Context, resourceSlug string, recordID string, commentID uint, userID uint) error
+}
+
+// NewCommentService 创建评论服务
+func NewCommentService(
+	service *Service,
+	commentRepository repository.CommentRepository,
+	userRepository repository.UserRepository,
+	resourceRepository *repository.ResourceRepository,
+	resourceManager *admin.ResourceManager,
+) CommentService {
+	return &commentService{
+		Service:            service,
+		commentRepository:  commentRepository,
+		userRepository:     userRepository,
+		resourceRepository: resourceRepository,
+		resourceManager:    resourceManager,
+	}
+}
+
+type commentService struct {
+	*Service
+	commentRepository  repository.CommentRepository
+	userRepository     repository.UserRepository
+	resourceRepository *repository.ResourceRepository
+	resourceManager    *admin.ResourceManager
+}
+
+// ListComments 获取记录评论（顶层分页，回复嵌套返回）
+func (s *commentService) ListComments(ctx context.Context, resourceSlug string, recordID string, page, pageSize int) ([]*model.Comment, int64, error) {
+	if err := s.authorize(ctx, resourceSlug, recordID); err != nil {
+		return nil, 0, err
+	}
+	roots, total, err := s.commentRepository.GetRootComments(ctx, resourceSlug, recordID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(roots) == 0 {
+		return roots, total, nil
+	}
+	replies, err := s.commentRepository.GetReplies(ctx, resourceSlug, recordID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// 组装线程：父评论已删除的回复不再展示
+	byID := make(map[uint]*model.Comment, len(roots)+len(replies))
+	for _, c := range roots {
+		byID[c.ID] = c
+	}
+	for _, c := range replies {
+		byID[c.ID] = c
+	}
+	for _, c := range replies {
+		if parent, ok := byID[*c.ParentID]; ok {
+			parent.Replies = append(parent.Replies, c)
+		}
+	}
+	return roots, total, nil
+}
+
+// CreateComment 发表评论或回复
+func (s *commentService) CreateComment(ctx context.Context, resourceSlug string, recordID string, userID uint, input *CommentInput) (*model.Comment, error) {
+	if err := s.authorize(ctx, resourceSlug, recordID); err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(input.Content)
+	if content == "" {
+		return nil, &ValidationError{Errors: map[string][]string{"content": {"评论内容不能为空"}}}
+	}
+	if input.ParentID != nil {
+		parent, err := s.commentRepository.GetComment(ctx, *input.ParentID)
+		if err != nil || parent.ResourceSlug != resourceSlug || parent.RecordID != recordID {
+			return nil, ErrCommentNotFound
+		}
+	}
+
+	author, err := s.userRepository.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment := &model.Comment{
+		ResourceSlug: resourceSlug,
+		RecordID:     recordID,
+		ParentID:     input.ParentID,
+		UserID:       userID,
+		UserName:     displayName(author),
+		Content:      content,
+		Mentions:     s.resolveMentions(ctx, content),
+	}
+	if err := s.commentRepository.CreateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// UpdateComment 编辑评论（仅作者）
+func (s *commentService) UpdateComment(ctx context.Context, resourceSlug string, recordID string, commentID uint, userID uint, input *CommentInput) (*model.Comment, error) {
+	comment, err := s.getOwnComment(ctx, resourceSlug, recordID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	content := strings.TrimSpace(input.Content)
+	if content == "" {
+		return nil, &ValidationError{Errors: map[string][]string{"content": {"评论内容不能为空"}}}
+	}
+
+	now := time.Now()
+	comment.Content = content
+	comment.EditedAt = &now
+	comment.Mentions = s.resolveMentions(ctx, content)
+	if err := s.commentRepository.UpdateComment(ctx, comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// DeleteComment 软删除评论（仅作者）
+func (s *commentService) DeleteComment(ctx context.Context, resourceSlug string, recordID string, commentID uint, userID uint) error {
+	comment, err := s.getOwnComment(ctx, resourceSlug, recordID, commentID, userID)
+	if err != nil {
+		return err
+	}
+	return s.commentRepository.DeleteComment(ctx, comment.ID)
+}
+
+// authorize 校验资源启用评论、记录存在且当前用户可查看父记录
+func (s *commentService) authorize(ctx context.Context, resourceSlug string, recordID string) error {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	commentable, ok := resource.(admin.Commentable)
+	if !ok || !commentable.IsCommentable() {
+		return ErrCommentsDisabled
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanView(ctx, recordID); err != nil {
+			return permissionDenied(err)
+		}
+	}
+	record, err := s.resourceRepository.FindByID(ctx, resourceSlug, recordID)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		return ErrRecordNotFound
+	}
+	return nil
+}
+
+// permissionDenied 将 Authorizable 的拒绝原因包装为 admin.ErrPermissionDenied，接口层据此返回 403
+func permissionDenied(err error) error {
+	if errors.Is(err, admin.ErrPermissionDenied) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", admin.ErrPermissionDenied, err)
+}
+
+// getOwnComment 获取当前用户在该记录下发表的评论
+func (s *commentService) getOwnComment(ctx context.Context, resourceSlug string, recordID string, commentID uint, userID uint) (*model.Comment, error) {
+	if err := s.authorize(ctx, resourceSlug, recordID); err != nil {
+		return nil, err
+	}
+	comment, err := s.commentRepository.GetComment(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+		return nil, err
+	}
+	if comment.ResourceSlug != resourceSlug || comment.RecordID != recordID {
+		return nil, ErrCommentNotFound
+	}
+	if comment.UserID != userID {
+		return nil, ErrCommentForbidden
+	}
+	return comment, nil
+}
+
+// resolveMentions 解析内容中的 @用户名，忽略不存在的用户
+func (s *commentService) resolveMentions(ctx context.Context, content string) []model.CommentMention {
+	mentions := make([]model.CommentMention, 0)
+	seen := make(map[uint]struct{})
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		user, err := s.userRepository.GetUserByUsername(ctx, match[1])
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[user.ID]; ok {
+			continue
+		}
+		seen[user.ID] = struct{}{}
+		mentions = append(mentions, model.CommentMention{UserID: user.ID, UserName: user.Username})
+	}
+	return mentions
+}
+
+// displayName 优先使用昵称
+func displayName(user *model.User) string {
+	if user.Nickname != "" {
+		return user.Nickname
+	}
+	if user.Username != "" {
+		return user.Username
+	}
+	return fmt.Sprintf("user-%d", user.ID)
+}
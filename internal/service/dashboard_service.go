@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
 	"fun-admin/pkg/admin/i18n"
 	"fun-admin/pkg/cache"
 	"time"
@@ -94,6 +95,9 @@ func (s *DashboardService) GetDashboardStats(ctx context.Context, language strin
 	}
 	stats["system_info"] = systemInfo
 
+	// 获取注册的仪表盘小组件
+	stats["widgets"] = s.getWidgets(ctx, language)
+
 	// 添加标签翻译
 	stats["user_count_label"] = i18n.Translate(language, "dashboard.user_count")
 	stats["department_count_label"] = i18n.Translate(language, "dashboard.department_count")
@@ -103,6 +107,29 @@ func (s *DashboardService) GetDashboardStats(ctx context.Context, language strin
 	return stats, nil
 }
 
+// getWidgets 计算已注册小组件的数据，单个小组件失败时记录错误并返回 error 字段，不影响其余数据
+func (s *DashboardService) getWidgets(ctx context.Context, language string) []map[string]interface{} {
+	widgets := admin.GlobalResourceManager.GetWidgets()
+	result := make([]map[string]interface{}, 0, len(widgets))
+	for _, widget := range widgets {
+		item := map[string]interface{}{
+			"name":  widget.GetName(),
+			"title": admin.TranslateLabel(widget.GetTitle(), language),
+			"type":  widget.GetType(),
+			"width": widget.GetWidth(),
+		}
+		data, err := s.dashboardRepo.GetWidgetData(ctx, widget)
+		if err != nil {
+			s.logger.Error("dashboard widget failed", zap.String("widget", widget.GetName()), zap.Error(err))
+			item["error"] = i18n.Translate(language, "error.load_failed")
+		} else {
+			item["data"] = data
+		}
+		result = append(result, item)
+	}
+	return result
+}
+
 // GetRecentUserStats 获取最近用户注册统计
 func (s *DashboardService) GetRecentUserStats(ctx context.Context, language string) ([]map[string]interface{}, error) {
 	// 尝试从缓存获取数据
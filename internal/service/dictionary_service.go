@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"fun-admin/internal/model"
 	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin/i18n"
 	"fun-admin/pkg/cache"
 	"fun-admin/pkg/logger"
 	"sync"
@@ -13,20 +14,25 @@ import (
 
 // DictionaryService 字典服务
 type DictionaryService struct {
-	dictionaryRepo repository.DictionaryRepository
-	cache          cache.CacheManager
-	logger         *logger.Logger
-	dictMap        map[string][]model.DictionaryData // 字典缓存
-	mu             sync.RWMutex
+	dictionaryRepo  repository.DictionaryRepository
+	translationRepo repository.TranslationRepository
+	cache           cache.CacheManager
+	logger          *logger.Logger
+	dictMap         map[string][]model.DictionaryData // 字典缓存（默认语言）
+	mu              sync.RWMutex
 }
 
+// dictDataTranslationSlug 字典标签翻译在翻译表中的资源标识，与字典数据资源一致
+const dictDataTranslationSlug = "admin_dictionary_data"
+
 // NewDictionaryService 创建字典服务
-func NewDictionaryService(dictionaryRepo repository.DictionaryRepository, logger *logger.Logger, cache cache.CacheManager) *DictionaryService {
+func NewDictionaryService(dictionaryRepo repository.DictionaryRepository, translationRepo repository.TranslationRepository, logger *logger.Logger, cache cache.CacheManager) *DictionaryService {
 	return &DictionaryService{
-		dictionaryRepo: dictionaryRepo,
-		logger:         logger,
-		cache:          cache,
-		dictMap:        make(map[string][]model.DictionaryData),
+		dictionaryRepo:  dictionaryRepo,
+		translationRepo: translationRepo,
+		logger:          logger,
+		cache:           cache,
+		dictMap:         make(map[string][]model.DictionaryData),
 	}
 }
 
@@ -162,11 +168,11 @@ func (s *DictionaryService) ListDictionaryData(ctx context.Context, typeID uint,
 	return s.dictionaryRepo.ListDictionaryData(ctx, typeID, label, status)
 }
 
-// GetDictByCode 根据字典编码获取字典数据
+// GetDictByCode 根据字典编码获取字典数据，标签按请求语言返回
 func (s *DictionaryService) GetDictByCode(ctx context.Context, code string) ([]model.DictionaryData, error) {
 	// 先从缓存获取
 	if data, exists := s.getCache(code); exists {
-		return data, nil
+		return s.localizeLabels(ctx, data)
 	}
 
 	// 从数据库获取
@@ -178,7 +184,44 @@ func (s *DictionaryService) GetDictByCode(ctx context.Context, code string) ([]m
 	// 设置缓存
 	s.setCache(code, data)
 
-	return data, nil
+	return s.localizeLabels(ctx, data)
+}
+
+// localizeLabels 按请求语言的回退链替换字典标签，缓存中保存默认语言的数据，此处返回副本
+func (s *DictionaryService) localizeLabels(ctx context.Context, data []model.DictionaryData) ([]model.DictionaryData, error) {
+	language := i18n.Normalize(i18n.FromContext(ctx))
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	if language == defaultLanguage || len(data) == 0 {
+		return data, nil
+	}
+	ids := make([]string, 0, len(data))
+	for _, item := range data {
+		ids = append(ids, fmt.Sprint(item.ID))
+	}
+	rows, err := s.translationRepo.GetTranslations(ctx, dictDataTranslationSlug, ids, []string{"label"})
+	if err != nil {
+		return nil, err
+	}
+	labels := make(map[string]map[string]string, len(data))
+	for _, row := range rows {
+		if labels[row.RecordID] == nil {
+			labels[row.RecordID] = make(map[string]string)
+		}
+		labels[row.RecordID][row.Locale] = row.Value
+	}
+	localized := make([]model.DictionaryData, len(data))
+	for i, item := range data {
+		values := labels[fmt.Sprint(item.ID)]
+		if values == nil {
+			values = make(map[string]string, 1)
+		}
+		values[defaultLanguage] = item.Label
+		if label, _, ok := i18n.Resolve(language, values); ok {
+			item.Label = label
+		}
+		localized[i] = item
+	}
+	return localized, nil
 }
 
 // GetDictValue 获取字典值对应的标签
@@ -0,0 +1,337 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/email"
+	"fun-admin/pkg/jwt"
+	"io"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrExportJobNotFound 表示导出任务不存在或不属于当前用户
+	ErrExportJobNotFound = errors.New("export job not found")
+	// ErrExportNotReady 表示导出文件尚未生成或已过期
+	ErrExportNotReady = errors.New("export file is not available")
+)
+
+const (
+	// exportFileRetention 导出文件保留时长，过期后由清理任务删除
+	exportFileRetention = 7 * 24 * time.Hour
+	// defaultExportURLExpire 导出文件签名下载地址有效期
+	defaultExportURLExpire = 15 * time.Minute
+	// exportHeartbeatInterval 执行中任务的租约续期间隔
+	exportHeartbeatInterval = 30 * time.Second
+	// exportLeaseTimeout 租约超时时长，超过该时长未续期的执行中任务视为中断
+	exportLeaseTimeout = 2 * time.Minute
+)
+
+// ExportJobService 异步导出任务服务
+type ExportJobService interface {
+	CreateJob(ctx context.Context, resourceSlug string, req ExportRequest, userID uint) (*model.ExportJob, error)
+	ListJobs(ctx context.Context, userID uint, page, pageSize int) ([]*model.ExportJob, int64, error)
+	GetDownloadURL(ctx context.Context, userID uint, id uint) (*SignedURL, error)
+	RecoverJobs(ctx context.Context) error
+	RunNext(ctx context.Context) (bool, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// NewExportJobService 创建导出任务服务
+func NewExportJobService(
+	service *Service,
+	exportJobRepository repository.ExportJobRepository,
+	userRepository repository.UserRepository,
+	resourceService *ResourceService,
+	fileService *FileService,
+	configService *ConfigService,
+) ExportJobService {
+	return &exportJobService{
+		Service:             service,
+		exportJobRepository: exportJobRepository,
+		userRepository:      userRepository,
+		resourceService:     resourceService,
+		fileService:         fileService,
+		configService:       configService,
+		owner:               exportJobOwner(),
+	}
+}
+
+// exportJobOwner 生成当前实例的任务执行者标识（主机名-进程号-启动时间）
+func exportJobOwner() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%d-%d", host, os.Getpid(), time.Now().UnixNano())
+}
+
+type exportJobService struct {
+	*Service
+	exportJobRepository repository.ExportJobRepository
+	userRepository      repository.UserRepository
+	resourceService     *ResourceService
+	fileService         *FileService
+	configService       *ConfigService
+	owner               string // 当前实例标识，领取任务时写入租约
+}
+
+// CreateJob 校验导出参数后创建待执行任务
+func (s *exportJobService) CreateJob(ctx context.Context, resourceSlug string, req ExportRequest, userID uint) (*model.ExportJob, error) {
+	export, err := s.resourceService.PrepareExport(ctx, resourceSlug, req)
+	if err != nil {
+		return nil, err
+	}
+	params, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	job := &model.ExportJob{
+		ResourceSlug: resourceSlug,
+		Format:       export.Format,
+		Params:       string(params),
+		Status:       model.ExportStatusPending,
+		FileName:     export.Filename,
+		CreatedBy:    userID,
+	}
+	if err := s.exportJobRepository.CreateExportJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListJobs 获取用户的导出历史，可下载的任务附带签名地址
+func (s *exportJobService) ListJobs(ctx context.Context, userID uint, page, pageSize int) ([]*model.ExportJob, int64, error) {
+	list, total, err := s.exportJobRepository.GetExportJobs(ctx, page, pageSize, map[string]interface{}{"created_by": userID})
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, job := range list {
+		if isExportDownloadable(job) {
+			job.DownloadURL, _ = s.fileService.GetFileURLWithContext(ctx, job.StorageType, job.StorageKey, defaultExportURLExpire)
+		}
+	}
+	return list, total, nil
+}
+
+// GetDownloadURL 生成导出文件的签名下载地址
+func (s *exportJobService) GetDownloadURL(ctx context.Context, userID uint, id uint) (*SignedURL, error) {
+	job, err := s.exportJobRepository.GetExportJob(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportJobNotFound
+		}
+		return nil, err
+	}
+	if job.CreatedBy != userID {
+		return nil, ErrExportJobNotFound
+	}
+	if !isExportDownloadable(job) {
+		return nil, ErrExportNotReady
+	}
+	url, err := s.fileService.GetFileURLWithContext(ctx, job.StorageType, job.StorageKey, defaultExportURLExpire)
+	if err != nil {
+		return nil, err
+	}
+	return &SignedURL{URL: url, ExpiresAt: time.Now().Add(defaultExportURLExpire)}, nil
+}
+
+// RecoverJobs 将租约已过期的执行中任务重新排队
+// 仍由其他实例执行（租约在续期）的任务不受影响
+func (s *exportJobService) RecoverJobs(ctx context.Context) error {
+	count, err := s.exportJobRepository.ReclaimExpiredExportJobs(ctx, time.Now().Add(-exportLeaseTimeout))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		s.logger.Info("重新排队租约过期的导出任务", zap.Int64("count", count))
+	}
+	return nil
+}
+
+// RunNext 领取并执行一个待执行任务，没有任务时返回 false
+// 执行期间定期续期租约，租约丢失时取消执行并丢弃结果
+func (s *exportJobService) RunNext(ctx context.Context) (bool, error) {
+	job, err := s.exportJobRepository.ClaimPendingExportJob(ctx, s.owner)
+	if err != nil || job == nil {
+		return false, err
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+		s.keepLease(runCtx, cancel, job.ID)
+	}()
+	rows, info, runErr := s.run(runCtx, job)
+	cancel()
+	<-heartbeatDone
+
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	if runErr != nil {
+		job.Status = model.ExportStatusFailed
+		job.Error = runErr.Error()
+		s.logger.Warn("导出任务失败", zap.Uint("job_id", job.ID), zap.Error(runErr))
+	} else {
+		expiresAt := finishedAt.Add(exportFileRetention)
+		job.Status = model.ExportStatusCompleted
+		job.Rows = rows
+		job.StorageType = info.StorageType
+		job.StorageKey = info.Path
+		job.FileSize = info.Size
+		job.ExpiresAt = &expiresAt
+	}
+	held, err := s.exportJobRepository.FinishExportJob(ctx, job, s.owner)
+	if err != nil {
+		return true, err
+	}
+	if !held {
+		s.logger.Warn("导出任务租约已失效，丢弃执行结果", zap.Uint("job_id", job.ID))
+		if info != nil {
+			if err := s.fileService.DeleteFileWithContext(ctx, info.StorageType, info.Path); err != nil {
+				s.logger.Warn("删除导出文件失败", zap.Uint("job_id", job.ID), zap.Error(err))
+			}
+		}
+		return true, nil
+	}
+
+	s.notify(ctx, job)
+	return true, nil
+}
+
+// keepLease 定期续期任务租约，直到 ctx 取消；租约被回收时调用 cancel 中止执行
+func (s *exportJobService) keepLease(ctx context.Context, cancel context.CancelFunc, id uint) {
+	ticker := time.NewTicker(exportHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			held, err := s.exportJobRepository.RenewExportJobLease(ctx, id, s.owner)
+			if err != nil {
+				// 续期失败时继续执行，租约超时前的下一次续期仍可成功
+				s.logger.Warn("导出任务续期失败", zap.Uint("job_id", id), zap.Error(err))
+				continue
+			}
+			if !held {
+				s.logger.Warn("导出任务租约已被回收，中止执行", zap.Uint("job_id", id))
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// run 以发起人身份执行导出，边生成边写入存储
+func (s *exportJobService) run(ctx context.Context, job *model.ExportJob) (int, *FileInfo, error) {
+	var req ExportRequest
+	if err := json.Unmarshal([]byte(job.Params), &req); err != nil {
+		return 0, nil, fmt.Errorf("解析导出参数失败: %w", err)
+	}
+
+	// 权限钩子按请求上下文中的用户判断，这里还原为任务发起人
+	userCtx := context.WithValue(ctx, "claims", &jwt.MyCustomClaims{UserId: job.CreatedBy})
+	export, err := s.resourceService.PrepareExport(userCtx, job.ResourceSlug, req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	type writeResult struct {
+		rows int
+		err  error
+	}
+	reader, writer := io.Pipe()
+	done := make(chan writeResult, 1)
+	go func() {
+		rows, err := s.resourceService.WriteExport(userCtx, export, writer)
+		writer.CloseWithError(err)
+		done <- writeResult{rows: rows, err: err}
+	}()
+
+	key := fmt.Sprintf("exports/%d/%d_%s", job.CreatedBy, job.ID, export.Filename)
+	info, err := s.fileService.SaveFileWithContext(ctx, "", key, reader, export.ContentType)
+	// 存储提前失败时关闭读端，避免写出协程阻塞
+	reader.CloseWithError(err)
+	result := <-done
+	if result.err != nil {
+		return 0, nil, result.err
+	}
+	if err != nil {
+		return 0, nil, err
+	}
+	return result.rows, info, nil
+}
+
+// notify 通过邮件通知发起人导出结果，邮件未配置时跳过
+func (s *exportJobService) notify(ctx context.Context, job *model.ExportJob) {
+	user, err := s.userRepository.GetUser(ctx, job.CreatedBy)
+	if err != nil || user.Email == "" {
+		return
+	}
+	emailService, err := s.configService.GetEmailService(ctx)
+	if err != nil {
+		s.logger.Debug("邮件服务未配置，跳过导出通知", zap.Error(err))
+		return
+	}
+
+	subject := fmt.Sprintf("导出失败: %s", job.FileName)
+	body := fmt.Sprintf("您发起的导出任务 #%d 执行失败：%s", job.ID, job.Error)
+	if job.Status == model.ExportStatusCompleted {
+		subject = fmt.Sprintf("导出完成: %s", job.FileName)
+		body = fmt.Sprintf("您发起的导出任务 #%d 已完成，共 %d 行，请在导出记录中下载。文件将于 %s 过期。",
+			job.ID, job.Rows, job.ExpiresAt.Format("2006-01-02 15:04"))
+	}
+	if err := emailService.Send(&email.EmailMessage{To: []string{user.Email}, Subject: subject, Body: body}); err != nil {
+		s.logger.Warn("发送导出通知失败", zap.Uint("job_id", job.ID), zap.Error(err))
+		return
+	}
+
+	notifiedAt := time.Now()
+	job.NotifiedAt = &notifiedAt
+	if err := s.exportJobRepository.UpdateExportJob(ctx, job); err != nil {
+		s.logger.Warn("保存导出通知状态失败", zap.Uint("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// PurgeExpired 删除过期导出文件并将任务标记为已过期
+func (s *exportJobService) PurgeExpired(ctx context.Context) (int, error) {
+	purged := 0
+	for {
+		list, err := s.exportJobRepository.GetExpiredExportJobs(ctx, time.Now(), 100)
+		if err != nil {
+			return purged, err
+		}
+		if len(list) == 0 {
+			return purged, nil
+		}
+		for _, job := range list {
+			if err := s.fileService.DeleteFileWithContext(ctx, job.StorageType, job.StorageKey); err != nil {
+				s.logger.Warn("删除过期导出文件失败", zap.Uint("job_id", job.ID), zap.Error(err))
+			}
+			job.Status = model.ExportStatusExpired
+			job.StorageKey = ""
+			if err := s.exportJobRepository.UpdateExportJob(ctx, job); err != nil {
+				return purged, err
+			}
+			purged++
+		}
+	}
+}
+
+// isExportDownloadable 判断导出文件是否可下载
+func isExportDownloadable(job *model.ExportJob) bool {
+	return job.Status == model.ExportStatusCompleted && job.StorageKey != "" &&
+		(job.ExpiresAt == nil || job.ExpiresAt.After(time.Now()))
+}
@@ -0,0 +1,476 @@
+package service
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf16"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// PDF 表格版式（A4 横向，单位 pt）
+const (
+	pdfPageWidth  = 842.0
+	pdfPageHeight = 595.0
+	pdfMargin     = 36.0
+	pdfTitleSize  = 16.0
+	pdfTextSize   = 9.0
+	pdfRowHeight  = 16.0
+	pdfCellPad    = 3.0
+)
+
+// 固定编号的 PDF 对象，其余对象按写出顺序分配编号
+const (
+	pdfCatalogObject = 1
+	pdfPagesObject   = 2
+	pdfFontObject    = 3
+)
+
+var (
+	pdfFontMu       sync.RWMutex
+	pdfEmbeddedFont *pdfFontData
+)
+
+// pdfFontData 已加载的 TrueType 字体
+type pdfFontData struct {
+	data []byte
+	font *sfnt.Font
+}
+
+// SetPDFFont 设置 PDF 导出嵌入的 TrueType 字体（.ttf），data 为空时恢复默认字体
+// 未设置时使用不嵌入的 STSong-Light 字体，中文能否显示取决于阅读器是否自带该字体
+// （Adobe Reader 需安装亚洲语言包，多数浏览器与 Linux 阅读器不支持），
+// 需要稳定显示中日韩文字时应通过 export.pdf_font 配置字体文件
+func SetPDFFont(data []byte) error {
+	var loaded *pdfFontData
+	if len(data) > 0 {
+		f, err := sfnt.Parse(data)
+		if err != nil {
+			return fmt.Errorf("failed to parse pdf font: %w", err)
+		}
+		loaded = &pdfFontData{data: data, font: f}
+	}
+	pdfFontMu.Lock()
+	pdfEmbeddedFont = loaded
+	pdfFontMu.Unlock()
+	return nil
+}
+
+// LoadPDFFont 从文件加载 PDF 导出嵌入的 TrueType 字体
+func LoadPDFFont(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read pdf font: %w", err)
+	}
+	if len(data) == 0 {
+		return errors.New("pdf font file is empty")
+	}
+	return SetPDFFont(data)
+}
+
+// pdfExportWriter 纯 Go 生成的 PDF 表格
+// 每页写满后立即写出内容流，内存中只保留当前页；
+// 页面对象、页码与字体在 Close 时补写，页码作为独立内容流追加到各页
+type pdfExportWriter struct {
+	out       *pdfCountingWriter
+	meta      ExportMeta
+	font      pdfFont
+	colWidth  float64
+	offsets   []int64 // 按对象编号记录偏移，0 表示尚未写出
+	contents  []int   // 各页内容流的对象编号
+	current   *bytes.Buffer
+	pageCount int
+	y         float64
+}
+
+func newPDFExportWriter(w io.Writer, meta ExportMeta) (ExportWriter, error) {
+	columns := len(meta.Labels)
+	if columns == 0 {
+		columns = 1
+	}
+	writer := &pdfExportWriter{
+		out:      &pdfCountingWriter{w: w},
+		meta:     meta,
+		font:     newPDFFont(),
+		colWidth: (pdfPageWidth - 2*pdfMargin) / float64(columns),
+		offsets:  make([]int64, pdfFontObject),
+	}
+	fmt.Fprint(writer.out, "%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	writer.newPage()
+	return writer, writer.out.err
+}
+
+// newObject 分配对象编号
+func (w *pdfExportWriter) newObject() int {
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+// writeObject 写出编号为 num 的对象
+func (w *pdfExportWriter) writeObject(num int, body string) {
+	w.offsets[num-1] = w.out.n
+	fmt.Fprintf(w.out, "%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+// writeStream 写出流对象，返回对象编号
+func (w *pdfExportWriter) writeStream(dict string, data []byte) int {
+	num := w.newObject()
+	w.offsets[num-1] = w.out.n
+	fmt.Fprintf(w.out, "%d 0 obj\n<< /Length %d%s >>\nstream\n", num, len(data), dict)
+	w.out.Write(data)
+	fmt.Fprint(w.out, "\nendstream\nendobj\n")
+	return num
+}
+
+// flushPage 写出当前页的内容流
+func (w *pdfExportWriter) flushPage() {
+	if w.current == nil {
+		return
+	}
+	w.contents = append(w.contents, w.writeStream("", w.current.Bytes()))
+	w.current = nil
+}
+
+// newPage 开始新页面：首页绘制标题、筛选条件与生成时间，每页重复表头
+func (w *pdfExportWriter) newPage() {
+	w.flushPage()
+	w.current = &bytes.Buffer{}
+	w.pageCount++
+	w.y = pdfPageHeight - pdfMargin
+
+	if w.pageCount == 1 {
+		w.y -= pdfTitleSize
+		w.text(pdfMargin, w.y, pdfTitleSize, w.meta.Title)
+		w.y -= pdfRowHeight
+
+		if len(w.meta.Conditions) > 0 {
+			keys := make([]string, 0, len(w.meta.Conditions))
+			for key := range w.meta.Conditions {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			parts := make([]string, 0, len(keys))
+			for _, key := range keys {
+				parts = append(parts, key+": "+w.meta.Conditions[key])
+			}
+			w.text(pdfMargin, w.y, pdfTextSize, w.fit("筛选条件: "+strings.Join(parts, "; "), pdfPageWidth-2*pdfMargin, pdfTextSize))
+			w.y -= pdfRowHeight
+		}
+		w.text(pdfMargin, w.y, pdfTextSize, "生成时间: "+w.meta.GeneratedAt.Format("2006-01-02 15:04:05"))
+		w.y -= pdfRowHeight
+	}
+
+	// 表头底色
+	fmt.Fprintf(w.current, "0.9 g %.2f %.2f %.2f %.2f re f 0 g\n",
+		pdfMargin, w.y-pdfRowHeight, pdfPageWidth-2*pdfMargin, pdfRowHeight)
+	w.row(w.meta.Labels)
+}
+
+// row 绘制一行单元格并在下方画分隔线
+func (w *pdfExportWriter) row(cells []string) {
+	baseline := w.y - pdfRowHeight + (pdfRowHeight-pdfTextSize)/2 + 1
+	for i, cell := range cells {
+		x := pdfMargin + float64(i)*w.colWidth + pdfCellPad
+		w.text(x, baseline, pdfTextSize, w.fit(cell, w.colWidth-2*pdfCellPad, pdfTextSize))
+	}
+	w.y -= pdfRowHeight
+	fmt.Fprintf(w.current, "0.8 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n",
+		pdfMargin, w.y, pdfPageWidth-pdfMargin, w.y)
+}
+
+// text 输出一段文本，编码方式由字体决定
+func (w *pdfExportWriter) text(x, y, size float64, s string) {
+	if s == "" {
+		return
+	}
+	fmt.Fprintf(w.current, "BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n", size, x, y, w.font.encode(s))
+}
+
+// fit 截断超出单元格宽度的文本
+func (w *pdfExportWriter) fit(s string, width, size float64) string {
+	if w.font.width(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && w.font.width(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func (w *pdfExportWriter) WriteRow(values []interface{}) error {
+	// 预留页脚页码的高度
+	if w.y-pdfRowHeight < pdfMargin+pdfRowHeight {
+		w.newPage()
+	}
+	cells := make([]string, len(values))
+	for i, value := range values {
+		cells[i] = strings.ReplaceAll(exportCellString(value), "\n", " ")
+	}
+	w.row(cells)
+	return w.out.err
+}
+
+func (w *pdfExportWriter) Close() error {
+	w.flushPage()
+
+	kids := make([]string, len(w.contents))
+	for i, content := range w.contents {
+		footer := fmt.Sprintf("第 %d / %d 页", i+1, len(w.contents))
+		x := (pdfPageWidth - w.font.width(footer, pdfTextSize)) / 2
+		footerObject := w.writeStream("", []byte(fmt.Sprintf("BT /F1 %.1f Tf %.2f %.2f Td <%s> Tj ET\n",
+			pdfTextSize, x, pdfMargin/2, w.font.encode(footer))))
+
+		page := w.newObject()
+		w.writeObject(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] "+
+			"/Resources << /Font << /F1 %d 0 R >> >> /Contents [%d 0 R %d 0 R] >>",
+			pdfPagesObject, pdfPageWidth, pdfPageHeight, pdfFontObject, content, footerObject))
+		kids[i] = fmt.Sprintf("%d 0 R", page)
+	}
+
+	if err := w.font.writeObjects(w, pdfFontObject); err != nil {
+		return err
+	}
+	w.writeObject(pdfPagesObject, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	w.writeObject(pdfCatalogObject, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObject))
+
+	xref := w.out.n
+	fmt.Fprintf(w.out, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, offset := range w.offsets {
+		fmt.Fprintf(w.out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(w.out, "trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(w.offsets)+1, pdfCatalogObject, xref)
+	return w.out.err
+}
+
+// pdfCountingWriter 记录已写出的字节数，用于生成交叉引用表
+type pdfCountingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *pdfCountingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+// pdfFont PDF 字体：负责文本编码、宽度计算以及在 Close 时写出字体对象
+type pdfFont interface {
+	encode(s string) string
+	width(s string, size float64) float64
+	writeObjects(w *pdfExportWriter, num int) error
+}
+
+// newPDFFont 已配置 TrueType 字体时嵌入该字体，否则使用 STSong-Light
+func newPDFFont() pdfFont {
+	pdfFontMu.RLock()
+	data := pdfEmbeddedFont
+	pdfFontMu.RUnlock()
+	if data == nil {
+		return pdfBuiltinFont{}
+	}
+	return &pdfTrueTypeFont{
+		pdfFontData: data,
+		glyphs:      make(map[sfnt.GlyphIndex]rune),
+		advances:    make(map[sfnt.GlyphIndex]int),
+	}
+}
+
+// pdfBuiltinFont 阅读器内置的 STSong-Light CID 字体，不嵌入字体文件，文本按 UTF-16BE 编码
+type pdfBuiltinFont struct{}
+
+func (pdfBuiltinFont) encode(s string) string {
+	var b strings.Builder
+	for _, unit := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", unit)
+	}
+	return b.String()
+}
+
+// width 估算文本宽度：半角字符按半个字宽计算
+func (pdfBuiltinFont) width(s string, size float64) float64 {
+	width := 0.0
+	for _, r := range s {
+		if r < 0x80 {
+			width += size * 0.5
+		} else {
+			width += size
+		}
+	}
+	return width
+}
+
+func (pdfBuiltinFont) writeObjects(w *pdfExportWriter, num int) error {
+	descendant := w.newObject()
+	descriptor := w.newObject()
+	w.writeObject(num, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /STSong-Light "+
+		"/Encoding /UniGB-UTF16-H /DescendantFonts [%d 0 R] >>", descendant))
+	w.writeObject(descendant, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /STSong-Light "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (GB1) /Supplement 2 >> "+
+		"/FontDescriptor %d 0 R /DW 1000 /W [1 95 500] >>", descriptor))
+	w.writeObject(descriptor, "<< /Type /FontDescriptor /FontName /STSong-Light /Flags 6 /FontBBox [-25 -254 1000 880] "+
+		"/ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>")
+	return nil
+}
+
+// pdfTrueTypeFont 嵌入的 TrueType 字体（CIDFontType2，Identity-H 编码）
+// 文本按字形编号编码，Close 时写出已用字形的宽度与 ToUnicode 映射；字体文件整体压缩嵌入
+type pdfTrueTypeFont struct {
+	*pdfFontData
+	buf      sfnt.Buffer
+	glyphs   map[sfnt.GlyphIndex]rune // 已使用的字形及其字符
+	advances map[sfnt.GlyphIndex]int  // 字形宽度（千分之一字号）
+}
+
+// glyph 查找字符对应的字形，缺失的字形使用 0 号字形（.notdef）
+func (f *pdfTrueTypeFont) glyph(r rune) (sfnt.GlyphIndex, int) {
+	gid, err := f.font.GlyphIndex(&f.buf, r)
+	if err != nil {
+		gid = 0
+	}
+	if advance, ok := f.advances[gid]; ok {
+		return gid, advance
+	}
+	unitsPerEm := fixed.Int26_6(f.font.UnitsPerEm())
+	advance, err := f.font.GlyphAdvance(&f.buf, gid, unitsPerEm<<6, font.HintingNone)
+	width := 0
+	if err == nil && unitsPerEm > 0 {
+		width = int(int64(advance) * 1000 / int64(unitsPerEm<<6))
+	}
+	f.advances[gid] = width
+	return gid, width
+}
+
+func (f *pdfTrueTypeFont) encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		gid, _ := f.glyph(r)
+		if _, ok := f.glyphs[gid]; !ok && gid != 0 {
+			f.glyphs[gid] = r
+		}
+		fmt.Fprintf(&b, "%04X", uint16(gid))
+	}
+	return b.String()
+}
+
+func (f *pdfTrueTypeFont) width(s string, size float64) float64 {
+	width := 0
+	for _, r := range s {
+		_, advance := f.glyph(r)
+		width += advance
+	}
+	return float64(width) * size / 1000
+}
+
+func (f *pdfTrueTypeFont) writeObjects(w *pdfExportWriter, num int) error {
+	name := "EmbeddedFont"
+	if psName, err := f.font.Name(&f.buf, sfnt.NameIDPostScript); err == nil && psName != "" {
+		name = pdfFontName(psName)
+	}
+	gids := make([]sfnt.GlyphIndex, 0, len(f.advances))
+	for gid := range f.advances {
+		gids = append(gids, gid)
+	}
+	sort.Slice(gids, func(i, j int) bool { return gids[i] < gids[j] })
+
+	var widths strings.Builder
+	for _, gid := range gids {
+		fmt.Fprintf(&widths, "%d [%d] ", gid, f.advances[gid])
+	}
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(f.data); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	fontFile := w.writeStream(fmt.Sprintf(" /Length1 %d /Filter /FlateDecode", len(f.data)), compressed.Bytes())
+	toUnicode := w.writeStream("", f.toUnicode(gids))
+
+	unitsPerEm := fixed.Int26_6(f.font.UnitsPerEm()) << 6
+	scale := func(v fixed.Int26_6) int { return int(int64(v) * 1000 / int64(unitsPerEm)) }
+	bounds, err := f.font.Bounds(&f.buf, unitsPerEm, font.HintingNone)
+	if err != nil {
+		return err
+	}
+	metrics, err := f.font.Metrics(&f.buf, unitsPerEm, font.HintingNone)
+	if err != nil {
+		return err
+	}
+	// sfnt 的 Y 轴向下，转换为 PDF 坐标需取反
+	descriptor := w.newObject()
+	w.writeObject(descriptor, fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] "+
+		"/ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		name, scale(bounds.Min.X), -scale(bounds.Max.Y), scale(bounds.Max.X), -scale(bounds.Min.Y),
+		scale(metrics.Ascent), -scale(metrics.Descent), scale(metrics.CapHeight), fontFile))
+
+	descendant := w.newObject()
+	w.writeObject(descendant, fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+		"/FontDescriptor %d 0 R /CIDToGIDMap /Identity /DW 1000 /W [%s] >>", name, descriptor, widths.String()))
+	w.writeObject(num, fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H "+
+		"/DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>", name, descendant, toUnicode))
+	return nil
+}
+
+// toUnicode 生成字形到字符的 ToUnicode CMap，使导出文件可复制与搜索
+func (f *pdfTrueTypeFont) toUnicode(gids []sfnt.GlyphIndex) []byte {
+	var b bytes.Buffer
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	mapped := make([]sfnt.GlyphIndex, 0, len(gids))
+	for _, gid := range gids {
+		if _, ok := f.glyphs[gid]; ok {
+			mapped = append(mapped, gid)
+		}
+	}
+	// 每个 bfchar 段最多 100 项
+	for start := 0; start < len(mapped); start += 100 {
+		end := min(start+100, len(mapped))
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, gid := range mapped[start:end] {
+			var unicode strings.Builder
+			for _, unit := range utf16.Encode([]rune{f.glyphs[gid]}) {
+				fmt.Fprintf(&unicode, "%04X", unit)
+			}
+			fmt.Fprintf(&b, "<%04X> <%s>\n", uint16(gid), unicode.String())
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return b.Bytes()
+}
+
+// pdfFontName 去除 PDF 名称中不允许的字符
+func pdfFontName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r > 0x20 && r < 0x7F && !strings.ContainsRune("()<>[]{}/%#", r) {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return "EmbeddedFont"
+	}
+	return b.String()
+}
@@ -1,10 +1,12 @@
 package service
 
 import (
-	"bytes"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
-	"strconv"
+	"io"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/xuri/excelize/v2"
@@ -19,114 +21,300 @@ func NewExportService() *ExportService {
 	return &ExportService{}
 }
 
-// ExportToCSV 导出数据到 CSV 格式
-func (s *ExportService) ExportToCSV(data []map[string]interface{}, headers map[string]string) ([]byte, error) {
-	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
-	defer writer.Flush()
+// ExportWriter 流式导出写入器，逐行写出，Close 时完成文件
+type ExportWriter interface {
+	WriteRow(values []interface{}) error
+	Close() error
+}
 
-	// 写入表头
-	if len(headers) > 0 {
-		headerRow := make([]string, 0, len(headers))
-		for _, label := range headers {
-			headerRow = append(headerRow, label)
-		}
-		if err := writer.Write(headerRow); err != nil {
-			return nil, fmt.Errorf("写入CSV表头失败: %w", err)
-		}
+// ExportMeta 导出元信息，由导出格式决定如何使用
+type ExportMeta struct {
+	Title       string            // 资源标题
+	Names       []string          // 列字段名
+	Labels      []string          // 列标题
+	Conditions  map[string]string // 已应用的过滤/搜索条件（标题 -> 值）
+	GeneratedAt time.Time         // 生成时间
+}
+
+// Exporter 导出格式定义，MIME 类型与扩展名由注册表统一管理
+type Exporter struct {
+	Name        string   // 格式名称，对应请求参数 format
+	Aliases     []string // 格式别名
+	Extension   string   // 文件扩展名
+	ContentType string   // MIME 类型
+	Typed       bool     // 为 true 时写入原始类型值，否则写入格式化后的展示文本
+	New         func(w io.Writer, meta ExportMeta) (ExportWriter, error)
+}
+
+var (
+	exportersMu sync.RWMutex
+	exporters   = make(map[string]*Exporter)
+)
+
+// RegisterExporter 注册导出格式，同名格式会被覆盖
+func RegisterExporter(exporter Exporter) {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+	e := exporter
+	exporters[strings.ToLower(e.Name)] = &e
+	for _, alias := range e.Aliases {
+		exporters[strings.ToLower(alias)] = &e
 	}
+}
 
-	// 写入数据行
-	for _, row := range data {
-		dataRow := make([]string, 0, len(headers))
-		for fieldName := range headers {
-			value := row[fieldName]
-			if value == nil {
-				dataRow = append(dataRow, "")
-			} else {
-				// 处理布尔值
-				if b, ok := value.(bool); ok {
-					if b {
-						dataRow = append(dataRow, "是")
-					} else {
-						dataRow = append(dataRow, "否")
-					}
-				} else {
-					dataRow = append(dataRow, fmt.Sprintf("%v", value))
-				}
-			}
-		}
-		if err := writer.Write(dataRow); err != nil {
-			return nil, fmt.Errorf("写入CSV数据行失败: %w", err)
+func init() {
+	RegisterExporter(Exporter{
+		Name:        "csv",
+		Extension:   "csv",
+		ContentType: "text/csv; charset=utf-8",
+		New:         newCSVExportWriter,
+	})
+	RegisterExporter(Exporter{
+		Name:        "xlsx",
+		Aliases:     []string{"excel"},
+		Extension:   "xlsx",
+		ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		New:         newXLSXExportWriter,
+	})
+	RegisterExporter(Exporter{
+		Name:        "json",
+		Extension:   "json",
+		ContentType: "application/json",
+		Typed:       true,
+		New:         newJSONExportWriter,
+	})
+	RegisterExporter(Exporter{
+		Name:        "ndjson",
+		Aliases:     []string{"jsonl"},
+		Extension:   "ndjson",
+		ContentType: "application/x-ndjson",
+		Typed:       true,
+		New:         newNDJSONExportWriter,
+	})
+	RegisterExporter(Exporter{
+		Name:        "pdf",
+		Extension:   "pdf",
+		ContentType: "application/pdf",
+		New:         newPDFExportWriter,
+	})
+}
+
+// GetExporter 按名称或别名查找导出格式，空名称默认为 csv
+func (s *ExportService) GetExporter(format string) (*Exporter, bool) {
+	if format == "" {
+		format = "csv"
+	}
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	exporter, ok := exporters[strings.ToLower(format)]
+	return exporter, ok
+}
+
+// Formats 返回已注册的导出格式名称
+func (s *ExportService) Formats() []string {
+	exportersMu.RLock()
+	defer exportersMu.RUnlock()
+	seen := make(map[string]struct{})
+	var names []string
+	for _, exporter := range exporters {
+		if _, ok := seen[exporter.Name]; ok {
+			continue
 		}
+		seen[exporter.Name] = struct{}{}
+		names = append(names, exporter.Name)
 	}
+	return names
+}
+
+// csvExportWriter 直接写入底层 Writer 的 CSV 写入器
+type csvExportWriter struct {
+	writer *csv.Writer
+}
 
-	return buf.Bytes(), nil
+func newCSVExportWriter(w io.Writer, meta ExportMeta) (ExportWriter, error) {
+	writer := &csvExportWriter{writer: csv.NewWriter(w)}
+	if err := writer.writer.Write(meta.Labels); err != nil {
+		return nil, fmt.Errorf("写入CSV表头失败: %w", err)
+	}
+	return writer, nil
 }
 
-// ExportToExcel 导出数据到 Excel 格式
-func (s *ExportService) ExportToExcel(data []map[string]interface{}, headers map[string]string) ([]byte, error) {
+func (w *csvExportWriter) WriteRow(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = exportCellString(value)
+	}
+	if err := w.writer.Write(record); err != nil {
+		return fmt.Errorf("写入CSV数据行失败: %w", err)
+	}
+	return nil
+}
+
+func (w *csvExportWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+// xlsxExportWriter 基于 excelize StreamWriter 的写入器
+// 表头使用加粗底色样式并冻结首行，便于作为模板二次编辑
+type xlsxExportWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXExportWriter(out io.Writer, meta ExportMeta) (ExportWriter, error) {
 	f := excelize.NewFile()
-	defer func() {
-		if err := f.Close(); err != nil {
-			// 关闭Excel文件失败，忽略错误
+	sheetName := "数据导出"
+	if err := f.SetSheetName("Sheet1", sheetName); err != nil {
+		f.Close()
+		return nil, err
+	}
+	stream, err := f.NewStreamWriter(sheetName)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("创建Excel写入器失败: %w", err)
+	}
+	if len(meta.Labels) > 0 {
+		if err := stream.SetColWidth(1, len(meta.Labels), 20); err != nil {
+			f.Close()
+			return nil, err
 		}
-	}()
+	}
+	if err := stream.SetPanes(&excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		f.Close()
+		return nil, err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#E6E6E6"}},
+	})
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
 
-	// 创建工作表
-	sheetName := "数据导出"
-	f.SetSheetName("Sheet1", sheetName)
-
-	// 写入表头
-	colIndex := 0
-	for _, label := range headers {
-		cell, _ := excelize.CoordinatesToCellName(colIndex+1, 1)
-		f.SetCellValue(sheetName, cell, label)
-		colIndex++
-	}
-
-	// 写入数据行
-	for rowIndex, row := range data {
-		colIndex = 0
-		for fieldName := range headers {
-			cell, _ := excelize.CoordinatesToCellName(colIndex+1, rowIndex+2)
-			value := row[fieldName]
-			if value == nil {
-				f.SetCellValue(sheetName, cell, "")
-			} else {
-				// 处理布尔值
-				if b, ok := value.(bool); ok {
-					if b {
-						f.SetCellValue(sheetName, cell, "是")
-					} else {
-						f.SetCellValue(sheetName, cell, "否")
-					}
-				} else {
-					// 处理数字
-					if fValue, err := strconv.ParseFloat(fmt.Sprintf("%v", value), 64); err == nil {
-						f.SetCellValue(sheetName, cell, fValue)
-					} else {
-						f.SetCellValue(sheetName, cell, value)
-					}
-				}
-			}
-			colIndex++
+	writer := &xlsxExportWriter{out: out, file: f, stream: stream, row: 1}
+	header := make([]interface{}, len(meta.Labels))
+	for i, label := range meta.Labels {
+		header[i] = excelize.Cell{StyleID: headerStyle, Value: label}
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("写入Excel表头失败: %w", err)
+	}
+	return writer, nil
+}
+
+func (w *xlsxExportWriter) WriteRow(values []interface{}) error {
+	w.row++
+	cell, err := excelize.CoordinatesToCellName(1, w.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+			row[i] = ""
+		case []byte:
+			row[i] = string(v)
+		default:
+			row[i] = v
+		}
+	}
+	if err := w.stream.SetRow(cell, row); err != nil {
+		return fmt.Errorf("写入Excel数据行失败: %w", err)
+	}
+	return nil
+}
+
+func (w *xlsxExportWriter) Close() error {
+	defer w.file.Close()
+	if err := w.stream.Flush(); err != nil {
+		return fmt.Errorf("保存Excel文件失败: %w", err)
+	}
+	if err := w.file.Write(w.out); err != nil {
+		return fmt.Errorf("保存Excel文件失败: %w", err)
+	}
+	return nil
+}
+
+// jsonExportWriter 以 JSON 数组输出，每行一个对象
+type jsonExportWriter struct {
+	out   io.Writer
+	names []string
+	rows  int
+	lines bool // NDJSON：每行一个对象，不包裹数组
+}
+
+func newJSONExportWriter(w io.Writer, meta ExportMeta) (ExportWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonExportWriter{out: w, names: meta.Names}, nil
+}
+
+func newNDJSONExportWriter(w io.Writer, meta ExportMeta) (ExportWriter, error) {
+	return &jsonExportWriter{out: w, names: meta.Names, lines: true}, nil
+}
+
+func (w *jsonExportWriter) WriteRow(values []interface{}) error {
+	// 按列顺序输出对象字段
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, _ := json.Marshal(w.names[i])
+		b.Write(key)
+		b.WriteByte(':')
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("序列化字段 %s 失败: %w", w.names[i], err)
 		}
+		b.Write(encoded)
 	}
+	b.WriteByte('}')
 
-	// 自动调整列宽
-	for i := 0; i < len(headers); i++ {
-		colName, _ := excelize.ColumnNumberToName(i + 1)
-		f.SetColWidth(sheetName, colName, colName, 20)
+	prefix := ""
+	if w.lines {
+		b.WriteByte('\n')
+	} else if w.rows > 0 {
+		prefix = ","
 	}
+	w.rows++
+	_, err := io.WriteString(w.out, prefix+b.String())
+	return err
+}
 
-	// 保存到字节流
-	var buf bytes.Buffer
-	if err := f.Write(&buf); err != nil {
-		return nil, fmt.Errorf("保存Excel文件失败: %w", err)
+func (w *jsonExportWriter) Close() error {
+	if w.lines {
+		return nil
 	}
+	_, err := io.WriteString(w.out, "]")
+	return err
+}
 
-	return buf.Bytes(), nil
+// exportCellString 将单元格值转换为文本
+func exportCellString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format("2006-01-02 15:04:05")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
 }
 
 // GenerateFileName 生成导出文件名
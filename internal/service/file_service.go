@@ -2,10 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"fun-admin/pkg/logger"
 	"fun-admin/pkg/storage"
+	"io"
 	"io/fs"
+	"mime"
 	"mime/multipart"
 	"path/filepath"
 	"sort"
@@ -143,13 +147,25 @@ func (s *FileService) UploadFileWithOptions(ctx context.Context, file *multipart
 	}
 	defer src.Close()
 
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	contentType := file.Header.Get("Content-Type")
+	if contentType == "" || contentType == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(ext); byExt != "" {
+			contentType = byExt
+		}
+	}
+
+	// 上传同时计算 SHA-256 校验和
+	hasher := sha256.New()
 	key := s.buildFileKey(pathPrefix, file.Filename)
-	fileInfo, err := store.Upload(ctx, key, src, file.Header.Get("Content-Type"))
+	fileInfo, err := store.Upload(ctx, key, io.TeeReader(src, hasher), contentType)
 	if err != nil {
 		return nil, fmt.Errorf("上传文件失败: %w", err)
 	}
+	if fileInfo.ContentType == "" {
+		fileInfo.ContentType = contentType
+	}
 
-	ext := strings.ToLower(filepath.Ext(file.Filename))
 	return &FileInfo{
 		Name:        file.Filename,
 		Path:        key,
@@ -159,9 +175,47 @@ func (s *FileService) UploadFileWithOptions(ctx context.Context, file *multipart
 		StorageType: diskName,
 		ContentType: fileInfo.ContentType,
 		ETag:        fileInfo.ETag,
+		Checksum:    hex.EncodeToString(hasher.Sum(nil)),
+	}, nil
+}
+
+// SaveFileWithContext 将内容写入指定存储（用于服务端生成的文件）
+func (s *FileService) SaveFileWithContext(ctx context.Context, storageType, fileKey string, reader io.Reader, contentType string) (*FileInfo, error) {
+	if fileKey == "" {
+		return nil, fmt.Errorf("文件标识不能为空")
+	}
+	store, diskName, err := s.resolveStorage(storageType)
+	if err != nil {
+		return nil, err
+	}
+	fileInfo, err := store.Upload(ctx, fileKey, reader, contentType)
+	if err != nil {
+		return nil, fmt.Errorf("保存文件失败: %w", err)
+	}
+	return &FileInfo{
+		Name:        filepath.Base(fileKey),
+		Path:        fileKey,
+		URL:         fileInfo.URL,
+		Size:        fileInfo.Size,
+		Ext:         strings.ToLower(filepath.Ext(fileKey)),
+		StorageType: diskName,
+		ContentType: contentType,
+		ETag:        fileInfo.ETag,
 	}, nil
 }
 
+// OpenFileWithContext 打开指定存储中的文件，调用方负责关闭
+func (s *FileService) OpenFileWithContext(ctx context.Context, storageType, fileKey string) (io.ReadCloser, error) {
+	if fileKey == "" {
+		return nil, fmt.Errorf("文件标识不能为空")
+	}
+	store, _, err := s.resolveStorage(storageType)
+	if err != nil {
+		return nil, err
+	}
+	return store.Download(ctx, fileKey)
+}
+
 // DeleteFile 删除默认存储的文件
 func (s *FileService) DeleteFile(fileKey string) error {
 	return s.DeleteFileWithContext(context.Background(), "", fileKey)
@@ -385,6 +439,13 @@ type FileInfo struct {
 	StorageType string `json:"storage_type"`
 	ContentType string `json:"content_type"`
 	ETag        string `json:"etag"`
+	Checksum    string `json:"checksum,omitempty"`
+}
+
+// SignedURL 带有效期的签名下载地址
+type SignedURL struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 type fileEntry struct {
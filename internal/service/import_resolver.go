@@ -0,0 +1,228 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// importBoolWords 导入时可识别的布尔词
+var importBoolWords = map[string]bool{
+	"1": true, "true": true, "yes": true, "y": true, "on": true, "t": true,
+	"是": true, "启用": true, "开启": true, "有效": true, "正常": true, "√": true,
+	"0": false, "false": false, "no": false, "n": false, "off": false, "f": false,
+	"否": false, "禁用": false, "关闭": false, "无效": false, "停用": false, "×": false,
+}
+
+// importTimeLayouts 导入时可识别的日期时间格式，按从具体到宽松的顺序尝试
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"2006.01.02",
+	"2006年1月2日 15:04:05",
+	"2006年1月2日",
+	"20060102",
+	"01-02-06",
+	"1/2/06 15:04",
+	"1/2/06",
+}
+
+// ImportValueResolver 按字段声明解析导入单元格
+// 关联字段按关联资源的 DisplayField 查找ID，选择字段按选项/字典标签映射为值，
+// 布尔、数字、日期按字段类型转换；同一导入内的关联与字典查找结果会被缓存
+type ImportValueResolver struct {
+	resourceRepository *repository.ResourceRepository
+	dictionaryService  *DictionaryService
+	fields             map[string]admin.Field
+	cache              map[string]map[string]interface{}
+}
+
+// NewValueResolver 为资源创建导入值解析器
+func (s *ImportService) NewValueResolver(resource admin.Resource) *ImportValueResolver {
+	fields := make(map[string]admin.Field)
+	for _, field := range resource.GetFields() {
+		fields[field.GetName()] = field
+	}
+	return &ImportValueResolver{
+		resourceRepository: s.resourceRepository,
+		dictionaryService:  s.dictionaryService,
+		fields:             fields,
+		cache:              make(map[string]map[string]interface{}),
+	}
+}
+
+// Resolve 解析单元格值，未声明的字段按内容推断类型
+func (r *ImportValueResolver) Resolve(ctx context.Context, fieldName, raw string) (interface{}, error) {
+	field, ok := r.fields[fieldName]
+	if !ok {
+		return convertValue(raw), nil
+	}
+	raw = strings.TrimSpace(raw)
+
+	switch f := field.(type) {
+	case *admin.RelationshipField:
+		if raw == "" {
+			return nil, nil
+		}
+		return r.cached(fieldName, raw, func() (interface{}, error) {
+			return r.resolveRelation(ctx, f, raw)
+		})
+	case *admin.SelectField:
+		if raw == "" {
+			return nil, nil
+		}
+		return r.cached(fieldName, raw, func() (interface{}, error) {
+			return r.resolveOption(ctx, f, raw)
+		})
+	}
+
+	switch field.GetType() {
+	case "boolean":
+		if raw == "" {
+			return nil, nil
+		}
+		return parseImportBool(raw)
+	case "number":
+		if raw == "" {
+			return nil, nil
+		}
+		return parseImportNumber(raw)
+	case "date":
+		if raw == "" {
+			return nil, nil
+		}
+		t, err := parseImportTime(raw)
+		if err != nil {
+			return nil, err
+		}
+		return t.Format("2006-01-02"), nil
+	case "datetime":
+		if raw == "" {
+			return nil, nil
+		}
+		return parseImportTime(raw)
+	default:
+		return raw, nil
+	}
+}
+
+// cached 读取或写入字段级解析缓存
+func (r *ImportValueResolver) cached(fieldName, raw string, resolve func() (interface{}, error)) (interface{}, error) {
+	if values, ok := r.cache[fieldName]; ok {
+		if value, ok := values[raw]; ok {
+			return value, nil
+		}
+	} else {
+		r.cache[fieldName] = make(map[string]interface{})
+	}
+	value, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+	r.cache[fieldName][raw] = value
+	return value, nil
+}
+
+// resolveRelation 按展示字段查找关联记录，找不到时将纯数字视为ID
+func (r *ImportValueResolver) resolveRelation(ctx context.Context, field *admin.RelationshipField, raw string) (interface{}, error) {
+	if field.DisplayField != "" {
+		record, err := r.resourceRepository.FindByField(ctx, field.RelatedResource, field.DisplayField, raw)
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > 0 {
+			return record["id"], nil
+		}
+	}
+	if id, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		record, err := r.resourceRepository.FindByField(ctx, field.RelatedResource, "id", id)
+		if err != nil {
+			return nil, err
+		}
+		if len(record) > 0 {
+			return record["id"], nil
+		}
+	}
+	return nil, fmt.Errorf("未找到关联记录: %s", raw)
+}
+
+// resolveOption 将选项值或标签映射为选项值，支持字典选项
+func (r *ImportValueResolver) resolveOption(ctx context.Context, field *admin.SelectField, raw string) (interface{}, error) {
+	options := field.Options
+	if field.DictCode != "" {
+		data, err := r.dictionaryService.GetDictByCode(ctx, field.DictCode)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range data {
+			options = append(options, admin.Option{Value: item.Value, Label: item.Label})
+		}
+	}
+	if len(options) == 0 {
+		return raw, nil
+	}
+	for _, option := range options {
+		if option.Value == raw {
+			return option.Value, nil
+		}
+	}
+	for _, option := range options {
+		if strings.EqualFold(strings.TrimSpace(option.Label), raw) {
+			return option.Value, nil
+		}
+		// 导入模板使用本地化选项标签
+		for _, language := range i18n.GlobalResourceManager.GetLanguages() {
+			if strings.EqualFold(strings.TrimSpace(admin.TranslateLabel(option.Label, language)), raw) {
+				return option.Value, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("无效的选项: %s", raw)
+}
+
+// parseImportBool 解析布尔词
+func parseImportBool(raw string) (bool, error) {
+	if value, ok := importBoolWords[strings.ToLower(raw)]; ok {
+		return value, nil
+	}
+	return false, fmt.Errorf("无效的布尔值: %s", raw)
+}
+
+// parseImportNumber 解析数字，允许千分位分隔符
+func parseImportNumber(raw string) (interface{}, error) {
+	cleaned := strings.NewReplacer(",", "", "，", "", "_", "", " ", "").Replace(raw)
+	if intVal, err := strconv.ParseInt(cleaned, 10, 64); err == nil {
+		return intVal, nil
+	}
+	if floatVal, err := strconv.ParseFloat(cleaned, 64); err == nil {
+		return floatVal, nil
+	}
+	return nil, fmt.Errorf("无效的数字: %s", raw)
+}
+
+// parseImportTime 按常见格式解析时间，最后尝试 Excel 日期序列号
+func parseImportTime(raw string) (time.Time, error) {
+	for _, layout := range importTimeLayouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	if serial, err := strconv.ParseFloat(raw, 64); err == nil && serial > 0 {
+		if t, err := excelize.ExcelDateToTime(serial, false); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("无效的日期: %s", raw)
+}
@@ -1,11 +1,14 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
 	"fun-admin/pkg/logger"
 	"io"
 	"mime/multipart"
@@ -14,38 +17,112 @@ import (
 	"time"
 
 	"github.com/xuri/excelize/v2"
+	"go.uber.org/zap"
 )
 
+// 导入模式
+const (
+	ImportModeInsert = "insert" // 仅新增
+	ImportModeUpdate = "update" // 按键更新，键不存在时报错
+	ImportModeUpsert = "upsert" // 按键更新，键不存在时新增
+)
+
+// 导入事务模式
+const (
+	ImportTransactionSingle = "single" // 整个导入一个事务，任一行失败全部回滚
+	ImportTransactionBatch  = "batch"  // 每批一个事务，失败批次逐行重试
+)
+
+// 导入行动作
+const (
+	ImportActionCreate = "create"
+	ImportActionUpdate = "update"
+)
+
+// AsyncImportThreshold 超过该大小的文件自动转为异步导入
+const AsyncImportThreshold = 5 * 1024 * 1024
+
+// maxImportPreviewRows 试运行预览最多返回的行数
+const maxImportPreviewRows = 100
+
+const (
+	// importHeartbeatInterval 执行中任务刷新心跳的间隔
+	importHeartbeatInterval = 30 * time.Second
+	// importStaleTimeout 心跳超过该时长未刷新的任务视为已中断
+	importStaleTimeout = 2 * time.Minute
+)
+
+// errImportRollback 单事务模式下存在失败行时用于触发回滚
+var errImportRollback = errors.New("import rolled back")
+
 // ImportService 数据导入服务
 type ImportService struct {
-	logger *logger.Logger
+	logger             *logger.Logger
+	tm                 repository.Transaction
+	importJobRepo      repository.ImportJobRepository
+	resourceRepository *repository.ResourceRepository
+	dictionaryService  *DictionaryService
+	fileService        *FileService
 }
 
 // NewImportService 创建导入服务
-func NewImportService(logger *logger.Logger) *ImportService {
+func NewImportService(
+	logger *logger.Logger,
+	tm repository.Transaction,
+	importJobRepo repository.ImportJobRepository,
+	resourceRepository *repository.ResourceRepository,
+	dictionaryService *DictionaryService,
+	fileService *FileService,
+) *ImportService {
 	return &ImportService{
-		logger: logger,
+		logger:             logger,
+		tm:                 tm,
+		importJobRepo:      importJobRepo,
+		resourceRepository: resourceRepository,
+		dictionaryService:  dictionaryService,
+		fileService:        fileService,
 	}
 }
 
 // ImportOption 导入选项
 type ImportOption struct {
-	HasHeader    bool                                 // 是否包含表头
-	SheetName    string                               // 工作表名称（Excel）
-	StartRow     int                                  // 开始行（从0开始）
-	FieldMapping map[string]string                    // 字段映射：列名 -> 字段名
-	DataHandler  func([]map[string]interface{}) error // 数据处理器
-	ValidateFunc func(map[string]interface{}) error   // 数据验证函数
-	BatchSize    int                                  // 批量处理大小
+	HasHeader       bool                                                                // 是否包含表头
+	SheetName       string                                                              // 工作表名称（Excel）
+	StartRow        int                                                                 // 开始行（从0开始）
+	FieldMapping    map[string]string                                                   // 字段映射：列名 -> 字段名
+	ConvertFunc     func(ctx context.Context, field, value string) (interface{}, error) // 单元格值转换，为空时按内容推断类型
+	Mode            string                                                              // 导入模式 insert/update/upsert
+	KeyField        string                                                              // update/upsert 匹配字段
+	DryRun          bool                                                                // 试运行：仅校验并返回预览，不写入
+	TransactionMode string                                                              // 事务模式 single/batch
+	PlanFunc        func(ctx context.Context, row *ImportRow) error                     // 判定行动作（新增/更新）
+	DataHandler     func(ctx context.Context, batch []*ImportRow) error                 // 数据处理器
+	ValidateFunc    func(map[string]interface{}) error                                  // 数据验证函数
+	Progress        func(processed int)                                                 // 进度回调
+	BatchSize       int                                                                 // 批量处理大小
+}
+
+// ImportRow 待导入的数据行
+type ImportRow struct {
+	Row    int                    // 源文件行号（从1开始）
+	Action string                 // create/update
+	Key    interface{}            // update 时目标记录ID
+	Data   map[string]interface{} // 字段数据
 }
 
 // ImportResult 导入结果
 type ImportResult struct {
-	TotalRows   int           `json:"total_rows"`   // 总行数
-	SuccessRows int           `json:"success_rows"` // 成功行数
-	FailedRows  int           `json:"failed_rows"`  // 失败行数
-	Errors      []ImportError `json:"errors"`       // 错误信息
-	ProcessedAt time.Time     `json:"processed_at"` // 处理时间
+	JobID       uint            `json:"job_id,omitempty"` // 导入任务ID
+	TotalRows   int             `json:"total_rows"`       // 总行数
+	SuccessRows int             `json:"success_rows"`     // 成功行数
+	CreatedRows int             `json:"created_rows"`     // 新增行数
+	UpdatedRows int             `json:"updated_rows"`     // 更新行数
+	FailedRows  int             `json:"failed_rows"`      // 失败行数
+	DryRun      bool            `json:"dry_run"`          // 是否试运行
+	RolledBack  bool            `json:"rolled_back"`      // 单事务模式下是否已回滚
+	Errors      []ImportError   `json:"errors"`           // 错误信息
+	Preview     []ImportPreview `json:"preview"`          // 试运行预览
+	ProcessedAt time.Time       `json:"processed_at"`     // 处理时间
 }
 
 // ImportError 导入错误
@@ -56,6 +133,14 @@ type ImportError struct {
 	Error  string `json:"error"`  // 错误信息
 }
 
+// ImportPreview 试运行预览行
+type ImportPreview struct {
+	Row    int                    `json:"row"`
+	Action string                 `json:"action"`
+	Key    interface{}            `json:"key,omitempty"`
+	Data   map[string]interface{} `json:"data"`
+}
+
 // ImportExcel 导入Excel文件
 func (s *ImportService) ImportExcel(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
 	return s.ImportExcelWithContext(ctx, file, option)
@@ -63,6 +148,238 @@ func (s *ImportService) ImportExcel(ctx context.Context, file *multipart.FileHea
 
 // ImportExcelWithContext 带上下文的Excel导入
 func (s *ImportService) ImportExcelWithContext(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+	rows, err := s.readExcelRows(file, option)
+	if err != nil {
+		return nil, err
+	}
+	return s.processImport(ctx, rows, option, "excel")
+}
+
+// ImportCSV 导入CSV文件
+func (s *ImportService) ImportCSV(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+	return s.ImportCSVWithContext(ctx, file, option)
+}
+
+// ImportCSVWithContext 带上下文的CSV导入
+func (s *ImportService) ImportCSVWithContext(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+	rows, err := s.readCSVRows(file)
+	if err != nil {
+		return nil, err
+	}
+	return s.processImport(ctx, rows, option, "csv")
+}
+
+// ImportJSON 导入JSON文件
+func (s *ImportService) ImportJSON(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+	return s.ImportJSONWithContext(ctx, file, option)
+}
+
+// ImportJSONWithContext 带上下文的JSON导入
+func (s *ImportService) ImportJSONWithContext(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+	rows, err := s.readJSONRows(file, option)
+	if err != nil {
+		return nil, err
+	}
+	return s.processImport(ctx, rows, option, "json")
+}
+
+// ReadRows 按文件类型读取原始行（异步导入前需在请求内完成读取）
+func (s *ImportService) ReadRows(file *multipart.FileHeader, fileType string, option ImportOption) ([][]string, error) {
+	switch fileType {
+	case "excel":
+		return s.readExcelRows(file, option)
+	case "csv":
+		return s.readCSVRows(file)
+	case "json":
+		return s.readJSONRows(file, option)
+	default:
+		return nil, fmt.Errorf("不支持的文件类型: %s", fileType)
+	}
+}
+
+// CreateJob 创建导入任务记录
+func (s *ImportService) CreateJob(ctx context.Context, resourceSlug, fileName, fileType string, option ImportOption, async bool, userID uint) (*model.ImportJob, error) {
+	job := &model.ImportJob{
+		ResourceSlug:    resourceSlug,
+		FileName:        fileName,
+		FileType:        fileType,
+		Mode:            option.Mode,
+		KeyField:        option.KeyField,
+		TransactionMode: option.TransactionMode,
+		DryRun:          option.DryRun,
+		Async:           async,
+		Status:          model.ImportStatusPending,
+		CreatedBy:       userID,
+	}
+	if err := s.importJobRepo.CreateImportJob(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RunJob 执行导入任务，记录进度、结果与错误报告
+func (s *ImportService) RunJob(ctx context.Context, job *model.ImportJob, rows [][]string, option ImportOption) (*ImportResult, error) {
+	startedAt := time.Now()
+	job.Status = model.ImportStatusRunning
+	job.StartedAt = &startedAt
+	job.TotalRows = countDataRows(rows, option)
+	if err := s.importJobRepo.UpdateImportJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	heartbeatCtx, stopHeartbeat := context.WithCancel(ctx)
+	defer stopHeartbeat()
+	go s.keepAlive(heartbeatCtx, job.ID)
+
+	option.Progress = func(processed int) {
+		if err := s.importJobRepo.UpdateImportProgress(ctx, job.ID, processed); err != nil {
+			s.logger.Warn("更新导入进度失败", zap.Uint("job_id", job.ID), zap.Error(err))
+		}
+	}
+
+	result, err := s.processImport(ctx, rows, option, job.FileType)
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	if err != nil {
+		job.Status = model.ImportStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = model.ImportStatusCompleted
+	}
+	if result != nil {
+		result.JobID = job.ID
+		job.ProcessedRows = result.TotalRows
+		job.CreatedRows = result.CreatedRows
+		job.UpdatedRows = result.UpdatedRows
+		job.FailedRows = result.FailedRows
+		if len(result.Errors) > 0 {
+			if key, reportErr := s.saveErrorReport(ctx, job, rows, option, result.Errors); reportErr != nil {
+				s.logger.Warn("保存导入错误报告失败", zap.Uint("job_id", job.ID), zap.Error(reportErr))
+			} else {
+				job.ErrorReportKey = key
+			}
+		}
+	}
+	if updateErr := s.importJobRepo.UpdateImportJob(ctx, job); updateErr != nil {
+		s.logger.Warn("保存导入任务失败", zap.Uint("job_id", job.ID), zap.Error(updateErr))
+	}
+	return result, err
+}
+
+// RunJobAsync 在后台执行导入任务，执行中 panic 时将任务标记为失败
+func (s *ImportService) RunJobAsync(ctx context.Context, job *model.ImportJob, rows [][]string, option ImportOption) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				s.logger.Error("导入任务异常", zap.Uint("job_id", job.ID), zap.Any("panic", r), zap.Stack("stack"))
+				s.failJob(ctx, job, fmt.Sprintf("导入任务异常: %v", r))
+			}
+		}()
+		_, _ = s.RunJob(ctx, job, rows, option)
+	}()
+}
+
+// RecoverJobs 将心跳已超时的待执行、执行中任务标记为失败，用于服务重启后清理中断的任务
+func (s *ImportService) RecoverJobs(ctx context.Context) error {
+	count, err := s.importJobRepo.FailStaleImportJobs(ctx, time.Now().Add(-importStaleTimeout), "导入任务已中断（服务重启或进程退出），请重新导入")
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		s.logger.Info("已标记中断的导入任务为失败", zap.Int64("count", count))
+	}
+	return nil
+}
+
+// keepAlive 定期刷新任务心跳，直到 ctx 取消
+func (s *ImportService) keepAlive(ctx context.Context, id uint) {
+	ticker := time.NewTicker(importHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.importJobRepo.TouchImportJob(ctx, id); err != nil {
+				s.logger.Warn("刷新导入任务心跳失败", zap.Uint("job_id", id), zap.Error(err))
+			}
+		}
+	}
+}
+
+// failJob 将任务标记为失败
+func (s *ImportService) failJob(ctx context.Context, job *model.ImportJob, reason string) {
+	finishedAt := time.Now()
+	job.Status = model.ImportStatusFailed
+	job.Error = reason
+	job.FinishedAt = &finishedAt
+	if err := s.importJobRepo.UpdateImportJob(ctx, job); err != nil {
+		s.logger.Warn("保存导入任务失败", zap.Uint("job_id", job.ID), zap.Error(err))
+	}
+}
+
+// GetJobs 获取导入历史
+func (s *ImportService) GetJobs(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ImportJob, int64, error) {
+	return s.importJobRepo.GetImportJobs(ctx, page, pageSize, filters)
+}
+
+// GetJob 获取导入任务
+func (s *ImportService) GetJob(ctx context.Context, id uint) (*model.ImportJob, error) {
+	return s.importJobRepo.GetImportJob(ctx, id)
+}
+
+// OpenErrorReport 打开导入任务的错误报告
+func (s *ImportService) OpenErrorReport(ctx context.Context, job *model.ImportJob) (io.ReadCloser, error) {
+	if job.ErrorReportKey == "" {
+		return nil, errors.New("该导入任务没有错误报告")
+	}
+	return s.fileService.OpenFileWithContext(ctx, "", job.ErrorReportKey)
+}
+
+// saveErrorReport 生成错误报告：原始行 + 错误列，写入存储
+func (s *ImportService) saveErrorReport(ctx context.Context, job *model.ImportJob, rows [][]string, option ImportOption, importErrors []ImportError) (string, error) {
+	messages := make(map[int][]string)
+	for _, e := range importErrors {
+		if e.Column != "" {
+			messages[e.Row] = append(messages[e.Row], e.Column+": "+e.Error)
+		} else {
+			messages[e.Row] = append(messages[e.Row], e.Error)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("\xEF\xBB\xBF")
+	writer := csv.NewWriter(&buf)
+	if option.HasHeader && len(rows) > 0 {
+		if err := writer.Write(append(append([]string{}, rows[0]...), "错误信息")); err != nil {
+			return "", err
+		}
+	}
+	for rowIndex := dataStartRow(option); rowIndex < len(rows); rowIndex++ {
+		msgs, ok := messages[rowIndex+1]
+		if !ok {
+			continue
+		}
+		record := append(append([]string{}, rows[rowIndex]...), strings.Join(msgs, "; "))
+		if err := writer.Write(record); err != nil {
+			return "", err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("imports/errors/%d_%s.csv", job.ID, time.Now().Format("20060102150405"))
+	if _, err := s.fileService.SaveFileWithContext(ctx, "", key, &buf, "text/csv"); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+// readExcelRows 读取Excel工作表
+func (s *ImportService) readExcelRows(file *multipart.FileHeader, option ImportOption) ([][]string, error) {
 	// 打开Excel文件
 	src, err := file.Open()
 	if err != nil {
@@ -90,18 +407,11 @@ func (s *ImportService) ImportExcelWithContext(ctx context.Context, file *multip
 	if len(rows) == 0 {
 		return nil, errors.New("Excel文件为空")
 	}
-
-	// 处理导入
-	return s.processImport(ctx, rows, option, "excel")
+	return rows, nil
 }
 
-// ImportCSV 导入CSV文件
-func (s *ImportService) ImportCSV(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
-	return s.ImportCSVWithContext(ctx, file, option)
-}
-
-// ImportCSVWithContext 带上下文的CSV导入
-func (s *ImportService) ImportCSVWithContext(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+// readCSVRows 读取CSV文件
+func (s *ImportService) readCSVRows(file *multipart.FileHeader) ([][]string, error) {
 	// 打开CSV文件
 	src, err := file.Open()
 	if err != nil {
@@ -119,24 +429,11 @@ func (s *ImportService) ImportCSVWithContext(ctx context.Context, file *multipar
 	if len(records) == 0 {
 		return nil, errors.New("CSV文件为空")
 	}
-
-	// 将records转换为 [][]string 格式
-	var rows [][]string
-	for _, record := range records {
-		rows = append(rows, record)
-	}
-
-	// 处理导入
-	return s.processImport(ctx, rows, option, "csv")
+	return records, nil
 }
 
-// ImportJSON 导入JSON文件
-func (s *ImportService) ImportJSON(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
-	return s.ImportJSONWithContext(ctx, file, option)
-}
-
-// ImportJSONWithContext 带上下文的JSON导入
-func (s *ImportService) ImportJSONWithContext(ctx context.Context, file *multipart.FileHeader, option ImportOption) (*ImportResult, error) {
+// readJSONRows 读取JSON数组并转换为行格式
+func (s *ImportService) readJSONRows(file *multipart.FileHeader, option ImportOption) ([][]string, error) {
 	// 打开JSON文件
 	src, err := file.Open()
 	if err != nil {
@@ -184,48 +481,96 @@ func (s *ImportService) ImportJSONWithContext(ctx context.Context, file *multipa
 		}
 		rows = append(rows, row)
 	}
-
-	// 处理导入
-	return s.processImport(ctx, rows, option, "json")
+	return rows, nil
 }
 
-// processImport 处理导入数据
+// processImport 处理导入数据，按事务模式包裹写入
 func (s *ImportService) processImport(ctx context.Context, rows [][]string, option ImportOption, fileType string) (*ImportResult, error) {
-	result := &ImportResult{
-		ProcessedAt: time.Now(),
-	}
-
 	// 设置默认值
 	if option.BatchSize == 0 {
 		option.BatchSize = 100
 	}
+	if option.Mode == "" {
+		option.Mode = ImportModeInsert
+	}
+	if option.TransactionMode == "" {
+		option.TransactionMode = ImportTransactionBatch
+	}
 
-	// 确定开始行
-	startRow := option.StartRow
-	if option.HasHeader {
-		startRow = 1
+	var (
+		result *ImportResult
+		err    error
+	)
+	if option.TransactionMode == ImportTransactionSingle && !option.DryRun {
+		txErr := s.tm.Transaction(ctx, func(txCtx context.Context) error {
+			result, err = s.processRows(txCtx, rows, option)
+			if err != nil {
+				return err
+			}
+			if len(result.Errors) > 0 {
+				return errImportRollback
+			}
+			return nil
+		})
+		if txErr != nil && result != nil {
+			result.RolledBack = true
+			result.SuccessRows, result.CreatedRows, result.UpdatedRows = 0, 0, 0
+		}
+		if errors.Is(txErr, errImportRollback) {
+			txErr = nil
+		}
+		if errors.Is(err, errImportRollback) {
+			err = nil
+		}
+		if err == nil {
+			err = txErr
+		}
+	} else {
+		result, err = s.processRows(ctx, rows, option)
 	}
+	if err != nil {
+		return result, err
+	}
+
+	s.logger.Info(fmt.Sprintf("导入完成: 文件类型=%s, 模式=%s, 试运行=%t, 总行数=%d, 新增=%d, 更新=%d, 失败=%d",
+		fileType, option.Mode, option.DryRun, result.TotalRows, result.CreatedRows, result.UpdatedRows, result.FailedRows))
+
+	return result, nil
+}
+
+// processRows 逐行转换、校验、判定动作并分批写入
+func (s *ImportService) processRows(ctx context.Context, rows [][]string, option ImportOption) (*ImportResult, error) {
+	result := &ImportResult{
+		DryRun:      option.DryRun,
+		Errors:      []ImportError{},
+		Preview:     []ImportPreview{},
+		ProcessedAt: time.Now(),
+	}
+
+	// 确定开始行
+	startRow := dataStartRow(option)
 
 	// 获取表头映射
 	headerMapping := make(map[int]string)
 	if option.HasHeader && len(rows) > 0 {
 		headers := rows[0]
 		for colIndex, header := range headers {
+			header = ImportHeaderFieldName(header)
 			if fieldName, exists := option.FieldMapping[header]; exists {
 				headerMapping[colIndex] = fieldName
 			} else {
 				// 如果没有映射，直接使用表头作为字段名
-				headerMapping[colIndex] = strings.TrimSpace(header)
+				headerMapping[colIndex] = header
 			}
 		}
 	}
 
 	// 处理数据行
-	var batch []map[string]interface{}
+	var batch []*ImportRow
 	for rowIndex := startRow; rowIndex < len(rows); rowIndex++ {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return result, ctx.Err()
 		default:
 		}
 
@@ -234,6 +579,7 @@ func (s *ImportService) processImport(ctx context.Context, rows [][]string, opti
 
 		// 构建数据行
 		data := make(map[string]interface{})
+		var cellErrors []ImportError
 		for colIndex, value := range row {
 			fieldName := ""
 			if option.HasHeader {
@@ -247,22 +593,63 @@ func (s *ImportService) processImport(ctx context.Context, rows [][]string, opti
 			}
 
 			// 数据类型转换
-			data[fieldName] = convertValue(value)
+			if option.ConvertFunc == nil {
+				data[fieldName] = convertValue(value)
+				continue
+			}
+			converted, err := option.ConvertFunc(ctx, fieldName, value)
+			if err != nil {
+				column := fieldName
+				if option.HasHeader {
+					column = rows[0][colIndex]
+				}
+				cellErrors = append(cellErrors, ImportError{
+					Row:    rowIndex + 1,
+					Column: column,
+					Field:  fieldName,
+					Error:  err.Error(),
+				})
+				continue
+			}
+			data[fieldName] = converted
+		}
+		if len(cellErrors) > 0 {
+			result.FailedRows++
+			result.Errors = append(result.Errors, cellErrors...)
+			continue
+		}
+		importRow := &ImportRow{Row: rowIndex + 1, Action: ImportActionCreate, Data: data}
+
+		// 判定新增/更新
+		if option.PlanFunc != nil {
+			if err := option.PlanFunc(ctx, importRow); err != nil {
+				result.addError(importRow.Row, err)
+				continue
+			}
 		}
 
 		// 数据验证
 		if option.ValidateFunc != nil {
-			if err := option.ValidateFunc(data); err != nil {
-				result.FailedRows++
-				result.Errors = append(result.Errors, ImportError{
-					Row:   rowIndex + 1,
-					Error: err.Error(),
-				})
+			if err := option.ValidateFunc(importRow.Data); err != nil {
+				result.addError(importRow.Row, err)
 				continue
 			}
 		}
 
-		batch = append(batch, data)
+		if option.DryRun {
+			result.countSuccess(importRow)
+			if len(result.Preview) < maxImportPreviewRows {
+				result.Preview = append(result.Preview, ImportPreview{
+					Row:    importRow.Row,
+					Action: importRow.Action,
+					Key:    importRow.Key,
+					Data:   importRow.Data,
+				})
+			}
+			continue
+		}
+
+		batch = append(batch, importRow)
 
 		// 批量处理
 		if len(batch) >= option.BatchSize {
@@ -270,6 +657,9 @@ func (s *ImportService) processImport(ctx context.Context, rows [][]string, opti
 				return result, err
 			}
 			batch = batch[:0] // 清空batch
+			if option.Progress != nil {
+				option.Progress(result.TotalRows)
+			}
 		}
 	}
 
@@ -279,38 +669,93 @@ func (s *ImportService) processImport(ctx context.Context, rows [][]string, opti
 			return result, err
 		}
 	}
-
-	s.logger.Info(fmt.Sprintf("导入完成: 文件类型=%s, 总行数=%d, 成功=%d, 失败=%d",
-		fileType, result.TotalRows, result.SuccessRows, result.FailedRows))
+	if option.Progress != nil {
+		option.Progress(result.TotalRows)
+	}
 
 	return result, nil
 }
 
 // processBatch 处理批量数据
-func (s *ImportService) processBatch(ctx context.Context, batch []map[string]interface{}, option ImportOption, result *ImportResult) error {
+// 单事务模式下逐行写入，首个失败即停止；批次模式下每批一个事务，失败后逐行重试
+func (s *ImportService) processBatch(ctx context.Context, batch []*ImportRow, option ImportOption, result *ImportResult) error {
 	if option.DataHandler == nil {
 		return errors.New("数据处理器未设置")
 	}
 
-	if err := option.DataHandler(batch); err != nil {
-		// 如果批量处理失败，尝试逐条处理
-		for _, data := range batch {
-			if err := option.DataHandler([]map[string]interface{}{data}); err != nil {
-				result.FailedRows++
-				result.Errors = append(result.Errors, ImportError{
-					Error: fmt.Sprintf("数据处理失败: %v", err),
-				})
-			} else {
-				result.SuccessRows++
+	if option.TransactionMode == ImportTransactionSingle {
+		for _, row := range batch {
+			if err := option.DataHandler(ctx, []*ImportRow{row}); err != nil {
+				result.addError(row.Row, fmt.Errorf("数据处理失败: %w", err))
+				// 事务已不可用，停止导入并整体回滚
+				return errImportRollback
 			}
+			result.countSuccess(row)
+		}
+		return nil
+	}
+
+	err := s.tm.Transaction(ctx, func(txCtx context.Context) error {
+		return option.DataHandler(txCtx, batch)
+	})
+	if err == nil {
+		for _, row := range batch {
+			result.countSuccess(row)
+		}
+		return nil
+	}
+
+	// 如果批量处理失败，尝试逐条处理
+	for _, row := range batch {
+		rowErr := s.tm.Transaction(ctx, func(txCtx context.Context) error {
+			return option.DataHandler(txCtx, []*ImportRow{row})
+		})
+		if rowErr != nil {
+			result.addError(row.Row, fmt.Errorf("数据处理失败: %w", rowErr))
+		} else {
+			result.countSuccess(row)
 		}
-	} else {
-		result.SuccessRows += len(batch)
 	}
 
 	return nil
 }
 
+// addError 记录行错误
+func (r *ImportResult) addError(row int, err error) {
+	r.FailedRows++
+	r.Errors = append(r.Errors, ImportError{
+		Row:   row,
+		Error: err.Error(),
+	})
+}
+
+// countSuccess 按动作累计成功行
+func (r *ImportResult) countSuccess(row *ImportRow) {
+	r.SuccessRows++
+	if row.Action == ImportActionUpdate {
+		r.UpdatedRows++
+	} else {
+		r.CreatedRows++
+	}
+}
+
+// dataStartRow 数据起始行索引
+func dataStartRow(option ImportOption) int {
+	if option.HasHeader {
+		return 1
+	}
+	return option.StartRow
+}
+
+// countDataRows 统计数据行数
+func countDataRows(rows [][]string, option ImportOption) int {
+	count := len(rows) - dataStartRow(option)
+	if count < 0 {
+		return 0
+	}
+	return count
+}
+
 // convertValue 转换值类型
 func convertValue(value string) interface{} {
 	// 尝试转换为整数
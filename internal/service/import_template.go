@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"fun-admin/pkg/admin"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	// ImportTemplateRequiredMark 必填列表头后缀，导入时会被忽略
+	ImportTemplateRequiredMark = "*"
+
+	importTemplateDataSheet    = "数据"
+	importTemplateHelpSheet    = "填写说明"
+	importTemplateOptionsSheet = "选项"
+	// importTemplateRows 下拉与日期格式覆盖的数据行数
+	importTemplateRows = 1000
+)
+
+// importTemplateColumn 导入模板列
+type importTemplateColumn struct {
+	field   admin.Field
+	header  string
+	options []string // 下拉可选值（选项标签）
+	sample  interface{}
+	note    string
+}
+
+// WriteImportTemplate 根据资源字段生成导入模板，只包含 writable 中的字段（见 ResourceService.WritableFieldSet）
+// 表头使用当前语言的字段标题，必填列追加 ImportTemplateRequiredMark；
+// XLSX 模板包含示例行、填写说明页，选择与字典字段提供下拉，日期字段使用日期格式
+func (s *ImportService) WriteImportTemplate(ctx context.Context, resource admin.Resource, writable map[string]struct{}, format, language string, w io.Writer) error {
+	columns, err := s.importTemplateColumns(ctx, resource, writable, language)
+	if err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return fmt.Errorf("资源 %s 没有可导入的字段", resource.GetSlug())
+	}
+
+	switch format {
+	case "csv":
+		return writeCSVImportTemplate(columns, w)
+	case "xlsx", "excel":
+		return writeXLSXImportTemplate(columns, w)
+	default:
+		return fmt.Errorf("不支持的模板格式: %s", format)
+	}
+}
+
+// ImportHeaderFieldName 规范化导入表头：去除 BOM、空白与必填标记
+func ImportHeaderFieldName(header string) string {
+	header = strings.TrimPrefix(header, "\ufeff")
+	header = strings.TrimSpace(header)
+	header = strings.TrimSuffix(header, ImportTemplateRequiredMark)
+	return strings.TrimSpace(header)
+}
+
+// importTemplateColumns 收集可导入字段，跳过主键、文件字段与不可写入的字段（只读、时间戳等）
+func (s *ImportService) importTemplateColumns(ctx context.Context, resource admin.Resource, writable map[string]struct{}, language string) ([]*importTemplateColumn, error) {
+	var columns []*importTemplateColumn
+	for _, field := range resource.GetFields() {
+		if _, ok := field.(*admin.IDField); ok || field.GetName() == "id" || field.GetType() == "file" {
+			continue
+		}
+		if _, ok := writable[field.GetName()]; !ok {
+			continue
+		}
+
+		label := strings.TrimSpace(field.GetLabel())
+		if label == "" {
+			label = field.GetName()
+		}
+		column := &importTemplateColumn{field: field, header: admin.TranslateLabel(label, language)}
+		if field.IsRequired() {
+			column.header += ImportTemplateRequiredMark
+		}
+
+		switch f := field.(type) {
+		case *admin.SelectField:
+			for _, option := range f.Options {
+				column.options = append(column.options, admin.TranslateLabel(option.Label, language))
+			}
+			if f.DictCode != "" {
+				data, err := s.dictionaryService.GetDictByCode(ctx, f.DictCode)
+				if err != nil {
+					return nil, fmt.Errorf("加载字典 %s 失败: %w", f.DictCode, err)
+				}
+				for _, item := range data {
+					column.options = append(column.options, item.Label)
+				}
+			}
+			column.note = "从下拉列表中选择"
+		case *admin.RelationshipField:
+			column.note = fmt.Sprintf("填写关联记录的 %s，也可填写ID", f.DisplayField)
+		}
+
+		switch field.GetType() {
+		case "boolean":
+			column.options = []string{"是", "否"}
+			column.sample = "是"
+			column.note = "填写 是/否"
+		case "number":
+			column.sample = 0
+			column.note = "填写数字"
+		case "date":
+			year, month, day := time.Now().Date()
+			column.sample = time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+			column.note = "日期，格式 2006-01-02"
+		case "datetime":
+			column.sample = time.Now().Truncate(time.Second)
+			column.note = "日期时间，格式 2006-01-02 15:04:05"
+		case "email":
+			column.sample = "user@example.com"
+		case "relationship":
+			// 关联记录因环境而异，示例留空
+			column.sample = ""
+		default:
+			if len(column.options) > 0 {
+				column.sample = column.options[0]
+			} else {
+				column.sample = "示例" + label
+			}
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// writeCSVImportTemplate 输出带 BOM 的 CSV 模板（表头 + 示例行）
+func writeCSVImportTemplate(columns []*importTemplateColumn, w io.Writer) error {
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+	writer := csv.NewWriter(w)
+	header := make([]string, len(columns))
+	sample := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.header
+		sample[i] = importTemplateSampleText(column)
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.Write(sample); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeXLSXImportTemplate 输出 XLSX 模板：数据页（首个工作表，导入时默认读取）、填写说明页与隐藏的选项页
+func writeXLSXImportTemplate(columns []*importTemplateColumn, w io.Writer) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", importTemplateDataSheet); err != nil {
+		return err
+	}
+	headerStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#E6E6E6"}},
+	})
+	if err != nil {
+		return err
+	}
+	requiredStyle, err := f.NewStyle(&excelize.Style{
+		Font: &excelize.Font{Bold: true, Color: "#C00000"},
+		Fill: excelize.Fill{Type: "pattern", Pattern: 1, Color: []string{"#E6E6E6"}},
+	})
+	if err != nil {
+		return err
+	}
+	dateFormat, dateTimeFormat := "yyyy-mm-dd", "yyyy-mm-dd hh:mm:ss"
+	dateStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateFormat})
+	if err != nil {
+		return err
+	}
+	dateTimeStyle, err := f.NewStyle(&excelize.Style{CustomNumFmt: &dateTimeFormat})
+	if err != nil {
+		return err
+	}
+
+	optionsColumn := 0
+	for i, column := range columns {
+		name, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		style := headerStyle
+		if column.field.IsRequired() {
+			style = requiredStyle
+		}
+		if err := f.SetCellValue(importTemplateDataSheet, name+"1", column.header); err != nil {
+			return err
+		}
+		if err := f.SetCellStyle(importTemplateDataSheet, name+"1", name+"1", style); err != nil {
+			return err
+		}
+		if err := f.SetColWidth(importTemplateDataSheet, name, name, 20); err != nil {
+			return err
+		}
+
+		switch column.field.GetType() {
+		case "date":
+			err = f.SetCellStyle(importTemplateDataSheet, name+"2", fmt.Sprintf("%s%d", name, importTemplateRows+1), dateStyle)
+		case "datetime":
+			err = f.SetCellStyle(importTemplateDataSheet, name+"2", fmt.Sprintf("%s%d", name, importTemplateRows+1), dateTimeStyle)
+		}
+		if err != nil {
+			return err
+		}
+		if column.sample != nil {
+			if err := f.SetCellValue(importTemplateDataSheet, name+"2", column.sample); err != nil {
+				return err
+			}
+		}
+
+		// 下拉选项写入隐藏页再引用，避免内联列表的 255 字符限制
+		if len(column.options) > 0 {
+			if optionsColumn == 0 {
+				if _, err := f.NewSheet(importTemplateOptionsSheet); err != nil {
+					return err
+				}
+			}
+			optionsColumn++
+			optionsName, err := excelize.ColumnNumberToName(optionsColumn)
+			if err != nil {
+				return err
+			}
+			for row, option := range column.options {
+				if err := f.SetCellValue(importTemplateOptionsSheet, fmt.Sprintf("%s%d", optionsName, row+1), option); err != nil {
+					return err
+				}
+			}
+			validation := excelize.NewDataValidation(true)
+			validation.Sqref = fmt.Sprintf("%s2:%s%d", name, name, importTemplateRows+1)
+			validation.SetSqrefDropList(fmt.Sprintf("'%s'!$%s$1:$%s$%d", importTemplateOptionsSheet, optionsName, optionsName, len(column.options)))
+			if err := f.AddDataValidation(importTemplateDataSheet, validation); err != nil {
+				return err
+			}
+		}
+	}
+	if err := f.SetPanes(importTemplateDataSheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return err
+	}
+
+	if err := writeImportTemplateHelp(f, columns, headerStyle); err != nil {
+		return err
+	}
+	if optionsColumn > 0 {
+		if err := f.SetSheetVisible(importTemplateOptionsSheet, false); err != nil {
+			return err
+		}
+	}
+	f.SetActiveSheet(0)
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("生成导入模板失败: %w", err)
+	}
+	return nil
+}
+
+// writeImportTemplateHelp 写入填写说明页：每列的字段名、类型、是否必填与填写要求
+func writeImportTemplateHelp(f *excelize.File, columns []*importTemplateColumn, headerStyle int) error {
+	if _, err := f.NewSheet(importTemplateHelpSheet); err != nil {
+		return err
+	}
+	rows := [][]interface{}{
+		{"导入说明：请在「" + importTemplateDataSheet + "」工作表中填写数据，第二行为示例，导入前请修改或删除；表头带 " +
+			ImportTemplateRequiredMark + " 的列为必填，请勿修改表头。"},
+		{},
+		{"列名", "字段", "类型", "必填", "说明", "可选值"},
+	}
+	for _, column := range columns {
+		required := "否"
+		if column.field.IsRequired() {
+			required = "是"
+		}
+		rows = append(rows, []interface{}{
+			column.header,
+			column.field.GetName(),
+			column.field.GetType(),
+			required,
+			column.note,
+			strings.Join(column.options, ", "),
+		})
+	}
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		if err := f.SetSheetRow(importTemplateHelpSheet, cell, &row); err != nil {
+			return err
+		}
+	}
+	if err := f.SetCellStyle(importTemplateHelpSheet, "A3", "F3", headerStyle); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(importTemplateHelpSheet, "A", "D", 16); err != nil {
+		return err
+	}
+	return f.SetColWidth(importTemplateHelpSheet, "E", "F", 40)
+}
+
+// importTemplateSampleText 示例值的文本形式，日期按导入可识别的格式输出
+func importTemplateSampleText(column *importTemplateColumn) string {
+	if t, ok := column.sample.(time.Time); ok {
+		if column.field.GetType() == "date" {
+			return t.Format("2006-01-02")
+		}
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return exportCellString(column.sample)
+}
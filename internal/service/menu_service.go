@@ -6,6 +6,7 @@ import (
 	"fun-admin/internal/model"
 	"fun-admin/internal/repository"
 	"fun-admin/pkg"
+	"fun-admin/pkg/admin/i18n"
 	"strings"
 
 	"github.com/duke-git/lancet/v2/convertor"
@@ -25,24 +26,31 @@ func NewMenuService(
 	menuRepository repository.MenuRepository,
 	userRepository repository.UserRepository,
 	permissionService PermissionService,
+	translationRepository repository.TranslationRepository,
 ) MenuService {
 	return &menuService{
-		Service:           service,
-		menuRepository:    menuRepository,
-		userRepository:    userRepository,
-		permissionService: permissionService,
+		Service:               service,
+		menuRepository:        menuRepository,
+		userRepository:        userRepository,
+		permissionService:     permissionService,
+		translationRepository: translationRepository,
 	}
 }
 
+// menuTranslationSlug 菜单标题翻译在翻译表中的资源标识
+const menuTranslationSlug = "admin_menu"
+
 type menuService struct {
 	*Service
-	menuRepository    repository.MenuRepository
-	userRepository    repository.UserRepository
-	permissionService PermissionService
+	menuRepository        repository.MenuRepository
+	userRepository        repository.UserRepository
+	permissionService     PermissionService
+	translationRepository repository.TranslationRepository
 }
 
 func (s *menuService) MenuUpdate(ctx context.Context, req *v1.MenuUpdateRequest) error {
-	return s.menuRepository.MenuUpdate(ctx, &model.Menu{
+	titles := s.splitTitles(&req.Title, req.Titles)
+	menu := &model.Menu{
 		Component:  req.Component,
 		Icon:       req.Icon,
 		KeepAlive:  req.KeepAlive,
@@ -58,11 +66,19 @@ func (s *menuService) MenuUpdate(ctx context.Context, req *v1.MenuUpdateRequest)
 		BaseModel: model.BaseModel{
 			ID: req.ID,
 		},
+	}
+	// 菜单与标题翻译在同一事务中写入，避免只保存其一
+	return s.tm.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.menuRepository.MenuUpdate(ctx, menu); err != nil {
+			return err
+		}
+		return s.saveTitles(ctx, req.ID, titles)
 	})
 }
 
 func (s *menuService) MenuCreate(ctx context.Context, req *v1.MenuCreateRequest) error {
-	return s.menuRepository.MenuCreate(ctx, &model.Menu{
+	titles := s.splitTitles(&req.Title, req.Titles)
+	menu := &model.Menu{
 		Component:  req.Component,
 		Icon:       req.Icon,
 		KeepAlive:  req.KeepAlive,
@@ -75,11 +91,79 @@ func (s *menuService) MenuCreate(ctx context.Context, req *v1.MenuCreateRequest)
 		Redirect:   req.Redirect,
 		Title:      req.Title,
 		URL:        req.URL,
+	}
+	return s.tm.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.menuRepository.MenuCreate(ctx, menu); err != nil {
+			return err
+		}
+		return s.saveTitles(ctx, menu.ID, titles)
 	})
 }
 
 func (s *menuService) MenuDelete(ctx context.Context, id uint) error {
-	return s.menuRepository.MenuDelete(ctx, id)
+	return s.tm.Transaction(ctx, func(ctx context.Context) error {
+		if err := s.menuRepository.MenuDelete(ctx, id); err != nil {
+			return err
+		}
+		return s.translationRepository.DeleteRecordTranslations(ctx, menuTranslationSlug, []string{convertor.ToString(id)})
+	})
+}
+
+// splitTitles 拆分请求中的多语言标题：默认语言的值在未提交 title 时作为菜单标题，其余语言返回待保存
+func (s *menuService) splitTitles(title *string, titles map[string]string) map[string]string {
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	translations := make(map[string]string, len(titles))
+	for language, value := range titles {
+		language = i18n.Normalize(language)
+		if language == defaultLanguage {
+			if *title == "" {
+				*title = value
+			}
+			continue
+		}
+		translations[language] = value
+	}
+	return translations
+}
+
+// saveTitles 保存菜单标题的翻译
+func (s *menuService) saveTitles(ctx context.Context, id uint, titles map[string]string) error {
+	if len(titles) == 0 {
+		return nil
+	}
+	return s.translationRepository.SaveTranslations(ctx, menuTranslationSlug, convertor.ToString(id), "title", titles)
+}
+
+// loadTitles 读取菜单标题的全部语言值：菜单ID -> 语言 -> 标题，菜单本身的标题作为默认语言的值
+func (s *menuService) loadTitles(ctx context.Context, menus []*model.Menu) (map[uint]map[string]string, error) {
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	titles := make(map[uint]map[string]string, len(menus))
+	ids := make([]string, 0, len(menus))
+	byID := make(map[string]uint, len(menus))
+	for _, menu := range menus {
+		titles[menu.ID] = map[string]string{defaultLanguage: menu.Title}
+		id := convertor.ToString(menu.ID)
+		ids = append(ids, id)
+		byID[id] = menu.ID
+	}
+	rows, err := s.translationRepository.GetTranslations(ctx, menuTranslationSlug, ids, []string{"title"})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if id, ok := byID[row.RecordID]; ok && row.Locale != defaultLanguage {
+			titles[id][row.Locale] = row.Value
+		}
+	}
+	return titles, nil
+}
+
+// localizeTitle 按请求语言的回退链选取菜单标题
+func localizeTitle(language string, menu *model.Menu, titles map[string]string) string {
+	if title, _, ok := i18n.Resolve(language, titles); ok {
+		return title
+	}
+	return menu.Title
 }
 
 func (s *menuService) GetMenus(ctx context.Context, uid uint) (*v1.GetMenuResponseData, error) {
@@ -112,12 +196,17 @@ func (s *menuService) GetMenus(ctx context.Context, uid uint) (*v1.GetMenuRespon
 		}
 	}
 
+	titles, err := s.loadTitles(ctx, menuList)
+	if err != nil {
+		return nil, err
+	}
+	language := i18n.FromContext(ctx)
 	for _, menu := range menuList {
 		if _, ok := menuPermMap[menu.Path]; ok {
 			data.List = append(data.List, v1.MenuDataItem{
 				ID:         menu.ID,
 				Name:       menu.Name,
-				Title:      menu.Title,
+				Title:      localizeTitle(language, menu, titles[menu.ID]),
 				Path:       menu.Path,
 				Component:  menu.Component,
 				Redirect:   menu.Redirect,
@@ -144,11 +233,16 @@ func (s *menuService) GetAdminMenus(ctx context.Context) (*v1.GetMenuResponseDat
 	data := &v1.GetMenuResponseData{
 		List: make([]v1.MenuDataItem, 0),
 	}
+	titles, err := s.loadTitles(ctx, menuList)
+	if err != nil {
+		return nil, err
+	}
+	language := i18n.FromContext(ctx)
 	for _, menu := range menuList {
 		data.List = append(data.List, v1.MenuDataItem{
 			ID:         menu.ID,
 			Name:       menu.Name,
-			Title:      menu.Title,
+			Title:      localizeTitle(language, menu, titles[menu.ID]),
 			Path:       menu.Path,
 			Component:  menu.Component,
 			Redirect:   menu.Redirect,
@@ -160,6 +254,7 @@ func (s *menuService) GetAdminMenus(ctx context.Context) (*v1.GetMenuResponseDat
 			ParentID:   menu.ParentID,
 			UpdatedAt:  menu.UpdatedAt.Format("2006-01-02 15:04:05"),
 			URL:        menu.URL,
+			Titles:     titles[menu.ID],
 		})
 	}
 	return data, nil
@@ -0,0 +1,330 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fun-admin/pkg/admin"
+)
+
+var (
+	// ErrRelationNotFound 表示资源未声明该关系
+	ErrRelationNotFound = errors.New("relation not found")
+	// ErrRelatedRecordMismatch 表示子记录不属于该父记录
+	ErrRelatedRecordMismatch = errors.New("related record does not belong to the parent record")
+)
+
+// relationContext 一次关系操作解析出的父资源、关系声明与子资源
+type relationContext struct {
+	parent  admin.Resource
+	manager *admin.RelationManager
+	child   admin.Resource
+	// owner 父记录中被外键引用的值
+	owner interface{}
+}
+
+// ListRelated 获取父记录下的子记录列表
+// 过滤、搜索与排序按子资源的白名单处理，并额外限定为属于父记录的数据
+func (s *ResourceService) ListRelated(
+	ctx context.Context,
+	resourceSlug string,
+	parentID interface{},
+	relation string,
+	page, pageSize int,
+	filters map[string]interface{},
+	search map[string]interface{},
+	orderBy string,
+	orderDirection string,
+) ([]map[string]interface{}, int64, error) {
+	rc, err := s.resolveRelation(ctx, resourceSlug, parentID, relation)
+	if err != nil {
+		return nil, 0, err
+	}
+	if auth, ok := rc.child.(admin.Authorizable); ok {
+		if err := auth.CanList(ctx); err != nil {
+			return nil, 0, err
+		}
+	}
+	parseBoolFilters(filters)
+	filters = s.sanitizeFilters(rc.child, filters)
+	search = s.sanitizeSearch(rc.child, search)
+	orderBy, orderDirection = s.sanitizeOrder(rc.child, orderBy, orderDirection)
+
+	if rc.manager.IsPivot() {
+		ids, err := s.pivotRelatedIDs(ctx, rc)
+		if err != nil {
+			return nil, 0, err
+		}
+		filters["id"] = ids
+	} else {
+		filters[rc.manager.ForeignKey] = rc.owner
+	}
+
+	search, err = s.translateSearch(ctx, rc.child, search)
+	if err != nil {
+		return nil, 0, err
+	}
+	results, total, err := s.resourceRepository.ListWithRelationshipsAndFilters(
+		ctx, rc.child.GetSlug(), page, pageSize, s.getRelationships(rc.child), filters, search, orderBy, orderDirection)
+	if err != nil {
+		return nil, 0, err
+	}
+	return s.localizeList(ctx, rc.child, results, total)
+}
+
+// CreateRelated 在父记录下创建子记录
+// 一对多关系的外键固定为父记录；多对多关系在创建后写入中间表
+func (s *ResourceService) CreateRelated(
+	ctx context.Context,
+	resourceSlug string,
+	parentID interface{},
+	relation string,
+	data map[string]interface{},
+) (map[string]interface{}, error) {
+	rc, err := s.resolveRelation(ctx, resourceSlug, parentID, relation)
+	if err != nil {
+		return nil, err
+	}
+	if !rc.manager.IsPivot() {
+		delete(data, rc.manager.ForeignKey)
+		return s.createRecord(ctx, rc.child, data, map[string]interface{}{rc.manager.ForeignKey: rc.owner})
+	}
+
+	var created map[string]interface{}
+	err = s.withTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.createRecord(ctx, rc.child, data, nil)
+		if err != nil {
+			return err
+		}
+		created = record
+		return s.resourceRepository.AttachPivot(ctx, rc.manager.PivotTable, rc.manager.ForeignKey, rc.manager.RelatedKey,
+			rc.owner, []interface{}{record["id"]}, rc.manager.PivotSoftDelete)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateRelated 更新父记录下的子记录，不允许通过该入口修改外键
+func (s *ResourceService) UpdateRelated(
+	ctx context.Context,
+	resourceSlug string,
+	parentID interface{},
+	relation string,
+	childID interface{},
+	data map[string]interface{},
+) error {
+	rc, err := s.resolveRelation(ctx, resourceSlug, parentID, relation)
+	if err != nil {
+		return err
+	}
+	if !rc.manager.IsPivot() {
+		if _, ok := data[rc.manager.ForeignKey]; ok {
+			return &ValidationError{Errors: map[string][]string{
+				rc.manager.ForeignKey: {"外键由父记录确定，不能修改"},
+			}}
+		}
+	}
+	if err := s.ensureRelated(ctx, rc, []interface{}{childID}); err != nil {
+		return err
+	}
+	return s.Update(ctx, rc.child.GetSlug(), childID, data)
+}
+
+// AttachRelated 将已有的子记录关联到父记录
+// 一对多关系更新子记录外键，需要子资源的更新权限；多对多关系写入中间表，需要子记录的查看权限
+func (s *ResourceService) AttachRelated(
+	ctx context.Context,
+	resourceSlug string,
+	parentID interface{},
+	relation string,
+	childIDs []interface{},
+) error {
+	rc, err := s.resolveRelation(ctx, resourceSlug, parentID, relation)
+	if err != nil {
+		return err
+	}
+	childSlug := rc.child.GetSlug()
+	for _, id := range childIDs {
+		record, err := s.resourceRepository.FindByID(ctx, childSlug, id)
+		if err != nil {
+			return err
+		}
+		if record == nil || record["deleted_at"] != nil {
+			return ErrRecordNotFound
+		}
+		if err := s.authorizeRelatedChange(ctx, rc, id, rc.owner); err != nil {
+			return err
+		}
+	}
+
+	if rc.manager.IsPivot() {
+		return s.resourceRepository.AttachPivot(ctx, rc.manager.PivotTable, rc.manager.ForeignKey, rc.manager.RelatedKey,
+			rc.owner, childIDs, rc.manager.PivotSoftDelete)
+	}
+	err = s.withTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range childIDs {
+			if err := s.resourceRepository.Update(ctx, childSlug, id, map[string]interface{}{rc.manager.ForeignKey: rc.owner}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.clearRecordsCache(ctx, childSlug, childIDs)
+	return nil
+}
+
+// DetachRelated 解除子记录与父记录的关联，不删除子记录
+// 一对多关系将子记录外键置为 NULL，外键列需允许为空；多对多关系删除中间表记录
+func (s *ResourceService) DetachRelated(
+	ctx context.Context,
+	resourceSlug string,
+	parentID interface{},
+	relation string,
+	childIDs []interface{},
+) (int64, error) {
+	rc, err := s.resolveRelation(ctx, resourceSlug, parentID, relation)
+	if err != nil {
+		return 0, err
+	}
+	if err := s.ensureRelated(ctx, rc, childIDs); err != nil {
+		return 0, err
+	}
+	for _, id := range childIDs {
+		if err := s.authorizeRelatedChange(ctx, rc, id, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	if rc.manager.IsPivot() {
+		return s.resourceRepository.DetachPivot(ctx, rc.manager.PivotTable, rc.manager.ForeignKey, rc.manager.RelatedKey,
+			rc.owner, childIDs, rc.manager.PivotSoftDelete)
+	}
+	childSlug := rc.child.GetSlug()
+	if err := s.resourceRepository.ClearField(ctx, childSlug, rc.manager.ForeignKey, childIDs); err != nil {
+		return 0, err
+	}
+	s.clearRecordsCache(ctx, childSlug, childIDs)
+	return int64(len(childIDs)), nil
+}
+
+// resolveRelation 解析关系声明并校验父记录存在且可查看
+func (s *ResourceService) resolveRelation(ctx context.Context, resourceSlug string, parentID interface{}, relation string) (*relationContext, error) {
+	parent := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if parent == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	manager := admin.GetRelationManager(parent, relation)
+	if manager == nil {
+		return nil, ErrRelationNotFound
+	}
+	child := s.resourceManager.GetResourceBySlug(manager.Resource)
+	if child == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: manager.Resource}
+	}
+	if auth, ok := parent.(admin.Authorizable); ok {
+		if err := auth.CanView(ctx, parentID); err != nil {
+			return nil, permissionDenied(err)
+		}
+	}
+	record, err := s.resourceRepository.FindByID(ctx, resourceSlug, parentID)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record["deleted_at"] != nil {
+		return nil, ErrRecordNotFound
+	}
+	ownerKey := manager.OwnerKey
+	if ownerKey == "" {
+		ownerKey = "id"
+	}
+	owner, ok := record[ownerKey]
+	if !ok || owner == nil {
+		return nil, fmt.Errorf("父记录缺少关系引用列 %s", ownerKey)
+	}
+	return &relationContext{parent: parent, manager: manager, child: child, owner: owner}, nil
+}
+
+// ensureRelated 校验子记录均属于父记录
+func (s *ResourceService) ensureRelated(ctx context.Context, rc *relationContext, childIDs []interface{}) error {
+	if rc.manager.IsPivot() {
+		ids, err := s.pivotRelatedIDs(ctx, rc)
+		if err != nil {
+			return err
+		}
+		linked := toSet(recordIDsToStrings(ids))
+		for _, id := range recordIDsToStrings(childIDs) {
+			if _, ok := linked[id]; !ok {
+				return ErrRelatedRecordMismatch
+			}
+		}
+		return nil
+	}
+	owner := relationIDString(rc.owner)
+	for _, id := range childIDs {
+		record, err := s.resourceRepository.FindByID(ctx, rc.child.GetSlug(), id)
+		if err != nil {
+			return err
+		}
+		if record == nil || record["deleted_at"] != nil {
+			return ErrRecordNotFound
+		}
+		if relationIDString(record[rc.manager.ForeignKey]) != owner {
+			return ErrRelatedRecordMismatch
+		}
+	}
+	return nil
+}
+
+// authorizeRelatedChange 校验关联变更的子资源权限
+func (s *ResourceService) authorizeRelatedChange(ctx context.Context, rc *relationContext, childID interface{}, owner interface{}) error {
+	auth, ok := rc.child.(admin.Authorizable)
+	if !ok {
+		return nil
+	}
+	var err error
+	if rc.manager.IsPivot() {
+		err = auth.CanView(ctx, childID)
+	} else {
+		err = auth.CanUpdate(ctx, childID, map[string]interface{}{rc.manager.ForeignKey: owner})
+	}
+	if err != nil {
+		return permissionDenied(err)
+	}
+	return nil
+}
+
+// pivotRelatedIDs 获取中间表中父记录关联的子记录 ID
+func (s *ResourceService) pivotRelatedIDs(ctx context.Context, rc *relationContext) ([]interface{}, error) {
+	ids, err := s.resourceRepository.PivotRelatedIDs(ctx, rc.manager.PivotTable, rc.manager.ForeignKey, rc.manager.RelatedKey,
+		rc.owner, rc.manager.PivotSoftDelete)
+	if err != nil {
+		return nil, err
+	}
+	for i, id := range ids {
+		if b, ok := id.([]byte); ok {
+			ids[i] = string(b)
+		}
+	}
+	return ids, nil
+}
+
+// clearRecordsCache 清除资源列表与指定记录的缓存
+func (s *ResourceService) clearRecordsCache(ctx context.Context, resourceSlug string, ids []interface{}) {
+	s.clearResourceCache(ctx, resourceSlug)
+	for _, id := range ids {
+		s.cacheManager.Delete(ctx, s.getRecordCacheKey(resourceSlug, id))
+	}
+}
+
+// relationIDString 统一不同驱动返回的 ID 类型，用于比较
+func relationIDString(id interface{}) string {
+	if b, ok := id.([]byte); ok {
+		return string(b)
+	}
+	return fmt.Sprint(id)
+}
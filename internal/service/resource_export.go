@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
+	"io"
+	"time"
+)
+
+// exportPageSize 导出时每批读取的记录数
+const exportPageSize = 500
+
+// ExportRequest 导出参数
+type ExportRequest struct {
+	Filters        map[string]interface{} // 精确过滤条件
+	Search         map[string]interface{} // 模糊搜索条件
+	OrderBy        string                 // 排序字段
+	OrderDirection string                 // 排序方向 ASC/DESC
+	Format         string                 // 导出格式，见 RegisterExporter
+	Columns        []string               // 导出列，为空时使用资源列定义
+	Language       string                 // 标题与表头语言，为空时使用默认语言
+}
+
+// ResourceExport 已校验的导出任务，WriteExport 按页流式写出
+type ResourceExport struct {
+	Filename    string
+	ContentType string
+	Format      string
+
+	title        string
+	resourceSlug string
+	request      ExportRequest
+	columns      []*exportColumn
+	exporter     *Exporter
+	conditions   map[string]string
+}
+
+// exportColumn 导出列：表头与取值方式
+type exportColumn struct {
+	name   string
+	label  string
+	format func(ctx context.Context, record map[string]interface{}) interface{}
+}
+
+// PrepareExport 校验资源与权限，解析导出列
+func (s *ResourceService) PrepareExport(ctx context.Context, resourceSlug string, req ExportRequest) (*ResourceExport, error) {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+
+	// 检查资源是否支持导出
+	if exportable, ok := resource.(admin.Exportable); ok {
+		if !exportable.IsExportable() {
+			return nil, fmt.Errorf("资源不支持导出功能")
+		}
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanList(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	columns, err := s.resolveExportColumns(ctx, resource, req.Columns, req.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	// 白名单过滤与默认排序
+	req.Filters = s.sanitizeFilters(resource, req.Filters)
+	req.Search = s.sanitizeSearch(resource, req.Search)
+	req.OrderBy, req.OrderDirection = s.sanitizeOrder(resource, req.OrderBy, req.OrderDirection)
+	conditions := s.exportConditions(resource, req)
+	if req.Search, err = s.translateSearch(ctx, resource, req.Search); err != nil {
+		return nil, err
+	}
+
+	exporter, ok := s.exportService.GetExporter(req.Format)
+	if !ok {
+		return nil, &ValidationError{Errors: map[string][]string{"format": {"不支持的导出格式"}}}
+	}
+
+	return &ResourceExport{
+		Filename:     s.exportService.GenerateFileName(admin.TranslateLabel(resource.GetTitle(), req.Language), exporter.Extension),
+		ContentType:  exporter.ContentType,
+		Format:       exporter.Name,
+		title:        admin.TranslateLabel(resource.GetTitle(), req.Language),
+		resourceSlug: resourceSlug,
+		request:      req,
+		columns:      columns,
+		exporter:     exporter,
+		conditions:   conditions,
+	}, nil
+}
+
+// WriteExport 按页读取记录并写入 w，返回写出的记录数
+func (s *ResourceService) WriteExport(ctx context.Context, export *ResourceExport, w io.Writer) (int, error) {
+	meta := ExportMeta{
+		Title:       export.title,
+		Names:       make([]string, len(export.columns)),
+		Labels:      make([]string, len(export.columns)),
+		Conditions:  export.conditions,
+		GeneratedAt: time.Now(),
+	}
+	for i, column := range export.columns {
+		meta.Names[i] = column.name
+		meta.Labels[i] = column.label
+	}
+	writer, err := export.exporter.New(w, meta)
+	if err != nil {
+		return 0, err
+	}
+
+	// 可翻译字段按导出语言输出
+	resource := s.resourceManager.GetResourceBySlug(export.resourceSlug)
+	localizeCtx := ctx
+	if export.request.Language != "" {
+		localizeCtx = i18n.WithLanguage(ctx, export.request.Language)
+	}
+
+	total := 0
+	err = s.resourceRepository.EachWithFilters(ctx, export.resourceSlug, exportPageSize,
+		export.request.Filters, export.request.Search, export.request.OrderBy, export.request.OrderDirection,
+		func(records []map[string]interface{}) error {
+			if err := s.localizeRecords(localizeCtx, resource, records, false); err != nil {
+				return err
+			}
+			for _, record := range records {
+				values := make([]interface{}, len(export.columns))
+				for i, column := range export.columns {
+					if export.exporter.Typed {
+						values[i] = exportTypedValue(record[column.name])
+					} else {
+						values[i] = column.format(ctx, record)
+					}
+				}
+				if err := writer.WriteRow(values); err != nil {
+					return err
+				}
+				total++
+			}
+			return nil
+		})
+	if err != nil {
+		return total, err
+	}
+
+	return total, writer.Close()
+}
+
+// resolveExportColumns 确定导出列：按请求或资源列定义，过滤不可读字段
+func (s *ResourceService) resolveExportColumns(ctx context.Context, resource admin.Resource, requested []string, language string) ([]*exportColumn, error) {
+	columnMeta := make(map[string]*admin.Column)
+	var defaults []string
+	for _, column := range resource.GetColumns() {
+		columnMeta[column.Name] = column
+		defaults = append(defaults, column.Name)
+	}
+	fieldMeta := make(map[string]admin.Field)
+	for _, field := range resource.GetFields() {
+		fieldMeta[field.GetName()] = field
+		if len(columnMeta) == 0 {
+			defaults = append(defaults, field.GetName())
+		}
+	}
+	readable := s.getReadableFieldSet(ctx, resource)
+
+	names := defaults
+	if len(requested) > 0 {
+		names = requested
+		invalid := make(map[string][]string)
+		for _, name := range requested {
+			_, isColumn := columnMeta[name]
+			_, isField := fieldMeta[name]
+			_, isReadable := readable[name]
+			if !isColumn && !isField && !isReadable {
+				invalid[name] = append(invalid[name], "未知的导出列")
+			}
+		}
+		if len(invalid) > 0 {
+			return nil, &ValidationError{Errors: invalid}
+		}
+	}
+
+	columns := make([]*exportColumn, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		if _, ok := readable[name]; !ok {
+			continue
+		}
+		columns = append(columns, s.newExportColumn(name, columnMeta[name], fieldMeta[name], language))
+	}
+	return columns, nil
+}
+
+// newExportColumn 按列定义与字段类型构造取值函数
+// 优先级：关联展示字段 > 列枚举映射 > 列格式化器 > 选择字段选项标签 > 布尔文本
+func (s *ResourceService) newExportColumn(name string, column *admin.Column, field admin.Field, language string) *exportColumn {
+	label := name
+	if field != nil && field.GetLabel() != "" {
+		label = admin.TranslateLabel(field.GetLabel(), language)
+	}
+	if column != nil && column.Label != "" {
+		label = admin.TranslateLabel(column.Label, language)
+	}
+
+	var formatter admin.FormatterFunc
+	var enumMap map[string]string
+	if column != nil {
+		enumMap = column.EnumMap
+		if column.Formatter != "" {
+			formatter, _ = admin.GetFormatter(column.Formatter)
+		} else if column.Type == "boolean" {
+			formatter, _ = admin.GetFormatter("boolean")
+		}
+	}
+
+	var relation *admin.RelationshipField
+	var options map[string]string
+	switch f := field.(type) {
+	case *admin.RelationshipField:
+		relation = f
+	case *admin.SelectField:
+		options = make(map[string]string, len(f.Options))
+		for _, option := range f.Options {
+			options[option.Value] = admin.TranslateLabel(option.Label, language)
+		}
+	case *admin.BooleanField:
+		if formatter == nil {
+			formatter, _ = admin.GetFormatter("boolean")
+		}
+	}
+
+	displayCache := make(map[string]interface{})
+	return &exportColumn{
+		name:  name,
+		label: label,
+		format: func(ctx context.Context, record map[string]interface{}) interface{} {
+			value := record[name]
+			if value == nil {
+				return ""
+			}
+			key := exportCellString(value)
+			if relation != nil {
+				return s.exportRelationDisplay(ctx, relation, value, key, displayCache)
+			}
+			if text, ok := enumMap[key]; ok {
+				return text
+			}
+			if formatter != nil {
+				return formatter(value)
+			}
+			if text, ok := options[key]; ok {
+				return text
+			}
+			if b, ok := value.([]byte); ok {
+				return string(b)
+			}
+			return value
+		},
+	}
+}
+
+// exportRelationDisplay 查询关联记录的展示字段，同一导出内缓存
+func (s *ResourceService) exportRelationDisplay(ctx context.Context, relation *admin.RelationshipField, value interface{}, key string, cache map[string]interface{}) interface{} {
+	if display, ok := cache[key]; ok {
+		return display
+	}
+	display := value
+	if record, err := s.resourceRepository.FindByID(ctx, relation.RelatedResource, value); err == nil && len(record) > 0 {
+		if v, ok := record[relation.DisplayField]; ok && v != nil {
+			display = v
+		}
+	}
+	if b, ok := display.([]byte); ok {
+		display = string(b)
+	}
+	cache[key] = display
+	return display
+}
+
+// exportConditions 汇总已应用的过滤与搜索条件，键为字段标题
+func (s *ResourceService) exportConditions(resource admin.Resource, req ExportRequest) map[string]string {
+	labels := make(map[string]string)
+	for _, field := range resource.GetFields() {
+		labels[field.GetName()] = admin.TranslateLabel(field.GetLabel(), req.Language)
+	}
+	conditions := make(map[string]string)
+	for name, value := range req.Filters {
+		label := name
+		if l := labels[name]; l != "" {
+			label = l
+		}
+		conditions[label] = exportCellString(value)
+	}
+	for name, value := range req.Search {
+		label := name
+		if l := labels[name]; l != "" {
+			label = l
+		}
+		conditions[label] = "包含 " + exportCellString(value)
+	}
+	return conditions
+}
+
+// exportTypedValue 规范化原始值，供 JSON 等保留类型的格式使用
+func exportTypedValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		return string(v)
+	default:
+		return v
+	}
+}
@@ -0,0 +1,226 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"fun-admin/pkg/admin"
+)
+
+// ErrNotReorderable 表示资源未实现 admin.Reorderable
+var ErrNotReorderable = errors.New("resource is not reorderable")
+
+// ReorderInput 排序请求
+// IDs 为记录的新顺序，可只包含部分记录（如当前页），其余记录位置不变；
+// 或指定 ID 与 Before/After 之一，将记录移动到目标记录之前/之后
+type ReorderInput struct {
+	IDs    []interface{} `json:"ids"`
+	ID     interface{}   `json:"id"`
+	Before interface{}   `json:"before"`
+	After  interface{}   `json:"after"`
+}
+
+// Reorder 调整记录顺序并在一个事务内重新编号，返回实际更新的记录数
+// 分组内排序值严格递增时沿用原有排序值，仅移动范围内的记录被更新；存在重复或空值时按 1..n 重新编号
+func (s *ResourceService) Reorder(ctx context.Context, resourceSlug string, input *ReorderInput) (int64, error) {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return 0, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	ro, ok := resource.(admin.Reorderable)
+	if !ok || ro.GetOrderColumn() == "" {
+		return 0, ErrNotReorderable
+	}
+	column := ro.GetOrderColumn()
+
+	anchor := input.ID
+	if len(input.IDs) > 0 {
+		anchor = input.IDs[0]
+	} else if input.ID == nil || (input.Before == nil) == (input.After == nil) {
+		return 0, &ValidationError{Errors: map[string][]string{"ids": {"请提供记录顺序，或提供 id 与 before/after 之一"}}}
+	}
+
+	// 读取、计算与重新编号在同一事务内完成，排序分组内的记录被锁定，避免并发排序互相覆盖
+	var changed []interface{}
+	err := s.withTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		changed, err = s.reorderRows(ctx, resource, column, anchor, input)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	s.clearRecordsCache(ctx, resourceSlug, changed)
+	return int64(len(changed)), nil
+}
+
+// reorderRows 锁定并读取排序分组、计算新顺序并写入变化的排序值，返回被更新的记录 ID
+func (s *ResourceService) reorderRows(
+	ctx context.Context,
+	resource admin.Resource,
+	column string,
+	anchor interface{},
+	input *ReorderInput,
+) ([]interface{}, error) {
+	resourceSlug := resource.GetSlug()
+	record, err := s.resourceRepository.FindByID(ctx, resourceSlug, anchor)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record["deleted_at"] != nil {
+		return nil, ErrRecordNotFound
+	}
+	scope := make(map[string]interface{})
+	if rs, ok := resource.(admin.ReorderScope); ok {
+		for _, field := range rs.GetReorderScope() {
+			scope[field] = record[field]
+		}
+	}
+
+	rows, err := s.resourceRepository.OrderRows(ctx, resourceSlug, column, scope)
+	if err != nil {
+		return nil, err
+	}
+	positions := make(map[string]int, len(rows))
+	for i, row := range rows {
+		positions[relationIDString(row["id"])] = i
+	}
+	lookup := func(id interface{}) (int, error) {
+		pos, ok := positions[relationIDString(id)]
+		if !ok {
+			return 0, &ValidationError{Errors: map[string][]string{"ids": {"记录不存在或不在同一排序分组: " + relationIDString(id)}}}
+		}
+		return pos, nil
+	}
+
+	var ordered []map[string]interface{}
+	if len(input.IDs) > 0 {
+		ordered, err = reorderByIDs(rows, input.IDs, lookup)
+	} else {
+		ordered, err = reorderByMove(rows, input, lookup)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 原排序值严格递增时按位置沿用，否则重新编号
+	targets := make([]int64, len(rows))
+	increasing := true
+	for i, row := range rows {
+		value, ok := orderValue(row[column])
+		if !ok || (i > 0 && value <= targets[i-1]) {
+			increasing = false
+			break
+		}
+		targets[i] = value
+	}
+	if !increasing {
+		for i := range targets {
+			targets[i] = int64(i + 1)
+		}
+	}
+
+	changes := make(map[string]int64)
+	changed := make([]interface{}, 0)
+	for i, row := range ordered {
+		if value, ok := orderValue(row[column]); ok && value == targets[i] {
+			continue
+		}
+		changes[relationIDString(row["id"])] = targets[i]
+		changed = append(changed, row["id"])
+	}
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		for _, id := range changed {
+			if err := auth.CanUpdate(ctx, id, map[string]interface{}{column: changes[relationIDString(id)]}); err != nil {
+				return nil, permissionDenied(err)
+			}
+		}
+	}
+
+	for _, id := range changed {
+		if err := s.resourceRepository.Update(ctx, resourceSlug, id, map[string]interface{}{column: changes[relationIDString(id)]}); err != nil {
+			return nil, err
+		}
+	}
+	return changed, nil
+}
+
+// reorderByIDs 将列出的记录按给定顺序填回它们原来占据的位置
+func reorderByIDs(rows []map[string]interface{}, ids []interface{}, lookup func(interface{}) (int, error)) ([]map[string]interface{}, error) {
+	slots := make([]int, 0, len(ids))
+	listed := make([]map[string]interface{}, 0, len(ids))
+	seen := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		pos, err := lookup(id)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[pos]; ok {
+			continue
+		}
+		seen[pos] = struct{}{}
+		listed = append(listed, rows[pos])
+	}
+	for i := range rows {
+		if _, ok := seen[i]; ok {
+			slots = append(slots, i)
+		}
+	}
+	ordered := append([]map[string]interface{}(nil), rows...)
+	for i, pos := range slots {
+		ordered[pos] = listed[i]
+	}
+	return ordered, nil
+}
+
+// reorderByMove 将记录移动到目标记录之前或之后
+func reorderByMove(rows []map[string]interface{}, input *ReorderInput, lookup func(interface{}) (int, error)) ([]map[string]interface{}, error) {
+	from, err := lookup(input.ID)
+	if err != nil {
+		return nil, err
+	}
+	target := input.Before
+	if target == nil {
+		target = input.After
+	}
+	to, err := lookup(target)
+	if err != nil {
+		return nil, err
+	}
+	if from == to {
+		return rows, nil
+	}
+	moving := rows[from]
+	rest := make([]map[string]interface{}, 0, len(rows))
+	rest = append(rest, rows[:from]...)
+	rest = append(rest, rows[from+1:]...)
+	if to > from {
+		to--
+	}
+	if input.After != nil {
+		to++
+	}
+	ordered := make([]map[string]interface{}, 0, len(rows))
+	ordered = append(ordered, rest[:to]...)
+	ordered = append(ordered, moving)
+	ordered = append(ordered, rest[to:]...)
+	return ordered, nil
+}
+
+// orderValue 将不同驱动返回的排序值解析为整数
+func orderValue(v interface{}) (int64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	var value int64
+	if _, err := fmt.Sscan(relationIDString(v), &value); err != nil {
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"fun-admin/pkg/admin"
+)
+
+// replicateMaxDepth 关系深度复制的最大层数，防止关系声明成环
+const replicateMaxDepth = 5
+
+// Replicate 复制记录：按资源的 ReplicateOptions 拷贝字段，合并 overrides 后经创建流程（钩子、校验）保存
+// 声明的关系（一对多子记录、中间表关联）在同一事务内一并复制，返回新记录
+func (s *ResourceService) Replicate(ctx context.Context, resourceSlug string, id interface{}, overrides map[string]interface{}) (map[string]interface{}, error) {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanView(ctx, id); err != nil {
+			return nil, permissionDenied(err)
+		}
+	}
+	source, err := s.resourceRepository.FindByID(ctx, resourceSlug, id)
+	if err != nil {
+		return nil, err
+	}
+	if source == nil || source["deleted_at"] != nil {
+		return nil, ErrRecordNotFound
+	}
+
+	var created map[string]interface{}
+	err = s.withTransaction(ctx, func(ctx context.Context) error {
+		created, err = s.replicateRecord(ctx, resource, source, overrides, nil, 0)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.filterReadableRecord(ctx, resource, created), nil
+}
+
+// replicateRecord 复制单条记录及其声明的关系，fixed 为由上级确定的字段（如外键）
+func (s *ResourceService) replicateRecord(
+	ctx context.Context,
+	resource admin.Resource,
+	source map[string]interface{},
+	overrides map[string]interface{},
+	fixed map[string]interface{},
+	depth int,
+) (map[string]interface{}, error) {
+	var opts admin.ReplicateOptions
+	if r, ok := resource.(admin.Replicable); ok {
+		opts = r.GetReplicateOptions()
+	}
+
+	data := s.replicateData(ctx, resource, source, opts)
+	for field := range fixed {
+		delete(data, field)
+	}
+	if err := s.replicateTranslations(ctx, resource, source, data); err != nil {
+		return nil, err
+	}
+	for field, value := range overrides {
+		data[field] = value
+	}
+	created, err := s.createRecord(ctx, resource, data, fixed)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(opts.Relations) == 0 {
+		return created, nil
+	}
+	if depth >= replicateMaxDepth {
+		return created, nil
+	}
+	for _, name := range opts.Relations {
+		manager := admin.GetRelationManager(resource, name)
+		if manager == nil {
+			return nil, ErrRelationNotFound
+		}
+		if err := s.replicateRelation(ctx, manager, source, created, depth); err != nil {
+			return nil, err
+		}
+	}
+	return created, nil
+}
+
+// replicateRelation 复制关系：一对多逐条复制子记录并指向新记录，多对多复制中间表关联
+func (s *ResourceService) replicateRelation(
+	ctx context.Context,
+	manager *admin.RelationManager,
+	source map[string]interface{},
+	created map[string]interface{},
+	depth int,
+) error {
+	ownerKey := manager.OwnerKey
+	if ownerKey == "" {
+		ownerKey = "id"
+	}
+	sourceOwner, newOwner := source[ownerKey], created[ownerKey]
+	if sourceOwner == nil || newOwner == nil {
+		return nil
+	}
+
+	if manager.IsPivot() {
+		ids, err := s.resourceRepository.PivotRelatedIDs(ctx, manager.PivotTable, manager.ForeignKey, manager.RelatedKey,
+			sourceOwner, manager.PivotSoftDelete)
+		if err != nil || len(ids) == 0 {
+			return err
+		}
+		return s.resourceRepository.AttachPivot(ctx, manager.PivotTable, manager.ForeignKey, manager.RelatedKey,
+			newOwner, ids, manager.PivotSoftDelete)
+	}
+
+	child := s.resourceManager.GetResourceBySlug(manager.Resource)
+	if child == nil {
+		return &ResourceNotFoundError{ResourceSlug: manager.Resource}
+	}
+	rows, err := s.resourceRepository.FindAllByField(ctx, manager.Resource, manager.ForeignKey, sourceOwner)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := s.replicateRecord(ctx, child, row, nil, map[string]interface{}{manager.ForeignKey: newOwner}, depth+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replicateData 从源记录中提取可复制的字段：跳过主键、时间戳、只读、无写权限与排除字段，并应用字段转换
+func (s *ResourceService) replicateData(
+	ctx context.Context,
+	resource admin.Resource,
+	source map[string]interface{},
+	opts admin.ReplicateOptions,
+) map[string]interface{} {
+	skip := s.getReadOnlyFieldSet(resource)
+	if skip == nil {
+		skip = make(map[string]struct{})
+	}
+	for _, field := range []string{"id", "created_at", "updated_at", "deleted_at"} {
+		skip[field] = struct{}{}
+	}
+	for _, field := range opts.Exclude {
+		skip[field] = struct{}{}
+	}
+	if tree, ok := admin.GetTreeOptions(resource); ok {
+		skip[tree.PathKey] = struct{}{}
+	}
+	writable := s.getWritableFieldSet(ctx, resource)
+
+	data := make(map[string]interface{})
+	for _, field := range s.getFieldNames(resource) {
+		if _, ok := skip[field]; ok {
+			continue
+		}
+		if writable != nil {
+			if _, ok := writable[field]; !ok {
+				continue
+			}
+		}
+		value, ok := source[field]
+		if !ok || value == nil {
+			continue
+		}
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		if transform, ok := opts.Transform[field]; ok && transform != nil {
+			value = transform(value)
+		}
+		data[field] = value
+	}
+	return data
+}
+
+// isReplicateAction 判断资源是否启用了内置复制动作：声明了 replicate 动作或实现了 admin.Replicable
+func isReplicateAction(resource admin.Resource, actionName string) bool {
+	if actionName != admin.ReplicateActionName {
+		return false
+	}
+	if _, ok := resource.(admin.Replicable); ok {
+		return true
+	}
+	for _, action := range resource.GetActions() {
+		if action.GetName() == admin.ReplicateActionName {
+			return true
+		}
+	}
+	return false
+}
+
+// runReplicateAction 逐条复制所选记录，params 作为覆盖字段；多条记录在同一事务内复制
+func (s *ResourceService) runReplicateAction(
+	ctx context.Context,
+	resourceSlug string,
+	ids []interface{},
+	params map[string]interface{},
+) (interface{}, error) {
+	if len(ids) == 0 {
+		return nil, &ValidationError{Errors: map[string][]string{"ids": {"请选择要复制的记录"}}}
+	}
+	records := make([]map[string]interface{}, 0, len(ids))
+	err := s.withTransaction(ctx, func(ctx context.Context) error {
+		for _, id := range ids {
+			record, err := s.Replicate(ctx, resourceSlug, id, params)
+			if err != nil {
+				return err
+			}
+			records = append(records, record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 1 {
+		return records[0], nil
+	}
+	return map[string]interface{}{"records": records, "created": len(records)}, nil
+}
@@ -12,23 +12,29 @@ import (
 
 // ResourceService 资源服务层
 type ResourceService struct {
-	resourceRepository *repository.ResourceRepository
-	resourceManager    *admin.ResourceManager
-	exportService      *ExportService
-	cacheManager       cache.CacheManager
+	resourceRepository    *repository.ResourceRepository
+	attachmentRepository  repository.AttachmentRepository
+	translationRepository repository.TranslationRepository
+	resourceManager       *admin.ResourceManager
+	exportService         *ExportService
+	cacheManager          cache.CacheManager
 }
 
 // NewResourceService 创建资源服务层
 func NewResourceService(
 	resourceRepository *repository.ResourceRepository,
+	attachmentRepository repository.AttachmentRepository,
+	translationRepository repository.TranslationRepository,
 	resourceManager *admin.ResourceManager,
 	cacheManager cache.CacheManager,
 ) *ResourceService {
 	return &ResourceService{
-		resourceRepository: resourceRepository,
-		resourceManager:    resourceManager,
-		exportService:      NewExportService(),
-		cacheManager:       cacheManager,
+		resourceRepository:    resourceRepository,
+		attachmentRepository:  attachmentRepository,
+		translationRepository: translationRepository,
+		resourceManager:       resourceManager,
+		exportService:         NewExportService(),
+		cacheManager:          cacheManager,
 	}
 }
 
@@ -38,6 +44,12 @@ func (s *ResourceService) Create(ctx context.Context, resourceSlug string, data
 	if resource == nil {
 		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
 	}
+	return s.createRecord(ctx, resource, data, nil)
+}
+
+// createRecord 创建记录；fixed 为由上下文确定的字段（如关系外键），在字段权限校验后写入
+func (s *ResourceService) createRecord(ctx context.Context, resource admin.Resource, data map[string]interface{}, fixed map[string]interface{}) (map[string]interface{}, error) {
+	resourceSlug := resource.GetSlug()
 	if auth, ok := resource.(admin.Authorizable); ok {
 		if err := auth.CanCreate(ctx, data); err != nil {
 			return nil, err
@@ -48,6 +60,13 @@ func (s *ResourceService) Create(ctx context.Context, resourceSlug string, data
 		return nil, err
 	}
 	data = permittedData
+	for field, value := range fixed {
+		data[field] = value
+	}
+	translations, _, err := s.extractTranslations(ctx, resource, data, true)
+	if err != nil {
+		return nil, err
+	}
 	if hook, ok := resource.(admin.CreateHook); ok {
 		if err := hook.BeforeCreate(ctx, data); err != nil {
 			return nil, err
@@ -57,7 +76,29 @@ func (s *ResourceService) Create(ctx context.Context, resourceSlug string, data
 	if len(errors) > 0 {
 		return nil, &ValidationError{Errors: errors}
 	}
-	if err := s.resourceRepository.Create(ctx, resourceSlug, data); err != nil {
+	insert := func(ctx context.Context) error {
+		if opts, ok := admin.GetTreeOptions(resource); ok {
+			return s.insertTreeNode(ctx, resourceSlug, opts, data)
+		}
+		id, err := s.resourceRepository.Create(ctx, resourceSlug, data)
+		if err != nil {
+			return err
+		}
+		data["id"] = id
+		return nil
+	}
+	if len(translations) > 0 {
+		// 翻译以新记录 ID 关联，与记录在同一事务内写入
+		err = s.withTransaction(ctx, func(ctx context.Context) error {
+			if err := insert(ctx); err != nil {
+				return err
+			}
+			return s.saveTranslations(ctx, resourceSlug, data["id"], translations)
+		})
+	} else {
+		err = insert(ctx)
+	}
+	if err != nil {
 		return nil, err
 	}
 	if hook, ok := resource.(admin.CreateHook); ok {
@@ -85,6 +126,10 @@ func (s *ResourceService) Update(ctx context.Context, resourceSlug string, id in
 		return err
 	}
 	data = permittedData
+	translations, skip, err := s.extractTranslations(ctx, resource, data, false)
+	if err != nil {
+		return err
+	}
 	if hook, ok := resource.(admin.UpdateHook); ok {
 		if err := hook.BeforeUpdate(ctx, id, data); err != nil {
 			return err
@@ -94,7 +139,40 @@ func (s *ResourceService) Update(ctx context.Context, resourceSlug string, id in
 	if len(errors) > 0 {
 		return &ValidationError{Errors: errors}
 	}
-	if err := s.resourceRepository.Update(ctx, resourceSlug, id, data); err != nil {
+	// 非默认语言的值只写入翻译，记录列保持默认语言的值
+	for field := range skip {
+		delete(data, field)
+	}
+	if opts, ok := admin.GetTreeOptions(resource); ok {
+		write, move, err := s.prepareTreeUpdate(ctx, resourceSlug, opts, id, data)
+		if err != nil {
+			return err
+		}
+		err = s.withTransaction(ctx, func(ctx context.Context) error {
+			if err := s.resourceRepository.Update(ctx, resourceSlug, id, write); err != nil {
+				return err
+			}
+			if move != nil {
+				if err := move(ctx); err != nil {
+					return err
+				}
+			}
+			return s.saveTranslations(ctx, resourceSlug, id, translations)
+		})
+		if err != nil {
+			return err
+		}
+	} else if len(translations) > 0 {
+		err = s.withTransaction(ctx, func(ctx context.Context) error {
+			if err := s.resourceRepository.Update(ctx, resourceSlug, id, data); err != nil {
+				return err
+			}
+			return s.saveTranslations(ctx, resourceSlug, id, translations)
+		})
+		if err != nil {
+			return err
+		}
+	} else if err := s.resourceRepository.Update(ctx, resourceSlug, id, data); err != nil {
 		return err
 	}
 	if hook, ok := resource.(admin.UpdateHook); ok {
@@ -119,11 +197,17 @@ func (s *ResourceService) Delete(ctx context.Context, resourceSlug string, id in
 			return err
 		}
 	}
+	if opts, ok := admin.GetTreeOptions(resource); ok {
+		if err := s.ensureTreeLeaves(ctx, resourceSlug, opts, []interface{}{id}); err != nil {
+			return err
+		}
+	}
 	if hook, ok := resource.(admin.DeleteHook); ok {
 		if err := hook.BeforeDelete(ctx, id); err != nil {
 			return err
 		}
 	}
+	// 软删除保留附件，恢复后文件仍可用；附件在 ForceDelete 时才移除
 	if err := s.resourceRepository.Delete(ctx, resourceSlug, id); err != nil {
 		return err
 	}
@@ -146,6 +230,12 @@ func (s *ResourceService) DeleteBatch(ctx context.Context, resourceSlug string,
 		return 0, &ResourceNotFoundError{ResourceSlug: resourceSlug}
 	}
 
+	if opts, ok := admin.GetTreeOptions(resource); ok {
+		if err := s.ensureTreeLeaves(ctx, resourceSlug, opts, ids); err != nil {
+			return 0, err
+		}
+	}
+
 	// 批量删除记录
 	affected, err := s.resourceRepository.DeleteBatch(ctx, resourceSlug, ids)
 	if err != nil {
@@ -181,7 +271,7 @@ func (s *ResourceService) Get(ctx context.Context, resourceSlug string, id inter
 
 		if result, ok := cached.(map[string]interface{}); ok {
 
-			return s.filterReadableRecord(ctx, resource, result), nil
+			return s.localizeRecord(ctx, resource, result)
 
 		}
 
@@ -213,8 +303,47 @@ func (s *ResourceService) Get(ctx context.Context, resourceSlug string, id inter
 
 	s.cacheManager.Set(ctx, cacheKey, result, cache.DefaultExpiration)
 
-	return s.filterReadableRecord(ctx, resource, result), nil
+	return s.localizeRecord(ctx, resource, result)
+
+}
+
+// localizeRecord 过滤可读字段后按请求语言返回可翻译字段，并附带全部语言值
+func (s *ResourceService) localizeRecord(ctx context.Context, resource admin.Resource, record map[string]interface{}) (map[string]interface{}, error) {
+	filtered := s.filterReadableRecord(ctx, resource, record)
+	if filtered == nil {
+		return nil, nil
+	}
+	if err := s.localizeRecords(ctx, resource, []map[string]interface{}{filtered}, true); err != nil {
+		return nil, err
+	}
+	return filtered, nil
+}
 
+// FindByField 按字段值查找未删除的记录，用于导入时按键匹配
+func (s *ResourceService) FindByField(ctx context.Context, resourceSlug string, field string, value interface{}) (map[string]interface{}, error) {
+	if s.resourceManager.GetResourceBySlug(resourceSlug) == nil {
+		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	return s.resourceRepository.FindByField(ctx, resourceSlug, field, value)
+}
+
+// CheckWritable 校验数据中的字段均可写，不执行写入
+func (s *ResourceService) CheckWritable(ctx context.Context, resourceSlug string, data map[string]interface{}) error {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	_, err := s.enforceWritableFields(ctx, resource, data)
+	return err
+}
+
+// WritableFieldSet 当前用户可写入的字段集合：字段权限允许写入且不是只读字段，与 CheckWritable 的判定一致
+func (s *ResourceService) WritableFieldSet(ctx context.Context, resource admin.Resource) map[string]struct{} {
+	writable := s.getWritableFieldSet(ctx, resource)
+	for field := range s.getReadOnlyFieldSet(resource) {
+		delete(writable, field)
+	}
+	return writable
 }
 
 // List 获取资源记录列表
@@ -236,17 +365,9 @@ func (s *ResourceService) List(
 		}
 	}
 	// 解析布尔值字符串
-	for k, v := range filters {
-		if sv, ok := v.(string); ok {
-			lv := strings.ToLower(sv)
-			if lv == "true" {
-				filters[k] = true
-			}
-			if lv == "false" {
-				filters[k] = false
-			}
-		}
-	}
+	parseBoolFilters(filters)
+	// 树形资源的子树过滤：descendants_of=节点ID
+	descendantsOf, hasDescendants := filters["descendants_of"]
 	// 白名单过滤：filters/search/orderBy
 	filters = s.sanitizeFilters(resource, filters)
 	search = s.sanitizeSearch(resource, search)
@@ -260,15 +381,27 @@ func (s *ResourceService) List(
 	}
 
 	cacheKey := s.getListCacheKey(resourceSlug, page, pageSize, filters, search, orderBy, orderDirection)
+	if opts, ok := admin.GetTreeOptions(resource); ok && hasDescendants {
+		cacheKey += ":descendants-" + relationIDString(descendantsOf)
+		ids, err := s.treeDescendantIDs(ctx, resourceSlug, opts, descendantsOf)
+		if err != nil {
+			return nil, 0, err
+		}
+		filters["id"] = ids
+	}
 	if cached, err := s.cacheManager.Get(ctx, cacheKey); err == nil && cached != nil {
 		if result, ok := cached.(map[string]interface{}); ok {
 			if items, ok := result["items"].([]map[string]interface{}); ok {
 				if total, ok := result["total"].(int64); ok {
-					return s.filterReadableList(ctx, resource, items), total, nil
+					return s.localizeList(ctx, resource, items, total)
 				}
 			}
 		}
 	}
+	search, err := s.translateSearch(ctx, resource, search)
+	if err != nil {
+		return nil, 0, err
+	}
 	results, total, err := s.resourceRepository.ListWithRelationshipsAndFilters(
 		ctx, resourceSlug, page, pageSize, relationships, filters, search, orderBy, orderDirection)
 	if err != nil {
@@ -276,76 +409,16 @@ func (s *ResourceService) List(
 	}
 	cacheData := map[string]interface{}{"items": results, "total": total}
 	s.cacheManager.Set(ctx, cacheKey, cacheData, cache.DefaultExpiration)
-	return s.filterReadableList(ctx, resource, results), total, nil
+	return s.localizeList(ctx, resource, results, total)
 }
 
-// Export 导出资源数据
-func (s *ResourceService) Export(
-	ctx context.Context,
-	resourceSlug string,
-	filters map[string]interface{}, // 精确过滤条件
-	search map[string]interface{}, // 模糊搜索条件
-	orderBy string, // 排序字段
-	orderDirection string, // 排序方向 ASC/DESC
-	format string, // 导出格式 (csv, excel)
-) ([]byte, string, error) {
-	// 获取资源配置
-	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
-	if resource == nil {
-		return nil, "", &ResourceNotFoundError{ResourceSlug: resourceSlug}
-	}
-
-	// 检查资源是否支持导出
-	if exportable, ok := resource.(admin.Exportable); ok {
-		if !exportable.IsExportable() {
-			return nil, "", fmt.Errorf("资源不支持导出功能")
-		}
-	}
-
-	// 白名单过滤与默认排序
-	filters = s.sanitizeFilters(resource, filters)
-	search = s.sanitizeSearch(resource, search)
-	orderBy, orderDirection = s.sanitizeOrder(resource, orderBy, orderDirection)
-
-	// 获取所有数据（不分页）
-	results, _, err := s.resourceRepository.ListWithRelationshipsAndFilters(
-		ctx, resourceSlug, 1, 10000, s.getRelationships(resource), filters, search, orderBy, orderDirection)
-	if err != nil {
-		return nil, "", err
-	}
-
-	// 获取字段配置
-	fields := resource.GetFields()
-
-	// 构建表头映射
-	headers := make(map[string]string)
-	for _, field := range fields {
-		headers[field.GetName()] = field.GetLabel()
-	}
-
-	// 处理导出数据
-	var exportedData []byte
-	var filename string
-
-	switch strings.ToLower(format) {
-	case "excel", "xlsx":
-		exportedData, err = s.exportService.ExportToExcel(results, headers)
-		if err != nil {
-			return nil, "", err
-		}
-		filename = s.exportService.GenerateFileName(resource.GetTitle(), "xlsx")
-
-	case "csv":
-		fallthrough
-	default:
-		exportedData, err = s.exportService.ExportToCSV(results, headers)
-		if err != nil {
-			return nil, "", err
-		}
-		filename = s.exportService.GenerateFileName(resource.GetTitle(), "csv")
+// localizeList 过滤可读字段后按请求语言返回可翻译字段
+func (s *ResourceService) localizeList(ctx context.Context, resource admin.Resource, items []map[string]interface{}, total int64) ([]map[string]interface{}, int64, error) {
+	filtered := s.filterReadableList(ctx, resource, items)
+	if err := s.localizeRecords(ctx, resource, filtered, false); err != nil {
+		return nil, 0, err
 	}
-
-	return exportedData, filename, nil
+	return filtered, total, nil
 }
 
 // Restore 恢复软删除
@@ -381,6 +454,13 @@ func (s *ResourceService) ForceDelete(ctx context.Context, resourceSlug string,
 	if err := s.resourceRepository.ForceDelete(ctx, resourceSlug, id); err != nil {
 		return err
 	}
+	if err := s.translationRepository.DeleteRecordTranslations(ctx, resourceSlug, recordIDsToStrings([]interface{}{id})); err != nil {
+		return err
+	}
+	// 附件随记录硬删除一同软删除，存储文件在保留期后由垃圾回收任务清理
+	if err := s.attachmentRepository.DeleteRecordAttachments(ctx, resourceSlug, recordIDsToStrings([]interface{}{id})); err != nil {
+		return err
+	}
 	s.clearResourceCache(ctx, resourceSlug)
 	return nil
 }
@@ -397,6 +477,9 @@ func (s *ResourceService) RunAction(
 	if resource == nil {
 		return nil, &ResourceNotFoundError{ResourceSlug: resourceSlug}
 	}
+	if isReplicateAction(resource, actionName) {
+		return s.runReplicateAction(ctx, resourceSlug, ids, params)
+	}
 	executor, ok := resource.(admin.ActionExecutor)
 	if !ok {
 		return s.handleBuiltInAction(ctx, resourceSlug, actionName, ids, params)
@@ -428,6 +511,9 @@ func (s *ResourceService) handleBuiltInAction(
 		if err != nil {
 			return nil, err
 		}
+		if err := s.attachmentRepository.DeleteRecordAttachments(ctx, resourceSlug, recordIDsToStrings(ids)); err != nil {
+			return nil, err
+		}
 		return map[string]interface{}{"deleted": count}, nil
 	default:
 		return nil, ErrActionNotSupported
@@ -518,7 +604,10 @@ func (s *ResourceService) sanitizeOrder(resource admin.Resource, orderBy, orderD
 		orderBy = ""
 	}
 	if orderBy == "" {
-		if def, ok := resource.(admin.DefaultOrder); ok {
+		if ro, ok := resource.(admin.Reorderable); ok && ro.GetOrderColumn() != "" {
+			// 可拖拽排序的资源默认按排序列升序
+			orderBy, orderDirection = ro.GetOrderColumn(), "ASC"
+		} else if def, ok := resource.(admin.DefaultOrder); ok {
 			f, d := def.GetDefaultOrder()
 			orderBy, orderDirection = f, normalizeDir(d)
 		}
@@ -528,6 +617,21 @@ func (s *ResourceService) sanitizeOrder(resource admin.Resource, orderBy, orderD
 	return orderBy, orderDirection
 }
 
+// parseBoolFilters 将过滤条件中的 "true"/"false" 字符串解析为布尔值
+func parseBoolFilters(filters map[string]interface{}) {
+	for k, v := range filters {
+		if sv, ok := v.(string); ok {
+			lv := strings.ToLower(sv)
+			if lv == "true" {
+				filters[k] = true
+			}
+			if lv == "false" {
+				filters[k] = false
+			}
+		}
+	}
+}
+
 func toSet(list []string) map[string]struct{} {
 	s := make(map[string]struct{}, len(list))
 	for _, v := range list {
@@ -0,0 +1,257 @@
+package service
+
+import (
+	"context"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
+	"sort"
+)
+
+// TranslationsKey 记录详情中可翻译字段的全部语言值：字段 -> 语言 -> 值
+const TranslationsKey = "_translations"
+
+// fieldTranslations 可翻译字段待保存的多语言值：字段 -> 语言 -> 值
+type fieldTranslations map[string]map[string]string
+
+// extractTranslations 拆分写入数据中可翻译字段的值
+// 单个文本作用于请求语言：默认语言写入记录列，其他语言写入翻译表（创建时同时作为记录列的值）；
+// 语言映射中默认语言的值写入记录列，其余语言写入翻译表。
+// data 中的字段被替换为文本以便钩子与校验处理；返回的 skip 为不应写入记录列的字段（更新非默认语言时）
+func (s *ResourceService) extractTranslations(
+	ctx context.Context,
+	resource admin.Resource,
+	data map[string]interface{},
+	creating bool,
+) (fieldTranslations, map[string]struct{}, error) {
+	fields := admin.GetTranslatableFields(resource)
+	if len(fields) == 0 {
+		return nil, nil, nil
+	}
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	translations := make(fieldTranslations)
+	skip := make(map[string]struct{})
+	errs := make(map[string][]string)
+
+	for _, field := range fields {
+		raw, ok := data[field]
+		if !ok || raw == nil {
+			continue
+		}
+		var values map[string]string
+		switch v := raw.(type) {
+		case string:
+			language := i18n.Normalize(i18n.FromContext(ctx))
+			if language == defaultLanguage {
+				continue
+			}
+			translations[field] = map[string]string{language: v}
+			if !creating {
+				skip[field] = struct{}{}
+			}
+			continue
+		case map[string]string:
+			values = make(map[string]string, len(v))
+			for language, text := range v {
+				values[i18n.Normalize(language)] = text
+			}
+		case map[string]interface{}:
+			values = make(map[string]string, len(v))
+			for language, text := range v {
+				if text == nil {
+					values[i18n.Normalize(language)] = ""
+					continue
+				}
+				str, ok := text.(string)
+				if !ok {
+					errs[field] = append(errs[field], "翻译值必须为文本")
+					break
+				}
+				values[i18n.Normalize(language)] = str
+			}
+			if len(errs[field]) > 0 {
+				continue
+			}
+		default:
+			continue
+		}
+
+		base, hasBase := values[defaultLanguage]
+		delete(values, defaultLanguage)
+		if len(values) > 0 {
+			translations[field] = values
+		}
+		if hasBase {
+			data[field] = base
+			continue
+		}
+		// 未提供默认语言的值：创建时以回退到的值填充记录列，更新时保留记录列原值
+		data[field] = fallbackTranslation(defaultLanguage, values)
+		if !creating {
+			skip[field] = struct{}{}
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, nil, &ValidationError{Errors: errs}
+	}
+	return translations, skip, nil
+}
+
+// fallbackTranslation 按默认语言的回退链选取值，均未命中时取按语言排序的第一个非空值
+func fallbackTranslation(defaultLanguage string, values map[string]string) string {
+	if value, _, ok := i18n.Resolve(defaultLanguage, values); ok {
+		return value
+	}
+	languages := make([]string, 0, len(values))
+	for language := range values {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	for _, language := range languages {
+		if values[language] != "" {
+			return values[language]
+		}
+	}
+	return ""
+}
+
+// saveTranslations 保存记录的多语言值，调用方需保证处于写入记录的同一事务
+func (s *ResourceService) saveTranslations(ctx context.Context, resourceSlug string, id interface{}, translations fieldTranslations) error {
+	recordID := relationIDString(id)
+	for field, values := range translations {
+		if err := s.translationRepository.SaveTranslations(ctx, resourceSlug, recordID, field, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadTranslations 读取记录可翻译字段的多语言值：记录ID -> 字段 -> 语言 -> 值，记录列的值作为默认语言的值
+func (s *ResourceService) loadTranslations(
+	ctx context.Context,
+	resourceSlug string,
+	fields []string,
+	records []map[string]interface{},
+) (map[string]map[string]map[string]string, error) {
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	result := make(map[string]map[string]map[string]string, len(records))
+	ids := make([]string, 0, len(records))
+	for _, record := range records {
+		id := relationIDString(record["id"])
+		ids = append(ids, id)
+		result[id] = make(map[string]map[string]string, len(fields))
+		for _, field := range fields {
+			values := make(map[string]string)
+			if base, ok := record[field].(string); ok && base != "" {
+				values[defaultLanguage] = base
+			}
+			result[id][field] = values
+		}
+	}
+	rows, err := s.translationRepository.GetTranslations(ctx, resourceSlug, ids, fields)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		if values, ok := result[row.RecordID][row.FieldName]; ok && row.Locale != defaultLanguage {
+			values[row.Locale] = row.Value
+		}
+	}
+	return result, nil
+}
+
+// localizeRecords 将记录中的可翻译字段替换为请求语言的值（按回退链），records 须为可修改的副本
+// withAll 为 true 时在 TranslationsKey 下附带各字段的全部语言值，供编辑表单使用
+func (s *ResourceService) localizeRecords(ctx context.Context, resource admin.Resource, records []map[string]interface{}, withAll bool) error {
+	if len(records) == 0 {
+		return nil
+	}
+	translatable := admin.GetTranslatableFields(resource)
+	fields := make([]string, 0, len(translatable))
+	for _, field := range translatable {
+		if _, ok := records[0][field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	language := i18n.Normalize(i18n.FromContext(ctx))
+	if !withAll && language == i18n.GlobalResourceManager.DefaultLanguage() {
+		return nil
+	}
+	translations, err := s.loadTranslations(ctx, resource.GetSlug(), fields, records)
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		byField := translations[relationIDString(record["id"])]
+		for _, field := range fields {
+			if value, _, ok := i18n.Resolve(language, byField[field]); ok {
+				record[field] = value
+			}
+		}
+		if withAll {
+			record[TranslationsKey] = byField
+		}
+	}
+	return nil
+}
+
+// translateSearch 可翻译字段的搜索同时匹配各语言的翻译
+func (s *ResourceService) translateSearch(ctx context.Context, resource admin.Resource, search map[string]interface{}) (map[string]interface{}, error) {
+	fields := admin.GetTranslatableFields(resource)
+	if len(fields) == 0 || len(search) == 0 {
+		return search, nil
+	}
+	translated := make(map[string]interface{}, len(search))
+	for field, value := range search {
+		translated[field] = value
+	}
+	for _, field := range fields {
+		keyword, ok := search[field].(string)
+		if !ok {
+			continue
+		}
+		ids, err := s.translationRepository.SearchRecordIDs(ctx, resource.GetSlug(), field, keyword)
+		if err != nil {
+			return nil, err
+		}
+		translated[field] = repository.TranslatedSearch{Keyword: keyword, RecordIDs: ids}
+	}
+	return translated, nil
+}
+
+// replicateTranslations 复制记录时带上源记录的翻译：可翻译字段改为语言映射，经创建流程一并保存
+func (s *ResourceService) replicateTranslations(ctx context.Context, resource admin.Resource, source, data map[string]interface{}) error {
+	fields := make([]string, 0)
+	for _, field := range admin.GetTranslatableFields(resource) {
+		if _, ok := data[field]; ok {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	translations, err := s.loadTranslations(ctx, resource.GetSlug(), fields, []map[string]interface{}{source})
+	if err != nil {
+		return err
+	}
+	defaultLanguage := i18n.GlobalResourceManager.DefaultLanguage()
+	for field, values := range translations[relationIDString(source["id"])] {
+		if len(values) == 0 {
+			continue
+		}
+		localized := make(map[string]string, len(values))
+		for language, value := range values {
+			localized[language] = value
+		}
+		// 默认语言的值以复制后的字段值为准（可能已追加后缀）
+		if base, ok := data[field].(string); ok {
+			localized[defaultLanguage] = base
+		}
+		data[field] = localized
+	}
+	return nil
+}
@@ -0,0 +1,496 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/database"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotTreeResource 表示资源未实现 admin.TreeResource
+	ErrNotTreeResource = errors.New("resource is not a tree")
+	// ErrTreePathMissing 表示节点缺少物化路径，需要先重建树路径
+	ErrTreePathMissing = errors.New("tree path is missing, rebuild tree paths first")
+)
+
+// Tree 获取嵌套树，每个节点的子节点位于 children
+// rootID 非空时返回以该节点为根的子树；maxDepth > 0 时限制返回的层数，1 表示仅返回根节点
+func (s *ResourceService) Tree(ctx context.Context, resourceSlug string, rootID interface{}, maxDepth int) ([]map[string]interface{}, error) {
+	resource, opts, err := s.treeResource(ctx, resourceSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := ""
+	rootKey := ""
+	if rootID != nil {
+		root, err := s.treeNode(ctx, resourceSlug, rootID)
+		if err != nil {
+			return nil, err
+		}
+		if prefix, err = treeNodePath(opts, root); err != nil {
+			return nil, err
+		}
+		rootKey = relationIDString(root["id"])
+	}
+	rows, err := s.resourceRepository.TreeNodes(ctx, resourceSlug, opts.PathKey, prefix, opts.SortKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// maxDepth 为返回的层数，根节点所在层为第 1 层；整棵树与子树含义一致
+	baseDepth := -1
+	if prefix != "" {
+		baseDepth = admin.TreeDepth(prefix) - 1
+	}
+	nodes := make(map[string]map[string]interface{}, len(rows))
+	for _, row := range rows {
+		if maxDepth > 0 && admin.TreeDepth(relationIDString(row[opts.PathKey]))-baseDepth > maxDepth {
+			continue
+		}
+		node := s.filterReadableRecord(ctx, resource, row)
+		node["children"] = make([]map[string]interface{}, 0)
+		nodes[relationIDString(row["id"])] = node
+	}
+
+	tree := make([]map[string]interface{}, 0)
+	for _, row := range rows {
+		key := relationIDString(row["id"])
+		node, ok := nodes[key]
+		if !ok {
+			continue
+		}
+		parent, hasParent := nodes[relationIDString(row[opts.ParentKey])]
+		if key == rootKey || opts.IsTreeRoot(row[opts.ParentKey]) || !hasParent {
+			tree = append(tree, node)
+			continue
+		}
+		parent["children"] = append(parent["children"].([]map[string]interface{}), node)
+	}
+	return tree, nil
+}
+
+// TreeChildren 获取节点的直接子节点，用于懒加载；parentID 为空时返回根节点
+// 每个节点附带 has_children 标记
+func (s *ResourceService) TreeChildren(ctx context.Context, resourceSlug string, parentID interface{}) ([]map[string]interface{}, error) {
+	resource, opts, err := s.treeResource(ctx, resourceSlug)
+	if err != nil {
+		return nil, err
+	}
+	if opts.IsTreeRoot(parentID) {
+		parentID = nil
+	} else if _, err := s.treeNode(ctx, resourceSlug, parentID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.resourceRepository.TreeChildren(ctx, resourceSlug, opts.ParentKey, parentID, opts.RootValue, opts.SortKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		ids = append(ids, row["id"])
+	}
+	counts, err := s.resourceRepository.CountTreeChildren(ctx, resourceSlug, opts.ParentKey, ids)
+	if err != nil {
+		return nil, err
+	}
+	children := s.filterReadableList(ctx, resource, rows)
+	for i, row := range rows {
+		children[i]["has_children"] = counts[relationIDString(row["id"])] > 0
+	}
+	return children, nil
+}
+
+// MoveNode 将节点移动到新的父节点下，position 为在新同级节点中的位置（从 0 开始），小于 0 表示末尾
+// parentID 为空时移动到根；不能移动到自身或其子孙节点下
+func (s *ResourceService) MoveNode(ctx context.Context, resourceSlug string, id interface{}, parentID interface{}, position int) error {
+	resource, opts, err := s.treeResource(ctx, resourceSlug)
+	if err != nil {
+		return err
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanUpdate(ctx, id, map[string]interface{}{opts.ParentKey: parentID}); err != nil {
+			return permissionDenied(err)
+		}
+	}
+	if err := s.withTransaction(ctx, s.treeMove(resourceSlug, opts, id, parentID, position)); err != nil {
+		return err
+	}
+	s.clearResourceCache(ctx, resourceSlug)
+	s.cacheManager.Delete(ctx, s.getRecordCacheKey(resourceSlug, id))
+	return nil
+}
+
+// ReorderNodes 按 ids 的顺序重排同级节点，未列出的同级节点保持原有顺序排在其后
+func (s *ResourceService) ReorderNodes(ctx context.Context, resourceSlug string, parentID interface{}, ids []interface{}) error {
+	resource, opts, err := s.treeResource(ctx, resourceSlug)
+	if err != nil {
+		return err
+	}
+	if opts.IsTreeRoot(parentID) {
+		parentID = nil
+	}
+	// 在事务内锁定同级节点后读取并重排，避免与并发的移动或重排交错
+	if err := s.withTransaction(ctx, func(ctx context.Context) error {
+		siblings, err := s.resourceRepository.LockTreeChildren(ctx, resourceSlug, opts.ParentKey, parentID, opts.RootValue, opts.SortKey)
+		if err != nil {
+			return err
+		}
+		byKey := make(map[string]map[string]interface{}, len(siblings))
+		for _, sibling := range siblings {
+			byKey[relationIDString(sibling["id"])] = sibling
+		}
+
+		ordered := make([]map[string]interface{}, 0, len(siblings))
+		listed := make(map[string]struct{}, len(ids))
+		for _, id := range ids {
+			key := relationIDString(id)
+			sibling, ok := byKey[key]
+			if !ok {
+				return &ValidationError{Errors: map[string][]string{"ids": {"节点不属于该父节点: " + key}}}
+			}
+			if _, ok := listed[key]; ok {
+				continue
+			}
+			listed[key] = struct{}{}
+			ordered = append(ordered, sibling)
+		}
+		for _, sibling := range siblings {
+			if _, ok := listed[relationIDString(sibling["id"])]; !ok {
+				ordered = append(ordered, sibling)
+			}
+		}
+
+		if auth, ok := resource.(admin.Authorizable); ok {
+			for i, node := range ordered {
+				if err := auth.CanUpdate(ctx, node["id"], map[string]interface{}{opts.SortKey: i}); err != nil {
+					return permissionDenied(err)
+				}
+			}
+		}
+		return s.resequenceTreeNodes(ctx, resourceSlug, opts, ordered)
+	}); err != nil {
+		return err
+	}
+	s.clearRecordsCache(ctx, resourceSlug, ids)
+	return nil
+}
+
+// RebuildTreePaths 按父节点列重新计算全部节点的物化路径，用于为已有数据补齐路径
+// 父节点不存在的节点视为根节点，返回更新的节点数
+func (s *ResourceService) RebuildTreePaths(ctx context.Context, resourceSlug string) (int64, error) {
+	_, opts, err := s.treeResource(ctx, resourceSlug)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := s.resourceRepository.TreeNodes(ctx, resourceSlug, opts.PathKey, "", opts.SortKey)
+	if err != nil {
+		return 0, err
+	}
+
+	children := make(map[string][]map[string]interface{})
+	known := make(map[string]struct{}, len(rows))
+	for _, row := range rows {
+		known[relationIDString(row["id"])] = struct{}{}
+	}
+	queue := make([]map[string]interface{}, 0)
+	paths := make(map[string]string, len(rows))
+	for _, row := range rows {
+		parent := row[opts.ParentKey]
+		if _, ok := known[relationIDString(parent)]; opts.IsTreeRoot(parent) || !ok {
+			paths[relationIDString(row["id"])] = admin.TreePath("", row["id"])
+			queue = append(queue, row)
+			continue
+		}
+		children[relationIDString(parent)] = append(children[relationIDString(parent)], row)
+	}
+	// 广度优先遍历，环中的节点不可达，保持原路径
+	for i := 0; i < len(queue); i++ {
+		key := relationIDString(queue[i]["id"])
+		for _, child := range children[key] {
+			childKey := relationIDString(child["id"])
+			if _, ok := paths[childKey]; ok {
+				continue
+			}
+			paths[childKey] = admin.TreePath(paths[key], child["id"])
+			queue = append(queue, child)
+		}
+	}
+
+	var updated int64
+	err = s.withTransaction(ctx, func(ctx context.Context) error {
+		for _, row := range queue {
+			path := paths[relationIDString(row["id"])]
+			if relationIDString(row[opts.PathKey]) == path {
+				continue
+			}
+			if err := s.resourceRepository.Update(ctx, resourceSlug, row["id"], map[string]interface{}{opts.PathKey: path}); err != nil {
+				return err
+			}
+			updated++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	s.clearResourceCache(ctx, resourceSlug)
+	return updated, nil
+}
+
+// treeResource 获取树形资源配置并校验列表权限
+func (s *ResourceService) treeResource(ctx context.Context, resourceSlug string) (admin.Resource, admin.TreeOptions, error) {
+	resource := s.resourceManager.GetResourceBySlug(resourceSlug)
+	if resource == nil {
+		return nil, admin.TreeOptions{}, &ResourceNotFoundError{ResourceSlug: resourceSlug}
+	}
+	opts, ok := admin.GetTreeOptions(resource)
+	if !ok {
+		return nil, admin.TreeOptions{}, ErrNotTreeResource
+	}
+	if auth, ok := resource.(admin.Authorizable); ok {
+		if err := auth.CanList(ctx); err != nil {
+			return nil, admin.TreeOptions{}, permissionDenied(err)
+		}
+	}
+	return resource, opts, nil
+}
+
+// treeNode 获取未删除的节点
+func (s *ResourceService) treeNode(ctx context.Context, resourceSlug string, id interface{}) (map[string]interface{}, error) {
+	record, err := s.resourceRepository.FindByID(ctx, resourceSlug, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record["deleted_at"] != nil {
+		return nil, ErrRecordNotFound
+	}
+	return record, nil
+}
+
+// treeNodePath 读取节点的物化路径
+func treeNodePath(opts admin.TreeOptions, record map[string]interface{}) (string, error) {
+	path := ""
+	if record[opts.PathKey] != nil {
+		path = relationIDString(record[opts.PathKey])
+	}
+	if path == "" {
+		return "", ErrTreePathMissing
+	}
+	return path, nil
+}
+
+// treeMove 返回在事务中执行的节点移动：先锁定节点与新父节点，再基于锁定后的路径校验并移动，
+// 避免并发移动基于过期路径形成环或写坏路径
+func (s *ResourceService) treeMove(
+	resourceSlug string,
+	opts admin.TreeOptions,
+	id interface{},
+	parentID interface{},
+	position int,
+) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		ids := []interface{}{id}
+		if !opts.IsTreeRoot(parentID) {
+			ids = append(ids, parentID)
+		}
+		rows, err := s.resourceRepository.LockTreeNodes(ctx, resourceSlug, ids)
+		if err != nil {
+			return err
+		}
+		locked := make(map[string]map[string]interface{}, len(rows))
+		for _, row := range rows {
+			locked[relationIDString(row["id"])] = row
+		}
+
+		node, ok := locked[relationIDString(id)]
+		if !ok {
+			return ErrRecordNotFound
+		}
+		oldPath, err := treeNodePath(opts, node)
+		if err != nil {
+			return err
+		}
+
+		parentPath := ""
+		var newParent interface{}
+		if !opts.IsTreeRoot(parentID) {
+			parent, ok := locked[relationIDString(parentID)]
+			if !ok {
+				return &ValidationError{Errors: map[string][]string{opts.ParentKey: {"父节点不存在"}}}
+			}
+			if parentPath, err = treeNodePath(opts, parent); err != nil {
+				return err
+			}
+			if strings.HasPrefix(parentPath, oldPath) {
+				return &ValidationError{Errors: map[string][]string{opts.ParentKey: {"不能移动到自身或其子节点下"}}}
+			}
+			newParent = parent["id"]
+		}
+		newPath := admin.TreePath(parentPath, node["id"])
+
+		if newParent == nil && opts.RootValue == nil {
+			if err := s.resourceRepository.ClearField(ctx, resourceSlug, opts.ParentKey, []interface{}{node["id"]}); err != nil {
+				return err
+			}
+		} else {
+			value := newParent
+			if value == nil {
+				value = opts.RootValue
+			}
+			if err := s.resourceRepository.Update(ctx, resourceSlug, node["id"], map[string]interface{}{opts.ParentKey: value}); err != nil {
+				return err
+			}
+		}
+		if newPath != oldPath {
+			if err := s.resourceRepository.ReplaceTreePath(ctx, resourceSlug, opts.PathKey, oldPath, newPath); err != nil {
+				return err
+			}
+		}
+
+		siblings, err := s.resourceRepository.LockTreeChildren(ctx, resourceSlug, opts.ParentKey, newParent, opts.RootValue, opts.SortKey)
+		if err != nil {
+			return err
+		}
+		key := relationIDString(node["id"])
+		ordered := make([]map[string]interface{}, 0, len(siblings))
+		for _, sibling := range siblings {
+			if relationIDString(sibling["id"]) != key {
+				ordered = append(ordered, sibling)
+			}
+		}
+		at := position
+		if at < 0 || at > len(ordered) {
+			at = len(ordered)
+		}
+		ordered = append(ordered[:at], append([]map[string]interface{}{node}, ordered[at:]...)...)
+		return s.resequenceTreeNodes(ctx, resourceSlug, opts, ordered)
+	}
+}
+
+// resequenceTreeNodes 按顺序将同级节点的排序值写为 0..n-1，未变化的节点跳过
+func (s *ResourceService) resequenceTreeNodes(ctx context.Context, resourceSlug string, opts admin.TreeOptions, ordered []map[string]interface{}) error {
+	for i, node := range ordered {
+		if relationIDString(node[opts.SortKey]) == s.intToString(i) {
+			continue
+		}
+		if err := s.resourceRepository.Update(ctx, resourceSlug, node["id"], map[string]interface{}{opts.SortKey: i}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertTreeNode 创建树节点并写入物化路径
+func (s *ResourceService) insertTreeNode(ctx context.Context, resourceSlug string, opts admin.TreeOptions, data map[string]interface{}) error {
+	delete(data, opts.PathKey)
+	parentPath := ""
+	if parent := data[opts.ParentKey]; !opts.IsTreeRoot(parent) {
+		record, err := s.treeNode(ctx, resourceSlug, parent)
+		if err != nil {
+			if errors.Is(err, ErrRecordNotFound) {
+				return &ValidationError{Errors: map[string][]string{opts.ParentKey: {"父节点不存在"}}}
+			}
+			return err
+		}
+		if parentPath, err = treeNodePath(opts, record); err != nil {
+			return err
+		}
+	}
+	return s.withTransaction(ctx, func(ctx context.Context) error {
+		id, err := s.resourceRepository.Create(ctx, resourceSlug, data)
+		if err != nil {
+			return err
+		}
+		data["id"] = id
+		path := admin.TreePath(parentPath, id)
+		if err := s.resourceRepository.Update(ctx, resourceSlug, id, map[string]interface{}{opts.PathKey: path}); err != nil {
+			return err
+		}
+		data[opts.PathKey] = path
+		return nil
+	})
+}
+
+// prepareTreeUpdate 处理树节点更新：路径列不可直接写入，父节点变化时转为节点移动
+func (s *ResourceService) prepareTreeUpdate(
+	ctx context.Context,
+	resourceSlug string,
+	opts admin.TreeOptions,
+	id interface{},
+	data map[string]interface{},
+) (map[string]interface{}, func(ctx context.Context) error, error) {
+	write := make(map[string]interface{}, len(data))
+	for k, v := range data {
+		if k != opts.PathKey && k != opts.ParentKey {
+			write[k] = v
+		}
+	}
+	parent, ok := data[opts.ParentKey]
+	if !ok {
+		return write, nil, nil
+	}
+	node, err := s.treeNode(ctx, resourceSlug, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := node[opts.ParentKey]
+	if relationIDString(current) == relationIDString(parent) || (opts.IsTreeRoot(current) && opts.IsTreeRoot(parent)) {
+		return write, nil, nil
+	}
+	return write, s.treeMove(resourceSlug, opts, id, parent, -1), nil
+}
+
+// ensureTreeLeaves 校验待删除的节点没有未删除的子节点
+func (s *ResourceService) ensureTreeLeaves(ctx context.Context, resourceSlug string, opts admin.TreeOptions, ids []interface{}) error {
+	counts, err := s.resourceRepository.CountTreeChildren(ctx, resourceSlug, opts.ParentKey, ids)
+	if err != nil {
+		return err
+	}
+	for _, count := range counts {
+		if count > 0 {
+			return &ValidationError{Errors: map[string][]string{"id": {"存在子节点，不能删除"}}}
+		}
+	}
+	return nil
+}
+
+// treeDescendantIDs 获取节点的全部子孙节点 ID（不含自身）
+func (s *ResourceService) treeDescendantIDs(ctx context.Context, resourceSlug string, opts admin.TreeOptions, id interface{}) ([]interface{}, error) {
+	node, err := s.treeNode(ctx, resourceSlug, id)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return []interface{}{}, nil
+		}
+		return nil, err
+	}
+	path, err := treeNodePath(opts, node)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.resourceRepository.TreeNodes(ctx, resourceSlug, opts.PathKey, path, opts.SortKey)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]interface{}, 0, len(rows))
+	key := relationIDString(node["id"])
+	for _, row := range rows {
+		if relationIDString(row["id"]) != key {
+			ids = append(ids, row["id"])
+		}
+	}
+	return ids, nil
+}
+
+// withTransaction 在事务中执行，已处于事务中时复用当前事务
+func (s *ResourceService) withTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if tx, ok := ctx.Value(database.TxKey).(*gorm.DB); ok && tx != nil {
+		return fn(ctx)
+	}
+	return s.resourceRepository.Transaction(ctx, fn)
+}
@@ -0,0 +1,487 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"fun-admin/internal/model"
+	"fun-admin/internal/repository"
+	"fun-admin/pkg"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/email"
+	"fun-admin/pkg/jwt"
+	"net/mail"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// ErrScheduledReportNotFound 表示定时报表不存在
+var ErrScheduledReportNotFound = errors.New("scheduled report not found")
+
+// reportCronParser 支持标准五段式表达式与 @daily 等描述符
+var reportCronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ScheduledReportInput 创建/更新定时报表的参数
+type ScheduledReportInput struct {
+	Name           string                 `json:"name"`
+	ResourceSlug   string                 `json:"resource_slug"`
+	Filters        map[string]interface{} `json:"filters"`
+	Search         map[string]interface{} `json:"search"`
+	Columns        []string               `json:"columns"`
+	OrderBy        string                 `json:"order_by"`
+	OrderDirection string                 `json:"order_direction"`
+	Period         string                 `json:"period"`
+	Format         string                 `json:"format"`
+	CronExpr       string                 `json:"cron_expr"`
+	Timezone       string                 `json:"timezone"`
+	Recipients     []string               `json:"recipients"`
+	StorageType    string                 `json:"storage_type"`
+	StoragePath    string                 `json:"storage_path"`
+	Enabled        *bool                  `json:"enabled"`
+}
+
+// ScheduledReportService 定时报表服务
+type ScheduledReportService interface {
+	ListReports(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ScheduledReport, int64, error)
+	GetReport(ctx context.Context, id uint) (*model.ScheduledReport, error)
+	CreateReport(ctx context.Context, input *ScheduledReportInput, ownerID uint) (*model.ScheduledReport, error)
+	UpdateReport(ctx context.Context, id uint, input *ScheduledReportInput) (*model.ScheduledReport, error)
+	DeleteReport(ctx context.Context, id uint) error
+	TriggerReport(ctx context.Context, id uint) error
+	ListRuns(ctx context.Context, id uint, page, pageSize int) ([]*model.ScheduledReportRun, int64, error)
+	RunDue(ctx context.Context) (int, error)
+}
+
+// NewScheduledReportService 创建定时报表服务
+func NewScheduledReportService(
+	service *Service,
+	reportRepository repository.ScheduledReportRepository,
+	resourceService *ResourceService,
+	fileService *FileService,
+	configService *ConfigService,
+) ScheduledReportService {
+	return &scheduledReportService{
+		Service:          service,
+		reportRepository: reportRepository,
+		resourceService:  resourceService,
+		fileService:      fileService,
+		configService:    configService,
+	}
+}
+
+type scheduledReportService struct {
+	*Service
+	reportRepository repository.ScheduledReportRepository
+	resourceService  *ResourceService
+	fileService      *FileService
+	configService    *ConfigService
+}
+
+// ListReports 获取定时报表列表，普通用户只能看到自己的报表，超级管理员可按 owner_id 过滤全部报表
+func (s *scheduledReportService) ListReports(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]*model.ScheduledReport, int64, error) {
+	userID, superAdmin, err := reportViewer(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !superAdmin {
+		filters["owner_id"] = userID
+	}
+	return s.reportRepository.GetReports(ctx, page, pageSize, filters)
+}
+
+// GetReport 获取定时报表，仅所有者与超级管理员可访问
+// 报表以所有者的权限导出，其他用户读取或修改报表即可获取所有者的数据
+func (s *scheduledReportService) GetReport(ctx context.Context, id uint) (*model.ScheduledReport, error) {
+	userID, superAdmin, err := reportViewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.reportRepository.GetReport(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrScheduledReportNotFound
+		}
+		return nil, err
+	}
+	if !superAdmin && report.OwnerID != userID {
+		return nil, fmt.Errorf("%w: scheduled report %d", admin.ErrPermissionDenied, id)
+	}
+	return report, nil
+}
+
+// CreateReport 校验后创建定时报表，所有者为当前用户
+func (s *scheduledReportService) CreateReport(ctx context.Context, input *ScheduledReportInput, ownerID uint) (*model.ScheduledReport, error) {
+	report := &model.ScheduledReport{OwnerID: ownerID, Enabled: true}
+	if err := s.apply(ctx, report, input); err != nil {
+		return nil, err
+	}
+	if err := s.reportRepository.CreateReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// UpdateReport 校验后更新定时报表并重新计算下次运行时间
+func (s *scheduledReportService) UpdateReport(ctx context.Context, id uint, input *ScheduledReportInput) (*model.ScheduledReport, error) {
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, report, input); err != nil {
+		return nil, err
+	}
+	if err := s.reportRepository.UpdateReport(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// DeleteReport 删除定时报表
+func (s *scheduledReportService) DeleteReport(ctx context.Context, id uint) error {
+	if _, err := s.GetReport(ctx, id); err != nil {
+		return err
+	}
+	return s.reportRepository.DeleteReport(ctx, id)
+}
+
+// TriggerReport 立即运行：将下次运行时间置为当前时间，由任务进程领取执行
+func (s *scheduledReportService) TriggerReport(ctx context.Context, id uint) error {
+	report, err := s.GetReport(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !report.Enabled {
+		return &ValidationError{Errors: map[string][]string{"enabled": {"报表已停用"}}}
+	}
+	now := time.Now()
+	report.NextRunAt = &now
+	return s.reportRepository.UpdateReport(ctx, report)
+}
+
+// ListRuns 获取报表运行记录
+func (s *scheduledReportService) ListRuns(ctx context.Context, id uint, page, pageSize int) ([]*model.ScheduledReportRun, int64, error) {
+	if _, err := s.GetReport(ctx, id); err != nil {
+		return nil, 0, err
+	}
+	return s.reportRepository.GetRuns(ctx, id, page, pageSize)
+}
+
+// apply 校验参数并写入报表，导出参数以所有者身份预检
+func (s *scheduledReportService) apply(ctx context.Context, report *model.ScheduledReport, input *ScheduledReportInput) error {
+	errs := make(map[string][]string)
+	if strings.TrimSpace(input.Name) == "" {
+		errs["name"] = append(errs["name"], "报表名称不能为空")
+	}
+	schedule, err := reportCronParser.Parse(strings.TrimSpace(input.CronExpr))
+	if err != nil {
+		errs["cron_expr"] = append(errs["cron_expr"], "无效的 Cron 表达式")
+	}
+	location, err := loadReportLocation(input.Timezone)
+	if err != nil {
+		errs["timezone"] = append(errs["timezone"], "无效的时区")
+	}
+	switch input.Period {
+	case model.ReportPeriodNone, model.ReportPeriodLastDay, model.ReportPeriodLastWeek, model.ReportPeriodLastMonth:
+	default:
+		errs["period"] = append(errs["period"], "不支持的统计区间")
+	}
+	var recipients []string
+	for _, recipient := range input.Recipients {
+		recipient = strings.TrimSpace(recipient)
+		if recipient == "" {
+			continue
+		}
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			errs["recipients"] = append(errs["recipients"], fmt.Sprintf("无效的邮箱地址: %s", recipient))
+			continue
+		}
+		recipients = append(recipients, recipient)
+	}
+	storagePath := strings.Trim(path.Clean("/"+strings.TrimSpace(input.StoragePath)), "/")
+	if len(recipients) == 0 && storagePath == "" {
+		errs["recipients"] = append(errs["recipients"], "收件人与存储目录至少填写一项")
+	}
+	if len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	request := ExportRequest{
+		Filters:        input.Filters,
+		Search:         input.Search,
+		OrderBy:        input.OrderBy,
+		OrderDirection: input.OrderDirection,
+		Format:         input.Format,
+		Columns:        input.Columns,
+	}
+	export, err := s.resourceService.PrepareExport(reportOwnerContext(ctx, report.OwnerID), input.ResourceSlug, request)
+	if err != nil {
+		return err
+	}
+
+	filters, _ := json.Marshal(input.Filters)
+	search, _ := json.Marshal(input.Search)
+	columns, _ := json.Marshal(input.Columns)
+	report.Name = strings.TrimSpace(input.Name)
+	report.ResourceSlug = input.ResourceSlug
+	report.Filters = string(filters)
+	report.Search = string(search)
+	report.Columns = string(columns)
+	report.OrderBy = input.OrderBy
+	report.OrderDirection = input.OrderDirection
+	report.Period = input.Period
+	report.Format = export.Format
+	report.CronExpr = strings.TrimSpace(input.CronExpr)
+	report.Timezone = location.String()
+	report.Recipients = strings.Join(recipients, ",")
+	report.StorageType = input.StorageType
+	report.StoragePath = storagePath
+	if input.Enabled != nil {
+		report.Enabled = *input.Enabled
+	}
+
+	next := schedule.Next(time.Now().In(location))
+	report.NextRunAt = &next
+	return nil
+}
+
+// RunDue 执行所有已到期的报表，返回执行数量
+func (s *scheduledReportService) RunDue(ctx context.Context) (int, error) {
+	reports, err := s.reportRepository.GetDueReports(ctx, time.Now(), 20)
+	if err != nil {
+		return 0, err
+	}
+	executed := 0
+	for _, report := range reports {
+		scheduledAt := *report.NextRunAt
+		next, err := nextReportRun(report, time.Now())
+		if err != nil {
+			s.logger.Warn("定时报表调度配置无效，已停用", zap.Uint("report_id", report.ID), zap.Error(err))
+			report.Enabled = false
+			report.NextRunAt = nil
+			report.LastStatus = model.ReportRunStatusFailed
+			report.LastError = err.Error()
+			if err := s.reportRepository.UpdateReport(ctx, report); err != nil {
+				return executed, err
+			}
+			continue
+		}
+		claimed, err := s.reportRepository.ClaimReport(ctx, report.ID, scheduledAt, &next)
+		if err != nil {
+			return executed, err
+		}
+		if !claimed {
+			continue
+		}
+		report.NextRunAt = &next
+
+		if err := s.execute(ctx, report, scheduledAt); err != nil {
+			return executed, err
+		}
+		executed++
+	}
+	return executed, nil
+}
+
+// execute 执行一次报表并记录结果，仅在记录无法保存时返回错误
+func (s *scheduledReportService) execute(ctx context.Context, report *model.ScheduledReport, scheduledAt time.Time) error {
+	run := &model.ScheduledReportRun{
+		ReportID:  report.ID,
+		Status:    model.ReportRunStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.reportRepository.CreateRun(ctx, run); err != nil {
+		return err
+	}
+
+	runErr := s.deliver(ctx, report, run, scheduledAt)
+	finishedAt := time.Now()
+	run.FinishedAt = &finishedAt
+	run.Status = model.ReportRunStatusSuccess
+	if runErr != nil {
+		run.Status = model.ReportRunStatusFailed
+		run.Error = runErr.Error()
+		s.logger.Warn("定时报表运行失败", zap.Uint("report_id", report.ID), zap.Error(runErr))
+	}
+	if err := s.reportRepository.UpdateRun(ctx, run); err != nil {
+		return err
+	}
+
+	report.LastRunAt = &run.StartedAt
+	report.LastStatus = run.Status
+	report.LastError = run.Error
+	return s.reportRepository.UpdateReport(ctx, report)
+}
+
+// deliver 以所有者身份导出到临时文件，再写入存储并发送邮件
+func (s *scheduledReportService) deliver(ctx context.Context, report *model.ScheduledReport, run *model.ScheduledReportRun, scheduledAt time.Time) error {
+	request, err := reportExportRequest(report, scheduledAt)
+	if err != nil {
+		return err
+	}
+	ownerCtx := reportOwnerContext(ctx, report.OwnerID)
+	export, err := s.resourceService.PrepareExport(ownerCtx, report.ResourceSlug, request)
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "report-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	// 附件名取自文件名，使用报表名称而非资源标题
+	filename := fmt.Sprintf("%s_%s.%s", report.Name, scheduledAt.Format("20060102_1504"), export.exporter.Extension)
+	filename = strings.NewReplacer("/", "_", "\\", "_").Replace(filename)
+	localPath := filepath.Join(dir, filename)
+	file, err := os.Create(localPath)
+	if err != nil {
+		return err
+	}
+	rows, err := s.resourceService.WriteExport(ownerCtx, export, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("导出失败: %w", err)
+	}
+	run.Rows = rows
+	run.FileName = filename
+
+	if report.StoragePath != "" {
+		src, err := os.Open(localPath)
+		if err != nil {
+			return err
+		}
+		key := path.Join(report.StoragePath, filename)
+		info, err := s.fileService.SaveFileWithContext(ctx, report.StorageType, key, src, export.ContentType)
+		src.Close()
+		if err != nil {
+			return fmt.Errorf("写入存储失败: %w", err)
+		}
+		run.StorageType = info.StorageType
+		run.StorageKey = info.Path
+		run.FileSize = info.Size
+	}
+
+	if report.Recipients != "" {
+		emailService, err := s.configService.GetEmailService(ctx)
+		if err != nil {
+			return fmt.Errorf("邮件服务不可用: %w", err)
+		}
+		recipients := strings.Split(report.Recipients, ",")
+		message := &email.EmailMessage{
+			To:      recipients,
+			Subject: fmt.Sprintf("定时报表: %s", report.Name),
+			Body: fmt.Sprintf("报表「%s」已于 %s 生成，共 %d 行，详见附件。",
+				report.Name, run.StartedAt.Format("2006-01-02 15:04"), rows),
+			Attachments: []string{localPath},
+		}
+		if err := emailService.Send(message); err != nil {
+			return err
+		}
+		run.Recipients = report.Recipients
+	}
+	return nil
+}
+
+// reportExportRequest 还原报表的导出参数，并按统计区间附加 created_at 范围
+func reportExportRequest(report *model.ScheduledReport, scheduledAt time.Time) (ExportRequest, error) {
+	request := ExportRequest{
+		OrderBy:        report.OrderBy,
+		OrderDirection: report.OrderDirection,
+		Format:         report.Format,
+	}
+	for _, item := range []struct {
+		raw    string
+		target interface{}
+	}{
+		{report.Filters, &request.Filters},
+		{report.Search, &request.Search},
+		{report.Columns, &request.Columns},
+	} {
+		if item.raw == "" {
+			continue
+		}
+		if err := json.Unmarshal([]byte(item.raw), item.target); err != nil {
+			return request, fmt.Errorf("解析报表参数失败: %w", err)
+		}
+	}
+
+	location, err := loadReportLocation(report.Timezone)
+	if err != nil {
+		return request, err
+	}
+	from, to, ok := reportPeriodRange(report.Period, scheduledAt.In(location))
+	if ok {
+		if request.Filters == nil {
+			request.Filters = make(map[string]interface{})
+		}
+		request.Filters["created_at_from"] = from
+		request.Filters["created_at_to"] = to
+	}
+	return request, nil
+}
+
+// reportPeriodRange 计算运行时间之前的完整统计周期 [from, to]
+func reportPeriodRange(period string, at time.Time) (time.Time, time.Time, bool) {
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, at.Location())
+	var from, to time.Time
+	switch period {
+	case model.ReportPeriodLastDay:
+		from, to = today.AddDate(0, 0, -1), today
+	case model.ReportPeriodLastWeek:
+		// 以周一为一周开始
+		weekday := (int(today.Weekday()) + 6) % 7
+		to = today.AddDate(0, 0, -weekday)
+		from = to.AddDate(0, 0, -7)
+	case model.ReportPeriodLastMonth:
+		to = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, at.Location())
+		from = to.AddDate(0, -1, 0)
+	default:
+		return time.Time{}, time.Time{}, false
+	}
+	return from, to.Add(-time.Nanosecond), true
+}
+
+// nextReportRun 按报表时区计算 after 之后的下次运行时间
+func nextReportRun(report *model.ScheduledReport, after time.Time) (time.Time, error) {
+	schedule, err := reportCronParser.Parse(report.CronExpr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	location, err := loadReportLocation(report.Timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return schedule.Next(after.In(location)), nil
+}
+
+// loadReportLocation 加载时区，为空时使用服务器本地时区
+func loadReportLocation(name string) (*time.Location, error) {
+	if strings.TrimSpace(name) == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(strings.TrimSpace(name))
+}
+
+// reportViewer 获取当前用户及其是否为超级管理员，未登录时返回 admin.ErrPermissionDenied
+func reportViewer(ctx context.Context) (uint, bool, error) {
+	claims, ok := ctx.Value("claims").(*jwt.MyCustomClaims)
+	if !ok || claims == nil {
+		return 0, false, admin.ErrPermissionDenied
+	}
+	return claims.UserId, fmt.Sprint(claims.UserId) == pkg.AdminUserID, nil
+}
+
+// reportOwnerContext 构造以报表所有者身份执行的上下文，权限钩子据此判断
+func reportOwnerContext(ctx context.Context, ownerID uint) context.Context {
+	return context.WithValue(ctx, "claims", &jwt.MyCustomClaims{UserId: ownerID})
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+
+	"fun-admin/internal/snapshot"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/database"
+
+	"github.com/casbin/casbin/v2"
+	"gorm.io/gorm"
+)
+
+// SnapshotService 数据快照服务：导出全部资源与系统数据表为与方言无关的归档，或从归档恢复
+type SnapshotService interface {
+	Export(ctx context.Context, w io.Writer) (*snapshot.Manifest, error)
+	Import(ctx context.Context, reader io.ReaderAt, size int64, conflict string) (*snapshot.Result, error)
+}
+
+// NewSnapshotService 创建数据快照服务，enforcer 不为 nil 时导入后重新加载 Casbin 策略
+func NewSnapshotService(db *gorm.DB, fileService *FileService, enforcer *casbin.SyncedEnforcer) SnapshotService {
+	return &snapshotService{
+		db:       db,
+		files:    &snapshotFiles{fileService: fileService},
+		enforcer: enforcer,
+	}
+}
+
+type snapshotService struct {
+	db       *gorm.DB
+	files    snapshot.Files
+	enforcer *casbin.SyncedEnforcer
+}
+
+// Export 将系统数据表、已注册资源的数据表与附件文件写入归档
+func (s *snapshotService) Export(ctx context.Context, w io.Writer) (*snapshot.Manifest, error) {
+	tables, err := snapshot.Tables(s.db, admin.GlobalResourceManager.GetResources())
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Export(ctx, s.db, tables, s.files, w)
+}
+
+// Import 从归档恢复数据，conflict 为主键冲突时的处理策略（skip/update/fail/replace）
+func (s *snapshotService) Import(ctx context.Context, reader io.ReaderAt, size int64, conflict string) (*snapshot.Result, error) {
+	if err := snapshot.ValidConflict(conflict); err != nil {
+		return nil, &ValidationError{Errors: map[string][]string{"conflict": {"冲突策略无效，可选 skip、update、fail、replace"}}}
+	}
+	archive, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, &ValidationError{Errors: map[string][]string{"file": {"不是有效的快照归档"}}}
+	}
+	if _, err := snapshot.ReadManifest(archive); err != nil {
+		return nil, &ValidationError{Errors: map[string][]string{"file": {err.Error()}}}
+	}
+
+	tables, err := snapshot.Tables(s.db, admin.GlobalResourceManager.GetResources())
+	if err != nil {
+		return nil, err
+	}
+	// 导入前检查与创建数据表，需读取主库的最新结构
+	result, err := snapshot.Import(ctx, database.Primary(s.db), archive, tables, s.files, conflict)
+	if err != nil {
+		return nil, err
+	}
+	if s.enforcer != nil {
+		// casbin_rule 已被替换，重新加载策略使其立即生效
+		if err := s.enforcer.LoadPolicy(); err != nil {
+			return result, fmt.Errorf("reload casbin policy: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// snapshotFiles 通过文件服务读写附件所在的存储
+type snapshotFiles struct {
+	fileService *FileService
+}
+
+func (f *snapshotFiles) Open(ctx context.Context, disk, key string) (io.ReadCloser, error) {
+	return f.fileService.OpenFileWithContext(ctx, disk, key)
+}
+
+func (f *snapshotFiles) Save(ctx context.Context, disk, key string, reader io.Reader) error {
+	_, err := f.fileService.SaveFileWithContext(ctx, disk, key, reader, "")
+	return err
+}
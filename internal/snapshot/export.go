@@ -0,0 +1,244 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fun-admin/internal/migrate"
+
+	"gorm.io/gorm"
+)
+
+// attachmentFile 附件表中引用的存储文件
+type attachmentFile struct {
+	disk string
+	key  string
+}
+
+// Export 将数据表写入归档，files 不为 nil 时同时打包附件引用的存储文件
+// 每张表从其所在的命名连接读取（migrate.Table.Connection），db 为默认连接；
+// 源数据库中不存在的表与无法读取的文件记录在清单的 Warnings 中，不会中断导出
+func Export(ctx context.Context, db *gorm.DB, tables []migrate.Table, files Files, w io.Writer) (*Manifest, error) {
+	db = db.WithContext(ctx)
+	manifest := &Manifest{
+		Format:    Format,
+		Version:   Version,
+		CreatedAt: time.Now().UTC(),
+		Dialect:   db.Dialector.Name(),
+	}
+	archive := zip.NewWriter(w)
+
+	var attachments []attachmentFile
+	for _, table := range tables {
+		conn, err := migrate.ConnectionDB(db, table.Connection)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", table.Name, err)
+		}
+		if !conn.Migrator().HasTable(table.Name) {
+			manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("table %s does not exist, skipped", table.Name))
+			continue
+		}
+		info, referenced, err := exportTable(conn, archive, table.Name, manifest.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("export %s: %w", table.Name, err)
+		}
+		info.Connection = table.Connection
+		manifest.Tables = append(manifest.Tables, *info)
+		attachments = append(attachments, referenced...)
+	}
+
+	if files != nil {
+		seen := make(map[attachmentFile]bool, len(attachments))
+		for _, attachment := range attachments {
+			if seen[attachment] {
+				continue
+			}
+			seen[attachment] = true
+			info, err := exportFile(ctx, archive, files, attachment, len(manifest.Files)+1, manifest.CreatedAt)
+			if err != nil {
+				manifest.Warnings = append(manifest.Warnings, fmt.Sprintf("file %s:%s skipped: %v", attachment.disk, attachment.key, err))
+				continue
+			}
+			manifest.Files = append(manifest.Files, *info)
+		}
+	}
+
+	if db.Migrator().HasTable(migrate.MigrationsTable) {
+		if err := db.Table(migrate.MigrationsTable).Order("id").Pluck("id", &manifest.Migrations).Error; err != nil {
+			return nil, fmt.Errorf("read migrations: %w", err)
+		}
+	}
+
+	writer, err := createEntry(archive, manifestName, manifest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// exportTable 按主键顺序逐行写入 NDJSON，附件表同时返回其引用的文件
+// 直接读取数据表，软删除的记录也会导出
+func exportTable(db *gorm.DB, archive *zip.Writer, name string, modified time.Time) (*TableInfo, []attachmentFile, error) {
+	columnTypes, err := db.Migrator().ColumnTypes(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	info := &TableInfo{Name: name, File: tablesDir + name + ".ndjson"}
+	kinds := make(map[string]string, len(columnTypes))
+	for _, columnType := range columnTypes {
+		kind := columnKind(columnType.DatabaseTypeName())
+		kinds[columnType.Name()] = kind
+		info.Columns = append(info.Columns, Column{Name: columnType.Name(), Type: kind})
+		if primaryKey, ok := columnType.PrimaryKey(); ok && primaryKey {
+			info.PrimaryKey = append(info.PrimaryKey, columnType.Name())
+		}
+	}
+	if len(info.PrimaryKey) == 0 {
+		if _, ok := kinds["id"]; ok {
+			info.PrimaryKey = []string{"id"}
+		}
+	}
+
+	query := db.Table(name)
+	for _, column := range info.PrimaryKey {
+		query = query.Order(column)
+	}
+	rows, err := query.Rows()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	writer, err := createEntry(archive, info.File, modified)
+	if err != nil {
+		return nil, nil, err
+	}
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	var attachments []attachmentFile
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	encoder := json.NewEncoder(writer)
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return nil, nil, err
+		}
+		record := make(map[string]interface{}, len(columns))
+		for i, column := range columns {
+			record[column] = exportValue(values[i], kinds[column])
+		}
+		if err := encoder.Encode(record); err != nil {
+			return nil, nil, err
+		}
+		info.Rows++
+
+		if name == attachmentTable {
+			key, _ := record["storage_key"].(string)
+			disk, _ := record["storage_type"].(string)
+			if key != "" {
+				attachments = append(attachments, attachmentFile{disk: disk, key: key})
+			}
+		}
+	}
+	return info, attachments, rows.Err()
+}
+
+// exportFile 将存储文件写入归档的 files/ 目录，按序号命名以避免存储键中的路径影响归档结构
+func exportFile(ctx context.Context, archive *zip.Writer, files Files, attachment attachmentFile, index int, modified time.Time) (*FileInfo, error) {
+	reader, err := files.Open(ctx, attachment.disk, attachment.key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	info := &FileInfo{Disk: attachment.disk, Key: attachment.key, Path: fmt.Sprintf("%s%06d", filesDir, index)}
+	writer, err := createEntry(archive, info.Path, modified)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.New()
+	info.Size, err = io.Copy(io.MultiWriter(writer, hash), reader)
+	if err != nil {
+		return nil, err
+	}
+	info.Checksum = hex.EncodeToString(hash.Sum(nil))
+	return info, nil
+}
+
+// createEntry 创建压缩的归档条目，修改时间取导出时间
+func createEntry(archive *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	return archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+}
+
+// columnKind 将数据库列类型归类为与方言无关的列类型
+func columnKind(databaseType string) string {
+	databaseType = strings.ToUpper(databaseType)
+	switch {
+	case strings.Contains(databaseType, "BOOL"):
+		return ColumnBool
+	case strings.Contains(databaseType, "INT"), strings.Contains(databaseType, "SERIAL"):
+		return ColumnInt
+	case strings.Contains(databaseType, "DEC"), strings.Contains(databaseType, "NUMERIC"),
+		strings.Contains(databaseType, "REAL"), strings.Contains(databaseType, "FLOAT"),
+		strings.Contains(databaseType, "DOUBLE"):
+		return ColumnFloat
+	case strings.Contains(databaseType, "DATE"), strings.Contains(databaseType, "TIME"):
+		return ColumnTime
+	case strings.Contains(databaseType, "BLOB"), strings.Contains(databaseType, "BINARY"),
+		strings.Contains(databaseType, "BYTEA"):
+		return ColumnBinary
+	}
+	return ColumnString
+}
+
+// exportValue 将驱动返回的值转换为可写入 JSON 的值
+// 时间统一为 UTC 的 RFC 3339；部分驱动（如 MySQL 文本协议）以 []byte 返回数值与文本，按列类型还原
+func exportValue(value interface{}, kind string) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case []byte:
+		switch kind {
+		case ColumnBinary:
+			return base64.StdEncoding.EncodeToString(v)
+		case ColumnInt, ColumnFloat:
+			if _, err := strconv.ParseFloat(string(v), 64); err == nil {
+				return json.Number(v)
+			}
+		case ColumnBool:
+			if b, err := strconv.ParseBool(string(v)); err == nil {
+				return b
+			}
+		}
+		return string(v)
+	case string:
+		if kind == ColumnBinary {
+			return base64.StdEncoding.EncodeToString([]byte(v))
+		}
+	}
+	return value
+}
@@ -0,0 +1,354 @@
+package snapshot
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"fun-admin/internal/migrate"
+	"fun-admin/pkg/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// importBatchSize 每条 INSERT 语句写入的记录数
+const importBatchSize = 200
+
+// Result 导入结果
+type Result struct {
+	Tables   []TableResult `json:"tables"`
+	Files    int           `json:"files"`    // 写回存储的文件数
+	Warnings []string      `json:"warnings"` // 跳过的表、列与文件
+}
+
+// TableResult 单张表的导入结果
+type TableResult struct {
+	Name     string `json:"name"`
+	Rows     int    `json:"rows"`     // 归档中的记录数
+	Imported int64  `json:"imported"` // 写入的记录数，skip 策略下不含已存在的记录
+}
+
+// ReadManifest 读取并校验归档清单
+func ReadManifest(archive *zip.Reader) (*Manifest, error) {
+	file, err := archive.Open(manifestName)
+	if err != nil {
+		return nil, fmt.Errorf("not a snapshot archive: %w", err)
+	}
+	defer file.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("read manifest: %w", err)
+	}
+	if manifest.Format != Format {
+		return nil, fmt.Errorf("not a snapshot archive: format %q", manifest.Format)
+	}
+	if manifest.Version > Version {
+		return nil, fmt.Errorf("snapshot version %d is newer than the supported version %d", manifest.Version, Version)
+	}
+	return &manifest, nil
+}
+
+// Import 将归档恢复到数据库，保留记录的主键
+// 每张表写入 tables 中为其声明的命名连接，未声明的写入默认连接 db；
+// 数据表按清单顺序（被引用的表在前）写入，各连接各开一个事务，任一表失败时全部回滚（非分布式事务）；
+// 目标库缺少的表按 tables 中的模型创建，没有模型的表跳过；目标表缺少的列跳过。
+// 数据写入成功后，files 不为 nil 时将归档中的文件写回存储
+func Import(ctx context.Context, db *gorm.DB, archive *zip.Reader, tables []migrate.Table, files Files, conflict string) (*Result, error) {
+	if err := ValidConflict(conflict); err != nil {
+		return nil, err
+	}
+	manifest, err := ReadManifest(archive)
+	if err != nil {
+		return nil, err
+	}
+	db = db.WithContext(ctx)
+	result := &Result{}
+
+	expected := make(map[string]migrate.Table, len(tables))
+	for _, table := range tables {
+		expected[table.Name] = table
+	}
+	// targets 与 connections 一一对应，connections 为表在目标库中所在的连接
+	var targets []TableInfo
+	var connections []string
+	for _, table := range manifest.Tables {
+		connection := expected[table.Name].Connection
+		conn, err := migrate.ConnectionDB(db, connection)
+		if err != nil {
+			return nil, fmt.Errorf("import %s: %w", table.Name, err)
+		}
+		if !conn.Migrator().HasTable(table.Name) {
+			model := expected[table.Name].Model
+			if model == nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("table %s does not exist, skipped", table.Name))
+				continue
+			}
+			// 建表语句在部分数据库（如 MySQL）中会隐式提交事务，在事务外执行
+			if err := conn.Table(table.Name).Migrator().CreateTable(model); err != nil {
+				return nil, fmt.Errorf("create table %s: %w", table.Name, err)
+			}
+		}
+		targets = append(targets, table)
+		connections = append(connections, connection)
+	}
+
+	manager := database.NewManager(db)
+	err = manager.WithTransaction(ctx, func(ctx context.Context) error {
+		if conflict == ConflictReplace {
+			// 先清空引用其他表的表
+			for i := len(targets) - 1; i >= 0; i-- {
+				tx := manager.Connection(ctx, connections[i])
+				if err := tx.Exec("DELETE FROM ?", clause.Table{Name: targets[i].Name}).Error; err != nil {
+					return fmt.Errorf("clear %s: %w", targets[i].Name, err)
+				}
+			}
+		}
+		for i, table := range targets {
+			tableResult, warnings, err := importTable(manager.Connection(ctx, connections[i]), archive, table, conflict)
+			if err != nil {
+				return fmt.Errorf("import %s: %w", table.Name, err)
+			}
+			result.Tables = append(result.Tables, *tableResult)
+			result.Warnings = append(result.Warnings, warnings...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if files != nil {
+		for _, file := range manifest.Files {
+			if err := importFile(ctx, archive, files, file); err != nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("file %s:%s skipped: %v", file.Disk, file.Key, err))
+				continue
+			}
+			result.Files++
+		}
+	}
+	return result, nil
+}
+
+// importTable 逐批写入一张表的记录，按目标表的列类型转换取值
+func importTable(tx *gorm.DB, archive *zip.Reader, table TableInfo, conflict string) (*TableResult, []string, error) {
+	columnTypes, err := tx.Migrator().ColumnTypes(table.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	targetKinds := make(map[string]string, len(columnTypes))
+	for _, columnType := range columnTypes {
+		targetKinds[columnType.Name()] = columnKind(columnType.DatabaseTypeName())
+	}
+	sourceKinds := make(map[string]string, len(table.Columns))
+	var warnings []string
+	var updates []string
+	for _, column := range table.Columns {
+		sourceKinds[column.Name] = column.Type
+		if _, ok := targetKinds[column.Name]; !ok {
+			warnings = append(warnings, fmt.Sprintf("column %s.%s does not exist, skipped", table.Name, column.Name))
+		} else if !contains(table.PrimaryKey, column.Name) {
+			updates = append(updates, column.Name)
+		}
+	}
+
+	query := tx.Table(table.Name)
+	if onConflict, ok := conflictClause(conflict, table.PrimaryKey, updates); ok {
+		query = query.Clauses(onConflict)
+	}
+
+	file, err := archive.Open(table.File)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer file.Close()
+
+	result := &TableResult{Name: table.Name}
+	flush := func(batch []map[string]interface{}) error {
+		if len(batch) == 0 {
+			return nil
+		}
+		inserted := query.Session(&gorm.Session{}).Create(&batch)
+		if inserted.Error != nil {
+			return inserted.Error
+		}
+		result.Imported += inserted.RowsAffected
+		return nil
+	}
+
+	decoder := json.NewDecoder(file)
+	decoder.UseNumber()
+	batch := make([]map[string]interface{}, 0, importBatchSize)
+	for {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, nil, fmt.Errorf("row %d: %w", result.Rows+1, err)
+		}
+		result.Rows++
+		row := make(map[string]interface{}, len(record))
+		for column, value := range record {
+			targetKind, ok := targetKinds[column]
+			if !ok {
+				continue
+			}
+			row[column], err = importValue(value, sourceKinds[column], targetKind)
+			if err != nil {
+				return nil, nil, fmt.Errorf("row %d column %s: %w", result.Rows, column, err)
+			}
+		}
+		batch = append(batch, row)
+		if len(batch) == importBatchSize {
+			if err := flush(batch); err != nil {
+				return nil, nil, err
+			}
+			batch = make([]map[string]interface{}, 0, importBatchSize)
+		}
+	}
+	if err := flush(batch); err != nil {
+		return nil, nil, err
+	}
+
+	if tx.Dialector.Name() == "postgres" && len(table.PrimaryKey) == 1 && targetKinds[table.PrimaryKey[0]] == ColumnInt {
+		// 写入了显式主键，自增序列需要前移到最大主键之后；表名按 SQL 标识符解析，加引号以保留大小写，
+		// 非自增列没有序列（pg_get_serial_sequence 为 NULL），此时跳过
+		primaryKey := table.PrimaryKey[0]
+		var sequence sql.NullString
+		if err := tx.Raw("SELECT pg_get_serial_sequence(?, ?)",
+			`"`+strings.ReplaceAll(table.Name, `"`, `""`)+`"`, primaryKey).Scan(&sequence).Error; err != nil {
+			return nil, nil, fmt.Errorf("find sequence: %w", err)
+		}
+		if sequence.Valid {
+			if err := tx.Exec("SELECT setval(CAST(? AS regclass), COALESCE((SELECT MAX(?) FROM ?), 0) + 1, false)",
+				sequence.String, clause.Column{Name: primaryKey}, clause.Table{Name: table.Name}).Error; err != nil {
+				return nil, nil, fmt.Errorf("reset sequence: %w", err)
+			}
+		}
+	}
+	return result, warnings, nil
+}
+
+// conflictClause 按冲突策略生成 ON CONFLICT 子句；fail、replace 策略与没有主键的表直接插入
+func conflictClause(conflict string, primaryKey []string, updates []string) (clause.OnConflict, bool) {
+	if len(primaryKey) == 0 {
+		return clause.OnConflict{}, false
+	}
+	columns := make([]clause.Column, 0, len(primaryKey))
+	for _, name := range primaryKey {
+		columns = append(columns, clause.Column{Name: name})
+	}
+	switch conflict {
+	case ConflictSkip:
+		return clause.OnConflict{Columns: columns, DoNothing: true}, true
+	case ConflictUpdate:
+		if len(updates) == 0 {
+			return clause.OnConflict{Columns: columns, DoNothing: true}, true
+		}
+		return clause.OnConflict{Columns: columns, DoUpdates: clause.AssignmentColumns(updates)}, true
+	}
+	return clause.OnConflict{}, false
+}
+
+// importFile 将归档中的文件写回存储，并校验 SHA-256
+func importFile(ctx context.Context, archive *zip.Reader, files Files, file FileInfo) error {
+	reader, err := archive.Open(file.Path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	if err := files.Save(ctx, file.Disk, file.Key, io.TeeReader(reader, hash)); err != nil {
+		return err
+	}
+	if checksum := hex.EncodeToString(hash.Sum(nil)); file.Checksum != "" && checksum != file.Checksum {
+		return fmt.Errorf("checksum mismatch")
+	}
+	return nil
+}
+
+// timeLayouts 导入时可识别的时间格式；导出使用 RFC 3339，其余为 SQLite 以文本保存的常见格式
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// importValue 将归档中的值转换为目标列可接受的值
+func importValue(value interface{}, sourceKind, targetKind string) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if s, ok := value.(string); ok && sourceKind == ColumnBinary {
+		decoded, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return nil, err
+		}
+		if targetKind == ColumnBinary {
+			return decoded, nil
+		}
+		value = string(decoded)
+	}
+
+	switch v := value.(type) {
+	case json.Number:
+		switch targetKind {
+		case ColumnBool:
+			return v.String() != "0", nil
+		case ColumnString:
+			return v.String(), nil
+		case ColumnFloat:
+			return v.Float64()
+		}
+		if i, err := v.Int64(); err == nil {
+			return i, nil
+		}
+		return v.Float64()
+	case bool:
+		if targetKind == ColumnInt || targetKind == ColumnFloat {
+			if v {
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		switch targetKind {
+		case ColumnTime:
+			for _, layout := range timeLayouts {
+				if t, err := time.Parse(layout, v); err == nil {
+					return t, nil
+				}
+			}
+		case ColumnBool:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		case ColumnBinary:
+			return []byte(v), nil
+		}
+	}
+	return value, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package snapshot
+
+import (
+	"fun-admin/internal/migrate"
+	"fun-admin/pkg/admin"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// sortTables 按外键依赖排序数据表，被引用的表在前，导入时依次插入即可满足外键约束
+// 依赖来自模型的 belongs to 关联与资源的关联字段；没有依赖关系的表保持原有顺序，循环依赖按原有顺序打断
+func sortTables(db *gorm.DB, tables []migrate.Table, resources []admin.Resource) []migrate.Table {
+	byName := make(map[string]migrate.Table, len(tables))
+	for _, table := range tables {
+		byName[table.Name] = table
+	}
+
+	dependencies := make(map[string][]string, len(tables))
+	for _, table := range tables {
+		dependencies[table.Name] = modelDependencies(db, table)
+	}
+	for _, resource := range resources {
+		name := resource.GetSlug()
+		for _, field := range resource.GetFields() {
+			relationship, ok := field.(*admin.RelationshipField)
+			if !ok || relationship.RelatedResource == "" || relationship.RelatedResource == name {
+				continue
+			}
+			dependencies[name] = append(dependencies[name], relationship.RelatedResource)
+		}
+	}
+
+	visited := make(map[string]bool, len(tables))
+	sorted := make([]migrate.Table, 0, len(tables))
+	var visit func(name string)
+	visit = func(name string) {
+		table, ok := byName[name]
+		if !ok || visited[name] {
+			return
+		}
+		visited[name] = true
+		for _, dependency := range dependencies[name] {
+			visit(dependency)
+		}
+		sorted = append(sorted, table)
+	}
+	for _, table := range tables {
+		visit(table.Name)
+	}
+	return sorted
+}
+
+// modelDependencies 模型通过 belongs to 关联引用的数据表
+func modelDependencies(db *gorm.DB, table migrate.Table) []string {
+	if table.Model == nil {
+		return nil
+	}
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.ParseWithSpecialTableName(table.Model, table.Name); err != nil {
+		return nil
+	}
+	var dependencies []string
+	for _, relationship := range stmt.Schema.Relationships.Relations {
+		if relationship.Type == schema.BelongsTo && relationship.FieldSchema.Table != table.Name {
+			dependencies = append(dependencies, relationship.FieldSchema.Table)
+		}
+	}
+	return dependencies
+}
@@ -0,0 +1,149 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"fun-admin/internal/migrate"
+	"fun-admin/internal/model"
+	"fun-admin/pkg/admin"
+
+	gormadapter "github.com/casbin/gorm-adapter/v3"
+	"gorm.io/gorm"
+)
+
+// 数据快照归档格式：zip 包内包含 manifest.json、每张表一个 tables/<表名>.ndjson，
+// 以及 files/ 下附件引用的存储文件。归档与数据库方言无关，可从 MySQL 导出后导入 SQLite 等
+const (
+	// Format 归档格式标识
+	Format = "fun-admin-snapshot"
+	// Version 归档格式版本，导入时拒绝更高版本的归档
+	Version = 1
+
+	manifestName = "manifest.json"
+	tablesDir    = "tables/"
+	filesDir     = "files/"
+
+	// attachmentTable 附件表，导出时一并打包其引用的文件
+	attachmentTable = "admin_attachment"
+)
+
+// 冲突策略：导入的记录与目标表中已有记录主键相同时的处理方式
+const (
+	ConflictSkip    = "skip"    // 保留已有记录
+	ConflictUpdate  = "update"  // 用归档中的记录覆盖已有记录
+	ConflictFail    = "fail"    // 报错并回滚整个导入
+	ConflictReplace = "replace" // 导入前清空归档中包含的表
+)
+
+// 列类型，导出时按源数据库的列类型记录，导入时据此还原为目标方言可接受的值
+const (
+	ColumnString = "string"
+	ColumnInt    = "int"
+	ColumnFloat  = "float"
+	ColumnBool   = "bool"
+	ColumnTime   = "time"
+	ColumnBinary = "binary" // base64 编码
+)
+
+// Manifest 归档清单
+type Manifest struct {
+	Format     string      `json:"format"`
+	Version    int         `json:"version"`
+	CreatedAt  time.Time   `json:"created_at"`
+	Dialect    string      `json:"dialect"`    // 导出时的数据库方言
+	Tables     []TableInfo `json:"tables"`     // 按外键依赖排序，被引用的表在前
+	Files      []FileInfo  `json:"files"`      // 附件引用的存储文件
+	Migrations []string    `json:"migrations"` // 源数据库已执行的版本化迁移
+	Warnings   []string    `json:"warnings"`   // 导出时跳过的表与无法读取的文件
+}
+
+// TableInfo 归档中的数据表
+type TableInfo struct {
+	Name       string   `json:"name"`
+	Connection string   `json:"connection,omitempty"` // 源数据表所在的命名连接，空表示默认连接
+	File       string   `json:"file"`
+	Rows       int      `json:"rows"`
+	PrimaryKey []string `json:"primary_key"`
+	Columns    []Column `json:"columns"`
+}
+
+// Column 数据表的列
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// FileInfo 归档中的存储文件
+type FileInfo struct {
+	Disk     string `json:"disk"`     // 存储磁盘（附件的 storage_type）
+	Key      string `json:"key"`      // 存储键
+	Path     string `json:"path"`     // 归档内路径
+	Size     int64  `json:"size"`     // 文件大小（字节）
+	Checksum string `json:"checksum"` // SHA-256 校验和
+}
+
+// Files 读写附件存储，由文件服务实现
+type Files interface {
+	Open(ctx context.Context, disk, key string) (io.ReadCloser, error)
+	Save(ctx context.Context, disk, key string, reader io.Reader) error
+}
+
+// CoreModels 快照包含的系统数据表：用户、角色、菜单、接口、Casbin 策略、系统设置、翻译、附件与评论
+// 导入/导出任务、定时报表运行记录等运行时数据不包含在内
+func CoreModels() []interface{} {
+	return []interface{}{
+		&model.User{},
+		&model.Role{},
+		&model.UserRole{},
+		&model.Menu{},
+		&model.RoleMenu{},
+		&model.Api{},
+		&gormadapter.CasbinRule{},
+		&model.Config{},
+		&model.DictionaryType{},
+		&model.DictionaryData{},
+		&model.Translation{},
+		&model.Attachment{},
+		&model.Comment{},
+		&model.CommentMention{},
+		&model.ScheduledReport{},
+	}
+}
+
+// coreTables 没有可导入模型的系统数据表，只按表名导出，导入时要求目标表已存在
+var coreTables = []string{"role_resources"}
+
+// Tables 汇总快照包含的数据表：系统数据表与全部已注册的资源表，按外键依赖排序
+func Tables(db *gorm.DB, resources []admin.Resource) ([]migrate.Table, error) {
+	tables, err := migrate.Tables(db, CoreModels(), resources)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range coreTables {
+		if !hasTable(tables, name) {
+			tables = append(tables, migrate.Table{Name: name})
+		}
+	}
+	return sortTables(db, tables, resources), nil
+}
+
+// ValidConflict 检查冲突策略是否有效
+func ValidConflict(conflict string) error {
+	switch conflict {
+	case ConflictSkip, ConflictUpdate, ConflictFail, ConflictReplace:
+		return nil
+	}
+	return fmt.Errorf("unknown conflict policy %q (skip, update, fail or replace)", conflict)
+}
+
+func hasTable(tables []migrate.Table, name string) bool {
+	for _, table := range tables {
+		if table.Name == name {
+			return true
+		}
+	}
+	return false
+}
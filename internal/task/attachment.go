@@ -0,0 +1,63 @@
+package task
+
+import (
+	"context"
+	"fun-admin/internal/repository"
+	"fun-admin/internal/service"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// attachmentRetention 软删除附件的保留时长，过期后清理存储文件
+const attachmentRetention = 7 * 24 * time.Hour
+
+type AttachmentTask interface {
+	CollectGarbage(ctx context.Context) error
+}
+
+func NewAttachmentTask(
+	task *Task,
+	attachmentRepo repository.AttachmentRepository,
+	fileService *service.FileService,
+) AttachmentTask {
+	return &attachmentTask{
+		attachmentRepo: attachmentRepo,
+		fileService:    fileService,
+		Task:           task,
+	}
+}
+
+type attachmentTask struct {
+	attachmentRepo repository.AttachmentRepository
+	fileService    *service.FileService
+	*Task
+}
+
+// CollectGarbage 删除超过保留期的软删除附件：先删存储文件，再物理删除记录
+func (t attachmentTask) CollectGarbage(ctx context.Context) error {
+	before := time.Now().Add(-attachmentRetention)
+	purged := 0
+	for {
+		list, err := t.attachmentRepo.GetTrashedAttachments(ctx, before, 100)
+		if err != nil {
+			return err
+		}
+		if len(list) == 0 {
+			break
+		}
+		for _, attachment := range list {
+			if err := t.fileService.DeleteFileWithContext(ctx, attachment.StorageType, attachment.StorageKey); err != nil {
+				t.logger.Warn("delete attachment file failed", zap.String("key", attachment.StorageKey), zap.Error(err))
+			}
+			if err := t.attachmentRepo.PurgeAttachment(ctx, attachment.ID); err != nil {
+				return err
+			}
+			purged++
+		}
+	}
+	if purged > 0 {
+		t.logger.Info("CollectGarbage attachments", zap.Int("purged", purged))
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package task
+
+import (
+	"context"
+	"fun-admin/internal/service"
+
+	"go.uber.org/zap"
+)
+
+type ReportTask interface {
+	RunDueReports(ctx context.Context) error
+}
+
+func NewReportTask(
+	task *Task,
+	reportService service.ScheduledReportService,
+) ReportTask {
+	return &reportTask{
+		reportService: reportService,
+		Task:          task,
+	}
+}
+
+type reportTask struct {
+	reportService service.ScheduledReportService
+	*Task
+}
+
+// RunDueReports 执行已到运行时间的定时报表，调度时间由各报表的 Cron 与时区决定
+func (t reportTask) RunDueReports(ctx context.Context) error {
+	executed, err := t.reportService.RunDue(ctx)
+	if executed > 0 {
+		t.logger.Info("RunDueReports", zap.Int("executed", executed))
+	}
+	return err
+}
@@ -6,6 +6,8 @@ import (
 	"strconv"
 	"strings"
 
+	"fun-admin/pkg/admin/i18n"
+
 	"github.com/gin-gonic/gin"
 )
 
@@ -128,9 +130,9 @@ func (ag *APIGenerator) RegisterAdminAPI() {
 func (ag *APIGenerator) listResourcesHandler() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 获取语言参数
-		language := c.Query("language")
+		language := c.GetString(i18n.ContextKey)
 		if language == "" {
-			language = "zh-CN"
+			language = i18n.RequestLanguage(c.Query("language"), c.GetHeader("Accept-Language"))
 		}
 
 		resources := ag.resourceManager.GetResources()
@@ -145,11 +147,13 @@ func (ag *APIGenerator) listResourcesHandler() gin.HandlerFunc {
 			}
 
 			resourceMap := map[string]interface{}{
-				"title":      translateResourceTitle(resource.GetTitle(), language),
+				"title":      TranslateLabel(resource.GetTitle(), language),
 				"slug":       resource.GetSlug(),
 				"model":      resource.GetModel(),
-				"fields":     resource.GetFields(),
-				"actions":    resource.GetActions(),
+				"fields":     LocalizeFields(resource.GetFields(), language),
+				"columns":    LocalizeColumns(resource.GetColumns(), language),
+				"filters":    LocalizeFilters(resource.GetFilters(), language),
+				"actions":    localizeActions(resource.GetActions(), language),
 				"editable":   true,
 				"creatable":  true,
 				"viewable":   true,
@@ -163,7 +167,7 @@ func (ag *APIGenerator) listResourcesHandler() gin.HandlerFunc {
 		pageList := make([]map[string]interface{}, 0)
 		for _, page := range pages {
 			pageMap := map[string]interface{}{
-				"title": translateResourceTitle(page.GetTitle(), language),
+				"title": TranslateLabel(page.GetTitle(), language),
 				"slug":  page.GetSlug(),
 				"type":  "page",
 			}
@@ -180,37 +184,6 @@ func (ag *APIGenerator) listResourcesHandler() gin.HandlerFunc {
 	}
 }
 
-// translateResourceTitle 翻译资源标题
-func translateResourceTitle(title, language string) string {
-	// 这里可以根据需要实现翻译逻辑
-	// 目前直接返回标题，后续可以扩展为从资源映射中获取翻译
-	key := strings.ToLower(strings.ReplaceAll(title, " ", "_"))
-	// 简单的中英文映射示例
-	if language == "en" {
-		switch key {
-		case "用户":
-			return "Users"
-		case "角色":
-			return "Roles"
-		case "部门":
-			return "Departments"
-		case "文章":
-			return "Posts"
-		case "分类":
-			return "Categories"
-		case "菜单":
-			return "Menus"
-		case "api":
-			return "APIs"
-		case "仪表板":
-			return "Dashboard"
-		default:
-			return title
-		}
-	}
-	return title
-}
-
 // listHandler 处理列表请求
 func (ag *APIGenerator) listHandler(resource Resource, slug string) gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -645,3 +618,16 @@ type ValidationError struct {
 func (e *ValidationError) Error() string {
 	return "validation failed"
 }
+
+// localizeActions 返回动作的名称、翻译后的标签与是否主要动作
+func localizeActions(actions []Action, language string) []map[string]interface{} {
+	localized := make([]map[string]interface{}, 0, len(actions))
+	for _, action := range actions {
+		localized = append(localized, map[string]interface{}{
+			"name":    action.GetName(),
+			"label":   TranslateLabel(action.GetLabel(), language),
+			"primary": action.IsPrimary(),
+		})
+	}
+	return localized
+}
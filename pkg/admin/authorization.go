@@ -1,6 +1,14 @@
 package admin
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"fun-admin/pkg"
+	"fun-admin/pkg/jwt"
+)
 
 // Authorizable 提供资源级/动作级鉴权的可选接口
 // 返回 nil 表示允许，返回错误表示拒绝并携带原因
@@ -14,3 +22,52 @@ type Authorizable interface {
 	CanUpdate(ctx context.Context, id interface{}, data map[string]interface{}) error
 	CanDelete(ctx context.Context, id interface{}) error
 }
+
+// ErrPermissionDenied 策略拒绝访问，接口层映射为 403
+var ErrPermissionDenied = errors.New("permission denied")
+
+// PolicyEnforcer 资源策略的判定器，*casbin.SyncedEnforcer 满足该接口
+type PolicyEnforcer interface {
+	Enforce(rvals ...interface{}) (bool, error)
+}
+
+var (
+	policyMu       sync.RWMutex
+	policyEnforcer PolicyEnforcer
+)
+
+// SetPolicyEnforcer 设置 AuthorizeResource 使用的判定器，由启动流程注入 Casbin
+func SetPolicyEnforcer(enforcer PolicyEnforcer) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	policyEnforcer = enforcer
+}
+
+// AuthorizeResource 以当前用户为主体、resource:<slug> 为对象、action 为动作查询 Casbin 策略
+// 超级管理员直接放行；未登录返回 ErrPermissionDenied；未设置判定器时放行，与未实现 Authorizable 一致
+func AuthorizeResource(ctx context.Context, slug, action string) error {
+	policyMu.RLock()
+	enforcer := policyEnforcer
+	policyMu.RUnlock()
+	if enforcer == nil {
+		return nil
+	}
+
+	claims, ok := ctx.Value("claims").(*jwt.MyCustomClaims)
+	if !ok || claims == nil {
+		return ErrPermissionDenied
+	}
+	subject := fmt.Sprint(claims.UserId)
+	if subject == pkg.AdminUserID {
+		return nil
+	}
+
+	allowed, err := enforcer.Enforce(subject, pkg.ResourcePolicyPrefix+slug, action)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return fmt.Errorf("%w: %s %s", ErrPermissionDenied, action, slug)
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package declarative
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"fun-admin/pkg/admin"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Error 定义文件中的错误，带文件与行号
+type Error struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.File, e.Message)
+}
+
+// Errors 一次加载中的全部错误
+type Errors []*Error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
+}
+
+var (
+	slugPattern       = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	yamlLinePattern   = regexp.MustCompile(`line (\d+)`)
+
+	fieldTypes  = []string{"text", "email", "number", "select", "textarea", "boolean", "date", "datetime", "relationship", "file"}
+	filterTypes = []string{"text", "select", "date", "daterange", "numberrange", "boolean"}
+	actionNames = []string{"view", "edit", "delete", admin.ReplicateActionName}
+	// systemColumns 资源仓库维护的列，可用于列、排序与过滤而无需声明字段
+	systemColumns = []string{"id", "created_at", "updated_at", "deleted_at"}
+)
+
+// document 解析后的单个定义文件
+type document struct {
+	file string
+	root *yaml.Node
+	spec ResourceSpec
+}
+
+// parseDocument 解析 YAML 或 JSON 定义，未知键视为错误
+func parseDocument(file string, data []byte) (*document, Errors) {
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		// JSON 缩进中的制表符在 YAML 中不合法，字符串内不允许出现原始制表符，可直接替换
+		data = bytes.ReplaceAll(data, []byte("\t"), []byte("  "))
+	}
+
+	doc := &document{file: file, root: &yaml.Node{}}
+	if err := yaml.Unmarshal(data, doc.root); err != nil {
+		return nil, Errors{yamlError(file, err)}
+	}
+	if len(doc.root.Content) == 0 || doc.root.Content[0].Kind != yaml.MappingNode {
+		return nil, Errors{{File: file, Line: 1, Message: "resource definition must be a mapping"}}
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&doc.spec); err != nil {
+		var errs Errors
+		if typeErr, ok := err.(*yaml.TypeError); ok {
+			for _, message := range typeErr.Errors {
+				errs = append(errs, yamlError(file, fmt.Errorf("%s", message)))
+			}
+			return nil, errs
+		}
+		return nil, Errors{yamlError(file, err)}
+	}
+	return doc, nil
+}
+
+// yamlError 从 yaml 错误信息中取出行号
+func yamlError(file string, err error) *Error {
+	message := strings.TrimPrefix(err.Error(), "yaml: ")
+	line := 0
+	if match := yamlLinePattern.FindStringSubmatch(message); match != nil {
+		line, _ = strconv.Atoi(match[1])
+		message = strings.TrimSpace(strings.TrimPrefix(strings.Replace(message, match[0], "", 1), ":"))
+	}
+	return &Error{File: file, Line: line, Message: message}
+}
+
+// line 按键与下标定位节点行号，找不到时返回最近一级的行号
+func (d *document) line(path ...interface{}) int {
+	node := d.root.Content[0]
+	for _, step := range path {
+		var next *yaml.Node
+		switch key := step.(type) {
+		case string:
+			if node.Kind == yaml.MappingNode {
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					if node.Content[i].Value == key {
+						next = node.Content[i+1]
+						break
+					}
+				}
+			}
+		case int:
+			if node.Kind == yaml.SequenceNode && key < len(node.Content) {
+				next = node.Content[key]
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node.Line
+}
+
+func (d *document) errorf(errs *Errors, path []interface{}, format string, args ...interface{}) {
+	*errs = append(*errs, &Error{File: d.file, Line: d.line(path...), Message: fmt.Sprintf(format, args...)})
+}
+
+// validate 检查单个文件内的定义
+func (d *document) validate() Errors {
+	var errs Errors
+	spec := &d.spec
+
+	switch {
+	case spec.Slug == "":
+		d.errorf(&errs, nil, "slug is required")
+	case !slugPattern.MatchString(spec.Slug):
+		d.errorf(&errs, path("slug"), "slug %q must be lower case letters, digits and underscores", spec.Slug)
+	}
+	if spec.Table != "" && spec.Table != spec.Slug {
+		d.errorf(&errs, path("table"), "table %q must equal the slug %q: records are stored in the table named after the slug", spec.Table, spec.Slug)
+	}
+	if len(spec.Fields) == 0 {
+		d.errorf(&errs, path("fields"), "at least one field is required")
+	}
+
+	known := make(map[string]*FieldSpec)
+	for _, name := range systemColumns {
+		known[name] = nil
+	}
+	for i := range spec.Fields {
+		field := &spec.Fields[i]
+		at := path("fields", i)
+		if field.Type == "" {
+			field.Type = "text"
+		}
+		switch {
+		case !identifierPattern.MatchString(field.Name):
+			d.errorf(&errs, path("fields", i, "name"), "field name %q is not a valid column name", field.Name)
+			continue
+		case known[field.Name] != nil:
+			d.errorf(&errs, at, "field %q is declared twice", field.Name)
+			continue
+		}
+		known[field.Name] = field
+
+		if !contains(fieldTypes, field.Type) {
+			d.errorf(&errs, path("fields", i, "type"), "field %q has unknown type %q (expected one of %s)", field.Name, field.Type, strings.Join(fieldTypes, ", "))
+		}
+		switch {
+		case field.Type == "relationship" && field.Resource == "":
+			d.errorf(&errs, at, "relationship field %q needs resource", field.Name)
+		case field.Type != "relationship" && field.Resource != "":
+			d.errorf(&errs, path("fields", i, "resource"), "resource is only valid on relationship fields")
+		case field.Type == "select" && len(field.Options) == 0 && field.Dict == "":
+			d.errorf(&errs, at, "select field %q needs options or dict", field.Name)
+		}
+		for j, rule := range field.Validators {
+			if _, err := parseValidator(rule); err != nil {
+				d.errorf(&errs, path("fields", i, "validators", j), "field %q: %v", field.Name, err)
+			}
+		}
+	}
+
+	checkNames := func(key string, names []string) {
+		for i, name := range names {
+			if _, ok := known[name]; !ok {
+				d.errorf(&errs, path(key, i), "%s refers to unknown field %q", key, name)
+			}
+		}
+	}
+	checkNames("read_only", spec.ReadOnly)
+	checkNames("searchable", spec.Searchable)
+	checkNames("sortable", spec.Sortable)
+
+	for i, column := range spec.Columns {
+		if _, ok := known[column.Name]; !ok && !strings.Contains(column.Name, ".") {
+			d.errorf(&errs, path("columns", i), "column refers to unknown field %q", column.Name)
+		}
+		if column.Align != "" && !contains([]string{"left", "center", "right"}, column.Align) {
+			d.errorf(&errs, path("columns", i, "align"), "align must be left, center or right")
+		}
+	}
+	for i, filter := range spec.Filters {
+		if _, ok := known[filter.Name]; !ok {
+			d.errorf(&errs, path("filters", i), "filter refers to unknown field %q", filter.Name)
+		}
+		if filter.Type != "" && !contains(filterTypes, filter.Type) {
+			d.errorf(&errs, path("filters", i, "type"), "filter %q has unknown type %q (expected one of %s)", filter.Name, filter.Type, strings.Join(filterTypes, ", "))
+		}
+	}
+	for i, action := range spec.Actions {
+		if !contains(actionNames, action) {
+			d.errorf(&errs, path("actions", i), "unknown action %q (expected one of %s)", action, strings.Join(actionNames, ", "))
+		}
+	}
+	if parts := strings.Fields(spec.DefaultOrder); len(parts) > 0 {
+		if _, ok := known[parts[0]]; !ok || len(parts) > 2 || (len(parts) == 2 && !contains([]string{"asc", "desc"}, strings.ToLower(parts[1]))) {
+			d.errorf(&errs, path("default_order"), "default_order must be \"<field> [asc|desc]\" on a declared field")
+		}
+	}
+	return errs
+}
+
+func path(steps ...interface{}) []interface{} {
+	return steps
+}
+
+// parseValidator 解析验证规则：required、email、min_length:N、max_length:N
+func parseValidator(rule string) (admin.Validator, error) {
+	name, arg, hasArg := strings.Cut(strings.TrimSpace(rule), ":")
+	switch name {
+	case "required":
+		return admin.NewRequiredValidator(), nil
+	case "email":
+		return admin.NewEmailValidator(), nil
+	case "min_length", "max_length":
+		n, err := strconv.Atoi(strings.TrimSpace(arg))
+		if !hasArg || err != nil || n <= 0 {
+			return nil, fmt.Errorf("validator %q needs a positive length, e.g. %s:64", rule, name)
+		}
+		if name == "min_length" {
+			return admin.NewMinLengthValidator(n), nil
+		}
+		return admin.NewMaxLengthValidator(n), nil
+	}
+	return nil, fmt.Errorf("unknown validator %q (expected required, email, min_length:N or max_length:N)", rule)
+}
+
+// LoadDir 加载目录（含子目录）下的 .yaml、.yml、.json 定义
+// registered 为已注册的其他资源（如 Go 资源），用于检查 slug 冲突与关联字段引用；任一文件有错时不返回资源
+func LoadDir(dir string, registered []admin.Resource) ([]*Resource, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(name))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml" || ext == ".json") {
+			files = append(files, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+
+	var errs Errors
+	var docs []*document
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		doc, parseErrs := parseDocument(file, data)
+		if len(parseErrs) > 0 {
+			errs = append(errs, parseErrs...)
+			continue
+		}
+		if validateErrs := doc.validate(); len(validateErrs) > 0 {
+			errs = append(errs, validateErrs...)
+			continue
+		}
+		docs = append(docs, doc)
+	}
+
+	// slug 冲突与关联引用跨文件检查
+	slugs := make(map[string]string)
+	for _, resource := range registered {
+		slugs[resource.GetSlug()] = "a Go resource"
+	}
+	for _, doc := range docs {
+		if owner, ok := slugs[doc.spec.Slug]; ok {
+			doc.errorf(&errs, path("slug"), "slug %q is already used by %s", doc.spec.Slug, owner)
+			continue
+		}
+		slugs[doc.spec.Slug] = doc.file
+	}
+	for _, doc := range docs {
+		for i, field := range doc.spec.Fields {
+			if field.Type == "relationship" {
+				if _, ok := slugs[field.Resource]; !ok {
+					doc.errorf(&errs, path("fields", i, "resource"), "field %q refers to unknown resource %q", field.Name, field.Resource)
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	resources := make([]*Resource, 0, len(docs))
+	for _, doc := range docs {
+		resources = append(resources, newResource(doc.spec, doc.file))
+	}
+	return resources, nil
+}
+
+// Loader 把目录中的定义注册到资源管理器，重新加载时整体替换上次加载的资源
+type Loader struct {
+	dir     string
+	manager *admin.ResourceManager
+
+	mu     sync.Mutex
+	loaded []*Resource
+}
+
+// NewLoader 创建加载器
+func NewLoader(dir string, manager *admin.ResourceManager) *Loader {
+	return &Loader{dir: dir, manager: manager}
+}
+
+// Load 加载并注册定义；有错误时保留上次加载的资源
+func (l *Loader) Load() ([]*Resource, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	previous := make(map[string]bool, len(l.loaded))
+	for _, resource := range l.loaded {
+		previous[resource.GetSlug()] = true
+	}
+	var registered []admin.Resource
+	for _, resource := range l.manager.GetResources() {
+		if !previous[resource.GetSlug()] {
+			registered = append(registered, resource)
+		}
+	}
+
+	resources, err := LoadDir(l.dir, registered)
+	if err != nil {
+		return nil, err
+	}
+	added := make([]admin.Resource, len(resources))
+	for i, resource := range resources {
+		added[i] = resource
+	}
+	removed := make([]string, 0, len(previous))
+	for slug := range previous {
+		removed = append(removed, slug)
+	}
+	l.manager.ReplaceResources(removed, added)
+	l.loaded = resources
+	return resources, nil
+}
+
+// UnresolvedRelations 列出关联字段引用了未注册资源的情况，格式为 "<slug>.<field> -> <resource>"
+func UnresolvedRelations(resources []admin.Resource) []string {
+	slugs := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		slugs[resource.GetSlug()] = true
+	}
+	var unresolved []string
+	for _, resource := range resources {
+		for _, field := range resource.GetFields() {
+			if relation, ok := field.(*admin.RelationshipField); ok && !slugs[relation.RelatedResource] {
+				unresolved = append(unresolved, fmt.Sprintf("%s.%s -> %s", resource.GetSlug(), relation.GetName(), relation.RelatedResource))
+			}
+		}
+	}
+	return unresolved
+}
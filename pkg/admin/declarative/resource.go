@@ -0,0 +1,413 @@
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fun-admin/pkg/admin"
+)
+
+// Resource 由定义文件生成的资源，实现 admin.Resource 及搜索、排序、过滤、导航、能力与鉴权等可选接口
+type Resource struct {
+	spec    ResourceSpec
+	file    string
+	title   string
+	group   string
+	fields  []admin.Field
+	columns []*admin.Column
+	filters []*admin.Filter
+	actions []admin.Action
+}
+
+// newResource 按已校验的定义构建资源，标签在此时解析一次
+func newResource(spec ResourceSpec, file string) *Resource {
+	r := &Resource{
+		spec:  spec,
+		file:  file,
+		title: spec.Title.Key(admin.LabelKey(spec.Slug, "title")),
+		group: spec.Navigation.Group.Key(admin.LabelKey(spec.Slug, "navigation", "group")),
+	}
+	if r.title == "" {
+		r.title = spec.Slug
+	}
+
+	byName := make(map[string]*FieldSpec, len(spec.Fields))
+	hasID := false
+	for i := range spec.Fields {
+		byName[spec.Fields[i].Name] = &spec.Fields[i]
+		hasID = hasID || spec.Fields[i].Name == "id"
+	}
+	if !hasID {
+		r.fields = append(r.fields, admin.NewIDField().Label("ID"))
+	}
+	for _, field := range spec.Fields {
+		r.fields = append(r.fields, buildField(spec.Slug, field))
+	}
+
+	if len(spec.Columns) == 0 {
+		r.columns = defaultColumns(spec)
+	}
+	for _, column := range spec.Columns {
+		r.columns = append(r.columns, buildColumn(spec.Slug, column, byName[column.Name], spec.Sortable))
+	}
+	for _, filter := range spec.Filters {
+		r.filters = append(r.filters, buildFilter(spec.Slug, filter, byName[filter.Name]))
+	}
+
+	actions := spec.Actions
+	if len(actions) == 0 {
+		actions = []string{"view", "edit", "delete"}
+	}
+	for _, name := range actions {
+		switch {
+		case name == "view":
+			r.actions = append(r.actions, admin.NewViewAction().Label("view"))
+		case name == "edit" && enabled(spec.Permissions.Update):
+			r.actions = append(r.actions, admin.NewEditAction().Label("edit"))
+		case name == "delete" && enabled(spec.Permissions.Delete):
+			r.actions = append(r.actions, admin.NewDeleteAction().Label("delete"))
+		case name == admin.ReplicateActionName && enabled(spec.Permissions.Create):
+			r.actions = append(r.actions, admin.NewReplicateAction().Label("replicate"))
+		}
+	}
+	return r
+}
+
+// File 定义所在文件
+func (r *Resource) File() string { return r.file }
+
+// Spec 返回资源定义
+func (r *Resource) Spec() ResourceSpec { return r.spec }
+
+func (r *Resource) GetTitle() string { return r.title }
+func (r *Resource) GetSlug() string  { return r.spec.Slug }
+
+// GetModel 声明式资源没有 Go 模型，数据按 slug 对应的表读写
+func (r *Resource) GetModel() interface{}       { return nil }
+func (r *Resource) GetFields() []admin.Field    { return r.fields }
+func (r *Resource) GetActions() []admin.Action  { return r.actions }
+func (r *Resource) GetColumns() []*admin.Column { return r.columns }
+func (r *Resource) GetFilters() []*admin.Filter { return r.filters }
+
+func (r *Resource) GetReadOnlyFields() []string {
+	return append([]string{"id", "created_at", "updated_at"}, r.spec.ReadOnly...)
+}
+
+func (r *Resource) GetSearchableFields() []string { return r.spec.Searchable }
+func (r *Resource) GetSortableFields() []string   { return r.spec.Sortable }
+
+// GetFilterableFields 过滤器声明的字段
+func (r *Resource) GetFilterableFields() []string {
+	names := make([]string, 0, len(r.filters))
+	for _, filter := range r.filters {
+		names = append(names, filter.Name)
+	}
+	return names
+}
+
+// GetDefaultOrder 解析 default_order，未声明时按 id 倒序
+func (r *Resource) GetDefaultOrder() (string, string) {
+	parts := strings.Fields(r.spec.DefaultOrder)
+	if len(parts) == 0 {
+		return "id", "DESC"
+	}
+	if len(parts) == 1 {
+		return parts[0], "ASC"
+	}
+	return parts[0], strings.ToUpper(parts[1])
+}
+
+func (r *Resource) IsExportable() bool {
+	return enabled(r.spec.Exportable) && enabled(r.spec.Permissions.Export)
+}
+
+func (r *Resource) IsCommentable() bool        { return r.spec.Commentable }
+func (r *Resource) GetConnection() string      { return r.spec.Connection }
+func (r *Resource) GetNavigationIcon() string  { return r.spec.Navigation.Icon }
+func (r *Resource) GetNavigationGroup() string { return r.group }
+func (r *Resource) GetNavigationSort() int     { return r.spec.Navigation.Sort }
+func (r *Resource) IsHiddenInNavigation(ctx context.Context) bool {
+	return r.spec.Navigation.Hidden
+}
+
+// GetFrontendCapabilities 按 permissions 控制前端按钮
+func (r *Resource) GetFrontendCapabilities(ctx context.Context) admin.FrontendCapabilities {
+	permissions := r.spec.Permissions
+	return admin.FrontendCapabilities{
+		Viewable:   true,
+		Creatable:  enabled(permissions.Create),
+		Editable:   enabled(permissions.Update),
+		Deletable:  enabled(permissions.Delete),
+		Exportable: r.IsExportable(),
+	}
+}
+
+// authorize 关闭的操作直接拒绝，开启 policy 时再查询 Casbin 策略
+func (r *Resource) authorize(ctx context.Context, action string, allowed bool) error {
+	if !allowed {
+		return fmt.Errorf("%w: %s is disabled for %s", admin.ErrPermissionDenied, action, r.spec.Slug)
+	}
+	if r.spec.Permissions.Policy {
+		return admin.AuthorizeResource(ctx, r.spec.Slug, action)
+	}
+	return nil
+}
+
+func (r *Resource) CanList(ctx context.Context) error {
+	return r.authorize(ctx, "list", true)
+}
+
+func (r *Resource) CanView(ctx context.Context, id interface{}) error {
+	return r.authorize(ctx, "view", true)
+}
+
+func (r *Resource) CanCreate(ctx context.Context, data map[string]interface{}) error {
+	return r.authorize(ctx, "create", enabled(r.spec.Permissions.Create))
+}
+
+func (r *Resource) CanUpdate(ctx context.Context, id interface{}, data map[string]interface{}) error {
+	return r.authorize(ctx, "update", enabled(r.spec.Permissions.Update))
+}
+
+func (r *Resource) CanDelete(ctx context.Context, id interface{}) error {
+	return r.authorize(ctx, "delete", enabled(r.spec.Permissions.Delete))
+}
+
+// buildField 按类型构建字段并挂载验证器
+func buildField(slug string, spec FieldSpec) admin.Field {
+	label := spec.Label.Key(admin.LabelKey(slug, "fields", spec.Name))
+	if label == "" {
+		label = spec.Name
+	}
+
+	var field admin.Field
+	var addValidator func(admin.Validator)
+	switch spec.Type {
+	case "email":
+		f := admin.NewEmailField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "number":
+		f := admin.NewNumberField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		if value, ok := spec.Default.(int); ok {
+			f.SetDefault(value)
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "select":
+		f := admin.NewSelectField(spec.Name).Label(label).SetOptions(buildOptions(admin.LabelKey(slug, "fields", spec.Name), spec.Options))
+		if spec.Required {
+			f.Required()
+		}
+		if spec.Dict != "" {
+			f.SetDictCode(spec.Dict)
+		}
+		if spec.Default != nil {
+			f.SetDefault(fmt.Sprint(spec.Default))
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "textarea":
+		f := admin.NewTextareaField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		if spec.Translatable {
+			f.Translatable()
+		}
+		if spec.Rows > 0 {
+			f.SetRows(spec.Rows)
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "boolean":
+		f := admin.NewBooleanField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		if value, ok := spec.Default.(bool); ok {
+			f.SetDefault(value)
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "date":
+		f := admin.NewDateField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "datetime":
+		f := admin.NewDateTimeField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "relationship":
+		f := admin.NewRelationshipField(spec.Name, spec.Resource).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		if spec.DisplayField != "" {
+			f.SetDisplayField(spec.DisplayField)
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	case "file":
+		f := admin.NewFileField(spec.Name).Label(label).SetMultiple(spec.Multiple)
+		if len(spec.AllowedTypes) > 0 {
+			f.SetAllowedTypes(spec.AllowedTypes)
+		}
+		if spec.MaxSize > 0 {
+			f.SetMaxSize(spec.MaxSize)
+		}
+		return f
+	default:
+		f := admin.NewTextField(spec.Name).Label(label)
+		if spec.Required {
+			f.Required()
+		}
+		if spec.Translatable {
+			f.Translatable()
+		}
+		field, addValidator = f, func(v admin.Validator) { f.AddValidator(v) }
+	}
+
+	for _, rule := range spec.Validators {
+		if validator, _ := parseValidator(rule); validator != nil {
+			addValidator(validator)
+		}
+	}
+	return field
+}
+
+// buildOptions 构建选项，prefix 为选项标签翻译键的前缀
+func buildOptions(prefix string, specs []OptionSpec) []admin.Option {
+	options := make([]admin.Option, 0, len(specs))
+	for _, option := range specs {
+		label := option.Label.Key(prefix + ".options." + option.Value)
+		if label == "" {
+			label = option.Value
+		}
+		options = append(options, admin.Option{Value: option.Value, Label: label})
+	}
+	return options
+}
+
+// columnType 字段类型对应的列类型，关联与选项字段按文本显示
+func columnType(fieldType string) string {
+	switch fieldType {
+	case "number", "boolean", "date", "datetime":
+		return fieldType
+	}
+	return "text"
+}
+
+// defaultColumns 未声明列时展示 id、除文本域与文件外的字段及创建时间
+func defaultColumns(spec ResourceSpec) []*admin.Column {
+	columns := []*admin.Column{admin.NewColumn("id", "ID", "number").SetSortable(true)}
+	hasCreatedAt := false
+	for i := range spec.Fields {
+		field := &spec.Fields[i]
+		if field.Name == "id" || field.Type == "textarea" || field.Type == "file" {
+			continue
+		}
+		hasCreatedAt = hasCreatedAt || field.Name == "created_at"
+		columns = append(columns, buildColumn(spec.Slug, ColumnSpec{Name: field.Name}, field, spec.Sortable))
+	}
+	if !hasCreatedAt {
+		columns = append(columns, admin.NewColumn("created_at", "created_at", "datetime").SetSortable(true))
+	}
+	return columns
+}
+
+func buildColumn(slug string, spec ColumnSpec, field *FieldSpec, sortable []string) *admin.Column {
+	label := spec.Label.Key(admin.LabelKey(slug, "columns", spec.Name))
+	if label == "" && field != nil {
+		label = field.Label.Key(admin.LabelKey(slug, "fields", field.Name))
+	}
+	if label == "" {
+		label = spec.Name
+	}
+	typ := spec.Type
+	if typ == "" {
+		typ = "text"
+		if field != nil {
+			typ = columnType(field.Type)
+		}
+	}
+
+	column := admin.NewColumn(spec.Name, label, typ).SetSortable(spec.Sortable || contains(sortable, spec.Name))
+	switch spec.Align {
+	case "center":
+		column.AlignCenter()
+	case "right":
+		column.AlignRight()
+	}
+	if spec.Width > 0 {
+		column.SetWidth(spec.Width)
+	}
+	if spec.Formatter != "" {
+		column.SetFormatter(spec.Formatter)
+	}
+	if spec.EnumMap != nil {
+		column.SetEnumMap(spec.EnumMap)
+	} else if field != nil && field.Type == "select" && len(field.Options) > 0 {
+		enum := make(map[string]string, len(field.Options))
+		for _, option := range buildOptions(admin.LabelKey(slug, "fields", field.Name), field.Options) {
+			enum[option.Value] = option.Label
+		}
+		column.SetEnumMap(enum)
+	}
+	if spec.BadgeMap != nil {
+		column.SetBadgeMap(spec.BadgeMap)
+	}
+	return column
+}
+
+// filterType 字段类型对应的过滤器类型
+func filterType(fieldType string) string {
+	switch fieldType {
+	case "select", "boolean":
+		return fieldType
+	case "date", "datetime":
+		return "daterange"
+	case "number":
+		return "numberrange"
+	}
+	return "text"
+}
+
+func buildFilter(slug string, spec FilterSpec, field *FieldSpec) *admin.Filter {
+	label := spec.Label.Key(admin.LabelKey(slug, "filters", spec.Name))
+	if label == "" && field != nil {
+		label = field.Label.Key(admin.LabelKey(slug, "fields", field.Name))
+	}
+	if label == "" {
+		label = spec.Name
+	}
+	typ := spec.Type
+	if typ == "" {
+		typ = "text"
+		if field != nil {
+			typ = filterType(field.Type)
+		}
+	}
+
+	filter := admin.NewFilter(spec.Name, label, typ)
+	if len(spec.Options) > 0 {
+		filter.SetOptions(buildOptions(admin.LabelKey(slug, "filters", spec.Name), spec.Options))
+	} else if field != nil && len(field.Options) > 0 {
+		filter.SetOptions(buildOptions(admin.LabelKey(slug, "fields", field.Name), field.Options))
+	}
+	return filter
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+// Package declarative 从 YAML/JSON 文件加载资源定义，无需编写 Go 代码
+//
+// 一个文件定义一个资源，例如 resources/products.yaml：
+//
+//	slug: products
+//	title: {zh-CN: 商品, en: Products}
+//	fields:
+//	  - {name: name, type: text, label: 名称, required: true, validators: [max_length:64]}
+//	  - {name: category_id, type: relationship, resource: categories, display_field: name}
+//	searchable: [name]
+//	permissions: {delete: false, policy: true}
+//	navigation: {icon: "heroicons-outline:cube", group: 商品管理, sort: 10}
+//
+// 表名即 slug（资源仓库按 slug 访问数据表）；关联字段可以引用 Go 资源或其他声明式资源
+package declarative
+
+import (
+	"fmt"
+
+	"fun-admin/pkg/admin"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceSpec 资源定义文件的结构
+type ResourceSpec struct {
+	Slug         string         `yaml:"slug"`
+	Table        string         `yaml:"table"` // 可省略，必须与 slug 相同
+	Title        Label          `yaml:"title"`
+	Fields       []FieldSpec    `yaml:"fields"`
+	ReadOnly     []string       `yaml:"read_only"` // 追加在 id、created_at、updated_at 之后
+	Columns      []ColumnSpec   `yaml:"columns"`   // 省略时按字段生成
+	Filters      []FilterSpec   `yaml:"filters"`
+	Searchable   []string       `yaml:"searchable"`
+	Sortable     []string       `yaml:"sortable"`
+	DefaultOrder string         `yaml:"default_order"` // 如 "created_at desc"
+	Actions      []string       `yaml:"actions"`       // 内置动作：view、edit、delete、replicate，默认 view、edit、delete
+	Exportable   *bool          `yaml:"exportable"`
+	Commentable  bool           `yaml:"commentable"`
+	Connection   string         `yaml:"connection"` // 命名数据库连接（data.db.connections），默认使用主库连接
+	Permissions  PermissionSpec `yaml:"permissions"`
+	Navigation   NavigationSpec `yaml:"navigation"`
+}
+
+// FieldSpec 字段定义
+// validators 为规则字符串：required、email、min_length:N、max_length:N
+type FieldSpec struct {
+	Name         string       `yaml:"name"`
+	Type         string       `yaml:"type"` // text、email、number、select、textarea、boolean、date、datetime、relationship、file
+	Label        Label        `yaml:"label"`
+	Required     bool         `yaml:"required"`
+	Translatable bool         `yaml:"translatable"`
+	Default      interface{}  `yaml:"default"`
+	Validators   []string     `yaml:"validators"`
+	Options      []OptionSpec `yaml:"options"`       // select
+	Dict         string       `yaml:"dict"`          // select：选项来自字典
+	Rows         int          `yaml:"rows"`          // textarea
+	Resource     string       `yaml:"resource"`      // relationship：关联资源 slug
+	DisplayField string       `yaml:"display_field"` // relationship：显示字段，默认 name
+	AllowedTypes []string     `yaml:"allowed_types"` // file
+	MaxSize      int64        `yaml:"max_size"`      // file，字节
+	Multiple     bool         `yaml:"multiple"`      // file
+}
+
+// OptionSpec 选项
+type OptionSpec struct {
+	Value string `yaml:"value"`
+	Label Label  `yaml:"label"`
+}
+
+// ColumnSpec 表格列定义，type 省略时按同名字段推断
+type ColumnSpec struct {
+	Name      string            `yaml:"name"`
+	Label     Label             `yaml:"label"`
+	Type      string            `yaml:"type"`
+	Sortable  bool              `yaml:"sortable"`
+	Align     string            `yaml:"align"`
+	Width     int               `yaml:"width"`
+	Formatter string            `yaml:"formatter"`
+	EnumMap   map[string]string `yaml:"enum_map"`
+	BadgeMap  map[string]string `yaml:"badge_map"`
+}
+
+// FilterSpec 过滤器定义，type 与 options 省略时按同名字段推断
+type FilterSpec struct {
+	Name    string       `yaml:"name"`
+	Label   Label        `yaml:"label"`
+	Type    string       `yaml:"type"`
+	Options []OptionSpec `yaml:"options"`
+}
+
+// PermissionSpec 操作开关与策略
+// create/update/delete/export 默认允许；policy 为 true 时按 Casbin 策略 resource:<slug> 鉴权
+type PermissionSpec struct {
+	Create *bool `yaml:"create"`
+	Update *bool `yaml:"update"`
+	Delete *bool `yaml:"delete"`
+	Export *bool `yaml:"export"`
+	Policy bool  `yaml:"policy"`
+}
+
+// NavigationSpec 导航元信息
+type NavigationSpec struct {
+	Icon   string `yaml:"icon"`
+	Group  Label  `yaml:"group"`
+	Sort   int    `yaml:"sort"`
+	Hidden bool   `yaml:"hidden"`
+}
+
+// Label 标签：翻译键或普通文本，也可以是按语言的映射 {zh-CN: 商品, en: Products}
+type Label struct {
+	Text         string
+	Translations map[string]string
+}
+
+// UnmarshalYAML 接受字符串或语言映射
+func (l *Label) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Decode(&l.Text)
+	case yaml.MappingNode:
+		return node.Decode(&l.Translations)
+	}
+	return fmt.Errorf("line %d: label must be a string or a language map", node.Line)
+}
+
+// Key 返回可用于 Label/Title 的值：语言映射以 key 通过 admin.Localize 注册后返回 key，否则返回原文本
+func (l Label) Key(key string) string {
+	if len(l.Translations) > 0 {
+		return admin.Localize(key, l.Translations)
+	}
+	return l.Text
+}
+
+// IsZero 是否未设置
+func (l Label) IsZero() bool {
+	return l.Text == "" && len(l.Translations) == 0
+}
+
+func enabled(flag *bool) bool {
+	return flag == nil || *flag
+}
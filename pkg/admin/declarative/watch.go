@@ -0,0 +1,79 @@
+package declarative
+
+import (
+	"context"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce 合并编辑器保存时的连续事件
+const watchDebounce = 300 * time.Millisecond
+
+// Watch 监听定义目录，文件变化后重新加载；onReload 接收每次加载的结果，出错时上次的资源仍然生效
+// 用于开发环境，ctx 取消后停止
+func (l *Loader) Watch(ctx context.Context, onReload func(resources []*Resource, err error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	// fsnotify 不递归，逐个监听子目录
+	err = filepath.WalkDir(l.dir, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		return watcher.Add(name)
+	})
+	if err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		var timer *time.Timer
+		reload := make(chan struct{}, 1)
+		for {
+			select {
+			case <-ctx.Done():
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if event.Has(fsnotify.Create) {
+					// 新建的子目录也需要监听
+					_ = watcher.Add(event.Name)
+				}
+				ext := strings.ToLower(filepath.Ext(event.Name))
+				if ext != ".yaml" && ext != ".yml" && ext != ".json" && !event.Has(fsnotify.Remove) {
+					continue
+				}
+				if timer != nil {
+					timer.Stop()
+				}
+				timer = time.AfterFunc(watchDebounce, func() {
+					select {
+					case reload <- struct{}{}:
+					default:
+					}
+				})
+			case <-reload:
+				resources, err := l.Load()
+				onReload(resources, err)
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onReload(nil, err)
+			}
+		}
+	}()
+	return nil
+}
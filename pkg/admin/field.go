@@ -17,10 +17,11 @@ type FieldWithValidators interface {
 
 // BaseField 是所有字段的基类
 type BaseField struct {
-	name      string
-	fieldType string
-	label     string
-	required  bool
+	name         string
+	fieldType    string
+	label        string
+	required     bool
+	translatable bool
 }
 
 func (f *BaseField) GetName() string {
@@ -39,6 +40,11 @@ func (f *BaseField) IsRequired() bool {
 	return f.required
 }
 
+// IsTranslatable 字段值是否按语言分别保存
+func (f *BaseField) IsTranslatable() bool {
+	return f.translatable
+}
+
 // TextField 文本字段
 type TextField struct {
 	BaseField
@@ -67,11 +73,22 @@ func (f *TextField) Required() *TextField {
 	return f
 }
 
+// Translatable 标记字段为可翻译：各语言的值分别保存，读取时返回请求语言的值
+func (f *TextField) Translatable() *TextField {
+	f.translatable = true
+	return f
+}
+
 func (f *TextField) AddValidator(validator Validator) *TextField {
 	f.validators = append(f.validators, validator)
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *TextField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *TextField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -117,6 +134,11 @@ func (f *EmailField) AddValidator(validator Validator) *EmailField {
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *EmailField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *EmailField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -161,11 +183,24 @@ func (f *NumberField) SetDefault(v int) *NumberField {
 	return f
 }
 
+// GetDefault 获取默认值，未设置时返回 nil
+func (f *NumberField) GetDefault() interface{} {
+	if f.defaultValue == nil {
+		return nil
+	}
+	return *f.defaultValue
+}
+
 func (f *NumberField) AddValidator(validator Validator) *NumberField {
 	f.validators = append(f.validators, validator)
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *NumberField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *NumberField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -180,6 +215,7 @@ func (f *NumberField) Validate(value interface{}) []error {
 type SelectField struct {
 	BaseField
 	Options      []Option
+	DictCode     string // 选项来自字典时的字典编码
 	validators   []Validator
 	defaultValue *string
 }
@@ -218,16 +254,35 @@ func (f *SelectField) SetOptions(options []Option) *SelectField {
 	return f
 }
 
+// SetDictCode 使用字典数据作为选项
+func (f *SelectField) SetDictCode(code string) *SelectField {
+	f.DictCode = code
+	return f
+}
+
 func (f *SelectField) SetDefault(v string) *SelectField {
 	f.defaultValue = &v
 	return f
 }
 
+// GetDefault 获取默认值，未设置时返回 nil
+func (f *SelectField) GetDefault() interface{} {
+	if f.defaultValue == nil {
+		return nil
+	}
+	return *f.defaultValue
+}
+
 func (f *SelectField) AddValidator(validator Validator) *SelectField {
 	f.validators = append(f.validators, validator)
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *SelectField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *SelectField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -268,6 +323,12 @@ func (f *TextareaField) Required() *TextareaField {
 	return f
 }
 
+// Translatable 标记字段为可翻译
+func (f *TextareaField) Translatable() *TextareaField {
+	f.translatable = true
+	return f
+}
+
 func (f *TextareaField) SetRows(rows int) *TextareaField {
 	f.Rows = rows
 	return f
@@ -278,6 +339,11 @@ func (f *TextareaField) AddValidator(validator Validator) *TextareaField {
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *TextareaField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *TextareaField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -322,11 +388,24 @@ func (f *BooleanField) SetDefault(v bool) *BooleanField {
 	return f
 }
 
+// GetDefault 获取默认值，未设置时返回 nil
+func (f *BooleanField) GetDefault() interface{} {
+	if f.defaultValue == nil {
+		return nil
+	}
+	return *f.defaultValue
+}
+
 func (f *BooleanField) AddValidator(validator Validator) *BooleanField {
 	f.validators = append(f.validators, validator)
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *BooleanField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *BooleanField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -370,6 +449,11 @@ func (f *DateTimeField) AddValidator(validator Validator) *DateTimeField {
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *DateTimeField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *DateTimeField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -413,6 +497,11 @@ func (f *DateField) AddValidator(validator Validator) *DateField {
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *DateField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *DateField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -465,6 +554,11 @@ func (f *RelationshipField) AddValidator(validator Validator) *RelationshipField
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *RelationshipField) GetValidators() []Validator {
+	return f.validators
+}
+
 func (f *RelationshipField) Validate(value interface{}) []error {
 	var errors []error
 	for _, validator := range f.validators {
@@ -492,6 +586,7 @@ type FileField struct {
 	BaseField
 	AllowedTypes []string
 	MaxSize      int64
+	Multiple     bool // 是否允许上传多个文件（以附件形式关联到记录）
 }
 
 func NewFileField(name string) *FileField {
@@ -516,3 +611,8 @@ func (f *FileField) SetMaxSize(size int64) *FileField {
 	f.MaxSize = size
 	return f
 }
+
+func (f *FileField) SetMultiple(multiple bool) *FileField {
+	f.Multiple = multiple
+	return f
+}
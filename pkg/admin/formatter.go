@@ -0,0 +1,165 @@
+package admin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FormatterFunc 列格式化函数，将原始值转换为展示/导出文本
+type FormatterFunc func(value interface{}) string
+
+var (
+	formattersMu sync.RWMutex
+	formatters   = map[string]FormatterFunc{
+		"date":            formatDate,
+		"datetime":        formatDateTime,
+		"datetimeFromNow": formatDateTime,
+		"money":           formatMoney,
+		"percent":         formatPercent,
+		"filesize":        formatFileSize,
+		"boolean":         formatBoolean,
+	}
+)
+
+// RegisterFormatter 注册列格式化器，Column.SetFormatter 按名称引用
+func RegisterFormatter(name string, fn FormatterFunc) {
+	formattersMu.Lock()
+	defer formattersMu.Unlock()
+	formatters[name] = fn
+}
+
+// GetFormatter 获取已注册的列格式化器
+func GetFormatter(name string) (FormatterFunc, bool) {
+	formattersMu.RLock()
+	defer formattersMu.RUnlock()
+	fn, ok := formatters[name]
+	return fn, ok
+}
+
+// formatterTime 将数据库返回的时间值统一为 time.Time
+func formatterTime(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v != nil {
+			return *v, true
+		}
+	case string:
+		for _, layout := range []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"} {
+			if t, err := time.ParseInLocation(layout, v, time.Local); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatterFloat 将数值或数字字符串转换为 float64
+func formatterFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func formatDate(value interface{}) string {
+	if t, ok := formatterTime(value); ok {
+		return t.Format("2006-01-02")
+	}
+	return fmt.Sprint(value)
+}
+
+func formatDateTime(value interface{}) string {
+	if t, ok := formatterTime(value); ok {
+		return t.Format("2006-01-02 15:04:05")
+	}
+	return fmt.Sprint(value)
+}
+
+// formatMoney 保留两位小数并添加千分位
+func formatMoney(value interface{}) string {
+	f, ok := formatterFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+	parts := strings.SplitN(strconv.FormatFloat(f, 'f', 2, 64), ".", 2)
+	intPart := parts[0]
+	var b strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(r)
+	}
+	return sign + b.String() + "." + parts[1]
+}
+
+func formatPercent(value interface{}) string {
+	f, ok := formatterFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	return strconv.FormatFloat(f*100, 'f', 2, 64) + "%"
+}
+
+func formatFileSize(value interface{}) string {
+	f, ok := formatterFloat(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	i := 0
+	for f >= 1024 && i < len(units)-1 {
+		f /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%.0f %s", f, units[i])
+	}
+	return fmt.Sprintf("%.2f %s", f, units[i])
+}
+
+func formatBoolean(value interface{}) string {
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return "是"
+		}
+		return "否"
+	}
+	if f, ok := formatterFloat(value); ok {
+		if f != 0 {
+			return "是"
+		}
+		return "否"
+	}
+	return fmt.Sprint(value)
+}
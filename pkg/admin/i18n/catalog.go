@@ -0,0 +1,143 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 翻译目录文件
+// 文件名即语言（locales/en.yaml、locales/zh-CN.json），也可按语言分目录（locales/zh-CN/admin.yaml）
+// 键可以扁平书写（"error.not_found": "..."）或嵌套，嵌套键以点号连接；
+// 值为 CLDR 复数形式映射（one/other 等，须包含 other）时作为复数消息
+
+//go:embed locales/*.yaml
+var embeddedLocales embed.FS
+
+// LoadDefaults 加载内置翻译目录
+func (rm *ResourceManager) LoadDefaults() error {
+	return rm.LoadFS(embeddedLocales, "locales")
+}
+
+// LoadDir 加载目录中的翻译文件，后加载的键覆盖已有翻译
+func (rm *ResourceManager) LoadDir(dir string) error {
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("翻译目录不可用: %w", err)
+	}
+	return rm.LoadFS(os.DirFS(dir), ".")
+}
+
+// LoadFS 从文件系统加载翻译文件（支持 .yaml、.yml、.json）
+func (rm *ResourceManager) LoadFS(fsys fs.FS, root string) error {
+	return fs.WalkDir(fsys, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		ext := strings.ToLower(path.Ext(name))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" {
+			return nil
+		}
+		language := strings.TrimSuffix(path.Base(name), path.Ext(name))
+		if dir := path.Dir(name); dir != root && dir != "." {
+			language = path.Base(dir)
+		}
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := rm.LoadCatalog(language, data, ext); err != nil {
+			return fmt.Errorf("加载翻译文件 %s 失败: %w", name, err)
+		}
+		return nil
+	})
+}
+
+// LoadCatalog 解析单个翻译目录内容，format 为 .yaml/.yml/.json
+func (rm *ResourceManager) LoadCatalog(language string, data []byte, format string) error {
+	var raw map[string]interface{}
+	switch strings.ToLower(format) {
+	case ".json", "json":
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	default:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+	}
+
+	texts := make(map[string]string)
+	plurals := make(map[string]map[string]string)
+	if err := flattenCatalog("", raw, texts, plurals); err != nil {
+		return err
+	}
+	rm.AddResource(language, texts)
+	for key, forms := range plurals {
+		rm.AddPlural(language, key, forms)
+	}
+	return nil
+}
+
+// flattenCatalog 展开嵌套键，区分普通文本与复数消息
+func flattenCatalog(prefix string, node map[string]interface{}, texts map[string]string, plurals map[string]map[string]string) error {
+	for name, value := range node {
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			if forms, ok := pluralMessage(v); ok {
+				plurals[key] = forms
+				continue
+			}
+			if err := flattenCatalog(key, v, texts, plurals); err != nil {
+				return err
+			}
+		case nil:
+			texts[key] = ""
+		case string:
+			texts[key] = v
+		case bool, int, int64, float64:
+			texts[key] = fmt.Sprint(v)
+		default:
+			return fmt.Errorf("键 %s 的值类型不受支持: %T", key, value)
+		}
+	}
+	return nil
+}
+
+// pluralMessage 判断映射是否为复数消息：键均为 CLDR 复数形式且包含 other
+func pluralMessage(node map[string]interface{}) (map[string]string, bool) {
+	if _, ok := node["other"]; !ok {
+		return nil, false
+	}
+	forms := make(map[string]string, len(node))
+	for form, value := range node {
+		text, ok := value.(string)
+		if !ok || !IsPluralForm(form) {
+			return nil, false
+		}
+		forms[form] = text
+	}
+	return forms, true
+}
+
+// LoadDefaults 加载内置翻译目录（全局）
+func LoadDefaults() error {
+	return GlobalResourceManager.LoadDefaults()
+}
+
+// LoadDir 加载目录中的翻译文件（全局）
+func LoadDir(dir string) error {
+	return GlobalResourceManager.LoadDir(dir)
+}
@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// ContextKey 请求语言在 gin.Context 与 context.Context 中的键
+const ContextKey = "language"
+
+type contextKey struct{}
+
+// WithLanguage 将请求语言写入 context，供服务层翻译使用
+func WithLanguage(ctx context.Context, lang string) context.Context {
+	return context.WithValue(ctx, contextKey{}, lang)
+}
+
+// FromContext 读取 context 中的请求语言，未设置时返回默认语言
+// 直接传入 *gin.Context 时按 ContextKey 读取中间件写入的语言
+func FromContext(ctx context.Context) string {
+	if ctx != nil {
+		if lang, ok := ctx.Value(contextKey{}).(string); ok && lang != "" {
+			return lang
+		}
+		if lang, ok := ctx.Value(ContextKey).(string); ok && lang != "" {
+			return lang
+		}
+	}
+	return GlobalResourceManager.DefaultLanguage()
+}
+
+var normalized sync.Map // 原始语言标签 -> 规范化标签
+
+// Normalize 规范化语言标签，如 zh_cn、ZH-cn 均返回 zh-CN；无法解析时原样返回
+func Normalize(lang string) string {
+	lang = strings.TrimSpace(lang)
+	if lang == "" {
+		return ""
+	}
+	if v, ok := normalized.Load(lang); ok {
+		return v.(string)
+	}
+	result := lang
+	if tag, err := language.Parse(strings.ReplaceAll(lang, "_", "-")); err == nil {
+		result = tag.String()
+	}
+	normalized.Store(lang, result)
+	return result
+}
+
+// baseLanguage 获取基础语言，如 zh-TW 返回 zh；本身即基础语言时返回空
+func baseLanguage(lang string) string {
+	if i := strings.IndexByte(lang, '-'); i > 0 {
+		return lang[:i]
+	}
+	return ""
+}
+
+// Negotiate 按 Accept-Language 在已加载的语言中协商，无匹配时返回默认语言
+func (rm *ResourceManager) Negotiate(acceptLanguage string) string {
+	fallback := rm.DefaultLanguage()
+	if strings.TrimSpace(acceptLanguage) == "" {
+		return fallback
+	}
+	desired, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(desired) == 0 {
+		return fallback
+	}
+
+	// 默认语言放在首位，作为匹配器的兜底结果
+	supported := []string{fallback}
+	for _, l := range rm.GetLanguages() {
+		if l != fallback {
+			supported = append(supported, l)
+		}
+	}
+	tags := make([]language.Tag, 0, len(supported))
+	for _, l := range supported {
+		tags = append(tags, language.Make(l))
+	}
+	_, index, confidence := language.NewMatcher(tags).Match(desired...)
+	if confidence == language.No {
+		return fallback
+	}
+	return supported[index]
+}
+
+// RequestLanguage 确定请求语言：显式指定（如 ?language=）优先，否则按 Accept-Language 协商
+func (rm *ResourceManager) RequestLanguage(explicit, acceptLanguage string) string {
+	if lang := Normalize(explicit); lang != "" {
+		return lang
+	}
+	return rm.Negotiate(acceptLanguage)
+}
+
+// Negotiate 按 Accept-Language 协商语言（全局）
+func Negotiate(acceptLanguage string) string {
+	return GlobalResourceManager.Negotiate(acceptLanguage)
+}
+
+// RequestLanguage 确定请求语言（全局）
+func RequestLanguage(explicit, acceptLanguage string) string {
+	return GlobalResourceManager.RequestLanguage(explicit, acceptLanguage)
+}
+
+// pluralForms CLDR 复数形式名称
+var pluralForms = map[plural.Form]string{
+	plural.Zero:  "zero",
+	plural.One:   "one",
+	plural.Two:   "two",
+	plural.Few:   "few",
+	plural.Many:  "many",
+	plural.Other: "other",
+}
+
+// IsPluralForm 判断是否为 CLDR 复数形式名称
+func IsPluralForm(name string) bool {
+	for _, form := range pluralForms {
+		if form == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PluralForm 按 CLDR 基数规则返回数量对应的复数形式，如 en 下 1 为 one、2 为 other
+func PluralForm(lang string, count interface{}) string {
+	var digits string
+	switch n := count.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		digits = fmt.Sprint(n)
+	case float32:
+		digits = strconv.FormatFloat(float64(n), 'f', -1, 32)
+	case float64:
+		digits = strconv.FormatFloat(n, 'f', -1, 64)
+	case string:
+		digits = strings.TrimSpace(n)
+	default:
+		return "other"
+	}
+	digits = strings.TrimPrefix(digits, "-")
+
+	// 操作数：i 整数部分，v 可见小数位数，w 去尾零后的小数位数，f/t 对应的小数值
+	intPart, fraction, _ := strings.Cut(digits, ".")
+	i, err := strconv.Atoi(intPart)
+	if err != nil {
+		return "other"
+	}
+	v := len(fraction)
+	trimmed := strings.TrimRight(fraction, "0")
+	w := len(trimmed)
+	f, t := 0, 0
+	if v > 0 && v <= 9 {
+		f, _ = strconv.Atoi(fraction)
+	}
+	if w > 0 && w <= 9 {
+		t, _ = strconv.Atoi(trimmed)
+	}
+	if i > math.MaxInt32 {
+		i = i % 1000000
+	}
+	return pluralForms[plural.Cardinal.MatchPlural(language.Make(lang), i, v, w, f, t)]
+}
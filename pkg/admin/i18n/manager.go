@@ -2,21 +2,32 @@ package i18n
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 )
 
+// message 单条翻译：普通文本或按 CLDR 复数形式区分的文本
+type message struct {
+	text   string
+	plural map[string]string
+}
+
 // ResourceManager 语言资源管理器
 type ResourceManager struct {
 	mu              sync.RWMutex
-	resources       map[string]map[string]string // language -> key -> translation
+	resources       map[string]map[string]message // language -> key -> translation
 	defaultLanguage string
+	fallbacks       map[string][]string // language -> 显式回退链
+	tracker         *usageTracker       // 缺失/未使用键统计，nil 表示未开启
 }
 
 // NewResourceManager 创建语言资源管理器
 func NewResourceManager(defaultLanguage string) *ResourceManager {
 	return &ResourceManager{
-		resources:       make(map[string]map[string]string),
-		defaultLanguage: defaultLanguage,
+		resources:       make(map[string]map[string]message),
+		defaultLanguage: Normalize(defaultLanguage),
+		fallbacks:       make(map[string][]string),
 	}
 }
 
@@ -25,38 +36,179 @@ func (rm *ResourceManager) AddResource(language string, resources map[string]str
 	rm.mu.Lock()
 	defer rm.mu.Unlock()
 
+	catalog := rm.catalog(language)
+	for key, value := range resources {
+		catalog[key] = message{text: value}
+	}
+}
+
+// AddPlural 添加复数消息，forms 的键为 CLDR 复数形式（zero、one、two、few、many、other）
+func (rm *ResourceManager) AddPlural(language, key string, forms map[string]string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	plural := make(map[string]string, len(forms))
+	for form, text := range forms {
+		plural[form] = text
+	}
+	rm.catalog(language)[key] = message{text: forms["other"], plural: plural}
+}
+
+// catalog 获取语言的翻译表，不存在时创建；调用方需持有写锁
+func (rm *ResourceManager) catalog(language string) map[string]message {
+	language = Normalize(language)
 	if rm.resources[language] == nil {
-		rm.resources[language] = make(map[string]string)
+		rm.resources[language] = make(map[string]message)
 	}
+	return rm.resources[language]
+}
 
-	for key, value := range resources {
-		rm.resources[language][key] = value
+// SetDefaultLanguage 设置默认语言，所有回退链最终回退到默认语言
+func (rm *ResourceManager) SetDefaultLanguage(language string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.defaultLanguage = Normalize(language)
+}
+
+// DefaultLanguage 获取默认语言
+func (rm *ResourceManager) DefaultLanguage() string {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.defaultLanguage
+}
+
+// SetFallbacks 设置语言的显式回退链，如 zh-TW -> zh-CN -> en
+func (rm *ResourceManager) SetFallbacks(language string, chain ...string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	normalized := make([]string, 0, len(chain))
+	for _, fallback := range chain {
+		normalized = append(normalized, Normalize(fallback))
 	}
+	rm.fallbacks[Normalize(language)] = normalized
 }
 
-// Translate 翻译文本
-func (rm *ResourceManager) Translate(language, key string, args ...interface{}) string {
+// FallbackChain 获取语言的查找顺序
+// 依次为：语言本身、显式回退链（未配置时为基础语言及同一基础语言的其他地区）、默认语言
+func (rm *ResourceManager) FallbackChain(language string) []string {
 	rm.mu.RLock()
 	defer rm.mu.RUnlock()
+	return rm.fallbackChain(Normalize(language))
+}
 
-	// 首先尝试在指定语言中查找
-	if translations, ok := rm.resources[language]; ok {
-		if translation, ok := translations[key]; ok {
-			return rm.formatTranslation(translation, args...)
+func (rm *ResourceManager) fallbackChain(language string) []string {
+	chain := make([]string, 0, 4)
+	seen := make(map[string]struct{})
+	add := func(languages ...string) {
+		for _, l := range languages {
+			if _, ok := seen[l]; ok || l == "" {
+				continue
+			}
+			seen[l] = struct{}{}
+			chain = append(chain, l)
 		}
 	}
 
-	// 如果在指定语言中找不到，尝试在默认语言中查找
-	if language != rm.defaultLanguage {
-		if translations, ok := rm.resources[rm.defaultLanguage]; ok {
-			if translation, ok := translations[key]; ok {
-				return rm.formatTranslation(translation, args...)
+	add(language)
+	if explicit, ok := rm.fallbacks[language]; ok {
+		add(explicit...)
+	} else if base := baseLanguage(language); base != "" {
+		add(base)
+		if explicit, ok := rm.fallbacks[base]; ok {
+			add(explicit...)
+		}
+		regional := make([]string, 0)
+		for l := range rm.resources {
+			if baseLanguage(l) == base {
+				regional = append(regional, l)
 			}
 		}
+		sort.Strings(regional)
+		add(regional...)
 	}
+	add(rm.defaultLanguage)
+	return chain
+}
 
-	// 如果都找不到，返回键名
-	return key
+// find 按回退链查找消息，返回消息与命中的语言
+func (rm *ResourceManager) find(language, key string) (message, string, bool) {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	language = Normalize(language)
+	primary := ""
+	for _, l := range rm.fallbackChain(language) {
+		translations, ok := rm.resources[l]
+		if !ok {
+			continue
+		}
+		if primary == "" {
+			primary = l
+		}
+		if msg, ok := translations[key]; ok {
+			rm.tracker.use(primary, l, key)
+			return msg, l, true
+		}
+	}
+	rm.tracker.miss(primary, key)
+	return message{}, "", false
+}
+
+// Resolve 按语言的回退链从多语言值中选取文本，返回选中的值与其语言；均为空时 ok 为 false
+func (rm *ResourceManager) Resolve(language string, values map[string]string) (string, string, bool) {
+	for _, l := range rm.FallbackChain(language) {
+		if value := values[l]; value != "" {
+			return value, l, true
+		}
+	}
+	return "", "", false
+}
+
+// Translate 翻译文本，args 按 fmt 占位符格式化；找不到时返回键名
+func (rm *ResourceManager) Translate(language, key string, args ...interface{}) string {
+	msg, _, ok := rm.find(language, key)
+	if !ok {
+		return key
+	}
+	return rm.formatTranslation(msg.text, args...)
+}
+
+// TranslateParams 翻译文本并替换命名占位符，如 "{name} 为必填项"
+func (rm *ResourceManager) TranslateParams(language, key string, params map[string]interface{}) string {
+	msg, _, ok := rm.find(language, key)
+	if !ok {
+		return key
+	}
+	return replaceParams(msg.text, params)
+}
+
+// TranslatePlural 按数量选择 CLDR 复数形式并替换命名占位符，{count} 自动填入数量
+func (rm *ResourceManager) TranslatePlural(language, key string, count interface{}, params map[string]interface{}) string {
+	msg, matched, ok := rm.find(language, key)
+	if !ok {
+		return key
+	}
+	text := msg.text
+	if msg.plural != nil {
+		if form, ok := msg.plural[PluralForm(matched, count)]; ok {
+			text = form
+		}
+	}
+	merged := make(map[string]interface{}, len(params)+1)
+	for name, value := range params {
+		merged[name] = value
+	}
+	if _, ok := merged["count"]; !ok {
+		merged["count"] = count
+	}
+	return replaceParams(text, merged)
+}
+
+// Lookup 查找翻译，找不到时 ok 为 false（按回退链查找）
+func (rm *ResourceManager) Lookup(language, key string) (string, bool) {
+	msg, _, ok := rm.find(language, key)
+	return msg.text, ok
 }
 
 // formatTranslation 格式化翻译文本
@@ -64,10 +216,27 @@ func (rm *ResourceManager) formatTranslation(translation string, args ...interfa
 	if len(args) == 0 {
 		return translation
 	}
+	if len(args) == 1 {
+		if params, ok := args[0].(map[string]interface{}); ok {
+			return replaceParams(translation, params)
+		}
+	}
 
 	return fmt.Sprintf(translation, args...)
 }
 
+// replaceParams 替换 {name} 形式的命名占位符，未提供的占位符原样保留
+func replaceParams(text string, params map[string]interface{}) string {
+	if len(params) == 0 || !strings.Contains(text, "{") {
+		return text
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(text)
+}
+
 // GetLanguages 获取支持的语言列表
 func (rm *ResourceManager) GetLanguages() []string {
 	rm.mu.RLock()
@@ -77,6 +246,7 @@ func (rm *ResourceManager) GetLanguages() []string {
 	for language := range rm.resources {
 		languages = append(languages, language)
 	}
+	sort.Strings(languages)
 
 	return languages
 }
@@ -93,3 +263,23 @@ func AddResource(language string, resources map[string]string) {
 func Translate(language, key string, args ...interface{}) string {
 	return GlobalResourceManager.Translate(language, key, args...)
 }
+
+// TranslateParams 翻译文本并替换命名占位符（全局）
+func TranslateParams(language, key string, params map[string]interface{}) string {
+	return GlobalResourceManager.TranslateParams(language, key, params)
+}
+
+// TranslatePlural 按数量翻译复数消息（全局）
+func TranslatePlural(language, key string, count interface{}, params map[string]interface{}) string {
+	return GlobalResourceManager.TranslatePlural(language, key, count, params)
+}
+
+// Resolve 按回退链从多语言值中选取文本（全局）
+func Resolve(language string, values map[string]string) (string, string, bool) {
+	return GlobalResourceManager.Resolve(language, values)
+}
+
+// Lookup 查找翻译（全局）
+func Lookup(language, key string) (string, bool) {
+	return GlobalResourceManager.Lookup(language, key)
+}
@@ -0,0 +1,131 @@
+package i18n
+
+import (
+	"sort"
+	"sync"
+)
+
+// usageTracker 记录运行期间被请求的键与缺失的键，仅在开发模式开启
+type usageTracker struct {
+	mu      sync.Mutex
+	used    map[string]struct{}            // 命中过的键
+	missing map[string]map[string]struct{} // language -> 未在该语言找到的键
+}
+
+// use 记录命中；命中语言与请求的首选语言不同，说明首选语言缺失该键
+func (t *usageTracker) use(primary, matched, key string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.used[key] = struct{}{}
+	if primary != "" && primary != matched {
+		t.addMissing(primary, key)
+	}
+}
+
+// miss 记录回退链上均未找到的键
+func (t *usageTracker) miss(primary, key string) {
+	if t == nil || primary == "" {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.addMissing(primary, key)
+}
+
+func (t *usageTracker) addMissing(language, key string) {
+	if t.missing[language] == nil {
+		t.missing[language] = make(map[string]struct{})
+	}
+	t.missing[language][key] = struct{}{}
+}
+
+// LocaleReport 单个语言的翻译检查结果
+type LocaleReport struct {
+	Missing []string `json:"missing"` // 其他语言已有而本语言缺失的键，以及运行中请求过但本语言未找到的键
+	Unused  []string `json:"unused"`  // 开启统计后从未被请求的键
+}
+
+// EnableTracking 开启缺失/未使用键统计（开发模式），会清空已有统计
+func (rm *ResourceManager) EnableTracking() {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.tracker = &usageTracker{
+		used:    make(map[string]struct{}),
+		missing: make(map[string]map[string]struct{}),
+	}
+}
+
+// TrackingEnabled 是否开启了键统计
+func (rm *ResourceManager) TrackingEnabled() bool {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+	return rm.tracker != nil
+}
+
+// Report 按语言汇总缺失与未使用的键
+// 缺失键无需开启统计即可得到（对比各语言目录）；未使用键与运行期缺失键依赖 EnableTracking
+func (rm *ResourceManager) Report() map[string]LocaleReport {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	all := make(map[string]struct{})
+	for _, catalog := range rm.resources {
+		for key := range catalog {
+			all[key] = struct{}{}
+		}
+	}
+
+	var used map[string]struct{}
+	var runtimeMissing map[string]map[string]struct{}
+	if rm.tracker != nil {
+		rm.tracker.mu.Lock()
+		used = make(map[string]struct{}, len(rm.tracker.used))
+		for key := range rm.tracker.used {
+			used[key] = struct{}{}
+		}
+		runtimeMissing = make(map[string]map[string]struct{}, len(rm.tracker.missing))
+		for language, keys := range rm.tracker.missing {
+			runtimeMissing[language] = make(map[string]struct{}, len(keys))
+			for key := range keys {
+				runtimeMissing[language][key] = struct{}{}
+			}
+		}
+		rm.tracker.mu.Unlock()
+	}
+
+	report := make(map[string]LocaleReport, len(rm.resources))
+	for language, catalog := range rm.resources {
+		missing := make(map[string]struct{})
+		for key := range all {
+			if _, ok := catalog[key]; !ok {
+				missing[key] = struct{}{}
+			}
+		}
+		for key := range runtimeMissing[language] {
+			missing[key] = struct{}{}
+		}
+		unused := make([]string, 0)
+		if used != nil {
+			for key := range catalog {
+				if _, ok := used[key]; !ok {
+					unused = append(unused, key)
+				}
+			}
+		}
+		sort.Strings(unused)
+		report[language] = LocaleReport{Missing: sortedKeys(missing), Unused: unused}
+	}
+	return report
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+package admin
+
+import (
+	"strings"
+
+	"fun-admin/pkg/admin/i18n"
+)
+
+// 标签国际化
+// 资源标题、字段、列、过滤器、选项与动作的标签均为字符串，可以是：
+//   - 翻译键，如 "resource.user.title"，按请求语言从 pkg/admin/i18n 解析
+//   - 多语言映射，通过 Localize("resource.user.title", map[string]string{"zh-CN": "用户", "en": "Users"}) 注册后返回键
+//   - 普通文本，找不到翻译时原样返回
+
+// Localize 以 key 注册多语言标签并返回 key，供 Label/Title 使用
+// key 应带命名空间（如 resource.<slug>.fields.<name>），避免不同资源的同名文本互相覆盖；
+// 请求语言缺少译文时按回退链（最终为默认语言）解析
+func Localize(key string, translations map[string]string) string {
+	if key == "" || len(translations) == 0 {
+		return key
+	}
+	for language, text := range translations {
+		i18n.AddResource(language, map[string]string{key: text})
+	}
+	return key
+}
+
+// LabelKey 拼接资源标签的翻译键：resource.<slug>.<parts...>
+func LabelKey(slug string, parts ...string) string {
+	return "resource." + slug + "." + strings.Join(parts, ".")
+}
+
+// TranslateLabel 按语言解析标签：先按原文查找翻译键，再按小写下划线形式查找，均无则返回原文
+// language 为空时使用默认语言
+func TranslateLabel(label, language string) string {
+	if label == "" {
+		return ""
+	}
+	if language == "" {
+		language = i18n.GlobalResourceManager.DefaultLanguage()
+	}
+	if text, ok := i18n.Lookup(language, label); ok {
+		return text
+	}
+	if key := strings.ToLower(strings.ReplaceAll(label, " ", "_")); key != label {
+		if text, ok := i18n.Lookup(language, key); ok {
+			return text
+		}
+	}
+	return label
+}
+
+// LocalizeOptions 返回翻译后的选项副本
+func LocalizeOptions(options []Option, language string) []Option {
+	if options == nil {
+		return nil
+	}
+	localized := make([]Option, len(options))
+	for i, option := range options {
+		localized[i] = Option{Value: option.Value, Label: TranslateLabel(option.Label, language)}
+	}
+	return localized
+}
+
+// LocalizeColumns 返回翻译后的表格列副本，枚举映射的显示文本一并翻译
+func LocalizeColumns(columns []*Column, language string) []*Column {
+	localized := make([]*Column, 0, len(columns))
+	for _, column := range columns {
+		if column == nil {
+			continue
+		}
+		copied := *column
+		copied.Label = TranslateLabel(column.Label, language)
+		if column.EnumMap != nil {
+			copied.EnumMap = make(map[string]string, len(column.EnumMap))
+			for value, text := range column.EnumMap {
+				copied.EnumMap[value] = TranslateLabel(text, language)
+			}
+		}
+		localized = append(localized, &copied)
+	}
+	return localized
+}
+
+// LocalizeFilters 返回翻译后的过滤器副本
+func LocalizeFilters(filters []*Filter, language string) []*Filter {
+	localized := make([]*Filter, 0, len(filters))
+	for _, filter := range filters {
+		if filter == nil {
+			continue
+		}
+		copied := *filter
+		copied.Label = TranslateLabel(filter.Label, language)
+		copied.Options = LocalizeOptions(filter.Options, language)
+		localized = append(localized, &copied)
+	}
+	return localized
+}
+
+// LocalizeField 返回字段的前端描述：名称、翻译后的标签、类型、是否必填，选择字段附带翻译后的选项
+func LocalizeField(field Field, language string) map[string]interface{} {
+	item := map[string]interface{}{
+		"name":     field.GetName(),
+		"label":    TranslateLabel(field.GetLabel(), language),
+		"type":     field.GetType(),
+		"required": field.IsRequired(),
+	}
+	if IsTranslatableField(field) {
+		item["translatable"] = true
+	}
+	if f, ok := field.(*SelectField); ok {
+		item["options"] = LocalizeOptions(f.Options, language)
+		if f.DictCode != "" {
+			item["dict_code"] = f.DictCode
+		}
+	}
+	return item
+}
+
+// LocalizeFields 批量生成字段的前端描述
+func LocalizeFields(fields []Field, language string) []map[string]interface{} {
+	localized := make([]map[string]interface{}, 0, len(fields))
+	for _, field := range fields {
+		localized = append(localized, LocalizeField(field, language))
+	}
+	return localized
+}
@@ -9,6 +9,7 @@ type ResourceManager struct {
 	mu        sync.RWMutex
 	resources []Resource
 	pages     []PageInterface
+	widgets   []Widget
 }
 
 // NewResourceManager 创建资源管理器
@@ -27,6 +28,24 @@ func (rm *ResourceManager) Register(resource Resource) {
 	rm.resources = append(rm.resources, resource)
 }
 
+// ReplaceResources 移除指定 slug 的资源并注册新资源，用于声明式资源的重新加载
+func (rm *ResourceManager) ReplaceResources(remove []string, add []Resource) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	removed := make(map[string]bool, len(remove))
+	for _, slug := range remove {
+		removed[slug] = true
+	}
+	kept := make([]Resource, 0, len(rm.resources)+len(add))
+	for _, resource := range rm.resources {
+		if !removed[resource.GetSlug()] {
+			kept = append(kept, resource)
+		}
+	}
+	rm.resources = append(kept, add...)
+}
+
 // RegisterPage 注册自定义页面
 func (rm *ResourceManager) RegisterPage(page PageInterface) {
 	rm.mu.Lock()
@@ -35,6 +54,14 @@ func (rm *ResourceManager) RegisterPage(page PageInterface) {
 	rm.pages = append(rm.pages, page)
 }
 
+// RegisterWidget 注册仪表盘小组件
+func (rm *ResourceManager) RegisterWidget(widget Widget) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	rm.widgets = append(rm.widgets, widget)
+}
+
 // GetResources 获取所有资源
 func (rm *ResourceManager) GetResources() []Resource {
 	rm.mu.RLock()
@@ -59,6 +86,17 @@ func (rm *ResourceManager) GetPages() []PageInterface {
 	return pages
 }
 
+// GetWidgets 获取所有仪表盘小组件，按注册顺序
+func (rm *ResourceManager) GetWidgets() []Widget {
+	rm.mu.RLock()
+	defer rm.mu.RUnlock()
+
+	widgets := make([]Widget, len(rm.widgets))
+	copy(widgets, rm.widgets)
+
+	return widgets
+}
+
 // GetResourceBySlug 根据 slug 获取资源
 func (rm *ResourceManager) GetResourceBySlug(slug string) Resource {
 	rm.mu.RLock()
@@ -115,6 +153,11 @@ func RegisterPage(page PageInterface) {
 	GlobalResourceManager.RegisterPage(page)
 }
 
+// RegisterWidget 全局注册仪表盘小组件
+func RegisterWidget(widget Widget) {
+	GlobalResourceManager.RegisterWidget(widget)
+}
+
 // BaseResource 可复用空实现，供资源内嵌
 // 资源可选择性嵌入以减少样板代码
 // 注意：方法返回零值，业务层应覆盖
@@ -0,0 +1,71 @@
+package admin
+
+// RelationManager 关系管理器：在父资源记录下管理子资源记录
+// 子记录的字段、过滤、搜索、排序与鉴权沿用子资源自身的声明，外键固定为父记录
+//
+// 一对多：ForeignKey 为子表中指向父记录的列，例如字典数据的 type_id
+// 多对多：PivotTable 为中间表，ForeignKey 为中间表中指向父记录的列，RelatedKey 为指向子记录的列
+
+type RelationManager struct {
+	Name       string // 关系名，用于路由 /:resource/:id/:relation
+	Label      string
+	Resource   string // 子资源 slug
+	ForeignKey string
+	OwnerKey   string // 父记录中被引用的列，默认 id
+	PivotTable string
+	RelatedKey string
+	// PivotSoftDelete 中间表是否使用 deleted_at 软删除
+	PivotSoftDelete bool
+}
+
+// NewHasManyRelation 声明一对多关系
+func NewHasManyRelation(name, resource, foreignKey string) *RelationManager {
+	return &RelationManager{Name: name, Label: name, Resource: resource, ForeignKey: foreignKey, OwnerKey: "id"}
+}
+
+// NewBelongsToManyRelation 声明经由中间表的多对多关系
+func NewBelongsToManyRelation(name, resource, pivotTable, foreignKey, relatedKey string) *RelationManager {
+	return &RelationManager{
+		Name:       name,
+		Label:      name,
+		Resource:   resource,
+		ForeignKey: foreignKey,
+		OwnerKey:   "id",
+		PivotTable: pivotTable,
+		RelatedKey: relatedKey,
+	}
+}
+
+func (m *RelationManager) SetLabel(label string) *RelationManager { m.Label = label; return m }
+func (m *RelationManager) SetOwnerKey(key string) *RelationManager {
+	m.OwnerKey = key
+	return m
+}
+func (m *RelationManager) SetPivotSoftDelete(v bool) *RelationManager {
+	m.PivotSoftDelete = v
+	return m
+}
+
+// IsPivot 是否为经由中间表的多对多关系
+func (m *RelationManager) IsPivot() bool {
+	return m.PivotTable != ""
+}
+
+// HasRelationManagers 可选接口：声明资源记录下可管理的子资源关系
+type HasRelationManagers interface {
+	GetRelationManagers() []*RelationManager
+}
+
+// GetRelationManager 按关系名查找资源声明的关系管理器
+func GetRelationManager(resource Resource, name string) *RelationManager {
+	provider, ok := resource.(HasRelationManagers)
+	if !ok {
+		return nil
+	}
+	for _, m := range provider.GetRelationManagers() {
+		if m != nil && m.Name == name {
+			return m
+		}
+	}
+	return nil
+}
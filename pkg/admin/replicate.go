@@ -0,0 +1,59 @@
+package admin
+
+import "fmt"
+
+// ReplicateActionName 内置复制动作名
+const ReplicateActionName = "replicate"
+
+// ReplicateAction 复制记录：以源记录为模板创建新记录，可通过表单覆盖字段
+type ReplicateAction struct {
+	BaseAction
+	formFields []Field
+}
+
+func NewReplicateAction() *ReplicateAction {
+	return &ReplicateAction{BaseAction: BaseAction{name: ReplicateActionName, label: "Replicate"}}
+}
+
+// Label 设置显示名称，返回 *ReplicateAction 以保留表单字段配置
+func (a *ReplicateAction) Label(label string) *ReplicateAction {
+	a.label = label
+	return a
+}
+
+// SetFormFields 设置保存前可覆盖的字段，如唯一编码
+func (a *ReplicateAction) SetFormFields(fields ...Field) *ReplicateAction {
+	a.formFields = fields
+	return a
+}
+
+// GetFormFields 实现 ActionWithForm
+func (a *ReplicateAction) GetFormFields() []Field {
+	return a.formFields
+}
+
+// ReplicateOptions 复制记录的配置
+// 主键、时间戳与只读字段始终不复制
+type ReplicateOptions struct {
+	Exclude   []string                                 // 不复制的字段，如唯一编码
+	Transform map[string]func(interface{}) interface{} // 字段值转换，如名称追加后缀
+	Relations []string                                 // 同时复制的关系，对应 GetRelationManagers 中的关系名
+}
+
+// Replicable 可选接口：声明复制记录时排除、转换的字段与需要深度复制的关系
+type Replicable interface {
+	GetReplicateOptions() ReplicateOptions
+}
+
+// AppendSuffix 字段值转换：在文本后追加后缀，空值保持不变
+func AppendSuffix(suffix string) func(interface{}) interface{} {
+	return func(value interface{}) interface{} {
+		if value == nil {
+			return nil
+		}
+		if b, ok := value.([]byte); ok {
+			value = string(b)
+		}
+		return fmt.Sprint(value) + suffix
+	}
+}
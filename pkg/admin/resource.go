@@ -36,11 +36,34 @@ type DefaultOrder interface {
 	GetDefaultOrder() (field string, direction string)
 }
 
+// Reorderable 可选接口：声明拖拽排序使用的排序列，升序即显示顺序
+// 实现后列表默认按该列升序，并可通过排序接口调整记录顺序
+type Reorderable interface {
+	GetOrderColumn() string
+}
+
+// ReorderScope 可选接口：声明排序分组列，仅在同一分组内调整顺序，如字典数据按 type_id 分组
+type ReorderScope interface {
+	GetReorderScope() []string
+}
+
 // Exportable 可选接口：声明资源是否支持导出功能
 type Exportable interface {
 	IsExportable() bool
 }
 
+// Commentable 可选接口：声明资源记录是否启用评论/内部备注
+// 评论按资源 slug 与记录 ID 存储，无需在业务表中增加列
+type Commentable interface {
+	IsCommentable() bool
+}
+
+// ConnectionResource 可选接口：声明资源数据表所在的命名数据库连接（data.db.connections 中的名称）
+// 未实现或返回空字符串时使用默认连接；事务中命名连接另开事务，先于默认连接提交（非分布式事务）
+type ConnectionResource interface {
+	GetConnection() string
+}
+
 // NavigationMeta 定义资源在导航中的元信息（参考 Filament 导航能力）
 // 通过可选接口提供，避免对现有资源实现造成破坏性变更
 type NavigationMeta struct {
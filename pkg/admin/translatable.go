@@ -0,0 +1,28 @@
+package admin
+
+// TranslatableField 可选接口：字段值按语言分别保存
+// 默认语言的值保存在记录本身的列中，其他语言的值保存在翻译表；
+// 写入时可提交单个文本（作用于请求语言）或语言映射（如 {"zh-CN": "启用", "en": "Enabled"}）
+type TranslatableField interface {
+	IsTranslatable() bool
+}
+
+// IsTranslatableField 判断字段是否可翻译
+func IsTranslatableField(field Field) bool {
+	t, ok := field.(TranslatableField)
+	return ok && t.IsTranslatable()
+}
+
+// GetTranslatableFields 获取资源中可翻译的字段名
+func GetTranslatableFields(resource Resource) []string {
+	if resource == nil {
+		return nil
+	}
+	fields := make([]string, 0)
+	for _, field := range resource.GetFields() {
+		if IsTranslatableField(field) {
+			fields = append(fields, field.GetName())
+		}
+	}
+	return fields
+}
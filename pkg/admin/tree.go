@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"fmt"
+	"strings"
+)
+
+// TreeOptions 树形资源的列约定
+// 节点的物化路径包含自身，形如 /1/5/9/，子树查询按路径前缀匹配，移动节点时整体替换前缀
+type TreeOptions struct {
+	ParentKey string // 父节点列，默认 parent_id
+	SortKey   string // 同级排序列，默认 sort
+	LabelKey  string // 节点标题列，默认 name
+	PathKey   string // 物化路径列，默认 tree_path
+	// RootValue 根节点的父节点值，nil 表示 NULL；父节点列不可为空的表（如菜单）可设为 0
+	RootValue interface{}
+}
+
+// TreeResource 可选接口：声明资源为树形结构
+type TreeResource interface {
+	GetTreeOptions() TreeOptions
+}
+
+// GetTreeOptions 获取资源的树形配置并补全默认值，非树形资源返回 false
+func GetTreeOptions(resource Resource) (TreeOptions, bool) {
+	tree, ok := resource.(TreeResource)
+	if !ok {
+		return TreeOptions{}, false
+	}
+	opts := tree.GetTreeOptions()
+	if opts.ParentKey == "" {
+		opts.ParentKey = "parent_id"
+	}
+	if opts.SortKey == "" {
+		opts.SortKey = "sort"
+	}
+	if opts.LabelKey == "" {
+		opts.LabelKey = "name"
+	}
+	if opts.PathKey == "" {
+		opts.PathKey = "tree_path"
+	}
+	return opts, true
+}
+
+// TreePath 拼接子节点的物化路径，parentPath 为空表示根节点
+func TreePath(parentPath string, id interface{}) string {
+	if parentPath == "" {
+		parentPath = "/"
+	}
+	if b, ok := id.([]byte); ok {
+		id = string(b)
+	}
+	return parentPath + fmt.Sprint(id) + "/"
+}
+
+// TreeDepth 根据物化路径计算节点深度，根节点为 0
+func TreeDepth(path string) int {
+	return strings.Count(strings.Trim(path, "/"), "/")
+}
+
+// IsTreeRoot 判断父节点值是否表示根节点
+func (o TreeOptions) IsTreeRoot(parent interface{}) bool {
+	if parent == nil {
+		return true
+	}
+	if b, ok := parent.([]byte); ok {
+		parent = string(b)
+	}
+	value := fmt.Sprint(parent)
+	if value == "" {
+		return true
+	}
+	return o.RootValue != nil && value == fmt.Sprint(o.RootValue)
+}
@@ -104,6 +104,11 @@ func NewMinLengthValidator(minLength int) *MinLengthValidator {
 	}
 }
 
+// GetMinLength 获取最小长度
+func (v *MinLengthValidator) GetMinLength() int {
+	return v.minLength
+}
+
 func (v *MinLengthValidator) Validate(value interface{}) error {
 	if value == nil {
 		return nil
@@ -136,6 +141,11 @@ func NewMaxLengthValidator(maxLength int) *MaxLengthValidator {
 	}
 }
 
+// GetMaxLength 获取最大长度
+func (v *MaxLengthValidator) GetMaxLength() int {
+	return v.maxLength
+}
+
 func (v *MaxLengthValidator) Validate(value interface{}) error {
 	if value == nil {
 		return nil
@@ -171,6 +181,11 @@ func (f *ValidatedField) AddValidator(validator Validator) *ValidatedField {
 	return f
 }
 
+// GetValidators 获取字段的验证器
+func (f *ValidatedField) GetValidators() []Validator {
+	return f.Validators
+}
+
 // Validate 执行所有验证器
 func (f *ValidatedField) Validate(value interface{}) []error {
 	var errors []error
@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Widget 仪表盘小组件
+// 标题为翻译键；Data 在每次请求仪表盘时调用，db 已绑定请求上下文
+type Widget interface {
+	GetName() string
+	GetTitle() string
+	GetType() string // stat, chart, table, list
+	GetWidth() int   // 栅格宽度 1-24
+	Data(ctx context.Context, db *gorm.DB) (interface{}, error)
+}
+
+// BaseWidget 小组件基类，内嵌后只需实现 Data
+type BaseWidget struct {
+	Name  string
+	Title string
+	Type  string
+	Width int
+}
+
+// NewBaseWidget 创建小组件基类，默认占 6 格
+func NewBaseWidget(name, title, typ string) *BaseWidget {
+	return &BaseWidget{Name: name, Title: title, Type: typ, Width: 6}
+}
+
+func (w *BaseWidget) GetName() string  { return w.Name }
+func (w *BaseWidget) GetTitle() string { return w.Title }
+func (w *BaseWidget) GetType() string  { return w.Type }
+func (w *BaseWidget) GetWidth() int    { return w.Width }
+
+// SetWidth 设置栅格宽度
+func (w *BaseWidget) SetWidth(width int) *BaseWidget {
+	w.Width = width
+	return w
+}
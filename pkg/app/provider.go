@@ -125,9 +125,8 @@ func (p *RepositoryServiceProvider) Register(c *container.Container) {
 
 	// 注册菜单仓储
 	c.Singleton("menu_repository", func(c *container.Container) repository.MenuRepository {
-		log := c.MustGet("logger").(*logger.Logger)
-		db := c.MustGet("database").(*gorm.DB)
-		return repository.NewMenuRepository(log, db)
+		repo := c.MustGet("repository").(*repository.Repository)
+		return repository.NewMenuRepository(repo)
 	})
 
 	// 注册接口仓储
@@ -186,6 +185,47 @@ func (p *RepositoryServiceProvider) Register(c *container.Container) {
 		return repository.NewLoginRepository(log, db)
 	})
 
+	// 注册评论仓储
+	c.Singleton("comment_repository", func(c *container.Container) repository.CommentRepository {
+		log := c.MustGet("logger").(*logger.Logger)
+		db := c.MustGet("database").(*gorm.DB)
+		return repository.NewCommentRepository(log, db)
+	})
+
+	// 注册导入任务仓储
+	c.Singleton("import_job_repository", func(c *container.Container) repository.ImportJobRepository {
+		log := c.MustGet("logger").(*logger.Logger)
+		db := c.MustGet("database").(*gorm.DB)
+		return repository.NewImportJobRepository(log, db)
+	})
+
+	// 注册导出任务仓储
+	c.Singleton("export_job_repository", func(c *container.Container) repository.ExportJobRepository {
+		log := c.MustGet("logger").(*logger.Logger)
+		db := c.MustGet("database").(*gorm.DB)
+		return repository.NewExportJobRepository(log, db)
+	})
+
+	// 注册附件仓储
+	c.Singleton("attachment_repository", func(c *container.Container) repository.AttachmentRepository {
+		log := c.MustGet("logger").(*logger.Logger)
+		db := c.MustGet("database").(*gorm.DB)
+		return repository.NewAttachmentRepository(log, db)
+	})
+
+	// 注册翻译仓储
+	c.Singleton("translation_repository", func(c *container.Container) repository.TranslationRepository {
+		repo := c.MustGet("repository").(*repository.Repository)
+		return repository.NewTranslationRepository(repo)
+	})
+
+	// 注册定时报表仓储
+	c.Singleton("scheduled_report_repository", func(c *container.Container) repository.ScheduledReportRepository {
+		log := c.MustGet("logger").(*logger.Logger)
+		db := c.MustGet("database").(*gorm.DB)
+		return repository.NewScheduledReportRepository(log, db)
+	})
+
 }
 
 func (p *RepositoryServiceProvider) Boot(c *container.Container) error {
@@ -225,9 +265,18 @@ func (p *ServiceServiceProvider) Register(c *container.Container) {
 	// 注册资源服务
 	c.Singleton("resource_service", func(c *container.Container) *service.ResourceService {
 		resourceRepo := c.MustGet("resource_repository").(*repository.ResourceRepository)
+		attachmentRepo := c.MustGet("attachment_repository").(repository.AttachmentRepository)
+		translationRepo := c.MustGet("translation_repository").(repository.TranslationRepository)
 		resourceManager := admin.GlobalResourceManager
 		cacheManager := c.MustGet("cache").(cache.CacheManager)
-		return service.NewResourceService(resourceRepo, resourceManager, cacheManager)
+		conf := c.MustGet("config").(*viper.Viper)
+		if path := conf.GetString("export.pdf_font"); path != "" {
+			if err := service.LoadPDFFont(path); err != nil {
+				log := c.MustGet("logger").(*logger.Logger)
+				log.Warn("Failed to load pdf export font, falling back to STSong-Light", zap.String("path", path), zap.Error(err))
+			}
+		}
+		return service.NewResourceService(resourceRepo, attachmentRepo, translationRepo, resourceManager, cacheManager)
 	})
 
 	// 注册API服务
@@ -250,15 +299,17 @@ func (p *ServiceServiceProvider) Register(c *container.Container) {
 		menuRepo := c.MustGet("menu_repository").(repository.MenuRepository)
 		userRepo := c.MustGet("user_repository").(repository.UserRepository)
 		permissionService := c.MustGet("permission_service").(service.PermissionService)
-		return service.NewMenuService(baseService, menuRepo, userRepo, permissionService)
+		translationRepo := c.MustGet("translation_repository").(repository.TranslationRepository)
+		return service.NewMenuService(baseService, menuRepo, userRepo, permissionService, translationRepo)
 	})
 
 	// 注册字典服务
 	c.Singleton("dictionary_service", func(c *container.Container) *service.DictionaryService {
 		dictionaryRepo := c.MustGet("dictionary_repository").(repository.DictionaryRepository)
+		translationRepo := c.MustGet("translation_repository").(repository.TranslationRepository)
 		log := c.MustGet("logger").(*logger.Logger)
 		cacheObj := c.MustGet("cache").(cache.CacheManager)
-		return service.NewDictionaryService(dictionaryRepo, log, cacheObj)
+		return service.NewDictionaryService(dictionaryRepo, translationRepo, log, cacheObj)
 	})
 
 	// 注册文件服务
@@ -271,7 +322,12 @@ func (p *ServiceServiceProvider) Register(c *container.Container) {
 	// 注册导入服务
 	c.Singleton("import_service", func(c *container.Container) *service.ImportService {
 		log := c.MustGet("logger").(*logger.Logger)
-		return service.NewImportService(log)
+		transaction := c.MustGet("transaction").(repository.Transaction)
+		importJobRepo := c.MustGet("import_job_repository").(repository.ImportJobRepository)
+		resourceRepo := c.MustGet("resource_repository").(*repository.ResourceRepository)
+		dictionaryService := c.MustGet("dictionary_service").(*service.DictionaryService)
+		fileService := c.MustGet("file_service").(*service.FileService)
+		return service.NewImportService(log, transaction, importJobRepo, resourceRepo, dictionaryService, fileService)
 	})
 
 	// 注册权限服务
@@ -314,6 +370,54 @@ func (p *ServiceServiceProvider) Register(c *container.Container) {
 		profileRepo := c.MustGet("profile_repository").(repository.ProfileRepository)
 		return service.NewProfileService(baseService, log.Logger, profileRepo)
 	})
+
+	// 注册评论服务
+	c.Singleton("comment_service", func(c *container.Container) service.CommentService {
+		baseService := c.MustGet("base_service").(*service.Service)
+		commentRepo := c.MustGet("comment_repository").(repository.CommentRepository)
+		userRepo := c.MustGet("user_repository").(repository.UserRepository)
+		resourceRepo := c.MustGet("resource_repository").(*repository.ResourceRepository)
+		return service.NewCommentService(baseService, commentRepo, userRepo, resourceRepo, admin.GlobalResourceManager)
+	})
+
+	// 注册附件服务
+	c.Singleton("attachment_service", func(c *container.Container) service.AttachmentService {
+		baseService := c.MustGet("base_service").(*service.Service)
+		attachmentRepo := c.MustGet("attachment_repository").(repository.AttachmentRepository)
+		resourceRepo := c.MustGet("resource_repository").(*repository.ResourceRepository)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		fileService := c.MustGet("file_service").(*service.FileService)
+		return service.NewAttachmentService(baseService, attachmentRepo, resourceRepo, resourceService, fileService)
+	})
+
+	// 注册导出任务服务
+	c.Singleton("export_job_service", func(c *container.Container) service.ExportJobService {
+		baseService := c.MustGet("base_service").(*service.Service)
+		exportJobRepo := c.MustGet("export_job_repository").(repository.ExportJobRepository)
+		userRepo := c.MustGet("user_repository").(repository.UserRepository)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		fileService := c.MustGet("file_service").(*service.FileService)
+		configService := c.MustGet("config_service").(*service.ConfigService)
+		return service.NewExportJobService(baseService, exportJobRepo, userRepo, resourceService, fileService, configService)
+	})
+
+	// 注册定时报表服务
+	c.Singleton("scheduled_report_service", func(c *container.Container) service.ScheduledReportService {
+		baseService := c.MustGet("base_service").(*service.Service)
+		reportRepo := c.MustGet("scheduled_report_repository").(repository.ScheduledReportRepository)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		fileService := c.MustGet("file_service").(*service.FileService)
+		configService := c.MustGet("config_service").(*service.ConfigService)
+		return service.NewScheduledReportService(baseService, reportRepo, resourceService, fileService, configService)
+	})
+
+	// 注册数据快照服务
+	c.Singleton("snapshot_service", func(c *container.Container) service.SnapshotService {
+		db := c.MustGet("database").(*gorm.DB)
+		fileService := c.MustGet("file_service").(*service.FileService)
+		enforcer := c.MustGet("enforcer").(*casbin.SyncedEnforcer)
+		return service.NewSnapshotService(db, fileService, enforcer)
+	})
 }
 
 func (p *ServiceServiceProvider) Boot(c *container.Container) error {
@@ -354,8 +458,9 @@ func (p *HandlerServiceProvider) Register(c *container.Container) {
 	c.Singleton("export_handler", func(c *container.Container) *handler.ExportHandler {
 		handlerInstance := c.MustGet("handler").(*handler.Handler)
 		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		exportJobService := c.MustGet("export_job_service").(service.ExportJobService)
 		log := c.MustGet("logger").(*logger.Logger)
-		return handler.NewExportHandler(handlerInstance, resourceService, log.Logger)
+		return handler.NewExportHandler(handlerInstance, resourceService, exportJobService, log.Logger)
 	})
 
 	// 注册API处理器
@@ -398,6 +503,20 @@ func (p *HandlerServiceProvider) Register(c *container.Container) {
 		return handler.NewImportHandler(importService, resourceService)
 	})
 
+	// 注册数据快照处理器
+	c.Singleton("snapshot_handler", func(c *container.Container) *handler.SnapshotHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		snapshotService := c.MustGet("snapshot_service").(service.SnapshotService)
+		return handler.NewSnapshotHandler(handlerInstance, snapshotService)
+	})
+
+	// 注册数据库连接状态处理器
+	c.Singleton("database_handler", func(c *container.Container) *handler.DatabaseHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		repo := c.MustGet("repository").(*repository.Repository)
+		return handler.NewDatabaseHandler(handlerInstance, repo)
+	})
+
 	// 注册权限处理器
 	c.Singleton("permission_handler", func(c *container.Container) *handler.PermissionHandler {
 		handlerInstance := c.MustGet("handler").(*handler.Handler)
@@ -435,8 +554,57 @@ func (p *HandlerServiceProvider) Register(c *container.Container) {
 
 	// 注册资源CRUD处理器
 	c.Singleton("resource_crud_handler", func(c *container.Container) *handler.ResourceCRUDHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
 		resourceService := c.MustGet("resource_service").(*service.ResourceService)
-		return handler.NewResourceCRUDHandler(resourceService)
+		return handler.NewResourceCRUDHandler(handlerInstance, resourceService)
+	})
+
+	// 注册评论处理器
+	c.Singleton("comment_handler", func(c *container.Container) *handler.CommentHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		commentService := c.MustGet("comment_service").(service.CommentService)
+		return handler.NewCommentHandler(handlerInstance, commentService)
+	})
+
+	// 注册附件处理器
+	c.Singleton("attachment_handler", func(c *container.Container) *handler.AttachmentHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		attachmentService := c.MustGet("attachment_service").(service.AttachmentService)
+		return handler.NewAttachmentHandler(handlerInstance, attachmentService)
+	})
+
+	// 注册关系管理器处理器
+	c.Singleton("relation_handler", func(c *container.Container) *handler.RelationHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		return handler.NewRelationHandler(handlerInstance, resourceService)
+	})
+
+	// 注册树形资源处理器
+	c.Singleton("tree_handler", func(c *container.Container) *handler.TreeHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		return handler.NewTreeHandler(handlerInstance, resourceService)
+	})
+
+	// 注册拖拽排序处理器
+	c.Singleton("reorder_handler", func(c *container.Container) *handler.ReorderHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		resourceService := c.MustGet("resource_service").(*service.ResourceService)
+		return handler.NewReorderHandler(handlerInstance, resourceService)
+	})
+
+	// 注册翻译目录处理器
+	c.Singleton("i18n_handler", func(c *container.Container) *handler.I18nHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		return handler.NewI18nHandler(handlerInstance)
+	})
+
+	// 注册定时报表处理器
+	c.Singleton("scheduled_report_handler", func(c *container.Container) *handler.ScheduledReportHandler {
+		handlerInstance := c.MustGet("handler").(*handler.Handler)
+		reportService := c.MustGet("scheduled_report_service").(service.ScheduledReportService)
+		return handler.NewScheduledReportHandler(handlerInstance, reportService)
 	})
 }
 
@@ -514,10 +682,14 @@ func (p *ServerServiceProvider) Register(c *container.Container) {
 		transaction := c.MustGet("transaction").(repository.Transaction)
 		sidObj := c.MustGet("sid").(*sid.Sid)
 		userRepo := c.MustGet("user_repository").(repository.UserRepository)
+		exportJobService := c.MustGet("export_job_service").(service.ExportJobService)
+		importService := c.MustGet("import_service").(*service.ImportService)
 
 		baseJob := job.NewJob(transaction, log, sidObj)
 		userJob := job.NewUserJob(baseJob, userRepo)
-		jobServer := internalserver.NewJobServer(log, userJob)
+		exportJob := job.NewExportJob(baseJob, exportJobService)
+		importJob := job.NewImportJob(baseJob, importService)
+		jobServer := internalserver.NewJobServer(log, userJob, exportJob, importJob)
 
 		return jobServer
 	})
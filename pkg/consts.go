@@ -10,3 +10,6 @@ const (
 	AdminUserID        = "1"     // 超管用户ID(字符串形式用于比较)
 	AdminRole          = "admin" // 超管角色SID
 )
+
+// ResourcePolicyPrefix 资源策略对象前缀，如 resource:orders，动作为 list/view/create/update/delete
+const ResourcePolicyPrefix = "resource:"
@@ -3,8 +3,8 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -22,12 +22,13 @@ const (
 
 // Manager 数据库管理器
 type Manager struct {
-	db *gorm.DB
+	db    *gorm.DB
+	conns *Connections
 }
 
-// NewManager 创建数据库管理器
+// NewManager 创建数据库管理器，db 上挂载的命名连接一并纳入管理
 func NewManager(db *gorm.DB) *Manager {
-	return &Manager{db: db}
+	return &Manager{db: db, conns: ConnectionsOf(db)}
 }
 
 // GetDB 获取数据库连接
@@ -42,8 +43,28 @@ func (m *Manager) GetDB(ctx context.Context) *gorm.DB {
 		return db
 	}
 
-	// 返回默认连接
-	return m.db.WithContext(ctx)
+	// 返回默认连接，同一请求内已写入时读取走主库
+	conn, _ := m.conns.Get(DefaultConnection)
+	return readDB(ctx, conn, m.db.WithContext(ctx))
+}
+
+// Connection 获取命名连接，name 为空或 default 时等同于 GetDB
+// 处于 WithTransaction 中时，在该连接上开启事务并随默认连接的事务一起提交或回滚；
+// 连接不存在时返回带错误的会话，执行任何操作都会返回该错误
+func (m *Manager) Connection(ctx context.Context, name string) *gorm.DB {
+	if name == "" || name == DefaultConnection {
+		return m.GetDB(ctx)
+	}
+	conn, ok := m.conns.Get(name)
+	if !ok {
+		db := m.db.WithContext(ctx)
+		_ = db.AddError(fmt.Errorf("unknown database connection %q", name))
+		return db
+	}
+	if scope := txScopeFrom(ctx); scope != nil {
+		return scope.enlist(ctx, conn)
+	}
+	return readDB(ctx, conn, conn.DB.WithContext(ctx))
 }
 
 // WithDB 在上下文中设置数据库连接
@@ -51,11 +72,10 @@ func (m *Manager) WithDB(ctx context.Context) context.Context {
 	return context.WithValue(ctx, DBKey, m.db.WithContext(ctx))
 }
 
-// WithTransaction 执行事务
+// WithTransaction 执行事务，事务内访问的命名连接同样开启事务，见 txScope
 func (m *Manager) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
 	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txCtx := context.WithValue(ctx, TxKey, tx)
-		return fn(txCtx)
+		return runInScope(ctx, tx, false, fn)
 	})
 }
 
@@ -64,46 +84,36 @@ func (m *Manager) WithReadOnlyTransaction(ctx context.Context, fn func(context.C
 	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// 设置为只读事务
 		tx.Exec("SET TRANSACTION READ ONLY")
-		txCtx := context.WithValue(ctx, TxKey, tx)
-		return fn(txCtx)
+		return runInScope(ctx, tx, true, fn)
 	})
 }
 
-// Health 检查数据库健康状态
+// Health 检查全部连接（含只读副本）的健康状态
 func (m *Manager) Health(ctx context.Context) error {
-	sqlDB, err := m.db.DB()
-	if err != nil {
-		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	var errs []error
+	for _, status := range m.Status(ctx) {
+		if !status.Healthy {
+			errs = append(errs, fmt.Errorf("%s: %s", status.Name(), status.Error))
+		}
 	}
-
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
-	defer cancel()
-
-	if err := sqlDB.PingContext(ctx); err != nil {
-		return fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	return nil
-}
-
-// Stats 获取数据库连接池统计信息
-func (m *Manager) Stats() (*sql.DBStats, error) {
-	sqlDB, err := m.db.DB()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
-	}
-
-	stats := sqlDB.Stats()
-	return &stats, nil
+	return errors.Join(errs...)
 }
 
-// Close 关闭数据库连接
+// Close 关闭全部连接（含只读副本）
 func (m *Manager) Close() error {
-	sqlDB, err := m.db.DB()
-	if err != nil {
-		return err
+	var errs []error
+	for _, conn := range m.conns.All() {
+		sqlDB, err := conn.DB.DB()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, sqlDB.Close())
+		for _, replica := range conn.Replicas {
+			errs = append(errs, replica.Close())
+		}
 	}
-	return sqlDB.Close()
+	return errors.Join(errs...)
 }
 
 // QueryBuilder 查询构建器
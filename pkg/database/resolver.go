@@ -0,0 +1,217 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// DefaultConnection 默认连接名，即 data.db.user 配置的主库
+const DefaultConnection = "default"
+
+// StickyKey 写后读主库标记在上下文中的键
+// 使用字符串键，使 gin.Context 可以通过 c.Set 直接提供该标记
+const StickyKey = "database_sticky_primary"
+
+// connectionsPluginName 命名连接集合注册为 GORM 插件时使用的名称
+const connectionsPluginName = "fun-admin:connections"
+
+// StickyPrimary 写后读主库标记
+// 同一请求内发生写操作后，后续读取改走主库，避免读到副本尚未同步的数据
+type StickyPrimary struct {
+	written atomic.Bool
+}
+
+// NewStickyPrimary 创建写后读主库标记
+func NewStickyPrimary() *StickyPrimary {
+	return &StickyPrimary{}
+}
+
+// WithStickyPrimary 在上下文中设置新的写后读主库标记
+func WithStickyPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, StickyKey, NewStickyPrimary())
+}
+
+// MarkWritten 记录已发生写操作
+func (s *StickyPrimary) MarkWritten() {
+	s.written.Store(true)
+}
+
+// Written 是否已发生写操作
+func (s *StickyPrimary) Written() bool {
+	return s.written.Load()
+}
+
+// stickyPrimary 从上下文获取写后读主库标记，未设置时返回 nil
+func stickyPrimary(ctx context.Context) *StickyPrimary {
+	if ctx == nil {
+		return nil
+	}
+	sticky, _ := ctx.Value(StickyKey).(*StickyPrimary)
+	return sticky
+}
+
+// Connection 命名数据库连接
+type Connection struct {
+	Name     string
+	Driver   string
+	DB       *gorm.DB
+	Replicas []*sql.DB // 只读副本连接池，读取按随机策略分发
+}
+
+// Connections 命名连接集合，以 GORM 插件的形式挂载在默认连接上，
+// 使只持有默认 *gorm.DB 的组件也能找到其余连接
+type Connections struct {
+	mu          sync.RWMutex
+	names       []string
+	connections map[string]*Connection
+}
+
+// NewConnections 创建命名连接集合
+func NewConnections() *Connections {
+	return &Connections{connections: make(map[string]*Connection)}
+}
+
+// Name 实现 gorm.Plugin
+func (c *Connections) Name() string {
+	return connectionsPluginName
+}
+
+// Initialize 实现 gorm.Plugin，未添加默认连接时以挂载的连接作为默认连接
+func (c *Connections) Initialize(db *gorm.DB) error {
+	if _, ok := c.Get(DefaultConnection); !ok {
+		c.Add(&Connection{Name: DefaultConnection, Driver: db.Dialector.Name(), DB: db})
+	}
+	return nil
+}
+
+// Add 添加连接，同名连接会被替换
+func (c *Connections) Add(conn *Connection) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.connections[conn.Name]; !ok {
+		c.names = append(c.names, conn.Name)
+	}
+	c.connections[conn.Name] = conn
+}
+
+// Get 按名称获取连接
+func (c *Connections) Get(name string) (*Connection, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	conn, ok := c.connections[name]
+	return conn, ok
+}
+
+// All 按添加顺序返回全部连接
+func (c *Connections) All() []*Connection {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	conns := make([]*Connection, 0, len(c.names))
+	for _, name := range c.names {
+		conns = append(conns, c.connections[name])
+	}
+	return conns
+}
+
+// ConnectionsOf 获取挂载在 db 上的命名连接集合，未挂载时返回只含 db 自身的集合
+func ConnectionsOf(db *gorm.DB) *Connections {
+	if plugin, ok := db.Config.Plugins[connectionsPluginName].(*Connections); ok {
+		return plugin
+	}
+	conns := NewConnections()
+	_ = conns.Initialize(db)
+	return conns
+}
+
+// UseReplicas 为 db 注册只读副本（基于 GORM dbresolver），返回副本的连接池
+// 查询与 SELECT 原生语句随机分发到副本，写操作、事务与 FOR UPDATE 查询使用主库；
+// 同时注册回调，在上下文带有 StickyPrimary 时记录写操作
+func UseReplicas(db *gorm.DB, replicas ...gorm.Dialector) ([]*sql.DB, error) {
+	if len(replicas) == 0 {
+		return nil, nil
+	}
+	primary, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.RandomPolicy{},
+	})
+	if err := db.Use(resolver); err != nil {
+		return nil, err
+	}
+	var pools []*sql.DB
+	err = resolver.Call(func(connPool gorm.ConnPool) error {
+		if sqlDB, ok := connPool.(*sql.DB); ok && sqlDB != primary {
+			pools = append(pools, sqlDB)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := registerStickyCallbacks(db); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// Primary 返回固定使用主库的会话，供迁移、结构比对等必须读取最新数据的场景使用
+func Primary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write).Session(&gorm.Session{})
+}
+
+// registerStickyCallbacks 注册写操作回调，标记上下文中的 StickyPrimary
+func registerStickyCallbacks(db *gorm.DB) error {
+	const name = "fun-admin:sticky_primary"
+	callback := db.Callback()
+	if err := callback.Create().After("gorm:create").Register(name, markWritten); err != nil {
+		return err
+	}
+	if err := callback.Update().After("gorm:update").Register(name, markWritten); err != nil {
+		return err
+	}
+	if err := callback.Delete().After("gorm:delete").Register(name, markWritten); err != nil {
+		return err
+	}
+	return callback.Raw().After("gorm:raw").Register(name, func(db *gorm.DB) {
+		if !isSelect(db.Statement.SQL.String()) {
+			markWritten(db)
+		}
+	})
+}
+
+func markWritten(db *gorm.DB) {
+	if sticky := stickyPrimary(db.Statement.Context); sticky != nil {
+		sticky.MarkWritten()
+	}
+}
+
+// isSelect 判断原生语句是否为只读查询
+func isSelect(rawSQL string) bool {
+	rawSQL = strings.TrimSpace(rawSQL)
+	return len(rawSQL) >= 6 && strings.EqualFold(rawSQL[:6], "select")
+}
+
+// readDB 同一请求内已发生写操作且连接配置了副本时，读取改走主库
+func readDB(ctx context.Context, conn *Connection, db *gorm.DB) *gorm.DB {
+	if len(conn.Replicas) == 0 {
+		return db
+	}
+	if sticky := stickyPrimary(ctx); sticky != nil && sticky.Written() {
+		return Primary(db)
+	}
+	return db
+}
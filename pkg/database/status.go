@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+const (
+	// RolePrimary 主库
+	RolePrimary = "primary"
+	// RoleReplica 只读副本
+	RoleReplica = "replica"
+)
+
+// healthTimeout 单个连接池健康检查的超时时间
+const healthTimeout = 5 * time.Second
+
+// PoolStatus 单个连接池的健康状态与统计信息
+type PoolStatus struct {
+	Connection string    `json:"connection"`
+	Driver     string    `json:"driver"`
+	Role       string    `json:"role"`  // primary / replica
+	Index      int       `json:"index"` // 副本序号，从 0 开始；主库为 0
+	Healthy    bool      `json:"healthy"`
+	Error      string    `json:"error,omitempty"`
+	LatencyMs  float64   `json:"latency_ms"` // Ping 耗时（毫秒）
+	Stats      PoolStats `json:"stats"`
+}
+
+// PoolStats 连接池统计信息，字段含义同 sql.DBStats
+type PoolStats struct {
+	MaxOpenConnections int     `json:"max_open_connections"`
+	OpenConnections    int     `json:"open_connections"`
+	InUse              int     `json:"in_use"`
+	Idle               int     `json:"idle"`
+	WaitCount          int64   `json:"wait_count"`
+	WaitDurationMs     float64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64   `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64   `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64   `json:"max_lifetime_closed"`
+}
+
+// Name 返回连接池的显示名称，如 default、default/replica#1
+func (s PoolStatus) Name() string {
+	if s.Role == RoleReplica {
+		return fmt.Sprintf("%s/%s#%d", s.Connection, RoleReplica, s.Index)
+	}
+	return s.Connection
+}
+
+// Status 检查全部连接（含只读副本）并返回连接池统计信息
+func (m *Manager) Status(ctx context.Context) []PoolStatus {
+	var statuses []PoolStatus
+	for _, conn := range m.conns.All() {
+		status := PoolStatus{Connection: conn.Name, Driver: conn.Driver, Role: RolePrimary}
+		sqlDB, err := conn.DB.DB()
+		if err != nil {
+			status.Error = fmt.Sprintf("failed to get underlying sql.DB: %v", err)
+			statuses = append(statuses, status)
+		} else {
+			statuses = append(statuses, poolStatus(ctx, status, sqlDB))
+		}
+		for i, replica := range conn.Replicas {
+			status := PoolStatus{Connection: conn.Name, Driver: conn.Driver, Role: RoleReplica, Index: i}
+			statuses = append(statuses, poolStatus(ctx, status, replica))
+		}
+	}
+	return statuses
+}
+
+// poolStatus Ping 连接池并填充统计信息
+func poolStatus(ctx context.Context, status PoolStatus, sqlDB *sql.DB) PoolStatus {
+	ctx, cancel := context.WithTimeout(ctx, healthTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := sqlDB.PingContext(ctx)
+	status.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to ping database: %v", err)
+	} else {
+		status.Healthy = true
+	}
+
+	stats := sqlDB.Stats()
+	status.Stats = PoolStats{
+		MaxOpenConnections: stats.MaxOpenConnections,
+		OpenConnections:    stats.OpenConnections,
+		InUse:              stats.InUse,
+		Idle:               stats.Idle,
+		WaitCount:          stats.WaitCount,
+		WaitDurationMs:     float64(stats.WaitDuration.Microseconds()) / 1000,
+		MaxIdleClosed:      stats.MaxIdleClosed,
+		MaxIdleTimeClosed:  stats.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stats.MaxLifetimeClosed,
+	}
+	return status
+}
@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// txScopeKey 事务范围在上下文中的键
+const txScopeKey ContextKey = "tx_scope"
+
+// txScope 一次 WithTransaction 调用的事务范围
+// 范围内首次访问命名连接时在该连接上开启事务，随默认连接的事务一起提交或回滚；
+// 各连接的事务相互独立（非分布式事务），命名连接先于默认连接提交，
+// 默认连接提交失败时已提交的命名连接无法回滚
+type txScope struct {
+	mu       sync.Mutex
+	readOnly bool
+	names    []string
+	txs      map[string]*gorm.DB
+}
+
+func newTxScope(readOnly bool) *txScope {
+	return &txScope{readOnly: readOnly, txs: make(map[string]*gorm.DB)}
+}
+
+// txScopeFrom 从上下文获取事务范围，未处于事务中时返回 nil
+func txScopeFrom(ctx context.Context) *txScope {
+	if ctx == nil {
+		return nil
+	}
+	scope, _ := ctx.Value(txScopeKey).(*txScope)
+	return scope
+}
+
+// enlist 返回命名连接在本范围内的事务，首次调用时开启
+func (s *txScope) enlist(ctx context.Context, conn *Connection) *gorm.DB {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tx, ok := s.txs[conn.Name]; ok {
+		return tx
+	}
+	tx := conn.DB.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return tx
+	}
+	if s.readOnly {
+		tx.Exec("SET TRANSACTION READ ONLY")
+	}
+	s.names = append(s.names, conn.Name)
+	s.txs[conn.Name] = tx
+	return tx
+}
+
+// commit 按开启顺序提交命名连接的事务，失败时回滚其余未提交的事务
+func (s *txScope) commit() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, name := range s.names {
+		if err := s.txs[name].Commit().Error; err != nil {
+			s.rollbackFrom(i + 1)
+			return err
+		}
+	}
+	s.names = nil
+	return nil
+}
+
+// rollback 回滚全部命名连接的事务
+func (s *txScope) rollback() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.rollbackFrom(0)
+}
+
+func (s *txScope) rollbackFrom(start int) error {
+	var errs []error
+	for _, name := range s.names[start:] {
+		errs = append(errs, s.txs[name].Rollback().Error)
+	}
+	s.names = nil
+	return errors.Join(errs...)
+}
+
+// runInScope 在默认连接的事务 tx 中执行 fn，并管理范围内命名连接的事务
+func runInScope(ctx context.Context, tx *gorm.DB, readOnly bool, fn func(context.Context) error) (err error) {
+	scope := newTxScope(readOnly)
+	txCtx := context.WithValue(context.WithValue(ctx, TxKey, tx), txScopeKey, scope)
+
+	finished := false
+	defer func() {
+		// fn panic 时回滚命名连接的事务，默认连接的事务由 GORM 回滚
+		if !finished {
+			_ = scope.rollback()
+		}
+	}()
+
+	if err = fn(txCtx); err != nil {
+		finished = true
+		_ = scope.rollback()
+		return err
+	}
+	finished = true
+	return scope.commit()
+}
@@ -3,629 +3,371 @@ package docs
 import (
 	"encoding/json"
 	"fmt"
-	"fun-admin/internal/model"
 	"fun-admin/pkg/admin"
-	"io/ioutil"
+	"fun-admin/pkg/admin/i18n"
+	"net/http"
 	"os"
 	"path/filepath"
-	"reflect"
+	"sort"
 	"strings"
+	"unicode"
 )
 
-// DocumentGenerator API文档生成器
-type DocumentGenerator struct {
-	swaggerDoc *SwaggerDocument
-}
-
-// SwaggerDocument Swagger文档结构
-type SwaggerDocument struct {
-	Swagger             string                 `json:"swagger"`
-	Info                Info                   `json:"info"`
-	Host                string                 `json:"host"`
-	BasePath            string                 `json:"basePath,omitempty"`
-	Paths               map[string]interface{} `json:"paths"`
-	Definitions         map[string]interface{} `json:"definitions"`
-	SecurityDefinitions map[string]interface{} `json:"securityDefinitions"`
-}
-
-// Info API信息
-type Info struct {
-	Description    string  `json:"description"`
-	Title          string  `json:"title"`
-	TermsOfService string  `json:"termsOfService"`
-	Contact        Contact `json:"contact"`
-	License        License `json:"license"`
-	Version        string  `json:"version"`
+// errorResponses 错误状态码对应的 components.responses 名称
+var errorResponses = map[string]string{
+	"400": "BadRequest",
+	"401": "Unauthorized",
+	"403": "Forbidden",
+	"404": "NotFound",
+	"409": "Conflict",
+	"500": "InternalError",
+	"501": "NotImplemented",
 }
 
-// Contact 联系信息
-type Contact struct {
-	Name  string `json:"name"`
-	URL   string `json:"url"`
-	Email string `json:"email"`
-}
-
-// License 许可证信息
-type License struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+// DocumentGenerator API文档生成器
+// 根据实际注册的路由与资源声明生成 OpenAPI 3.1 文档：资源路由按资源展开，
+// 请求与响应结构由字段、校验器、选项与字段权限推导
+type DocumentGenerator struct {
+	doc          *Document
+	language     string
+	slugs        []string
+	resources    map[string]admin.Resource
+	operationIDs map[string]int
 }
 
 // NewDocumentGenerator 创建文档生成器
 func NewDocumentGenerator() *DocumentGenerator {
 	return &DocumentGenerator{
-		swaggerDoc: &SwaggerDocument{
-			Swagger: "2.0",
+		doc: &Document{
+			OpenAPI: OpenAPIVersion,
 			Info: Info{
-				Description:    "Fun-Admin 现代化管理后台 API",
 				Title:          "Fun-Admin API",
+				Description:    "Fun-Admin 现代化管理后台 API",
 				TermsOfService: "https://github.com/your-username/fun-admin",
-				Contact: Contact{
+				Contact: &Contact{
 					Name:  "Fun-Admin Team",
 					URL:   "https://github.com/your-username/fun-admin",
 					Email: "support@fun-admin.com",
 				},
-				License: License{
+				License: &License{
 					Name: "MIT",
 					URL:  "https://opensource.org/licenses/MIT",
 				},
 				Version: "1.0.0",
 			},
-			Host:        "localhost:8000",
-			BasePath:    "/api/v1",
-			Paths:       make(map[string]interface{}),
-			Definitions: make(map[string]interface{}),
-			SecurityDefinitions: map[string]interface{}{
-				"Bearer": map[string]interface{}{
-					"type": "apiKey",
-					"name": "Authorization",
-					"in":   "header",
+			Servers: []Server{{URL: "http://localhost:8000"}},
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas:   make(map[string]Schema),
+				Responses: make(map[string]Response),
+				SecuritySchemes: map[string]SecurityScheme{
+					"bearerAuth": {
+						Type:         "http",
+						Scheme:       "bearer",
+						BearerFormat: "JWT",
+						Description:  "登录接口返回的 accessToken，通过 Authorization: Bearer <token> 传递",
+					},
 				},
 			},
+			Security: []SecurityRequirement{{"bearerAuth": []string{}}},
 		},
+		operationIDs: make(map[string]int),
 	}
 }
 
-// Generate 生成API文档
-func (dg *DocumentGenerator) Generate(handlers map[string]interface{}, resources map[string]admin.Resource) error {
-	// 生成基础模块文档
-	dg.generateBasicHandlers()
-
-	// 生成资源模块文档
-	dg.generateResourceHandlers(resources)
-
-	// 生成数据字典文档
-	dg.generateDictHandlers()
-
-	// 生成配置管理文档
-	dg.generateConfigHandlers()
-
-	// 生成文件管理文档
-	dg.generateFileHandlers()
-
-	// 生成数据导入文档
-	dg.generateImportHandlers()
-
-	// 生成通用模型定义
-	dg.generateCommonDefinitions()
-
-	return nil
-}
-
-// generateBasicHandlers 生成基础处理器文档
-func (dg *DocumentGenerator) generateBasicHandlers() {
-	// 用户管理
-	dg.addHandlerPath("/users", "get", "用户模块", "获取用户列表", []Parameter{
-		{Name: "page", Type: "integer", Description: "页码", Required: true},
-		{Name: "page_size", Type: "integer", Description: "每页数量", Required: true},
-		{Name: "username", Type: "string", Description: "用户名"},
-		{Name: "nickname", Type: "string", Description: "昵称"},
-	})
-
-	dg.addHandlerPath("/users/{id}", "get", "用户模块", "获取用户详情", []Parameter{
-		{Name: "id", Type: "integer", Description: "用户ID", Required: true, In: "path"},
-	})
-
-	// 角色管理
-	dg.addHandlerPath("/roles", "get", "角色模块", "获取角色列表", []Parameter{
-		{Name: "page", Type: "integer", Description: "页码", Required: true},
-		{Name: "page_size", Type: "integer", Description: "每页数量", Required: true},
-		{Name: "name", Type: "string", Description: "角色名称"},
-	})
-
-	// 菜单管理
-	dg.addHandlerPath("/menus", "get", "菜单模块", "获取菜单列表", []Parameter{
-		{Name: "type", Type: "string", Description: "菜单类型"},
-	})
-
-	// API管理
-	dg.addHandlerPath("/apis", "get", "API模块", "获取API列表", []Parameter{
-		{Name: "page", Type: "integer", Description: "页码", Required: true},
-		{Name: "page_size", Type: "integer", Description: "每页数量", Required: true},
-		{Name: "group", Type: "string", Description: "API分组"},
-	})
+// SetLanguage 设置标题与说明使用的语言，默认使用系统默认语言
+func (dg *DocumentGenerator) SetLanguage(language string) *DocumentGenerator {
+	dg.language = i18n.Normalize(language)
+	return dg
 }
 
-// generateResourceHandlers 生成资源处理器文档
-func (dg *DocumentGenerator) generateResourceHandlers(resources map[string]admin.Resource) {
-	for slug, resource := range resources {
-		// 资源列表
-		dg.addHandlerPath("/"+slug, "get", resource.GetTitle(), "获取"+resource.GetTitle()+"列表", []Parameter{
-			{Name: "page", Type: "integer", Description: "页码", Required: true},
-			{Name: "page_size", Type: "integer", Description: "每页数量", Required: true},
-		})
-
-		// 资源详情
-		dg.addHandlerPath("/"+slug+"/{id}", "get", resource.GetTitle(), "获取"+resource.GetTitle()+"详情", []Parameter{
-			{Name: "id", Type: "integer", Description: "ID", Required: true, In: "path"},
-		})
-
-		// 创建资源
-		dg.addHandlerPath("/"+slug, "post", resource.GetTitle(), "创建"+resource.GetTitle(), []Parameter{
-			{Name: "data", Type: "object", Description: "资源数据", Required: true, In: "body", Schema: "#/definitions/" + dg.getModelName(resource.GetModel())},
-		})
-
-		// 更新资源
-		dg.addHandlerPath("/"+slug+"/{id}", "put", resource.GetTitle(), "更新"+resource.GetTitle(), []Parameter{
-			{Name: "id", Type: "integer", Description: "ID", Required: true, In: "path"},
-			{Name: "data", Type: "object", Description: "资源数据", Required: true, In: "body", Schema: "#/definitions/" + dg.getModelName(resource.GetModel())},
-		})
-
-		// 删除资源
-		dg.addHandlerPath("/"+slug+"/{id}", "delete", resource.GetTitle(), "删除"+resource.GetTitle(), []Parameter{
-			{Name: "id", Type: "integer", Description: "ID", Required: true, In: "path"},
-		})
-
-		// 导出资源
-		dg.addHandlerPath("/"+slug+"/export", "get", resource.GetTitle(), "导出"+resource.GetTitle(), []Parameter{
-			{Name: "format", Type: "string", Description: "导出格式 (excel/csv)"},
-		})
+// SetServers 设置文档中的服务地址
+func (dg *DocumentGenerator) SetServers(urls ...string) *DocumentGenerator {
+	dg.doc.Servers = dg.doc.Servers[:0]
+	for _, url := range urls {
+		dg.doc.Servers = append(dg.doc.Servers, Server{URL: url})
 	}
+	return dg
 }
 
-// generateDictHandlers 生成字典处理器文档
-func (dg *DocumentGenerator) generateDictHandlers() {
-	// 字典类型
-	dg.addHandlerPath("/dict/types", "get", "字典管理", "获取字典类型列表", []Parameter{
-		{Name: "page", Type: "integer", Description: "页码", Required: true},
-		{Name: "page_size", Type: "integer", Description: "每页数量", Required: true},
-		{Name: "name", Type: "string", Description: "字典类型名称"},
-	})
-
-	dg.addHandlerPath("/dict/types", "post", "字典管理", "创建字典类型", []Parameter{
-		{Name: "data", Type: "object", Description: "字典类型数据", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/dict/types/{id}", "put", "字典管理", "更新字典类型", []Parameter{
-		{Name: "id", Type: "integer", Description: "字典类型ID", Required: true, In: "path"},
-		{Name: "data", Type: "object", Description: "字典类型数据", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/dict/types/{id}", "delete", "字典管理", "删除字典类型", []Parameter{
-		{Name: "id", Type: "integer", Description: "字典类型ID", Required: true, In: "path"},
-	})
-
-	// 字典数据
-	dg.addHandlerPath("/dict/data", "get", "字典管理", "获取字典数据列表", []Parameter{
-		{Name: "type_id", Type: "integer", Description: "字典类型ID", Required: true},
-		{Name: "label", Type: "string", Description: "字典标签"},
-		{Name: "status", Type: "integer", Description: "状态"},
-	})
-
-	dg.addHandlerPath("/dict/data", "post", "字典管理", "创建字典数据", []Parameter{
-		{Name: "data", Type: "object", Description: "字典数据", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/dict/data/{id}", "put", "字典管理", "更新字典数据", []Parameter{
-		{Name: "id", Type: "integer", Description: "字典数据ID", Required: true, In: "path"},
-		{Name: "data", Type: "object", Description: "字典数据", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/dict/data/{id}", "delete", "字典管理", "删除字典数据", []Parameter{
-		{Name: "id", Type: "integer", Description: "字典数据ID", Required: true, In: "path"},
-	})
-
-	dg.addHandlerPath("/dict/code/{code}", "get", "字典管理", "根据编码获取字典数据", []Parameter{
-		{Name: "code", Type: "string", Description: "字典类型编码", Required: true, In: "path"},
-	})
+// Document 返回生成的文档
+func (dg *DocumentGenerator) Document() *Document {
+	return dg.doc
 }
 
-// generateConfigHandlers 生成配置处理器文档
-func (dg *DocumentGenerator) generateConfigHandlers() {
-	// 系统设置
-	dg.addHandlerPath("/config/settings/{key}", "get", "配置管理", "获取系统设置", []Parameter{
-		{Name: "key", Type: "string", Description: "设置键", Required: true, In: "path"},
-	})
-
-	dg.addHandlerPath("/config/settings", "post", "配置管理", "设置系统设置", []Parameter{
-		{Name: "data", Type: "object", Description: "设置数据", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/config/settings/group/{group}", "get", "配置管理", "根据分组获取系统设置", []Parameter{
-		{Name: "group", Type: "string", Description: "分组名称", Required: true, In: "path"},
-	})
-
-	// 邮件配置
-	dg.addHandlerPath("/config/email", "get", "配置管理", "获取邮件配置", []Parameter{})
-
-	dg.addHandlerPath("/config/email", "post", "配置管理", "设置邮件配置", []Parameter{
-		{Name: "config", Type: "object", Description: "邮件配置", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/config/email/test", "post", "配置管理", "测试邮件配置", []Parameter{
-		{Name: "config", Type: "object", Description: "邮件配置", Required: true, In: "body"},
-		{Name: "test_email", Type: "string", Description: "测试邮箱", Required: true, In: "body"},
-	})
-
-	// 存储配置
-	dg.addHandlerPath("/config/storage", "get", "配置管理", "获取存储配置", []Parameter{})
-
-	dg.addHandlerPath("/config/storage", "post", "配置管理", "设置存储配置", []Parameter{
-		{Name: "config", Type: "object", Description: "存储配置", Required: true, In: "body"},
-	})
-
-	dg.addHandlerPath("/config/storage/test", "post", "配置管理", "测试存储配置", []Parameter{
-		{Name: "config", Type: "object", Description: "存储配置", Required: true, In: "body"},
-	})
-}
-
-// generateFileHandlers 生成文件管理文档
-func (dg *DocumentGenerator) generateFileHandlers() {
-	// 文件上传
-	dg.addHandlerPath("/files/upload", "post", "文件管理", "上传文件", []Parameter{
-		{Name: "file", Type: "file", Description: "上传文件", Required: true, In: "formData"},
-		{Name: "type", Type: "string", Description: "文件类型", In: "formData"},
-	})
-
-	// 文件删除
-	dg.addHandlerPath("/files/{key}", "delete", "文件管理", "删除文件", []Parameter{
-		{Name: "key", Type: "string", Description: "文件键", Required: true, In: "path"},
-	})
-
-	// 获取文件URL
-	dg.addHandlerPath("/files/{key}/url", "get", "文件管理", "获取文件访问URL", []Parameter{
-		{Name: "key", Type: "string", Description: "文件键", Required: true, In: "path"},
-		{Name: "expire", Type: "integer", Description: "过期时间(秒)", In: "query"},
-	})
-}
-
-// generateImportHandlers 生成数据导入文档
-func (dg *DocumentGenerator) generateImportHandlers() {
-	// 数据导入
-	dg.addHandlerPath("/import/data", "post", "数据导入", "导入数据", []Parameter{
-		{Name: "file", Type: "file", Description: "导入文件", Required: true, In: "formData"},
-		{Name: "type", Type: "string", Description: "文件类型 (excel/csv/json)", Required: true, In: "formData"},
-		{Name: "resource", Type: "string", Description: "资源名称", Required: true, In: "formData"},
-		{Name: "has_header", Type: "boolean", Description: "是否包含表头", In: "formData"},
-		{Name: "sheet_name", Type: "string", Description: "工作表名称", In: "formData"},
-		{Name: "start_row", Type: "integer", Description: "开始行", In: "formData"},
-	})
-
-	// 获取Excel工作表
-	dg.addHandlerPath("/import/excel/sheets", "post", "数据导入", "获取Excel工作表列表", []Parameter{
-		{Name: "file", Type: "file", Description: "Excel文件", Required: true, In: "formData"},
-	})
+// Generate 生成API文档
+// routes 通常来自 RoutesFromGin(engine.Routes())，路径中含 :resource 的路由按资源展开
+func (dg *DocumentGenerator) Generate(routes []Route, resources map[string]admin.Resource) error {
+	if dg.language == "" {
+		dg.language = i18n.GlobalResourceManager.DefaultLanguage()
+	}
+	dg.resources = resources
+	dg.slugs = make([]string, 0, len(resources))
+	for slug := range resources {
+		dg.slugs = append(dg.slugs, slug)
+	}
+	sort.Strings(dg.slugs)
+
+	dg.addCommonComponents()
+	for _, slug := range dg.slugs {
+		resource := resources[slug]
+		dg.addResourceSchemas(resource)
+		dg.doc.Tags = append(dg.doc.Tags, Tag{
+			Name:        slug,
+			Description: admin.TranslateLabel(resource.GetTitle(), dg.language),
+		})
+	}
 
-	// 获取Excel列信息
-	dg.addHandlerPath("/import/excel/columns", "post", "数据导入", "获取Excel列信息", []Parameter{
-		{Name: "file", Type: "file", Description: "Excel文件", Required: true, In: "formData"},
-		{Name: "sheet_name", Type: "string", Description: "工作表名称", Required: true, In: "formData"},
-		{Name: "has_header", Type: "boolean", Description: "是否包含表头", In: "formData"},
+	sorted := make([]Route, len(routes))
+	copy(sorted, routes)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
 	})
+	for _, route := range sorted {
+		if route.Method == http.MethodHead || strings.Contains(route.Path, "*") {
+			continue
+		}
+		if dg.addResourceRoute(route) {
+			continue
+		}
+		if err := dg.addRoute(route); err != nil {
+			return fmt.Errorf("%s %s: %w", route.Method, route.Path, err)
+		}
+	}
+	return nil
 }
 
-// generateCommonDefinitions 生成通用模型定义
-func (dg *DocumentGenerator) generateCommonDefinitions() {
-	// 响应结构
-	dg.swaggerDoc.Definitions["Response"] = map[string]interface{}{
-		"type": "object",
+// addCommonComponents 生成统一响应、错误响应与分页结构
+func (dg *DocumentGenerator) addCommonComponents() {
+	schemas := dg.doc.Components.Schemas
+	schemas["Response"] = Schema{
+		"type":     "object",
+		"required": []string{"code", "message"},
 		"properties": map[string]interface{}{
-			"code": map[string]interface{}{
-				"type":        "integer",
-				"description": "响应码",
-			},
-			"message": map[string]interface{}{
-				"type":        "string",
-				"description": "响应消息",
-			},
-			"data": map[string]interface{}{
-				"description": "响应数据",
-			},
+			"code":    Schema{"type": "integer", "description": "0 表示成功"},
+			"message": Schema{"type": "string"},
+			"data":    Schema{},
 		},
 	}
-
-	// 分页响应
-	dg.swaggerDoc.Definitions["PageResponse"] = map[string]interface{}{
-		"type": "object",
+	schemas["ErrorResponse"] = Schema{
+		"type":     "object",
+		"required": []string{"code", "message"},
 		"properties": map[string]interface{}{
-			"code": map[string]interface{}{
-				"type":        "integer",
-				"description": "响应码",
-			},
-			"message": map[string]interface{}{
-				"type":        "string",
-				"description": "响应消息",
-			},
-			"data": map[string]interface{}{
-				"$ref": "#/definitions/PageData",
+			"code":    Schema{"type": "integer", "description": "与 HTTP 状态码一致"},
+			"message": Schema{"type": "string"},
+			"errors": Schema{
+				"type":                 "object",
+				"description":          "字段校验错误：字段 -> 错误信息",
+				"additionalProperties": Schema{"type": "array", "items": Schema{"type": "string"}},
 			},
 		},
 	}
-
-	// 分页数据
-	dg.swaggerDoc.Definitions["PageData"] = map[string]interface{}{
-		"type": "object",
+	schemas["PageData"] = Schema{
+		"type":     "object",
+		"required": []string{"items", "total", "page", "page_size"},
 		"properties": map[string]interface{}{
-			"items": map[string]interface{}{
-				"type":        "array",
-				"description": "数据列表",
-			},
-			"total": map[string]interface{}{
-				"type":        "integer",
-				"description": "总数量",
-			},
-			"page": map[string]interface{}{
-				"type":        "integer",
-				"description": "当前页",
-			},
-			"page_size": map[string]interface{}{
-				"type":        "integer",
-				"description": "每页数量",
-			},
-			"total_pages": map[string]interface{}{
-				"type":        "integer",
-				"description": "总页数",
-			},
+			"items":     Schema{"type": "array", "items": Schema{}},
+			"total":     Schema{"type": "integer"},
+			"page":      Schema{"type": "integer"},
+			"page_size": Schema{"type": "integer"},
 		},
 	}
-
-	// 文件信息
-	dg.swaggerDoc.Definitions["FileInfo"] = map[string]interface{}{
-		"type": "object",
-		"properties": map[string]interface{}{
-			"name": map[string]interface{}{
-				"type":        "string",
-				"description": "文件名",
-			},
-			"path": map[string]interface{}{
-				"type":        "string",
-				"description": "文件路径",
-			},
-			"url": map[string]interface{}{
-				"type":        "string",
-				"description": "访问URL",
-			},
-			"size": map[string]interface{}{
-				"type":        "integer",
-				"description": "文件大小",
-			},
-			"ext": map[string]interface{}{
-				"type":        "string",
-				"description": "文件扩展名",
-			},
-			"storage_type": map[string]interface{}{
-				"type":        "string",
-				"description": "存储类型",
-			},
-			"content_type": map[string]interface{}{
-				"type":        "string",
-				"description": "内容类型",
-			},
-			"etag": map[string]interface{}{
-				"type":        "string",
-				"description": "文件标识",
-			},
-		},
+	schemas["IDList"] = Schema{
+		"type":  "array",
+		"items": idSchema,
 	}
-
-	// 导入结果
-	dg.swaggerDoc.Definitions["ImportResult"] = map[string]interface{}{
-		"type": "object",
-		"properties": map[string]interface{}{
-			"total_rows": map[string]interface{}{
-				"type":        "integer",
-				"description": "总行数",
-			},
-			"success_rows": map[string]interface{}{
-				"type":        "integer",
-				"description": "成功行数",
-			},
-			"failed_rows": map[string]interface{}{
-				"type":        "integer",
-				"description": "失败行数",
-			},
-			"errors": map[string]interface{}{
-				"type":        "array",
-				"description": "错误信息",
-			},
-			"processed_at": map[string]interface{}{
-				"type":        "string",
-				"format":      "date-time",
-				"description": "处理时间",
-			},
-		},
+	schemas["TranslationMap"] = Schema{
+		"type":                 "object",
+		"description":          "语言 -> 值，如 {\"zh-CN\": \"启用\", \"en\": \"Enabled\"}，值为空时删除该语言",
+		"additionalProperties": Schema{"type": "string"},
 	}
 
-	// 从 model 包中直接引用核心业务模型
-	dg.addModelDefinition("User", &model.User{})
-	dg.addModelDefinition("Role", &model.Role{})
-	dg.addModelDefinition("Menu", &model.Menu{})
-	dg.addModelDefinition("Api", &model.Api{})
-	dg.addModelDefinition("OperationLog", &model.OperationLog{})
-	dg.addModelDefinition("DictionaryType", &model.DictionaryType{})
-	dg.addModelDefinition("DictionaryData", &model.DictionaryData{})
-	dg.addModelDefinition("Config", &model.Config{})
+	descriptions := map[string]string{
+		"BadRequest":     "请求参数无效或校验失败",
+		"Unauthorized":   "未登录或令牌无效",
+		"Forbidden":      "无权访问",
+		"NotFound":       "资源或记录不存在",
+		"Conflict":       "数据冲突",
+		"InternalError":  "服务器内部错误",
+		"NotImplemented": "资源未实现该操作",
+	}
+	for name, description := range descriptions {
+		dg.doc.Components.Responses[name] = jsonResponse(description, Ref("schemas", "ErrorResponse"))
+	}
 }
 
-// addModelDefinition 根据 Go 结构体自动生成 Swagger 模型定义
-func (dg *DocumentGenerator) addModelDefinition(name string, model interface{}) {
-	t := reflect.TypeOf(model)
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+// envelope 统一响应结构，data 为指定结构
+func envelope(data Schema) Schema {
+	if data == nil {
+		return Ref("schemas", "Response")
 	}
+	return Schema{
+		"allOf": []interface{}{
+			Ref("schemas", "Response"),
+			Schema{"type": "object", "properties": map[string]interface{}{"data": data}},
+		},
+	}
+}
 
-	properties := make(map[string]interface{})
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		jsonTag := field.Tag.Get("json")
-		if jsonTag == "" || jsonTag == "-" {
-			continue
-		}
-		// 解析 json tag, 获取字段名 (忽略 ,omitempty 等选项)
-		fieldName := strings.Split(jsonTag, ",")[0]
-
-		property := map[string]interface{}{
-			"description": field.Tag.Get("comment"),
-		}
-
-		switch field.Type.Kind() {
-		case reflect.String:
-			property["type"] = "string"
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
-			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			property["type"] = "integer"
-		case reflect.Float32, reflect.Float64:
-			property["type"] = "number"
-		case reflect.Bool:
-			property["type"] = "boolean"
-		default:
-			property["type"] = "string"
-		}
+// pageEnvelope 分页列表响应结构
+func pageEnvelope(item Schema) Schema {
+	return envelope(Schema{
+		"allOf": []interface{}{
+			Ref("schemas", "PageData"),
+			Schema{"type": "object", "properties": map[string]interface{}{
+				"items": Schema{"type": "array", "items": item},
+			}},
+		},
+	})
+}
 
-		properties[fieldName] = property
+// responses 组合成功响应与错误响应，非公开接口附带 401/403
+func responses(success Response, public bool, codes ...string) map[string]Response {
+	result := map[string]Response{"200": success}
+	if !public {
+		codes = append(codes, "401", "403")
 	}
-
-	dg.swaggerDoc.Definitions[name] = map[string]interface{}{
-		"type":       "object",
-		"properties": properties,
+	codes = append(codes, "500")
+	for _, code := range codes {
+		result[code] = refResponse(errorResponses[code])
 	}
+	return result
 }
 
-// Parameter API参数
-type Parameter struct {
-	Name        string `json:"name"`
-	Type        string `json:"type"`
-	Description string `json:"description"`
-	Required    bool   `json:"required"`
-	In          string `json:"in,omitempty"`
-	Schema      string `json:"schema,omitempty"`
+// addOperation 添加操作到路径，operationId 重复时追加序号
+func (dg *DocumentGenerator) addOperation(path, method string, op *Operation, public bool) {
+	if n := dg.operationIDs[op.OperationID]; n > 0 {
+		dg.operationIDs[op.OperationID] = n + 1
+		op.OperationID = fmt.Sprintf("%s%d", op.OperationID, n+1)
+	} else {
+		dg.operationIDs[op.OperationID] = 1
+	}
+	if public {
+		op.Security = &[]SecurityRequirement{}
+	}
+	item, ok := dg.doc.Paths[path]
+	if !ok {
+		item = make(PathItem)
+		dg.doc.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
 }
 
-// addHandlerPath 添加处理器路径
-func (dg *DocumentGenerator) addHandlerPath(path, method, tag, summary string, params []Parameter) {
-	fullPath := dg.swaggerDoc.BasePath + path
-
-	if dg.swaggerDoc.Paths[fullPath] == nil {
-		dg.swaggerDoc.Paths[fullPath] = make(map[string]interface{})
+// addRoute 添加非资源展开的路由，路径参数 resource 取已注册资源的 slug
+func (dg *DocumentGenerator) addRoute(route Route) error {
+	op := &Operation{
+		Tags:        []string{routeTag(route.Path)},
+		Summary:     route.Handler,
+		OperationID: operationID(route),
 	}
-
-	pathItem := dg.swaggerDoc.Paths[fullPath].(map[string]interface{})
-
-	operation := map[string]interface{}{
-		"tags":        []string{tag},
-		"summary":     summary,
-		"description": summary,
-		"consumes":    []string{"application/json"},
-		"produces":    []string{"application/json"},
-		"responses": map[string]interface{}{
-			"200": map[string]interface{}{
-				"description": "OK",
-				"schema": map[string]interface{}{
-					"$ref": "#/definitions/Response",
-				},
-			},
-		},
+	for _, name := range pathParams(route.Path) {
+		op.Parameters = append(op.Parameters, dg.pathParameter(name))
 	}
 
-	// 添加参数
-	if len(params) > 0 {
-		var parameters []map[string]interface{}
-		for _, param := range params {
-			p := map[string]interface{}{
-				"name":        param.Name,
-				"type":        param.Type,
-				"description": param.Description,
-				"required":    param.Required,
-			}
-			if param.In != "" {
-				p["in"] = param.In
+	if route.Request != nil {
+		if route.Method == http.MethodGet || route.Method == http.MethodDelete {
+			params, err := queryParametersOf(route.Request)
+			if err != nil {
+				return err
 			}
-			if param.Schema != "" {
-				p["schema"] = map[string]interface{}{
-					"$ref": param.Schema,
-				}
+			op.Parameters = append(op.Parameters, params...)
+		} else {
+			schema, err := dg.modelSchema(route.Request)
+			if err != nil {
+				return err
 			}
-			parameters = append(parameters, p)
+			op.RequestBody = jsonBody(schema, true)
 		}
-		operation["parameters"] = parameters
 	}
 
-	// 添加安全认证（除了登录接口）
-	if path != "/login" {
-		operation["security"] = []map[string][]string{
-			{"Bearer": {}},
+	var data Schema
+	if route.Response != nil {
+		schema, err := dg.modelSchema(route.Response)
+		if err != nil {
+			return err
 		}
+		data = schema
 	}
 
-	pathItem[method] = operation
+	codes := []string{"400"}
+	if len(pathParams(route.Path)) > 0 {
+		codes = append(codes, "404")
+	}
+	op.Responses = responses(jsonResponse("成功", envelope(data)), route.Public, codes...)
+	dg.addOperation(openAPIPath(route.Path), route.Method, op, route.Public)
+	return nil
 }
 
-// getModelName 获取模型名称
-func (dg *DocumentGenerator) getModelName(model interface{}) string {
-	t := reflect.TypeOf(model)
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+// pathParameter 路径参数，resource 取已注册资源的 slug，id 类参数兼容数字与字符串
+func (dg *DocumentGenerator) pathParameter(name string) Parameter {
+	param := Parameter{Name: name, In: "path", Required: true, Schema: Schema{"type": "string"}}
+	switch {
+	case name == "resource" || name == "slug":
+		param.Description = "资源标识"
+		if len(dg.slugs) > 0 {
+			param.Schema["enum"] = dg.slugs
+		}
+	case name == "id" || strings.HasSuffix(name, "_id"):
+		param.Schema = idSchema
 	}
+	return param
+}
 
-	// 获取类型名称
-	typeName := t.Name()
-
-	// 处理包名
-	if idx := strings.LastIndex(typeName, "."); idx != -1 {
-		typeName = typeName[idx+1:]
+// routeTag 接口分组：/api/admin/v1/ 之后的第一段路径，如 users
+func routeTag(path string) string {
+	for _, segment := range strings.Split(path, "/") {
+		switch segment {
+		case "", "api", "admin", "v1":
+			continue
+		}
+		if !strings.HasPrefix(segment, ":") {
+			return segment
+		}
 	}
-
-	return typeName
+	return "default"
 }
 
-// SaveToFile 保存到文件
-func (dg *DocumentGenerator) SaveToFile(filename string) error {
-	// 确保目录存在
-	dir := filepath.Dir(filename)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("创建目录失败: %w", err)
+// operationID 由处理函数名生成，匿名函数按方法与路径生成
+func operationID(route Route) string {
+	if route.Handler != "" {
+		return lowerFirst(route.Handler)
 	}
-
-	// 序列化为JSON
-	data, err := json.MarshalIndent(dg.swaggerDoc, "", "  ")
-	if err != nil {
-		return fmt.Errorf("序列化JSON失败: %w", err)
+	var b strings.Builder
+	b.WriteString(strings.ToLower(route.Method))
+	for _, segment := range strings.Split(route.Path, "/") {
+		segment = strings.TrimPrefix(segment, ":")
+		switch segment {
+		case "", "api", "admin", "v1":
+			continue
+		}
+		b.WriteString(schemaName(segment))
 	}
+	return b.String()
+}
 
-	// 写入文件
-	if err := ioutil.WriteFile(filename, data, 0644); err != nil {
-		return fmt.Errorf("写入文件失败: %w", err)
+// lowerFirst 首字母小写
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
 	}
-
-	return nil
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
 }
 
-// GenerateSwagger 生成Swagger文档
-func GenerateSwagger(outputFile string) error {
-	generator := NewDocumentGenerator()
-
-	// 这里可以传入实际的handlers和resources
-	// 目前使用空的map生成基础文档
-	handlers := make(map[string]interface{})
-	resources := make(map[string]admin.Resource)
-
-	if err := generator.Generate(handlers, resources); err != nil {
-		return fmt.Errorf("生成API文档失败: %w", err)
+// SaveToFile 保存文档到文件
+func (dg *DocumentGenerator) SaveToFile(filename string) error {
+	if dir := filepath.Dir(filename); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return err
+		}
 	}
-
-	if err := generator.SaveToFile(outputFile); err != nil {
-		return fmt.Errorf("保存API文档失败: %w", err)
+	data, err := json.MarshalIndent(dg.doc, "", "  ")
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return os.WriteFile(filename, data, 0644)
 }
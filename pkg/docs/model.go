@@ -0,0 +1,194 @@
+package docs
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// modelSchema 通过反射生成请求或响应结构的 schema，具名结构体登记到 components.schemas 并返回引用
+func (dg *DocumentGenerator) modelSchema(model interface{}) (Schema, error) {
+	return dg.typeSchema(reflect.TypeOf(model))
+}
+
+func (dg *DocumentGenerator) typeSchema(t reflect.Type) (Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return Schema{"type": "string", "format": "date-time"}, nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		name := t.Name()
+		if name == "" {
+			return dg.structSchema(t)
+		}
+		if _, ok := dg.doc.Components.Schemas[name]; !ok {
+			// 先占位，避免自引用结构无限递归
+			dg.doc.Components.Schemas[name] = Schema{}
+			schema, err := dg.structSchema(t)
+			if err != nil {
+				delete(dg.doc.Components.Schemas, name)
+				return nil, err
+			}
+			dg.doc.Components.Schemas[name] = schema
+		}
+		return Ref("schemas", name), nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}, nil
+		}
+		items, err := dg.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := dg.typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return Schema{"type": "object", "additionalProperties": values}, nil
+	case reflect.Interface:
+		return Schema{}, nil
+	default:
+		if schema := basicSchema(t); schema != nil {
+			return schema, nil
+		}
+		return nil, fmt.Errorf("不支持的类型 %s", t)
+	}
+}
+
+// structSchema 按 json 标签生成对象结构，binding:"required" 的字段为必填，example 标签作为示例
+func (dg *DocumentGenerator) structSchema(t reflect.Type) (Schema, error) {
+	properties := make(map[string]interface{})
+	var required []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, ok := bodyFieldName(field)
+		if !ok {
+			continue
+		}
+		if field.Anonymous && field.Tag.Get("json") == "" && indirect(field.Type).Kind() == reflect.Struct {
+			embedded, err := dg.structSchema(indirect(field.Type))
+			if err != nil {
+				return nil, err
+			}
+			if props, ok := embedded["properties"].(map[string]interface{}); ok {
+				for k, v := range props {
+					properties[k] = v
+				}
+			}
+			if req, ok := embedded["required"].([]string); ok {
+				required = append(required, req...)
+			}
+			continue
+		}
+		schema, err := dg.typeSchema(field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", t.Name(), field.Name, err)
+		}
+		if example := field.Tag.Get("example"); example != "" && schema["$ref"] == nil {
+			schema = copySchema(schema)
+			schema["examples"] = []string{example}
+		}
+		properties[name] = schema
+		if isRequired(field) {
+			required = append(required, name)
+		}
+	}
+	schema := Schema{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// queryParametersOf 按 form 标签生成查询参数
+func queryParametersOf(model interface{}) ([]Parameter, error) {
+	t := indirect(reflect.TypeOf(model))
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("查询参数须为结构体，实际为 %s", t)
+	}
+	var params []Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := tagName(field, "form", "json")
+		if name == "-" {
+			continue
+		}
+		schema := basicSchema(indirect(field.Type))
+		if schema == nil {
+			schema = Schema{"type": "string"}
+		}
+		if example := field.Tag.Get("example"); example != "" {
+			schema["examples"] = []string{example}
+		}
+		params = append(params, Parameter{Name: name, In: "query", Required: isRequired(field), Schema: schema})
+	}
+	return params, nil
+}
+
+// basicSchema 基本类型的 schema，非基本类型返回 nil
+func basicSchema(t reflect.Type) Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Schema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	}
+	return nil
+}
+
+// bodyFieldName 请求体中的字段名：json 标签优先，其次 form 标签（JSON 解码按字段名忽略大小写匹配）
+func bodyFieldName(field reflect.StructField) (string, bool) {
+	name := tagName(field, "json", "form")
+	return name, name != "-"
+}
+
+// tagName 依次取标签中的名称，均未设置时使用字段名
+func tagName(field reflect.StructField, keys ...string) string {
+	for _, key := range keys {
+		tag := field.Tag.Get(key)
+		if tag == "" {
+			continue
+		}
+		if name := strings.Split(tag, ",")[0]; name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// isRequired 是否声明了 binding:"required"
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,143 @@
+package docs
+
+// OpenAPIVersion 生成文档遵循的 OpenAPI 版本，3.1 的 Schema 与 JSON Schema 2020-12 一致
+const OpenAPIVersion = "3.1.0"
+
+// Schema JSON Schema 对象
+type Schema map[string]interface{}
+
+// Ref 引用 components 中的对象，如 Ref("schemas", "Response")
+func Ref(kind, name string) Schema {
+	return Schema{"$ref": "#/components/" + kind + "/" + name}
+}
+
+// Document OpenAPI 文档结构
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Tags       []Tag                 `json:"tags,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Info API信息
+type Info struct {
+	Title          string   `json:"title"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"`
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+	Version        string   `json:"version"`
+}
+
+// Contact 联系信息
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License 许可证信息
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Server 服务地址
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag 接口分组
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem 路径下各请求方法的操作，键为小写方法名
+type PathItem map[string]*Operation
+
+// Operation 接口操作
+type Operation struct {
+	Tags        []string            `json:"tags,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Description string              `json:"description,omitempty"`
+	OperationID string              `json:"operationId"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+	// Security 为 nil 时沿用全局鉴权，空切片表示无需鉴权
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+// Parameter 路径、查询或请求头参数
+type Parameter struct {
+	Name        string `json:"name"`
+	In          string `json:"in"`
+	Description string `json:"description,omitempty"`
+	Required    bool   `json:"required,omitempty"`
+	Schema      Schema `json:"schema"`
+}
+
+// RequestBody 请求体
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// MediaType 指定内容类型的数据结构
+type MediaType struct {
+	Schema Schema `json:"schema"`
+}
+
+// Response 响应，Ref 非空时引用 components.responses
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// Components 可复用的结构、响应与鉴权方式
+type Components struct {
+	Schemas         map[string]Schema         `json:"schemas"`
+	Responses       map[string]Response       `json:"responses"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes"`
+}
+
+// SecurityScheme 鉴权方式
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement 操作所需的鉴权方式
+type SecurityRequirement map[string][]string
+
+// jsonBody 构造 application/json 请求体
+func jsonBody(schema Schema, required bool) *RequestBody {
+	return &RequestBody{
+		Required: required,
+		Content:  map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// jsonResponse 构造 application/json 响应
+func jsonResponse(description string, schema Schema) Response {
+	return Response{
+		Description: description,
+		Content:     map[string]MediaType{"application/json": {Schema: schema}},
+	}
+}
+
+// refResponse 引用 components.responses 中的响应
+func refResponse(name string) Response {
+	return Response{Ref: "#/components/responses/" + name}
+}
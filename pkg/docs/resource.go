@@ -0,0 +1,377 @@
+package docs
+
+import (
+	"context"
+	"fun-admin/pkg/admin"
+	"strings"
+)
+
+// idSchema 记录ID，兼容数字与字符串主键
+var idSchema = Schema{"type": []string{"integer", "string"}}
+
+// validatorProvider 字段可选实现：暴露校验器以生成长度等约束
+type validatorProvider interface {
+	GetValidators() []admin.Validator
+}
+
+// defaultProvider 字段可选实现：暴露默认值
+type defaultProvider interface {
+	GetDefault() interface{}
+}
+
+// schemaName 将资源 slug 转为结构名，如 admin_dictionary_data 转为 AdminDictionaryData
+func schemaName(slug string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(slug, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == '/'
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// fieldSchema 根据字段类型、选项、校验器与默认值生成字段的 schema
+func (dg *DocumentGenerator) fieldSchema(field admin.Field) Schema {
+	base := field
+	if wrapped, ok := field.(*admin.ValidatedField); ok {
+		base = wrapped.Field
+	}
+
+	schema := Schema{}
+	switch f := base.(type) {
+	case *admin.IDField:
+		schema["type"] = "integer"
+		schema["readOnly"] = true
+	case *admin.SelectField:
+		schema["type"] = "string"
+		if len(f.Options) > 0 {
+			schema["enum"] = optionValues(f.Options)
+		}
+		if f.DictCode != "" {
+			schema["x-dict-code"] = f.DictCode
+		}
+	case *admin.RelationshipField:
+		schema["type"] = "integer"
+		schema["x-related-resource"] = f.RelatedResource
+	case *admin.FileField:
+		if f.Multiple {
+			schema["type"] = "array"
+			schema["items"] = Schema{"type": "string"}
+		} else {
+			schema["type"] = "string"
+		}
+		if len(f.AllowedTypes) > 0 {
+			schema["x-allowed-types"] = f.AllowedTypes
+		}
+	default:
+		switch base.GetType() {
+		case "number":
+			schema["type"] = "number"
+		case "boolean":
+			schema["type"] = "boolean"
+		case "email":
+			schema["type"] = "string"
+			schema["format"] = "email"
+		case "datetime":
+			schema["type"] = "string"
+			schema["format"] = "date-time"
+		case "date":
+			schema["type"] = "string"
+			schema["format"] = "date"
+		default:
+			schema["type"] = "string"
+		}
+	}
+
+	if label := admin.TranslateLabel(field.GetLabel(), dg.language); label != "" {
+		schema["title"] = label
+	}
+	if provider, ok := field.(validatorProvider); ok {
+		for _, validator := range provider.GetValidators() {
+			switch v := validator.(type) {
+			case *admin.MinLengthValidator:
+				schema["minLength"] = v.GetMinLength()
+			case *admin.MaxLengthValidator:
+				schema["maxLength"] = v.GetMaxLength()
+			case *admin.EmailValidator:
+				schema["format"] = "email"
+			}
+		}
+	}
+	if provider, ok := base.(defaultProvider); ok {
+		if value := provider.GetDefault(); value != nil {
+			schema["default"] = value
+		}
+	}
+	return schema
+}
+
+// optionValues 选项的取值列表
+func optionValues(options []admin.Option) []string {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		values = append(values, option.Value)
+	}
+	return values
+}
+
+// writeSchema 写入时的字段 schema，可翻译字段同时接受单个文本与语言映射
+func writeSchema(field admin.Field, schema Schema) Schema {
+	if !admin.IsTranslatableField(field) {
+		return schema
+	}
+	return Schema{
+		"title":       schema["title"],
+		"description": "单个文本作用于请求语言，或按语言提交的映射",
+		"oneOf":       []interface{}{schema, Ref("schemas", "TranslationMap")},
+	}
+}
+
+// fieldSets 计算资源的可读字段、可写字段与只读字段
+// 字段级权限按无用户上下文计算，与未声明权限时的默认行为一致
+func fieldSets(resource admin.Resource) (readable, writable, readOnly map[string]struct{}) {
+	readable = make(map[string]struct{})
+	writable = make(map[string]struct{})
+	readOnly = make(map[string]struct{})
+	var perms admin.FieldPermissions
+	if provider, ok := resource.(admin.FieldPermissionProvider); ok {
+		perms = provider.GetFieldPermissions(context.Background())
+	}
+	for _, field := range resource.GetFields() {
+		name := field.GetName()
+		if len(perms.Readable) == 0 {
+			readable[name] = struct{}{}
+		}
+		if len(perms.Writable) == 0 {
+			writable[name] = struct{}{}
+		}
+	}
+	for _, name := range perms.Readable {
+		readable[name] = struct{}{}
+	}
+	for _, name := range perms.Writable {
+		writable[name] = struct{}{}
+	}
+	for _, name := range []string{"id", "created_at", "updated_at"} {
+		readable[name] = struct{}{}
+	}
+	for _, name := range resource.GetReadOnlyFields() {
+		readOnly[name] = struct{}{}
+	}
+	readOnly["id"] = struct{}{}
+	return readable, writable, readOnly
+}
+
+// addResourceSchemas 生成资源的记录、详情、创建与更新结构
+func (dg *DocumentGenerator) addResourceSchemas(resource admin.Resource) {
+	name := schemaName(resource.GetSlug())
+	readable, writable, readOnly := fieldSets(resource)
+
+	record := make(map[string]interface{})
+	create := make(map[string]interface{})
+	update := make(map[string]interface{})
+	var required []string
+	translations := make(map[string]interface{})
+
+	for _, field := range resource.GetFields() {
+		fieldName := field.GetName()
+		schema := dg.fieldSchema(field)
+		if _, ok := readable[fieldName]; ok {
+			read := copySchema(schema)
+			if _, ok := readOnly[fieldName]; ok {
+				read["readOnly"] = true
+			}
+			record[fieldName] = read
+			if admin.IsTranslatableField(field) {
+				translations[fieldName] = Ref("schemas", "TranslationMap")
+			}
+		}
+		_, canWrite := writable[fieldName]
+		_, isReadOnly := readOnly[fieldName]
+		if !canWrite || isReadOnly {
+			continue
+		}
+		write := writeSchema(field, schema)
+		delete(write, "readOnly")
+		create[fieldName] = write
+		update[fieldName] = write
+		if field.IsRequired() {
+			required = append(required, fieldName)
+		}
+	}
+	if _, ok := record["id"]; !ok {
+		record["id"] = Schema{"type": "integer", "readOnly": true}
+	}
+	for _, key := range []string{"created_at", "updated_at"} {
+		if _, ok := record[key]; !ok {
+			record[key] = Schema{"type": "string", "format": "date-time", "readOnly": true}
+		}
+	}
+
+	title := admin.TranslateLabel(resource.GetTitle(), dg.language)
+	dg.doc.Components.Schemas[name] = Schema{
+		"type":       "object",
+		"title":      title,
+		"properties": record,
+	}
+	createSchema := Schema{
+		"type":       "object",
+		"title":      title,
+		"properties": create,
+	}
+	if len(required) > 0 {
+		createSchema["required"] = required
+	}
+	dg.doc.Components.Schemas[name+"Create"] = createSchema
+	dg.doc.Components.Schemas[name+"Update"] = Schema{
+		"type":       "object",
+		"title":      title,
+		"properties": update,
+	}
+	if len(translations) > 0 {
+		dg.doc.Components.Schemas[name+"Detail"] = Schema{
+			"allOf": []interface{}{
+				Ref("schemas", name),
+				Schema{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"_translations": Schema{
+							"type":        "object",
+							"description": "可翻译字段的全部语言值",
+							"properties":  translations,
+						},
+					},
+				},
+			},
+		}
+	}
+}
+
+// detailSchema 记录详情结构，含可翻译字段时附带全部语言值
+func (dg *DocumentGenerator) detailSchema(slug string) Schema {
+	name := schemaName(slug)
+	if _, ok := dg.doc.Components.Schemas[name+"Detail"]; ok {
+		return Ref("schemas", name+"Detail")
+	}
+	return Ref("schemas", name)
+}
+
+// copySchema 浅拷贝 schema
+func copySchema(schema Schema) Schema {
+	result := make(Schema, len(schema))
+	for k, v := range schema {
+		result[k] = v
+	}
+	return result
+}
+
+// listParameters 资源列表的分页、排序、过滤与搜索参数
+func (dg *DocumentGenerator) listParameters(resource admin.Resource) []Parameter {
+	params := []Parameter{
+		{Name: "page", In: "query", Description: "页码", Schema: Schema{"type": "integer", "minimum": 1, "default": 1}},
+		{Name: "page_size", In: "query", Description: "每页数量", Schema: Schema{"type": "integer", "minimum": 1, "maximum": 100, "default": 10}},
+	}
+	params = append(params, dg.queryParameters(resource)...)
+	return params
+}
+
+// queryParameters 资源列表与导出共用的排序、过滤与搜索参数
+func (dg *DocumentGenerator) queryParameters(resource admin.Resource) []Parameter {
+	orderBy := Schema{"type": "string"}
+	if sortable, ok := resource.(admin.Sortable); ok && len(sortable.GetSortableFields()) > 0 {
+		orderBy["enum"] = sortable.GetSortableFields()
+	}
+	params := []Parameter{
+		{Name: "order_by", In: "query", Description: "排序字段", Schema: orderBy},
+		{Name: "order_direction", In: "query", Description: "排序方向", Schema: Schema{"type": "string", "enum": []string{"ASC", "DESC"}}},
+	}
+
+	fields := make(map[string]admin.Field)
+	var names []string
+	for _, field := range resource.GetFields() {
+		fields[field.GetName()] = field
+		names = append(names, field.GetName())
+	}
+	filterOptions := make(map[string][]admin.Option)
+	for _, filter := range resource.GetFilters() {
+		if filter != nil && len(filter.Options) > 0 {
+			filterOptions[filter.Name] = filter.Options
+		}
+	}
+	if filterable, ok := resource.(admin.Filterable); ok && len(filterable.GetFilterableFields()) > 0 {
+		names = filterable.GetFilterableFields()
+	}
+	for _, name := range names {
+		schema := Schema{"type": "string"}
+		description := name
+		if field, ok := fields[name]; ok {
+			schema = dg.fieldSchema(field)
+			description = stringValue(schema["title"])
+			delete(schema, "title")
+			delete(schema, "default")
+			delete(schema, "readOnly")
+		}
+		if options, ok := filterOptions[name]; ok {
+			schema["enum"] = optionValues(options)
+		}
+		params = append(params, Parameter{Name: name, In: "query", Description: "按" + description + "精确过滤", Schema: schema})
+	}
+
+	if searchable, ok := resource.(admin.Searchable); ok {
+		for _, name := range searchable.GetSearchableFields() {
+			description := name
+			if field, ok := fields[name]; ok {
+				description = admin.TranslateLabel(field.GetLabel(), dg.language)
+			}
+			params = append(params, Parameter{Name: "search_" + name, In: "query", Description: "按" + description + "模糊搜索", Schema: Schema{"type": "string"}})
+		}
+	}
+
+	params = append(params,
+		Parameter{Name: "trashed", In: "query", Description: "软删除记录：without 不含（默认）、with 包含、only 仅已删除", Schema: Schema{"type": "string", "enum": []string{"without", "with", "only"}}},
+		Parameter{Name: "created_at_from", In: "query", Description: "创建时间起", Schema: Schema{"type": "string", "format": "date-time"}},
+		Parameter{Name: "created_at_to", In: "query", Description: "创建时间止", Schema: Schema{"type": "string", "format": "date-time"}},
+	)
+	return params
+}
+
+// actionParamsSchema 动作表单字段生成的参数结构
+func (dg *DocumentGenerator) actionParamsSchema(action admin.Action) Schema {
+	schema := Schema{"type": "object"}
+	withForm, ok := action.(admin.ActionWithForm)
+	if !ok {
+		return schema
+	}
+	properties := make(map[string]interface{})
+	var required []string
+	for _, field := range withForm.GetFormFields() {
+		properties[field.GetName()] = dg.fieldSchema(field)
+		if field.IsRequired() {
+			required = append(required, field.GetName())
+		}
+	}
+	schema["properties"] = properties
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// customActions 需要通过动作接口执行的动作，查看、编辑、删除与创建由 CRUD 接口承担
+func customActions(resource admin.Resource) []admin.Action {
+	var actions []admin.Action
+	for _, action := range resource.GetActions() {
+		switch action.GetName() {
+		case "view", "edit", "delete", "create":
+			continue
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// stringValue 取字符串值，非字符串返回空
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
@@ -0,0 +1,373 @@
+package docs
+
+import (
+	"fun-admin/pkg/admin"
+	"path"
+	"strings"
+)
+
+// resourceOperation 资源展开后的一个操作，Suffix 为资源 slug 之后的路径
+type resourceOperation struct {
+	Suffix    string
+	Operation *Operation
+}
+
+// addResourceRoute 将含 :resource 的路由按资源展开为具体路径，返回是否已处理
+// 未识别的资源路由（如评论、附件）返回 false，按普通路由以 slug 枚举参数生成
+func (dg *DocumentGenerator) addResourceRoute(route Route) bool {
+	i := strings.Index(route.Path, "/:resource")
+	if i < 0 || len(dg.slugs) == 0 {
+		return false
+	}
+	prefix := route.Path[:i]
+	key := route.Method + " " + path.Base(prefix) + route.Path[i+len("/:resource"):]
+	if !knownResourceRoutes[key] {
+		return false
+	}
+	for _, slug := range dg.slugs {
+		for _, ro := range dg.resourceOperations(key, slug, dg.resources[slug]) {
+			ro.Operation.Tags = []string{slug}
+			dg.addOperation(openAPIPath(prefix)+"/"+slug+ro.Suffix, route.Method, ro.Operation, route.Public)
+		}
+	}
+	return true
+}
+
+// knownResourceRoutes 按资源展开的路由：方法、:resource 前一段路径与其后的路径
+var knownResourceRoutes = map[string]bool{
+	"GET resource-crud":                         true,
+	"POST resource-crud":                        true,
+	"GET resource-crud/:id":                     true,
+	"PUT resource-crud/:id":                     true,
+	"DELETE resource-crud/:id":                  true,
+	"POST resource-crud/actions/:action":        true,
+	"POST resource-crud/reorder":                true,
+	"GET resource-crud/tree":                    true,
+	"GET resource-crud/tree/children":           true,
+	"POST resource-crud/tree/move":              true,
+	"POST resource-crud/tree/reorder":           true,
+	"POST resource-crud/tree/rebuild":           true,
+	"GET resource-crud/:id/:relation":           true,
+	"POST resource-crud/:id/:relation":          true,
+	"POST resource-crud/:id/:relation/attach":   true,
+	"POST resource-crud/:id/:relation/detach":   true,
+	"PUT resource-crud/:id/:relation/:child_id": true,
+	"GET export":                                true,
+	"POST import":                               true,
+	"GET import/template":                       true,
+}
+
+// resourceOperations 生成资源在指定路由下的操作，资源不支持时返回空
+func (dg *DocumentGenerator) resourceOperations(key, slug string, resource admin.Resource) []resourceOperation {
+	name := schemaName(slug)
+	title := admin.TranslateLabel(resource.GetTitle(), dg.language)
+	idParam := Parameter{Name: "id", In: "path", Required: true, Description: "记录ID", Schema: idSchema}
+	single := func(suffix string, op *Operation) []resourceOperation {
+		return []resourceOperation{{Suffix: suffix, Operation: op}}
+	}
+	message := jsonResponse("成功", envelope(nil))
+
+	switch key {
+	case "GET resource-crud":
+		return single("", &Operation{
+			Summary:     "获取" + title + "列表",
+			OperationID: "list" + name,
+			Parameters:  append(dg.listParameters(resource), languageParameter()),
+			Responses:   responses(jsonResponse("成功", pageEnvelope(Ref("schemas", name))), false, "400", "404"),
+		})
+	case "POST resource-crud":
+		return single("", &Operation{
+			Summary:     "创建" + title,
+			OperationID: "create" + name,
+			RequestBody: jsonBody(Ref("schemas", name+"Create"), true),
+			Responses:   responses(jsonResponse("成功", envelope(Ref("schemas", name))), false, "400", "404", "409"),
+		})
+	case "GET resource-crud/:id":
+		return single("/{id}", &Operation{
+			Summary:     "获取" + title + "详情",
+			OperationID: "get" + name,
+			Parameters:  []Parameter{idParam, languageParameter()},
+			Responses:   responses(jsonResponse("成功", envelope(dg.detailSchema(slug))), false, "404"),
+		})
+	case "PUT resource-crud/:id":
+		return single("/{id}", &Operation{
+			Summary:     "更新" + title,
+			OperationID: "update" + name,
+			Parameters:  []Parameter{idParam},
+			RequestBody: jsonBody(Ref("schemas", name+"Update"), true),
+			Responses:   responses(message, false, "400", "404", "409"),
+		})
+	case "DELETE resource-crud/:id":
+		return single("/{id}", &Operation{
+			Summary:     "删除" + title,
+			OperationID: "delete" + name,
+			Parameters:  []Parameter{idParam},
+			Responses:   responses(message, false, "404"),
+		})
+	case "POST resource-crud/actions/:action":
+		var result []resourceOperation
+		for _, action := range customActions(resource) {
+			body := Schema{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"ids":    Ref("schemas", "IDList"),
+					"params": dg.actionParamsSchema(action),
+				},
+			}
+			result = append(result, resourceOperation{
+				Suffix: "/actions/" + action.GetName(),
+				Operation: &Operation{
+					Summary:     admin.TranslateLabel(action.GetLabel(), dg.language),
+					OperationID: "run" + name + schemaName(action.GetName()),
+					RequestBody: jsonBody(body, false),
+					Responses:   responses(jsonResponse("成功", envelope(Schema{})), false, "400", "404", "501"),
+				},
+			})
+		}
+		return result
+	case "POST resource-crud/reorder":
+		if _, ok := resource.(admin.Reorderable); !ok {
+			return nil
+		}
+		body := Schema{
+			"type":        "object",
+			"description": "提交完整顺序 ids，或移动单条记录 id 到 before/after 指定记录之前/之后",
+			"properties": map[string]interface{}{
+				"ids":    Ref("schemas", "IDList"),
+				"id":     idSchema,
+				"before": idSchema,
+				"after":  idSchema,
+			},
+		}
+		return single("/reorder", &Operation{
+			Summary:     "调整" + title + "顺序",
+			OperationID: "reorder" + name,
+			RequestBody: jsonBody(body, true),
+			Responses:   responses(jsonResponse("成功", envelope(updatedSchema())), false, "400", "404"),
+		})
+	}
+
+	if strings.HasPrefix(key, "GET resource-crud/tree") || strings.HasPrefix(key, "POST resource-crud/tree") {
+		return dg.treeOperations(key, name, title, resource)
+	}
+	if strings.Contains(key, "/:relation") {
+		return dg.relationOperations(key, name, resource)
+	}
+	return dg.transferOperations(key, name, title, resource)
+}
+
+// treeOperations 树形资源的嵌套树、子节点、移动、同级排序与路径重建
+func (dg *DocumentGenerator) treeOperations(key, name, title string, resource admin.Resource) []resourceOperation {
+	if _, ok := admin.GetTreeOptions(resource); !ok {
+		return nil
+	}
+	dg.doc.Components.Schemas[name+"TreeNode"] = Schema{
+		"allOf": []interface{}{
+			Ref("schemas", name),
+			Schema{"type": "object", "properties": map[string]interface{}{
+				"children": Schema{"type": "array", "items": Ref("schemas", name+"TreeNode")},
+			}},
+		},
+	}
+	var suffix string
+	op := &Operation{}
+	switch key {
+	case "GET resource-crud/tree":
+		suffix = "/tree"
+		op.Summary = "获取" + title + "树"
+		op.OperationID = "tree" + name
+		op.Parameters = []Parameter{
+			{Name: "root", In: "query", Description: "子树根节点ID，为空返回整棵树", Schema: idSchema},
+			{Name: "max_depth", In: "query", Description: "最大深度，0 表示不限", Schema: Schema{"type": "integer", "minimum": 0}},
+		}
+		op.Responses = responses(jsonResponse("成功", envelope(Schema{"type": "array", "items": Ref("schemas", name+"TreeNode")})), false, "404")
+	case "GET resource-crud/tree/children":
+		suffix = "/tree/children"
+		op.Summary = "获取" + title + "子节点"
+		op.OperationID = "treeChildren" + name
+		op.Parameters = []Parameter{
+			{Name: "parent_id", In: "query", Description: "父节点ID，为空返回根节点", Schema: idSchema},
+		}
+		op.Responses = responses(jsonResponse("成功", envelope(Schema{"type": "array", "items": Ref("schemas", name)})), false, "404")
+	case "POST resource-crud/tree/move":
+		suffix = "/tree/move"
+		op.Summary = "移动" + title + "节点"
+		op.OperationID = "moveTree" + name
+		op.RequestBody = jsonBody(Schema{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]interface{}{
+				"id":        idSchema,
+				"parent_id": Schema{"type": []string{"integer", "string", "null"}, "description": "为空移动到根"},
+				"position":  Schema{"type": "integer", "minimum": 0, "description": "缺省追加到末尾"},
+			},
+		}, true)
+		op.Responses = responses(jsonResponse("成功", envelope(nil)), false, "400", "404", "409")
+	case "POST resource-crud/tree/reorder":
+		suffix = "/tree/reorder"
+		op.Summary = "调整" + title + "同级顺序"
+		op.OperationID = "reorderTree" + name
+		op.RequestBody = jsonBody(Schema{
+			"type":     "object",
+			"required": []string{"ids"},
+			"properties": map[string]interface{}{
+				"parent_id": Schema{"type": []string{"integer", "string", "null"}},
+				"ids":       Ref("schemas", "IDList"),
+			},
+		}, true)
+		op.Responses = responses(jsonResponse("成功", envelope(nil)), false, "400", "404")
+	case "POST resource-crud/tree/rebuild":
+		suffix = "/tree/rebuild"
+		op.Summary = "重建" + title + "树路径"
+		op.OperationID = "rebuildTree" + name
+		op.Responses = responses(jsonResponse("成功", envelope(updatedSchema())), false, "404")
+	default:
+		return nil
+	}
+	return []resourceOperation{{Suffix: suffix, Operation: op}}
+}
+
+// relationOperations 关系管理器的子记录接口，按资源声明的关系展开，结构沿用子资源
+func (dg *DocumentGenerator) relationOperations(key, name string, resource admin.Resource) []resourceOperation {
+	provider, ok := resource.(admin.HasRelationManagers)
+	if !ok {
+		return nil
+	}
+	idParam := Parameter{Name: "id", In: "path", Required: true, Description: "父记录ID", Schema: idSchema}
+	var result []resourceOperation
+	for _, m := range provider.GetRelationManagers() {
+		child, ok := dg.resources[m.Resource]
+		if m == nil || !ok {
+			continue
+		}
+		childName := schemaName(m.Resource)
+		relation := schemaName(m.Name)
+		label := admin.TranslateLabel(m.Label, dg.language)
+		base := "/{id}/" + m.Name
+		switch key {
+		case "GET resource-crud/:id/:relation":
+			result = append(result, resourceOperation{Suffix: base, Operation: &Operation{
+				Summary:     "获取" + label + "列表",
+				OperationID: "list" + name + relation,
+				Parameters:  append([]Parameter{idParam}, dg.listParameters(child)...),
+				Responses:   responses(jsonResponse("成功", pageEnvelope(Ref("schemas", childName))), false, "400", "404"),
+			}})
+		case "POST resource-crud/:id/:relation":
+			result = append(result, resourceOperation{Suffix: base, Operation: &Operation{
+				Summary:     "创建" + label,
+				OperationID: "create" + name + relation,
+				Parameters:  []Parameter{idParam},
+				RequestBody: jsonBody(Ref("schemas", childName+"Create"), true),
+				Responses:   responses(jsonResponse("成功", envelope(Ref("schemas", childName))), false, "400", "404", "409"),
+			}})
+		case "POST resource-crud/:id/:relation/attach", "POST resource-crud/:id/:relation/detach":
+			if !m.IsPivot() {
+				continue
+			}
+			verb := path.Base(key)
+			summary, success := "关联", jsonResponse("成功", envelope(nil))
+			if verb == "detach" {
+				summary = "取消关联"
+				success = jsonResponse("成功", envelope(Schema{
+					"type":       "object",
+					"properties": map[string]interface{}{"affected": Schema{"type": "integer"}},
+				}))
+			}
+			result = append(result, resourceOperation{Suffix: base + "/" + verb, Operation: &Operation{
+				Summary:     summary + label,
+				OperationID: verb + name + relation,
+				Parameters:  []Parameter{idParam},
+				RequestBody: jsonBody(Schema{
+					"type":       "object",
+					"required":   []string{"ids"},
+					"properties": map[string]interface{}{"ids": Ref("schemas", "IDList")},
+				}, true),
+				Responses: responses(success, false, "400", "404"),
+			}})
+		case "PUT resource-crud/:id/:relation/:child_id":
+			result = append(result, resourceOperation{Suffix: base + "/{child_id}", Operation: &Operation{
+				Summary:     "更新" + label,
+				OperationID: "update" + name + relation,
+				Parameters: []Parameter{
+					idParam,
+					{Name: "child_id", In: "path", Required: true, Description: "子记录ID", Schema: idSchema},
+				},
+				RequestBody: jsonBody(Ref("schemas", childName+"Update"), true),
+				Responses:   responses(jsonResponse("成功", envelope(nil)), false, "400", "404", "409"),
+			}})
+		}
+	}
+	return result
+}
+
+// transferOperations 资源的导出、导入与导入模板
+func (dg *DocumentGenerator) transferOperations(key, name, title string, resource admin.Resource) []resourceOperation {
+	if exportable, ok := resource.(admin.Exportable); ok && !exportable.IsExportable() {
+		return nil
+	}
+	file := Response{
+		Description: "数据文件",
+		Content:     map[string]MediaType{"application/octet-stream": {Schema: Schema{"type": "string", "contentMediaType": "application/octet-stream"}}},
+	}
+	switch key {
+	case "GET export":
+		params := append(dg.queryParameters(resource),
+			Parameter{Name: "format", In: "query", Description: "导出格式，如 csv、xlsx", Schema: Schema{"type": "string", "default": "csv"}},
+			Parameter{Name: "columns", In: "query", Description: "导出列，逗号分隔", Schema: Schema{"type": "string"}},
+			Parameter{Name: "async", In: "query", Description: "为 1 或 true 时创建后台导出任务", Schema: Schema{"type": "string", "enum": []string{"1", "true"}}},
+			languageParameter(),
+		)
+		return []resourceOperation{{Operation: &Operation{
+			Summary:     "导出" + title,
+			OperationID: "export" + name,
+			Parameters:  params,
+			Responses:   responses(file, false, "400", "404", "409"),
+		}}}
+	case "POST import":
+		form := Schema{
+			"type":     "object",
+			"required": []string{"file", "type"},
+			"properties": map[string]interface{}{
+				"file":        Schema{"type": "string", "contentMediaType": "application/octet-stream"},
+				"type":        Schema{"type": "string", "enum": []string{"excel", "csv", "json"}},
+				"has_header":  Schema{"type": "boolean", "default": true},
+				"sheet_name":  Schema{"type": "string"},
+				"start_row":   Schema{"type": "integer"},
+				"mode":        Schema{"type": "string", "enum": []string{"insert", "update", "upsert"}, "default": "insert"},
+				"key_field":   Schema{"type": "string", "description": "update/upsert 模式匹配已有记录的字段"},
+				"transaction": Schema{"type": "string", "enum": []string{"single", "batch"}, "default": "batch"},
+				"dry_run":     Schema{"type": "boolean", "default": false},
+				"async":       Schema{"type": "boolean", "default": false},
+			},
+		}
+		return []resourceOperation{{Operation: &Operation{
+			Summary:     "导入" + title,
+			OperationID: "import" + name,
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{"multipart/form-data": {Schema: form}}},
+			Responses:   responses(jsonResponse("成功", envelope(Schema{})), false, "400", "404"),
+		}}}
+	case "GET import/template":
+		return []resourceOperation{{Suffix: "/template", Operation: &Operation{
+			Summary:     "下载" + title + "导入模板",
+			OperationID: "importTemplate" + name,
+			Parameters: []Parameter{
+				{Name: "format", In: "query", Description: "模板格式", Schema: Schema{"type": "string", "default": "xlsx"}},
+			},
+			Responses: responses(file, false, "400", "404"),
+		}}}
+	}
+	return nil
+}
+
+// languageParameter 响应内容使用的语言，未指定时按 Accept-Language 协商
+func languageParameter() Parameter {
+	return Parameter{Name: "language", In: "query", Description: "响应语言，未指定时按 Accept-Language 协商", Schema: Schema{"type": "string"}}
+}
+
+// updatedSchema 批量更新类接口返回的更新数量
+func updatedSchema() Schema {
+	return Schema{
+		"type":       "object",
+		"properties": map[string]interface{}{"updated": Schema{"type": "integer"}},
+	}
+}
@@ -0,0 +1,71 @@
+package docs
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Route 文档中的一条路由，通常由 gin 路由表转换而来
+type Route struct {
+	Method  string // 请求方法，如 GET
+	Path    string // gin 风格路径，如 /api/admin/v1/users/:id
+	Handler string // 处理函数名，用于生成摘要与 operationId
+	Public  bool   // 无需登录即可访问
+	// Request 可选的请求结构，GET/DELETE 按 form 标签生成查询参数，其他方法按 json 标签生成请求体
+	Request interface{}
+	// Response 可选的响应 data 结构
+	Response interface{}
+}
+
+// RoutesFromGin 将 gin 路由表转换为文档路由，忽略 HEAD 请求与通配路由（如静态文件）
+func RoutesFromGin(routes gin.RoutesInfo) []Route {
+	result := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		if route.Method == http.MethodHead || strings.Contains(route.Path, "*") {
+			continue
+		}
+		result = append(result, Route{
+			Method:  route.Method,
+			Path:    route.Path,
+			Handler: handlerName(route.Handler),
+		})
+	}
+	return result
+}
+
+// handlerName 从 gin 记录的函数全名中取出方法名
+// 如 fun-admin/internal/handler.(*UserHandler).GetUsers-fm 取 GetUsers，匿名函数返回空
+func handlerName(name string) string {
+	name = strings.TrimSuffix(name, "-fm")
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	if strings.HasPrefix(name, "func") {
+		return ""
+	}
+	return name
+}
+
+// openAPIPath 将 gin 路径参数 :id 转换为 {id}
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// pathParams 返回 gin 路径中的参数名
+func pathParams(path string) []string {
+	var params []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			params = append(params, segment[1:])
+		}
+	}
+	return params
+}
@@ -0,0 +1,424 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"fun-admin/pkg/admin"
+	"fun-admin/pkg/admin/i18n"
+	"sort"
+	"strings"
+)
+
+// TypeScriptHeader 生成文件的首行，用于识别与跳过 lint
+const TypeScriptHeader = "// Code generated by cmd/typegen. DO NOT EDIT."
+
+// TypeScriptOptions TypeScript 生成选项
+type TypeScriptOptions struct {
+	APIPrefix     string // 接口路径前缀，默认 /api/admin/v1
+	RequestModule string // 请求函数 useGet/usePost/usePut/useDelete 所在模块，默认 @/utils/request.js
+	Language      string // 注释使用的语言，默认使用系统默认语言
+}
+
+// TypeScriptGenerator 根据资源声明生成 TypeScript 类型与 API 客户端
+// 字段类型与 OpenAPI 文档使用同一套结构推导，前后端结构变化可通过比对生成结果发现
+type TypeScriptGenerator struct {
+	options TypeScriptOptions
+	dg      *DocumentGenerator
+}
+
+// NewTypeScriptGenerator 创建 TypeScript 生成器
+func NewTypeScriptGenerator(options TypeScriptOptions) *TypeScriptGenerator {
+	if options.APIPrefix == "" {
+		options.APIPrefix = "/api/admin/v1"
+	}
+	if options.RequestModule == "" {
+		options.RequestModule = "@/utils/request.js"
+	}
+	return &TypeScriptGenerator{
+		options: options,
+		dg:      NewDocumentGenerator(),
+	}
+}
+
+// Generate 生成类型文件 types.ts 与客户端文件 client.ts，返回文件名到内容的映射，输出与资源注册顺序无关
+func (g *TypeScriptGenerator) Generate(resources map[string]admin.Resource) (map[string][]byte, error) {
+	language := g.options.Language
+	if language == "" {
+		language = i18n.GlobalResourceManager.DefaultLanguage()
+	}
+	g.dg.SetLanguage(language)
+	g.dg.resources = resources
+	g.dg.slugs = make([]string, 0, len(resources))
+	for slug := range resources {
+		g.dg.slugs = append(g.dg.slugs, slug)
+	}
+	sort.Strings(g.dg.slugs)
+	for _, slug := range g.dg.slugs {
+		g.dg.addResourceSchemas(resources[slug])
+	}
+
+	types := &bytes.Buffer{}
+	client := &bytes.Buffer{}
+	g.writeCommonTypes(types)
+	g.writeClientHeader(client)
+	for _, slug := range g.dg.slugs {
+		g.writeResourceTypes(types, slug, resources[slug])
+		g.writeResourceClient(client, slug, resources[slug])
+	}
+	return map[string][]byte{
+		"types.ts":  types.Bytes(),
+		"client.ts": client.Bytes(),
+	}, nil
+}
+
+// writeCommonTypes 统一响应、分页与列表参数等公共类型
+func (g *TypeScriptGenerator) writeCommonTypes(w *bytes.Buffer) {
+	w.WriteString(TypeScriptHeader + "\n\n")
+	w.WriteString(`export type ID = number | string
+
+/** 语言 -> 值，值为空时删除该语言 */
+export type TranslationMap = Record<string, string>
+
+export interface ApiResponse<T = unknown> {
+  code: number
+  message: string
+  data: T
+}
+
+export interface ApiError {
+  code: number
+  message: string
+  /** 字段校验错误：字段 -> 错误信息 */
+  errors?: Record<string, string[]>
+}
+
+export interface PageData<T> {
+  items: T[]
+  total: number
+  page: number
+  page_size: number
+}
+
+export interface ListParams {
+  page?: number
+  page_size?: number
+  order_direction?: 'ASC' | 'DESC'
+  trashed?: 'without' | 'with' | 'only'
+  created_at_from?: string
+  created_at_to?: string
+  language?: string
+}
+
+export interface DetailParams {
+  language?: string
+}
+
+/** 提交完整顺序 ids，或移动单条记录 id 到 before/after 指定记录之前/之后 */
+export interface ReorderInput {
+  ids?: ID[]
+  id?: ID
+  before?: ID
+  after?: ID
+}
+
+export interface ActionPayload<P = Record<string, unknown>> {
+  ids?: ID[]
+  params?: P
+}
+`)
+	if len(g.dg.slugs) > 0 {
+		quoted := make([]string, 0, len(g.dg.slugs))
+		for _, slug := range g.dg.slugs {
+			quoted = append(quoted, tsString(slug))
+		}
+		fmt.Fprintf(w, "\nexport type ResourceSlug = %s\n", strings.Join(quoted, " | "))
+	}
+}
+
+// writeResourceTypes 资源的选项联合类型、记录、详情、创建、更新、过滤与动作参数类型
+func (g *TypeScriptGenerator) writeResourceTypes(w *bytes.Buffer, slug string, resource admin.Resource) {
+	name := schemaName(slug)
+	schemas := g.dg.doc.Components.Schemas
+	title := admin.TranslateLabel(resource.GetTitle(), g.dg.language)
+	fmt.Fprintf(w, "\n// %s\n", title)
+
+	// 选项字段生成具名联合类型：字段 -> 类型名
+	enums := make(map[string]string)
+	fields := resource.GetFields()
+	for _, field := range fields {
+		schema := g.dg.fieldSchema(field)
+		values, ok := schema["enum"].([]string)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		alias := name + schemaName(field.GetName())
+		enums[field.GetName()] = alias
+		fmt.Fprintf(w, "\nexport type %s = %s\n", alias, tsUnion(values))
+	}
+
+	order := make([]string, 0, len(fields))
+	for _, field := range fields {
+		order = append(order, field.GetName())
+	}
+
+	g.writeInterface(w, name, "", schemas[name], order, enums, true)
+	if detail, ok := schemas[name+"Detail"]; ok {
+		ext := detail["allOf"].([]interface{})[1].(Schema)
+		g.writeInterface(w, name+"Detail", name, ext, nil, enums, false)
+	}
+	g.writeInterface(w, name+"Create", "", schemas[name+"Create"], order, enums, false)
+	fmt.Fprintf(w, "\nexport type %sUpdate = Partial<%sCreate>\n", name, name)
+
+	fmt.Fprintf(w, "\nexport interface %sFilters extends ListParams {\n", name)
+	for _, param := range g.dg.queryParameters(resource) {
+		switch param.Name {
+		case "order_direction", "trashed", "created_at_from", "created_at_to":
+			continue
+		}
+		typ := g.tsType(param.Schema)
+		if alias, ok := enums[param.Name]; ok && param.Schema["enum"] != nil {
+			typ = alias
+		}
+		if param.Description != "" {
+			fmt.Fprintf(w, "  /** %s */\n", param.Description)
+		}
+		fmt.Fprintf(w, "  %s?: %s\n", tsKey(param.Name), typ)
+	}
+	w.WriteString("}\n")
+
+	for _, action := range customActions(resource) {
+		params := actionParamsName(name, action)
+		if params == "" {
+			continue
+		}
+		var formOrder []string
+		withForm := action.(admin.ActionWithForm)
+		for _, field := range withForm.GetFormFields() {
+			formOrder = append(formOrder, field.GetName())
+		}
+		g.writeInterface(w, params, "", g.dg.actionParamsSchema(action), formOrder, nil, false)
+	}
+}
+
+// writeInterface 按字段声明顺序输出接口，未在 order 中的属性按名称排序追加
+// allRequired 为 true 时全部属性必填（记录读取结构），否则按 required 判断
+func (g *TypeScriptGenerator) writeInterface(
+	w *bytes.Buffer,
+	name, extends string,
+	schema Schema,
+	order []string,
+	enums map[string]string,
+	allRequired bool,
+) {
+	properties, _ := schema["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	if list, ok := schema["required"].([]string); ok {
+		for _, key := range list {
+			required[key] = true
+		}
+	}
+
+	if extends != "" {
+		fmt.Fprintf(w, "\nexport interface %s extends %s {\n", name, extends)
+	} else {
+		fmt.Fprintf(w, "\nexport interface %s {\n", name)
+	}
+	for _, key := range propertyOrder(properties, order) {
+		prop, _ := properties[key].(Schema)
+		typ := g.tsType(prop)
+		if alias, ok := enums[key]; ok && prop["enum"] != nil {
+			typ = alias
+		}
+		if title := stringValue(prop["title"]); title != "" {
+			fmt.Fprintf(w, "  /** %s */\n", title)
+		}
+		optional := "?"
+		if allRequired || required[key] {
+			optional = ""
+		}
+		fmt.Fprintf(w, "  %s%s: %s\n", tsKey(key), optional, typ)
+	}
+	w.WriteString("}\n")
+}
+
+// propertyOrder 属性输出顺序：先按 order，再按名称
+func propertyOrder(properties map[string]interface{}, order []string) []string {
+	keys := make([]string, 0, len(properties))
+	seen := make(map[string]bool, len(properties))
+	for _, key := range order {
+		if _, ok := properties[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+	var rest []string
+	for key := range properties {
+		if !seen[key] {
+			rest = append(rest, key)
+		}
+	}
+	sort.Strings(rest)
+	return append(keys, rest...)
+}
+
+// tsType 将 JSON Schema 转为 TypeScript 类型
+func (g *TypeScriptGenerator) tsType(schema Schema) string {
+	if schema == nil {
+		return "unknown"
+	}
+	if ref := stringValue(schema["$ref"]); ref != "" {
+		name := ref[strings.LastIndex(ref, "/")+1:]
+		if name == "IDList" {
+			return "ID[]"
+		}
+		return name
+	}
+	if list, ok := schema["oneOf"].([]interface{}); ok {
+		return g.tsCombine(list, " | ")
+	}
+	if list, ok := schema["allOf"].([]interface{}); ok {
+		return g.tsCombine(list, " & ")
+	}
+	if values, ok := schema["enum"].([]string); ok && len(values) > 0 {
+		return tsUnion(values)
+	}
+	switch t := schema["type"].(type) {
+	case []string:
+		if len(t) == 2 && t[0] == "integer" && t[1] == "string" {
+			return "ID"
+		}
+		parts := make([]string, 0, len(t))
+		for _, item := range t {
+			parts = append(parts, tsPrimitive(item))
+		}
+		return strings.Join(parts, " | ")
+	case string:
+		switch t {
+		case "array":
+			items, _ := schema["items"].(Schema)
+			item := g.tsType(items)
+			if strings.ContainsAny(item, "|&") {
+				item = "(" + item + ")"
+			}
+			return item + "[]"
+		case "object":
+			if values, ok := schema["additionalProperties"].(Schema); ok {
+				return "Record<string, " + g.tsType(values) + ">"
+			}
+			properties, _ := schema["properties"].(map[string]interface{})
+			if len(properties) == 0 {
+				return "Record<string, unknown>"
+			}
+			parts := make([]string, 0, len(properties))
+			for _, key := range propertyOrder(properties, nil) {
+				prop, _ := properties[key].(Schema)
+				parts = append(parts, tsKey(key)+"?: "+g.tsType(prop))
+			}
+			return "{ " + strings.Join(parts, "; ") + " }"
+		default:
+			return tsPrimitive(t)
+		}
+	}
+	return "unknown"
+}
+
+func (g *TypeScriptGenerator) tsCombine(list []interface{}, sep string) string {
+	parts := make([]string, 0, len(list))
+	for _, item := range list {
+		schema, _ := item.(Schema)
+		parts = append(parts, g.tsType(schema))
+	}
+	return strings.Join(parts, sep)
+}
+
+// tsPrimitive JSON Schema 基本类型对应的 TypeScript 类型
+func tsPrimitive(t string) string {
+	switch t {
+	case "integer", "number":
+		return "number"
+	case "string", "boolean", "null":
+		return t
+	}
+	return "unknown"
+}
+
+// tsUnion 字符串字面量联合类型
+func tsUnion(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, tsString(value))
+	}
+	return strings.Join(quoted, " | ")
+}
+
+// tsString 单引号字符串字面量
+func tsString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
+
+// tsKey 属性名，非标识符时加引号
+func tsKey(key string) string {
+	for i, r := range key {
+		if r == '_' || r == '$' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (i > 0 && r >= '0' && r <= '9') {
+			continue
+		}
+		return tsString(key)
+	}
+	return key
+}
+
+// writeClientHeader 客户端文件的导入与路径前缀
+func (g *TypeScriptGenerator) writeClientHeader(w *bytes.Buffer) {
+	w.WriteString(TypeScriptHeader + "\n")
+	fmt.Fprintf(w, "import { useDelete, useGet, usePost, usePut } from %s\n", tsString(g.options.RequestModule))
+	w.WriteString("import type * as T from './types'\n")
+	fmt.Fprintf(w, "\nconst prefix = %s\n", tsString(g.options.APIPrefix))
+}
+
+// writeResourceClient 资源的 CRUD、排序与动作接口
+func (g *TypeScriptGenerator) writeResourceClient(w *bytes.Buffer, slug string, resource admin.Resource) {
+	name := schemaName(slug)
+	detail := name
+	if _, ok := g.dg.doc.Components.Schemas[name+"Detail"]; ok {
+		detail = name + "Detail"
+	}
+	base := "${prefix}/resource-crud/" + slug
+
+	fmt.Fprintf(w, "\n/** %s */\n", admin.TranslateLabel(resource.GetTitle(), g.dg.language))
+	fmt.Fprintf(w, "export const %sApi = {\n", lowerFirst(name))
+	fmt.Fprintf(w, "  list: (params: T.%sFilters = {}) =>\n    useGet(`%s`, params) as Promise<T.ApiResponse<T.PageData<T.%s>>>,\n", name, base, name)
+	fmt.Fprintf(w, "  get: (id: T.ID, params: T.DetailParams = {}) =>\n    useGet(`%s/${id}`, params) as Promise<T.ApiResponse<T.%s>>,\n", base, detail)
+	fmt.Fprintf(w, "  create: (data: T.%sCreate) =>\n    usePost(`%s`, data) as Promise<T.ApiResponse<T.%s>>,\n", name, base, name)
+	fmt.Fprintf(w, "  update: (id: T.ID, data: T.%sUpdate) =>\n    usePut(`%s/${id}`, data) as Promise<T.ApiResponse<null>>,\n", name, base)
+	fmt.Fprintf(w, "  remove: (id: T.ID) =>\n    useDelete(`%s/${id}`) as Promise<T.ApiResponse<null>>,\n", base)
+	if _, ok := resource.(admin.Reorderable); ok {
+		fmt.Fprintf(w, "  reorder: (input: T.ReorderInput) =>\n    usePost(`%s/reorder`, input) as Promise<T.ApiResponse<{ updated: number }>>,\n", base)
+	}
+	actions := customActions(resource)
+	if len(actions) > 0 {
+		w.WriteString("  actions: {\n")
+		for _, action := range actions {
+			payload := "T.ActionPayload"
+			if params := actionParamsName(name, action); params != "" {
+				payload = "T.ActionPayload<T." + params + ">"
+			}
+			if label := admin.TranslateLabel(action.GetLabel(), g.dg.language); label != "" {
+				fmt.Fprintf(w, "    /** %s */\n", label)
+			}
+			fmt.Fprintf(w, "    %s: (payload: %s = {}) =>\n      usePost(`%s/actions/%s`, payload) as Promise<T.ApiResponse<unknown>>,\n",
+				tsKey(lowerFirst(schemaName(action.GetName()))), payload, base, action.GetName())
+		}
+		w.WriteString("  },\n")
+	}
+	w.WriteString("}\n")
+}
+
+// actionParamsName 动作参数类型名，动作未声明表单字段时返回空
+func actionParamsName(name string, action admin.Action) string {
+	withForm, ok := action.(admin.ActionWithForm)
+	if !ok || len(withForm.GetFormFields()) == 0 {
+		return ""
+	}
+	return name + schemaName(action.GetName()) + "Params"
+}
@@ -10,6 +10,7 @@ export default antfu({
   ignores: [
     'types/auto-imports.d.ts',
     'types/components.d.ts',
+    'src/api/generated',
     'public',
     'tsconfig.*.json',
     'tsconfig.json',
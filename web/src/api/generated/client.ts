@@ -0,0 +1,92 @@
+// Code generated by cmd/typegen. DO NOT EDIT.
+import { useDelete, useGet, usePost, usePut } from '@/utils/request.js'
+import type * as T from './types'
+
+const prefix = '/api/admin/v1'
+
+/** 字典数据 */
+export const adminDictionaryDataApi = {
+  list: (params: T.AdminDictionaryDataFilters = {}) =>
+    useGet(`${prefix}/resource-crud/admin_dictionary_data`, params) as Promise<T.ApiResponse<T.PageData<T.AdminDictionaryData>>>,
+  get: (id: T.ID, params: T.DetailParams = {}) =>
+    useGet(`${prefix}/resource-crud/admin_dictionary_data/${id}`, params) as Promise<T.ApiResponse<T.AdminDictionaryDataDetail>>,
+  create: (data: T.AdminDictionaryDataCreate) =>
+    usePost(`${prefix}/resource-crud/admin_dictionary_data`, data) as Promise<T.ApiResponse<T.AdminDictionaryData>>,
+  update: (id: T.ID, data: T.AdminDictionaryDataUpdate) =>
+    usePut(`${prefix}/resource-crud/admin_dictionary_data/${id}`, data) as Promise<T.ApiResponse<null>>,
+  remove: (id: T.ID) =>
+    useDelete(`${prefix}/resource-crud/admin_dictionary_data/${id}`) as Promise<T.ApiResponse<null>>,
+  reorder: (input: T.ReorderInput) =>
+    usePost(`${prefix}/resource-crud/admin_dictionary_data/reorder`, input) as Promise<T.ApiResponse<{ updated: number }>>,
+}
+
+/** 字典类型 */
+export const adminDictionaryTypeApi = {
+  list: (params: T.AdminDictionaryTypeFilters = {}) =>
+    useGet(`${prefix}/resource-crud/admin_dictionary_type`, params) as Promise<T.ApiResponse<T.PageData<T.AdminDictionaryType>>>,
+  get: (id: T.ID, params: T.DetailParams = {}) =>
+    useGet(`${prefix}/resource-crud/admin_dictionary_type/${id}`, params) as Promise<T.ApiResponse<T.AdminDictionaryType>>,
+  create: (data: T.AdminDictionaryTypeCreate) =>
+    usePost(`${prefix}/resource-crud/admin_dictionary_type`, data) as Promise<T.ApiResponse<T.AdminDictionaryType>>,
+  update: (id: T.ID, data: T.AdminDictionaryTypeUpdate) =>
+    usePut(`${prefix}/resource-crud/admin_dictionary_type/${id}`, data) as Promise<T.ApiResponse<null>>,
+  remove: (id: T.ID) =>
+    useDelete(`${prefix}/resource-crud/admin_dictionary_type/${id}`) as Promise<T.ApiResponse<null>>,
+  reorder: (input: T.ReorderInput) =>
+    usePost(`${prefix}/resource-crud/admin_dictionary_type/reorder`, input) as Promise<T.ApiResponse<{ updated: number }>>,
+  actions: {
+    /** 复制 */
+    replicate: (payload: T.ActionPayload<T.AdminDictionaryTypeReplicateParams> = {}) =>
+      usePost(`${prefix}/resource-crud/admin_dictionary_type/actions/replicate`, payload) as Promise<T.ApiResponse<unknown>>,
+  },
+}
+
+/** 增删改查表格 */
+export const crudItemsApi = {
+  list: (params: T.CrudItemsFilters = {}) =>
+    useGet(`${prefix}/resource-crud/crud_items`, params) as Promise<T.ApiResponse<T.PageData<T.CrudItems>>>,
+  get: (id: T.ID, params: T.DetailParams = {}) =>
+    useGet(`${prefix}/resource-crud/crud_items/${id}`, params) as Promise<T.ApiResponse<T.CrudItems>>,
+  create: (data: T.CrudItemsCreate) =>
+    usePost(`${prefix}/resource-crud/crud_items`, data) as Promise<T.ApiResponse<T.CrudItems>>,
+  update: (id: T.ID, data: T.CrudItemsUpdate) =>
+    usePut(`${prefix}/resource-crud/crud_items/${id}`, data) as Promise<T.ApiResponse<null>>,
+  remove: (id: T.ID) =>
+    useDelete(`${prefix}/resource-crud/crud_items/${id}`) as Promise<T.ApiResponse<null>>,
+  actions: {
+    /** 重置值 */
+    resetValues: (payload: T.ActionPayload = {}) =>
+      usePost(`${prefix}/resource-crud/crud_items/actions/reset_values`, payload) as Promise<T.ApiResponse<unknown>>,
+    /** 批量删除 */
+    bulkDelete: (payload: T.ActionPayload = {}) =>
+      usePost(`${prefix}/resource-crud/crud_items/actions/bulk_delete`, payload) as Promise<T.ApiResponse<unknown>>,
+  },
+}
+
+/** 操作日志 */
+export const operationLogsApi = {
+  list: (params: T.OperationLogsFilters = {}) =>
+    useGet(`${prefix}/resource-crud/operation-logs`, params) as Promise<T.ApiResponse<T.PageData<T.OperationLogs>>>,
+  get: (id: T.ID, params: T.DetailParams = {}) =>
+    useGet(`${prefix}/resource-crud/operation-logs/${id}`, params) as Promise<T.ApiResponse<T.OperationLogs>>,
+  create: (data: T.OperationLogsCreate) =>
+    usePost(`${prefix}/resource-crud/operation-logs`, data) as Promise<T.ApiResponse<T.OperationLogs>>,
+  update: (id: T.ID, data: T.OperationLogsUpdate) =>
+    usePut(`${prefix}/resource-crud/operation-logs/${id}`, data) as Promise<T.ApiResponse<null>>,
+  remove: (id: T.ID) =>
+    useDelete(`${prefix}/resource-crud/operation-logs/${id}`) as Promise<T.ApiResponse<null>>,
+}
+
+/** 用户管理 */
+export const usersApi = {
+  list: (params: T.UsersFilters = {}) =>
+    useGet(`${prefix}/resource-crud/users`, params) as Promise<T.ApiResponse<T.PageData<T.Users>>>,
+  get: (id: T.ID, params: T.DetailParams = {}) =>
+    useGet(`${prefix}/resource-crud/users/${id}`, params) as Promise<T.ApiResponse<T.Users>>,
+  create: (data: T.UsersCreate) =>
+    usePost(`${prefix}/resource-crud/users`, data) as Promise<T.ApiResponse<T.Users>>,
+  update: (id: T.ID, data: T.UsersUpdate) =>
+    usePut(`${prefix}/resource-crud/users/${id}`, data) as Promise<T.ApiResponse<null>>,
+  remove: (id: T.ID) =>
+    useDelete(`${prefix}/resource-crud/users/${id}`) as Promise<T.ApiResponse<null>>,
+}
@@ -0,0 +1,344 @@
+// Code generated by cmd/typegen. DO NOT EDIT.
+
+export type ID = number | string
+
+/** 语言 -> 值，值为空时删除该语言 */
+export type TranslationMap = Record<string, string>
+
+export interface ApiResponse<T = unknown> {
+  code: number
+  message: string
+  data: T
+}
+
+export interface ApiError {
+  code: number
+  message: string
+  /** 字段校验错误：字段 -> 错误信息 */
+  errors?: Record<string, string[]>
+}
+
+export interface PageData<T> {
+  items: T[]
+  total: number
+  page: number
+  page_size: number
+}
+
+export interface ListParams {
+  page?: number
+  page_size?: number
+  order_direction?: 'ASC' | 'DESC'
+  trashed?: 'without' | 'with' | 'only'
+  created_at_from?: string
+  created_at_to?: string
+  language?: string
+}
+
+export interface DetailParams {
+  language?: string
+}
+
+/** 提交完整顺序 ids，或移动单条记录 id 到 before/after 指定记录之前/之后 */
+export interface ReorderInput {
+  ids?: ID[]
+  id?: ID
+  before?: ID
+  after?: ID
+}
+
+export interface ActionPayload<P = Record<string, unknown>> {
+  ids?: ID[]
+  params?: P
+}
+
+export type ResourceSlug = 'admin_dictionary_data' | 'admin_dictionary_type' | 'crud_items' | 'operation-logs' | 'users'
+
+// 字典数据
+
+export type AdminDictionaryDataStatus = '1' | '0'
+
+export interface AdminDictionaryData {
+  /** ID */
+  id: number
+  /** 字典类型 */
+  type_id: number
+  /** 标签 */
+  label: string
+  /** 值 */
+  value: string
+  /** 状态 */
+  status: AdminDictionaryDataStatus
+  /** 默认值 */
+  is_default: boolean
+  /** 排序 */
+  sort: number
+  /** 备注 */
+  remark: string
+  /** 扩展字段1 */
+  ext1: string
+  /** 扩展字段2 */
+  ext2: string
+  /** 扩展字段3 */
+  ext3: string
+  /** 创建时间 */
+  created_at: string
+  /** 更新时间 */
+  updated_at: string
+}
+
+export interface AdminDictionaryDataDetail extends AdminDictionaryData {
+  _translations?: { label?: TranslationMap }
+}
+
+export interface AdminDictionaryDataCreate {
+  /** 字典类型 */
+  type_id: number
+  /** 标签 */
+  label: string | TranslationMap
+  /** 值 */
+  value: string
+  /** 状态 */
+  status: AdminDictionaryDataStatus
+  /** 默认值 */
+  is_default?: boolean
+  /** 排序 */
+  sort?: number
+  /** 备注 */
+  remark?: string
+  /** 扩展字段1 */
+  ext1?: string
+  /** 扩展字段2 */
+  ext2?: string
+  /** 扩展字段3 */
+  ext3?: string
+}
+
+export type AdminDictionaryDataUpdate = Partial<AdminDictionaryDataCreate>
+
+export interface AdminDictionaryDataFilters extends ListParams {
+  /** 排序字段 */
+  order_by?: 'label' | 'value' | 'sort' | 'created_at'
+  /** 按字典类型精确过滤 */
+  type_id?: number
+  /** 按状态精确过滤 */
+  status?: AdminDictionaryDataStatus
+  /** 按默认值精确过滤 */
+  is_default?: boolean
+  /** 按标签模糊搜索 */
+  search_label?: string
+  /** 按值模糊搜索 */
+  search_value?: string
+}
+
+// 字典类型
+
+export type AdminDictionaryTypeStatus = '1' | '0'
+
+export interface AdminDictionaryType {
+  /** ID */
+  id: number
+  /** 名称 */
+  name: string
+  /** 编码 */
+  code: string
+  /** 状态 */
+  status: AdminDictionaryTypeStatus
+  /** 排序 */
+  sort: number
+  /** 备注 */
+  remark: string
+  /** 创建时间 */
+  created_at: string
+  /** 更新时间 */
+  updated_at: string
+}
+
+export interface AdminDictionaryTypeCreate {
+  /** 名称 */
+  name: string
+  /** 编码 */
+  code: string
+  /** 状态 */
+  status: AdminDictionaryTypeStatus
+  /** 排序 */
+  sort?: number
+  /** 备注 */
+  remark?: string
+}
+
+export type AdminDictionaryTypeUpdate = Partial<AdminDictionaryTypeCreate>
+
+export interface AdminDictionaryTypeFilters extends ListParams {
+  /** 排序字段 */
+  order_by?: 'name' | 'code' | 'sort' | 'created_at'
+  /** 按名称精确过滤 */
+  name?: string
+  /** 按编码精确过滤 */
+  code?: string
+  /** 按状态精确过滤 */
+  status?: AdminDictionaryTypeStatus
+  /** 按名称模糊搜索 */
+  search_name?: string
+  /** 按编码模糊搜索 */
+  search_code?: string
+}
+
+export interface AdminDictionaryTypeReplicateParams {
+  /** 编码 */
+  code: string
+}
+
+// 增删改查表格
+
+export interface CrudItems {
+  /** ID */
+  id: number
+  /** 名 */
+  name: string
+  /** 值 */
+  value: string
+  /** 备注 */
+  remark: string
+  /** 创建时间 */
+  created_at: string
+  /** 更新时间 */
+  updated_at: string
+}
+
+export interface CrudItemsCreate {
+  /** 名 */
+  name: string
+  /** 值 */
+  value: string
+  /** 备注 */
+  remark?: string
+}
+
+export type CrudItemsUpdate = Partial<CrudItemsCreate>
+
+export interface CrudItemsFilters extends ListParams {
+  /** 排序字段 */
+  order_by?: 'name' | 'value' | 'created_at' | 'updated_at'
+  /** 按名精确过滤 */
+  name?: string
+  /** 按值精确过滤 */
+  value?: string
+  /** 按备注精确过滤 */
+  remark?: string
+  /** 按名模糊搜索 */
+  search_name?: string
+  /** 按值模糊搜索 */
+  search_value?: string
+  /** 按备注模糊搜索 */
+  search_remark?: string
+}
+
+// 操作日志
+
+export interface OperationLogs {
+  /** ID */
+  id: number
+  /** 用户ID */
+  user_id: string
+  /** 用户名 */
+  username: string
+  /** IP地址 */
+  ip: string
+  /** 请求方法 */
+  method: string
+  /** 请求路径 */
+  path: string
+  /** 用户代理 */
+  user_agent: string
+  /** 创建时间 */
+  created_at: string
+  updated_at: string
+}
+
+export interface OperationLogsCreate {
+}
+
+export type OperationLogsUpdate = Partial<OperationLogsCreate>
+
+export interface OperationLogsFilters extends ListParams {
+  /** 排序字段 */
+  order_by?: string
+  /** 按ID精确过滤 */
+  id?: number
+  /** 按用户ID精确过滤 */
+  user_id?: string
+  /** 按用户名精确过滤 */
+  username?: string
+  /** 按IP地址精确过滤 */
+  ip?: string
+  /** 按请求方法精确过滤 */
+  method?: 'GET' | 'POST' | 'PUT' | 'DELETE' | 'PATCH'
+  /** 按请求路径精确过滤 */
+  path?: string
+  /** 按用户代理精确过滤 */
+  user_agent?: string
+  /** 按创建时间精确过滤 */
+  created_at?: string
+}
+
+// 用户管理
+
+export type UsersStatus = '1' | '2'
+
+export interface Users {
+  /** ID */
+  id: number
+  /** 用户名 */
+  username: string
+  /** 昵称 */
+  nickname: string
+  /** 邮箱 */
+  email: string
+  /** 手机号 */
+  phone: string
+  /** 状态 */
+  status: UsersStatus
+  /** 创建时间 */
+  created_at: string
+  /** 更新时间 */
+  updated_at: string
+}
+
+export interface UsersCreate {
+  /** 用户名 */
+  username: string
+  /** 昵称 */
+  nickname?: string
+  /** 邮箱 */
+  email?: string
+  /** 手机号 */
+  phone?: string
+  /** 状态 */
+  status?: UsersStatus
+}
+
+export type UsersUpdate = Partial<UsersCreate>
+
+export interface UsersFilters extends ListParams {
+  /** 排序字段 */
+  order_by?: string
+  /** 按用户名精确过滤 */
+  username?: string
+  /** 按昵称精确过滤 */
+  nickname?: string
+  /** 按邮箱精确过滤 */
+  email?: string
+  /** 按手机号精确过滤 */
+  phone?: string
+  /** 按状态精确过滤 */
+  status?: UsersStatus
+  /** 按用户名模糊搜索 */
+  search_username?: string
+  /** 按昵称模糊搜索 */
+  search_nickname?: string
+  /** 按邮箱模糊搜索 */
+  search_email?: string
+  /** 按手机号模糊搜索 */
+  search_phone?: string
+}